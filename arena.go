@@ -0,0 +1,164 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include "dpiImpl.h"
+*/
+import "C"
+
+import (
+	"database/sql/driver"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// FetchArenaStats are the counters of fetch arenas.
+type FetchArenaStats struct {
+	// Arenas is the number of result sets that used an arena.
+	Arenas int64 `json:"arenas"`
+	// Batches is the number of background fetch batches allocated,
+	// BatchReuses is the number of times a consumed batch has been reused instead.
+	Batches     int64 `json:"batches"`
+	BatchReuses int64 `json:"batchReuses"`
+	// Slabs is the number of slabs allocated for the rows of the background fetch batches
+	// and for the NUMBER texts (with the FetchArena option).
+	Slabs int64 `json:"slabs"`
+	// Values is the number of NUMBER values whose text is carved from the slabs,
+	// each saving an allocation.
+	Values int64 `json:"values"`
+}
+
+type arenaCounters struct {
+	arenas, batches, batchReuses, slabs, values atomic.Int64
+}
+
+func (c *arenaCounters) stats() FetchArenaStats {
+	return FetchArenaStats{
+		Arenas:      c.arenas.Load(),
+		Batches:     c.batches.Load(),
+		BatchReuses: c.batchReuses.Load(),
+		Slabs:       c.slabs.Load(),
+		Values:      c.values.Load(),
+	}
+}
+
+var fetchArenaCounters arenaCounters
+
+// GetFetchArenaStats returns the counters of the fetch arenas of all the result sets.
+// See the FetchArena option for the counters of one result set.
+func GetFetchArenaStats() FetchArenaStats { return fetchArenaCounters.stats() }
+
+// arenaTextLen is the size of a NUMBER text slab.
+//
+// A retained value keeps its whole slab alive, so it's kept small.
+const arenaTextLen = 4096
+
+// fetchArena holds the memory of the intermediate values of fetching a result set,
+// reused across the fetched arrays, and dropped on Close:
+// the Data of the column converters, the row batches of the background fetch,
+// and (with the FetchArena option) the slabs the NUMBER texts are carved from.
+type fetchArena struct {
+	counters arenaCounters
+	data     Data
+	free     []*bgBatch
+	text     []byte
+	mu       sync.Mutex
+}
+
+func (r *rows) fetchArena() *fetchArena {
+	if r.arena == nil {
+		r.arena = new(fetchArena)
+		r.arena.count(func(c *arenaCounters) *atomic.Int64 { return &c.arenas })
+	}
+	return r.arena
+}
+
+// count increments the counter both of this arena and the global one.
+func (a *fetchArena) count(field func(*arenaCounters) *atomic.Int64) {
+	field(&a.counters).Add(1)
+	field(&fetchArenaCounters).Add(1)
+}
+
+// converterData returns the reused Data for the column converters,
+// set to the fetched value.
+func (a *fetchArena) converterData(col Column, d *C.dpiData) *Data {
+	a.data = Data{NativeTypeNum: col.NativeType, dpiData: *d}
+	return &a.data
+}
+
+// batch returns a consumed batch for reuse, or a new one.
+func (a *fetchArena) batch() *bgBatch {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if n := len(a.free); n != 0 {
+		b := a.free[n-1]
+		a.free = a.free[:n-1]
+		a.count(func(c *arenaCounters) *atomic.Int64 { return &c.batchReuses })
+		return b
+	}
+	a.count(func(c *arenaCounters) *atomic.Int64 { return &c.batches })
+	return &bgBatch{arena: a}
+}
+
+// put returns the consumed batch for reuse, dropping its values.
+func (a *fetchArena) put(b *bgBatch) {
+	if b == nil {
+		return
+	}
+	clear(b.vals[:cap(b.vals)])
+	clear(b.rows[:cap(b.rows)])
+	b.vals, b.rows, b.err = b.vals[:0], b.rows[:0], nil
+	a.mu.Lock()
+	a.free = append(a.free, b)
+	a.mu.Unlock()
+}
+
+// nextRow returns the space of the next row of n values, to be committed with addRow.
+func (b *bgBatch) nextRow(n int) []driver.Value {
+	if len(b.vals)+n > cap(b.vals) {
+		// the already added rows keep referencing the previous slab
+		b.vals = make([]driver.Value, 0, max(2*cap(b.vals), 64*n))
+		if b.arena != nil {
+			b.arena.count(func(c *arenaCounters) *atomic.Int64 { return &c.slabs })
+		}
+	}
+	return b.vals[len(b.vals) : len(b.vals)+n]
+}
+
+// addRow adds the row returned by nextRow.
+func (b *bgBatch) addRow(row []driver.Value) {
+	b.vals = b.vals[:len(b.vals)+len(row)]
+	b.rows = append(b.rows, row)
+}
+
+// textOf returns a copy of b carved from the text slab.
+func (a *fetchArena) textOf(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	if len(b) > arenaTextLen/4 {
+		return string(b)
+	}
+	if len(a.text)+len(b) > cap(a.text) {
+		a.text = make([]byte, 0, arenaTextLen)
+		a.count(func(c *arenaCounters) *atomic.Int64 { return &c.slabs })
+	}
+	n := len(a.text)
+	a.text = append(a.text, b...)
+	return unsafe.String(&a.text[n], len(b))
+}
+
+// number returns the NUMBER text as Number, or as string.
+func (a *fetchArena) number(b []byte, asString bool) driver.Value {
+	a.count(func(c *arenaCounters) *atomic.Int64 { return &c.values })
+	s := a.textOf(b)
+	if asString {
+		return s
+	}
+	return Number(s)
+}
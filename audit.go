@@ -0,0 +1,270 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AuditEvent is the report of a successful DML execution (OpExec) or commit (OpCommit).
+type AuditEvent struct {
+	Time time.Time
+	// Query is the DML statement, empty for OpCommit.
+	Query string
+	// TxID is the local transaction ID (DBMS_TRANSACTION.LOCAL_TRANSACTION_ID),
+	// empty for the DML executed (and committed) outside of a transaction.
+	TxID string
+	// Tables are the names of the modified tables, as parsed from the statement
+	// (for OpCommit, from all the statements of the transaction).
+	Tables []string
+	// Rows is the number of affected rows (for OpCommit, the sum for the transaction).
+	Rows int64
+	Kind OpKind
+}
+
+// auditTx is the DML executed in the transaction, reported by the commit.
+type auditTx struct {
+	id     string
+	tables []string
+	rows   int64
+}
+
+// SetAuditHook sets the hook called after each successful DML (INSERT, UPDATE, DELETE, MERGE)
+// execution and each commit of a transaction with DML.
+// A nil hook switches it off.
+//
+// The DML executed by PL/SQL blocks is not reported.
+// Determining the transaction ID costs a round trip, once for each transaction.
+// The hook is called synchronously, and must not use the connection.
+func (d *drv) SetAuditHook(hook func(context.Context, AuditEvent)) {
+	if hook == nil {
+		d.audit.Store(nil)
+		return
+	}
+	d.audit.Store(&hook)
+}
+
+// SetAuditHook sets the audit hook of the default driver.
+func SetAuditHook(hook func(context.Context, AuditEvent)) { defaultDrv.SetAuditHook(hook) }
+
+func (c *conn) auditHook() func(context.Context, AuditEvent) {
+	if c == nil || c.drv == nil {
+		return nil
+	}
+	if p := c.drv.audit.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// audit reports the successful execution of the DML statement.
+func (st *statement) audit(ctx context.Context, rows int64) {
+	if st.conn == nil || st.dpiStmtInfo.isDML != 1 {
+		return
+	}
+	c := st.conn
+	hook := c.auditHook()
+	if hook == nil {
+		return
+	}
+	ev := AuditEvent{
+		Time: time.Now(), Kind: OpExec,
+		Query: st.query, Tables: dmlTables(st.query), Rows: rows,
+	}
+	c.mu.RLock()
+	inTx := c.inTransaction
+	c.mu.RUnlock()
+	if inTx {
+		ev.TxID, _ = c.transactionID(ctx)
+		c.mu.Lock()
+		c.audit.tables = appendNewStrings(c.audit.tables, ev.Tables...)
+		c.audit.rows += rows
+		c.mu.Unlock()
+	}
+	hook(ctx, ev)
+}
+
+// auditCommit reports the commit of the transaction, if it executed DML.
+func (c *conn) auditCommit(ctx context.Context, tx auditTx) {
+	hook := c.auditHook()
+	if hook == nil || tx.id == "" && tx.tables == nil {
+		return
+	}
+	hook(ctx, AuditEvent{
+		Time: time.Now(), Kind: OpCommit,
+		TxID: tx.id, Tables: tx.tables, Rows: tx.rows,
+	})
+}
+
+// transactionID returns the (cached) local transaction ID of the current transaction.
+func (c *conn) transactionID(ctx context.Context) (string, error) {
+	c.mu.RLock()
+	id := c.audit.id
+	c.mu.RUnlock()
+	if id != "" {
+		return id, nil
+	}
+	if ctx == nil || ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	const qry = `SELECT DBMS_TRANSACTION.local_transaction_id FROM DUAL`
+	stmt, err := c.prepareContext(ctx, qry)
+	if err != nil {
+		return "", err
+	}
+	defer stmt.Close()
+	rows, err := stmt.(*statement).queryContext(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	dest := make([]driver.Value, 1)
+	if err = rows.Next(dest); err != nil {
+		return "", fmt.Errorf("%s: %w", qry, err)
+	}
+	if dest[0] != nil {
+		id = fmt.Sprint(dest[0])
+	}
+	c.mu.Lock()
+	c.audit.id = id
+	c.mu.Unlock()
+	return id, nil
+}
+
+// dmlTables returns the names of the tables modified by the INSERT (also multi-table),
+// UPDATE, DELETE or MERGE statement, normalized as ParseObjectName does.
+//
+// This is a best effort lexer, not a parser: comments (hints) and string literals are skipped,
+// and nil is returned for the not recognized statements.
+func dmlTables(qry string) []string {
+	toks := sqlTokens(qry)
+	if len(toks) == 0 {
+		return nil
+	}
+	var tables []string
+	add := func(i int) {
+		if i >= len(toks) {
+			return
+		}
+		// skip subqueries, bind variables and other non-identifiers
+		if on, err := ParseObjectName(toks[i]); err == nil {
+			tables = appendNewStrings(tables, on.String())
+		}
+	}
+	isKw := func(i int, kw string) bool { return i < len(toks) && strings.EqualFold(toks[i], kw) }
+	switch strings.ToUpper(toks[0]) {
+	case "INSERT":
+		if isKw(1, "INTO") {
+			add(2)
+			break
+		}
+		if !isKw(1, "ALL") && !isKw(1, "FIRST") {
+			break
+		}
+		// INSERT ALL|FIRST [WHEN ... THEN] INTO a ... [ELSE] INTO b ... SELECT,
+		// the INTOs of the subquery (and of RETURNING) are not tables to insert into.
+		var depth int
+	Loop:
+		for i := 2; i < len(toks); i++ {
+			switch {
+			case toks[i] == "(":
+				depth++
+			case toks[i] == ")":
+				depth--
+			case depth != 0:
+			case isKw(i, "INTO"):
+				add(i + 1)
+			case isKw(i, "SELECT"), isKw(i, "WITH"), isKw(i, "RETURNING"), isKw(i, "RETURN"):
+				break Loop
+			}
+		}
+	case "UPDATE":
+		add(1)
+	case "DELETE":
+		if isKw(1, "FROM") {
+			add(2)
+		} else {
+			add(1)
+		}
+	case "MERGE":
+		if isKw(1, "INTO") {
+			add(2)
+		}
+	}
+	return tables
+}
+
+// sqlTokens splits the SQL text to names (with their schema, dblink and quoted parts)
+// and single punctuation characters, skipping whitespace, comments and string literals.
+func sqlTokens(qry string) []string {
+	var toks []string
+	isName := func(b byte) bool {
+		return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9' ||
+			b == '_' || b == '$' || b == '#' || b == '.' || b == '@' || b >= 0x80
+	}
+	for i := 0; i < len(qry); {
+		b := qry[i]
+		switch {
+		case b == ' ' || b == '\t' || b == '\n' || b == '\r':
+			i++
+		case strings.HasPrefix(qry[i:], "--"):
+			if j := strings.IndexByte(qry[i:], '\n'); j >= 0 {
+				i += j + 1
+			} else {
+				i = len(qry)
+			}
+		case strings.HasPrefix(qry[i:], "/*"):
+			if j := strings.Index(qry[i+2:], "*/"); j >= 0 {
+				i += 2 + j + 2
+			} else {
+				i = len(qry)
+			}
+		case b == '\'':
+			// '' is the escaped quote, so skipping two literals is the same
+			if j := strings.IndexByte(qry[i+1:], '\''); j >= 0 {
+				i += 1 + j + 1
+			} else {
+				i = len(qry)
+			}
+		case b == '"' || isName(b):
+			start := i
+			for i < len(qry) && (qry[i] == '"' || isName(qry[i])) {
+				if qry[i] == '"' {
+					if j := strings.IndexByte(qry[i+1:], '"'); j >= 0 {
+						i += 1 + j + 1
+						continue
+					}
+					i = len(qry)
+					break
+				}
+				i++
+			}
+			toks = append(toks, qry[start:i])
+		default:
+			toks = append(toks, qry[i:i+1])
+			i++
+		}
+	}
+	return toks
+}
+
+// appendNewStrings appends the elements of ss not already in dst.
+func appendNewStrings(dst []string, ss ...string) []string {
+Outer:
+	for _, s := range ss {
+		for _, d := range dst {
+			if d == s {
+				continue Outer
+			}
+		}
+		dst = append(dst, s)
+	}
+	return dst
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDMLTables(t *testing.T) {
+	for _, tc := range []struct {
+		qry  string
+		want []string
+	}{
+		{"INSERT INTO emp (id) VALUES (:1)", []string{"EMP"}},
+		{"insert /*+ APPEND */ into scott.emp SELECT * FROM emp_stage", []string{"SCOTT.EMP"}},
+		{"INSERT ALL INTO a VALUES (1) INTO \"b\" VALUES (2) INTO a VALUES (3) SELECT * FROM DUAL", []string{"A", `"b"`}},
+		{"INSERT INTO emp (id) VALUES (emp_seq.NEXTVAL) RETURNING id INTO :id", []string{"EMP"}},
+		{"INSERT FIRST WHEN x > 1 THEN INTO a VALUES (x) ELSE INTO b (y) VALUES (x) SELECT x FROM (SELECT 1 x FROM DUAL)", []string{"A", "B"}},
+		{"INSERT INTO :1 VALUES (1)", nil},
+		{"UPDATE hr.\"Emp\"@remote.link SET name = 'INTO x' WHERE id = :id", []string{`HR."Emp"@REMOTE.LINK`}},
+		{"-- comment\nDELETE FROM t1 WHERE x IN (SELECT y FROM t2)", []string{"T1"}},
+		{"DELETE t1 WHERE 1 = 0", []string{"T1"}},
+		{"MERGE INTO dst d USING src s ON (d.id = s.id) WHEN MATCHED THEN UPDATE SET d.x = s.x", []string{"DST"}},
+		{"UPDATE (SELECT * FROM t) SET x = 1", nil},
+		{"SELECT * FROM t", nil},
+		{"", nil},
+	} {
+		if got := dmlTables(tc.qry); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: got %q, wanted %q", tc.qry, got, tc.want)
+		}
+	}
+}
+
+func TestAuditCommit(t *testing.T) {
+	d := &drv{}
+	c := &conn{drv: d}
+	c.auditCommit(context.Background(), auditTx{id: "1.2.3"}) // no hook
+
+	var got []AuditEvent
+	d.SetAuditHook(func(_ context.Context, ev AuditEvent) { got = append(got, ev) })
+	c.auditCommit(context.Background(), auditTx{})
+	c.auditCommit(context.Background(), auditTx{id: "1.2.3", tables: []string{"T"}, rows: 2})
+	if len(got) != 1 || got[0].Kind != OpCommit || got[0].TxID != "1.2.3" || got[0].Rows != 2 {
+		t.Errorf("got %+v", got)
+	}
+
+	d.SetAuditHook(nil)
+	c.auditCommit(context.Background(), auditTx{id: "4.5.6"})
+	if len(got) != 1 {
+		t.Errorf("hook called after switching off")
+	}
+}
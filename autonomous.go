@@ -0,0 +1,58 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Autonomous executes f in a new transaction on another session of the pool,
+// and commits it if f returns nil, independently of the transaction of the caller
+// - e.g. for audit logging which must persist even if the caller rolls back.
+//
+// The work is rolled back if f returns an error (or panics).
+//
+// WARNING: the autonomous transaction does not see the uncommitted changes of the caller,
+// and waits for (deadlocks on) the rows locked by the caller!
+func Autonomous(ctx context.Context, db *sql.DB, f func(*sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err = f(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AutonomousBlock executes the PL/SQL statements (the body of a block) in an
+// autonomous transaction on the session of ex, committing them independently of
+// the transaction of the session, or rolling them back on error.
+//
+// The body can have bind variables, bound to the args.
+func AutonomousBlock(ctx context.Context, ex Execer, body string, args ...interface{}) error {
+	body = strings.TrimSpace(body)
+	if !strings.HasSuffix(body, ";") {
+		body += ";"
+	}
+	qry := `DECLARE
+  PRAGMA AUTONOMOUS_TRANSACTION;
+BEGIN
+  ` + body + `
+  COMMIT;
+EXCEPTION WHEN OTHERS THEN
+  ROLLBACK;
+  RAISE;
+END;`
+	if _, err := ex.ExecContext(ctx, qry, args...); err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return nil
+}
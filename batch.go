@@ -8,26 +8,49 @@ package godror
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 const DefaultBatchLimit = 1024
 
 // Batch collects the Added rows and executes in batches, after collecting Limit number of rows.
 // The default Limit is DefaultBatchLimit.
+//
+// The Stmt can be any DML, such as the ones InsertStatement and MergeStatement return.
+//
+// With ContinueOnError, the rows failing are collected (see RowErrors) and the rest is executed,
+// otherwise the first failing row fails the whole Flush.
 type Batch struct {
-	Stmt         *sql.Stmt
-	values       []interface{}
-	rValues      []reflect.Value
-	size, Limit  int
-	rowsAffected int64
+	Stmt            *sql.Stmt
+	values          []interface{}
+	rValues         []reflect.Value
+	rowErrors       []BatchRowError
+	size, Limit     int
+	flushed         int
+	rowsAffected    int64
+	ContinueOnError bool
+}
+
+// BatchRowError is the error of a row of a Batch.
+type BatchRowError struct {
+	Err *OraErr
+	// Row is the index of the row, counting all the rows Added to the Batch.
+	Row int
 }
 
+func (re BatchRowError) Error() string { return fmt.Sprintf("%d. %v", re.Row, re.Err) }
+func (re BatchRowError) Unwrap() error { return re.Err }
+
 // Add the values. The first call initializes the storage,
 // so all the subsequent calls to Add must use the same number of values,
 // with the same types.
 //
+// A row can be a single struct with BindStructTag tagged fields, too,
+// which are bound by name.
+//
 // When the number of added rows reaches Size, Flush is called.
 func (b *Batch) Add(ctx context.Context, values ...interface{}) error {
 	if b.rValues == nil {
@@ -87,6 +110,9 @@ func (b *Batch) Size() int { return b.size }
 // RowsAffected returns the accumulated number of rows affected by all Flush operations.
 func (b *Batch) RowsAffected() int64 { return b.rowsAffected }
 
+// RowErrors returns the errors of the failed rows, collected with ContinueOnError.
+func (b *Batch) RowErrors() []BatchRowError { return b.rowErrors }
+
 // Flush executes the statement and clears the storage.
 func (b *Batch) Flush(ctx context.Context) error {
 	if len(b.rValues) == 0 || b.rValues[0].Len() == 0 {
@@ -104,17 +130,31 @@ func (b *Batch) Flush(ctx context.Context) error {
 		}
 	}
 
-	result, err := b.Stmt.ExecContext(ctx, b.values...)
-	if err != nil {
-		return err
+	args := b.values
+	if b.ContinueOnError {
+		args = append(args[:len(args):len(args)], ContinueOnError())
 	}
+	result, err := b.Stmt.ExecContext(ctx, args...)
+	if err != nil {
+		var be *BatchErrors
+		if !b.ContinueOnError || !errors.As(err, &be) {
+			return err
+		}
+		for _, oe := range be.Errs {
+			b.rowErrors = append(b.rowErrors, BatchRowError{Row: b.flushed + oe.Offset(), Err: oe})
+		}
+		for _, n := range be.RowCounts {
+			b.rowsAffected += n
+		}
+	} else {
+		rowsAffected, rowsAffectedErr := result.RowsAffected()
+		if rowsAffectedErr != nil {
+			return rowsAffectedErr
+		}
 
-	rowsAffected, rowsAffectedErr := result.RowsAffected()
-	if rowsAffectedErr != nil {
-		return rowsAffectedErr
+		b.rowsAffected += rowsAffected
 	}
-
-	b.rowsAffected += rowsAffected
+	b.flushed += b.size
 
 	for i, v := range b.rValues {
 		if v.IsValid() {
@@ -125,3 +165,66 @@ func (b *Batch) Flush(ctx context.Context) error {
 
 	return nil
 }
+
+// InsertStatement returns an INSERT statement for the columns of the table,
+// with the columns' names as bind variable names.
+func InsertStatement(table string, columns ...string) string {
+	var buf strings.Builder
+	buf.WriteString("INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (")
+	for i, c := range columns {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(":" + c)
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+// MergeStatement returns a MERGE statement which updates the rows of the table matching on the keys,
+// and inserts the rest. The columns must contain the keys, too.
+// The columns' names are the bind variable names.
+func MergeStatement(table string, keys []string, columns ...string) string {
+	isKey := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		isKey[strings.ToUpper(k)] = true
+	}
+	var buf strings.Builder
+	buf.WriteString("MERGE INTO " + table + " D USING (SELECT ")
+	for i, c := range columns {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(":" + c + " AS " + c)
+	}
+	buf.WriteString(" FROM DUAL) S ON (")
+	for i, k := range keys {
+		if i != 0 {
+			buf.WriteString(" AND ")
+		}
+		buf.WriteString("D." + k + " = S." + k)
+	}
+	buf.WriteString(")")
+	var n int
+	for _, c := range columns {
+		if isKey[strings.ToUpper(c)] {
+			continue
+		}
+		if n == 0 {
+			buf.WriteString(" WHEN MATCHED THEN UPDATE SET ")
+		} else {
+			buf.WriteString(", ")
+		}
+		n++
+		buf.WriteString("D." + c + " = S." + c)
+	}
+	buf.WriteString(" WHEN NOT MATCHED THEN INSERT (" + strings.Join(columns, ", ") + ") VALUES (")
+	for i, c := range columns {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("S." + c)
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+)
+
+// BindValue is a bound parameter, as passed to the BindHook.
+type BindValue struct {
+	// Value is the value after the conversions (driver.Valuer etc.), just before binding.
+	Value interface{}
+	// Name is the name of the parameter, empty for positional binds.
+	Name string
+	// Position is the 1-based position of the parameter.
+	Position int
+	// IsOut is true for OUT (and IN OUT) parameters.
+	IsOut bool
+}
+
+// BindHook is called with the statement text and the bind values, before each execution.
+//
+// The values (and the slice) must not be modified, nor kept after the call.
+type BindHook func(ctx context.Context, qry string, binds []BindValue)
+
+// RedactPolicy says which parameters must be masked before passing them to the BindHook.
+type RedactPolicy struct {
+	// Mask replaces the values of the redacted parameters, "***" by default.
+	Mask interface{}
+	// Match reports whether the named parameter must be redacted, in addition to Names.
+	Match func(name string) bool
+	// Names of the redacted parameters, case insensitive, without the colon.
+	Names []string
+	// Positions (1-based) of the redacted parameters.
+	Positions []int
+	// All parameters are redacted.
+	All bool
+}
+
+// Redact returns the binds with the values of the matching parameters replaced by the mask.
+//
+// The binds are modified in place.
+func (p RedactPolicy) Redact(binds []BindValue) []BindValue {
+	if !p.All && p.Match == nil && len(p.Names) == 0 && len(p.Positions) == 0 {
+		return binds
+	}
+	mask := p.mask()
+	for i, b := range binds {
+		if p.redacted(b) {
+			binds[i].Value = mask
+		}
+	}
+	return binds
+}
+
+func (p RedactPolicy) mask() interface{} {
+	if p.Mask != nil {
+		return p.Mask
+	}
+	return "***"
+}
+
+func (p RedactPolicy) redacted(b BindValue) bool {
+	if p.All {
+		return true
+	}
+	for _, pos := range p.Positions {
+		if pos == b.Position {
+			return true
+		}
+	}
+	if b.Name == "" {
+		return false
+	}
+	name := strings.TrimPrefix(b.Name, ":")
+	for _, nm := range p.Names {
+		if strings.EqualFold(strings.TrimPrefix(nm, ":"), name) {
+			return true
+		}
+	}
+	return p.Match != nil && p.Match(name)
+}
+
+type bindHook struct {
+	hook   BindHook
+	policy RedactPolicy
+}
+
+var globalBindHook atomic.Pointer[bindHook]
+
+// SetBindHook sets the global BindHook, with the redaction policy applied before calling it.
+//
+// A nil hook removes the global hook.
+func SetBindHook(hook BindHook, policy RedactPolicy) {
+	if hook == nil {
+		globalBindHook.Store(nil)
+		return
+	}
+	globalBindHook.Store(&bindHook{hook: hook, policy: policy})
+}
+
+type bindHookCtxKey struct{}
+
+// ContextWithBindHook returns a context with the given BindHook and redaction policy,
+// overriding the global BindHook for the statements executed with this context.
+func ContextWithBindHook(ctx context.Context, hook BindHook, policy RedactPolicy) context.Context {
+	return context.WithValue(ctx, bindHookCtxKey{}, &bindHook{hook: hook, policy: policy})
+}
+
+func getBindHook(ctx context.Context) *bindHook {
+	if ctx != nil {
+		if bh, ok := ctx.Value(bindHookCtxKey{}).(*bindHook); ok {
+			if bh.hook == nil {
+				return nil
+			}
+			return bh
+		}
+	}
+	return globalBindHook.Load()
+}
+
+func (bh *bindHook) call(ctx context.Context, qry string, binds []BindValue) {
+	bh.hook(ctx, qry, bh.policy.Redact(binds))
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRedactPolicy(t *testing.T) {
+	binds := []BindValue{
+		{Name: "user", Position: 1, Value: "scott"},
+		{Name: "PASSWD", Position: 2, Value: "tiger"},
+		{Name: "card_no", Position: 3, Value: "1234"},
+		{Position: 4, Value: int64(4)},
+	}
+	p := RedactPolicy{
+		Names:     []string{":passwd"},
+		Positions: []int{4},
+		Match:     func(name string) bool { return strings.HasPrefix(name, "card") },
+	}
+	got := p.Redact(binds)
+	for i, want := range []interface{}{"scott", "***", "***", "***"} {
+		if got[i].Value != want {
+			t.Errorf("%d. got %v, wanted %v", i, got[i].Value, want)
+		}
+	}
+}
+
+func TestGetBindHook(t *testing.T) {
+	if bh := getBindHook(context.Background()); bh != nil {
+		t.Fatalf("got %+v without hook", bh)
+	}
+	var called bool
+	SetBindHook(func(context.Context, string, []BindValue) { called = true }, RedactPolicy{})
+	defer SetBindHook(nil, RedactPolicy{})
+	bh := getBindHook(context.Background())
+	if bh == nil {
+		t.Fatal("no global hook")
+	}
+	bh.call(context.Background(), "", nil)
+	if !called {
+		t.Error("hook not called")
+	}
+	if bh = getBindHook(ContextWithBindHook(context.Background(), nil, RedactPolicy{})); bh != nil {
+		t.Error("context with nil hook should disable the global hook")
+	}
+}
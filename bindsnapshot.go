@@ -0,0 +1,46 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+)
+
+// BindsError is the error of a failed execution with the redacted snapshot of its bind variables,
+// returned with the BindsOnError option.
+type BindsError struct {
+	Err error
+	// Binds are the bind variables: their names, types and sizes, followed by "=" and the value
+	// (or the mask of the RedactPolicy), such as `:1 string(3)="abc"` or ":id int64=***".
+	Binds []string
+}
+
+func (be *BindsError) Error() string {
+	return be.Err.Error() + " [binds: " + strings.Join(be.Binds, ", ") + "]"
+}
+func (be *BindsError) Unwrap() error { return be.Err }
+
+// BindsOnError returns an option to wrap the error of a failed execution in a *BindsError,
+// with the snapshot of the bind variables - their values masked as the policy says
+// (RedactPolicy{All: true} masks all of them).
+//
+// Use it "naked", without sql.Named!
+func BindsOnError(policy RedactPolicy) Option {
+	return func(o *stmtOptions) {
+		o.bindsOnError = true
+		o.bindsPolicy = policy
+	}
+}
+
+// snapshotBinds wraps err with the snapshot of the bind variables, if requested.
+func (st *statement) snapshotBinds(err error, args []driver.NamedValue) error {
+	if err == nil || !st.bindsOnError || errors.Is(err, driver.ErrBadConn) {
+		return err
+	}
+	return &BindsError{Err: err, Binds: redactBinds(args, st.bindsPolicy)}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotBinds(t *testing.T) {
+	args := []driver.NamedValue{
+		{Ordinal: 1, Value: "secret"},
+		{Name: "id", Ordinal: 2, Value: int64(42)},
+	}
+	oraErr := &OraErr{code: 1400}
+	st := &statement{}
+	if err := st.snapshotBinds(oraErr, args); err != oraErr {
+		t.Errorf("wrapped without the option: %v", err)
+	}
+
+	BindsOnError(RedactPolicy{Positions: []int{1}})(&st.stmtOptions)
+	if err := st.snapshotBinds(nil, args); err != nil {
+		t.Errorf("nil error wrapped: %v", err)
+	}
+	if err := st.snapshotBinds(driver.ErrBadConn, args); err != driver.ErrBadConn {
+		t.Errorf("ErrBadConn wrapped: %v", err)
+	}
+	err := st.snapshotBinds(oraErr, args)
+	t.Log(err)
+	var be *BindsError
+	if !errors.As(err, &be) {
+		t.Fatalf("got %T, wanted *BindsError", err)
+	}
+	if want := []string{":1 string(6)=***", ":id int64=42"}; !reflect.DeepEqual(be.Binds, want) {
+		t.Errorf("got %q, wanted %q", be.Binds, want)
+	}
+	if oe, ok := AsOraErr(err); !ok || oe.Code() != 1400 {
+		t.Errorf("OraErr is lost: %v", err)
+	}
+	if want := "ORA-01400:  [binds: :1 string(6)=***, :id int64=42]"; err.Error() != want {
+		t.Errorf("got %q, wanted %q", err.Error(), want)
+	}
+}
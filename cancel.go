@@ -0,0 +1,88 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CancelStats holds the statistics of breaking in-flight calls on context cancelation.
+type CancelStats struct {
+	// Breaks is the number of calls broken because of context cancelation.
+	Breaks uint64
+	// TotalLatency is the sum, MaxLatency is the maximum of the durations
+	// from the cancelation till the broken call returned.
+	TotalLatency, MaxLatency time.Duration
+}
+
+type cancelStats struct {
+	stats CancelStats
+	mu    sync.Mutex
+}
+
+func (s *cancelStats) add(latency time.Duration) {
+	s.mu.Lock()
+	s.stats.Breaks++
+	s.stats.TotalLatency += latency
+	if latency > s.stats.MaxLatency {
+		s.stats.MaxLatency = latency
+	}
+	s.mu.Unlock()
+}
+
+func (s *cancelStats) get() CancelStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// watchCancel starts a watchdog which breaks the execution on the connection
+// when ctx is canceled before the returned stop function is called.
+//
+// The caller must call stop right after the guarded call returned.
+func (c *conn) watchCancel(ctx context.Context, what string) (stop func()) {
+	if c == nil || ctx == nil || ctx.Done() == nil || c.params.IsPrelim || c.params.NoBreakOnContextCancel {
+		return func() {}
+	}
+	done := make(chan struct{})
+	var canceledAt atomic.Int64
+	go func() {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			select {
+			case <-done:
+			default:
+				canceledAt.Store(time.Now().UnixNano())
+				if logger := c.getLogger(ctx); logger != nil {
+					logger.Warn("BREAK "+what, "error", ctx.Err())
+				}
+				_ = c.Break()
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		if t := canceledAt.Load(); t != 0 && c.drv != nil {
+			c.drv.cancelStats.add(time.Since(time.Unix(0, t)))
+		}
+	}
+}
+
+// CancelStats returns the statistics of the calls broken on context cancelation.
+func (d *drv) CancelStats() CancelStats {
+	if d == nil {
+		return CancelStats{}
+	}
+	return d.cancelStats.get()
+}
+
+// GetCancelStats returns the statistics of the calls broken on context cancelation,
+// for the default driver.
+func GetCancelStats() CancelStats { return defaultDrv.CancelStats() }
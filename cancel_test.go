@@ -0,0 +1,20 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCancelStats(t *testing.T) {
+	var s cancelStats
+	s.add(time.Second)
+	s.add(3 * time.Second)
+	if got, want := s.get(), (CancelStats{Breaks: 2, TotalLatency: 4 * time.Second, MaxLatency: 3 * time.Second}); got != want {
+		t.Errorf("got %+v, wanted %+v", got, want)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import "bytes"
+
+// maxCharBind is the maximum size in bytes of a CHAR in SQL.
+const maxCharBind = 2000
+
+// TrimChar returns an option to return the CHAR(n) and NCHAR(n) values with their trailing blanks trimmed,
+// as other drivers (for example JDBC with fixedString=false) do - by default they're returned padded to n.
+//
+// Use it "naked", without sql.Named!
+func TrimChar() Option { return func(o *stmtOptions) { o.trimChar = true } }
+
+// BindStringAsChar returns an option to bind the IN string (NString) parameters as CHAR (NCHAR), not VARCHAR2,
+// so comparisons use blank-padded semantics: WHERE char_col = :1 matches "abc" for 'abc  ' in a CHAR(5).
+// Strings longer than 2000 bytes are bound as VARCHAR2.
+//
+// Use it "naked", without sql.Named!
+func BindStringAsChar() Option { return func(o *stmtOptions) { o.bindStringAsChar = true } }
+
+// trimBlanks returns b without the trailing blanks.
+func trimBlanks(b []byte) []byte { return bytes.TrimRight(b, " ") }
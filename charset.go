@@ -0,0 +1,110 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include "dpiImpl.h"
+*/
+import "C"
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// EncodingInfo is the client character set of the connection, the data is converted to.
+type EncodingInfo struct {
+	// Encoding is the IANA name of the character set of CHAR, VARCHAR2 and CLOB data,
+	// NEncoding is of NCHAR, NVARCHAR2 and NCLOB data.
+	Encoding, NEncoding string
+	// MaxBytesPerChar is the maximum number of bytes of a character in Encoding,
+	// NMaxBytesPerChar in NEncoding.
+	MaxBytesPerChar, NMaxBytesPerChar int
+}
+
+// EncodingConn is the optional interface of a Conn for its client character set.
+type EncodingConn interface {
+	EncodingInfo() (EncodingInfo, error)
+}
+
+var _ EncodingConn = (*conn)(nil)
+
+// EncodingInfo returns the client character set of the connection.
+func (c *conn) EncodingInfo() (EncodingInfo, error) {
+	var info C.dpiEncodingInfo
+	if err := c.checkExec(func() C.int { return C.dpiConn_getEncodingInfo(c.dpiConn, &info) }); err != nil {
+		return EncodingInfo{}, fmt.Errorf("getEncodingInfo: %w", err)
+	}
+	return EncodingInfo{
+		Encoding: C.GoString(info.encoding), MaxBytesPerChar: int(info.maxBytesPerCharacter),
+		NEncoding: C.GoString(info.nencoding), NMaxBytesPerChar: int(info.nmaxBytesPerCharacter),
+	}, nil
+}
+
+// ClientEncoding returns the client character set of the connection.
+func ClientEncoding(ctx context.Context, ex Execer) (ei EncodingInfo, err error) {
+	err = Raw(ctx, ex, func(c Conn) error {
+		ec, ok := c.(EncodingConn)
+		if !ok {
+			return fmt.Errorf("%T has no encoding info: %w", c, ErrNotSupported)
+		}
+		ei, err = ec.EncodingInfo()
+		return err
+	})
+	return ei, err
+}
+
+// CharsetForm is the character set form of a column (SQLCS_IMPLICIT or SQLCS_NCHAR).
+type CharsetForm uint8
+
+const (
+	// CharsetFormImplicit is the database character set, of CHAR, VARCHAR2, LONG and CLOB.
+	CharsetFormImplicit = CharsetForm(1)
+	// CharsetFormNChar is the national character set, of NCHAR, NVARCHAR2 and NCLOB.
+	CharsetFormNChar = CharsetForm(2)
+)
+
+// charsetFormOf returns the character set form of the Oracle type, 0 for non-character types.
+func charsetFormOf(typ C.dpiOracleTypeNum) CharsetForm {
+	switch typ {
+	case C.DPI_ORACLE_TYPE_VARCHAR, C.DPI_ORACLE_TYPE_CHAR, C.DPI_ORACLE_TYPE_LONG_VARCHAR, C.DPI_ORACLE_TYPE_CLOB:
+		return CharsetFormImplicit
+	case C.DPI_ORACLE_TYPE_NVARCHAR, C.DPI_ORACLE_TYPE_NCHAR, C.DPI_ORACLE_TYPE_LONG_NVARCHAR, C.DPI_ORACLE_TYPE_NCLOB:
+		return CharsetFormNChar
+	}
+	return 0
+}
+
+// ErrCharsetConversion is returned with StrictCharsetConversion for text that could not be
+// converted to the client character set.
+var ErrCharsetConversion = errors.New("lossy character set conversion")
+
+// StrictCharsetConversion returns an option to return ErrCharsetConversion, instead of the text
+// with replacement characters, for fetched text that could not be represented in the client character set
+// - for data quality sensitive migrations.
+//
+// This needs a UTF-8 client character set (the default), as it checks for invalid UTF-8 and the
+// U+FFFD replacement character, which is refused even if stored as is.
+//
+// Use it "naked", without sql.Named!
+func StrictCharsetConversion() Option {
+	return func(o *stmtOptions) { o.strictCharset = true }
+}
+
+var utf8Replacement = []byte(string(utf8.RuneError))
+
+// checkCharsetConversion returns ErrCharsetConversion if b has a replacement character or is not valid UTF-8.
+func checkCharsetConversion(b []byte) error {
+	if i := bytes.Index(b, utf8Replacement); i >= 0 {
+		return fmt.Errorf("replacement character at byte %d: %w", i, ErrCharsetConversion)
+	}
+	if !utf8.Valid(b) {
+		return fmt.Errorf("invalid UTF-8: %w", ErrCharsetConversion)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckCharsetConversion(t *testing.T) {
+	for s, wantErr := range map[string]bool{
+		"":                       false,
+		"árvíztűrő tükörfúrógép": false,
+		"a\uFFFDb":               true,
+		"a\xffb":                 true,
+		"\xc3":                   true,
+	} {
+		if err := checkCharsetConversion([]byte(s)); wantErr != errors.Is(err, ErrCharsetConversion) {
+			t.Errorf("%q: got %+v, wanted error: %t", s, err, wantErr)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include "dpiImpl.h"
+*/
+import "C"
+
+// ColumnInfo is the detailed metadata of a result column,
+// which does not fit into sql.ColumnType.
+//
+// Get them with the FetchColumnInfo option.
+type ColumnInfo struct {
+	Name string
+	// TypeName is the database type name, as ColumnTypeDatabaseTypeName returns.
+	TypeName string
+	// ObjectTypeName is the full name (schema.[package.]name) of the user-defined type of the column.
+	ObjectTypeName string
+	DomainAnnotation
+	// Size is the size in bytes (in the client character set), SizeInChars is the size in characters of character columns.
+	Size, SizeInChars int
+	Precision, Scale  int
+	Nullable          bool
+	// DBSize is the size in bytes in the database character set.
+	DBSize int
+	// CharSemantics is true if the length of the character column is in characters (VARCHAR2(10 CHAR)),
+	// false if in bytes (VARCHAR2(10 BYTE)) - NCHAR and NVARCHAR2 always have CHAR semantics.
+	CharSemantics bool
+	// FsPrecision is the fractional seconds precision of TIMESTAMP and INTERVAL DAY TO SECOND columns.
+	FsPrecision int
+	// National is true for national character set (NCHAR, NVARCHAR2, NCLOB) columns.
+	National bool
+	// CharsetForm is the character set form of the character columns, 0 for the others.
+	CharsetForm CharsetForm
+	// IsJSON is true for JSON columns, and columns with an IS JSON check constraint.
+	IsJSON bool
+	// IsOSON is true for columns with the binary JSON (OSON) format.
+	IsOSON bool
+	// IsVector is true for VECTOR columns, VectorDimensions is their number of dimensions (0 if flexible).
+	IsVector         bool
+	VectorDimensions int
+}
+
+// ColumnInfo returns the detailed metadata of the column.
+func (r *rows) ColumnInfo(index int) ColumnInfo {
+	col := r.columns[index]
+	ci := ColumnInfo{
+		Name:             col.Name,
+		TypeName:         r.ColumnTypeDatabaseTypeName(index),
+		DomainAnnotation: col.DomainAnnotation,
+		Size:             int(col.Size),
+		SizeInChars:      int(col.SizeInChars),
+		DBSize:           int(col.DBSize),
+		CharSemantics:    col.CharSemantics,
+		Precision:        int(col.Precision),
+		Scale:            int(col.Scale),
+		FsPrecision:      int(col.FsPrecision),
+		Nullable:         col.Nullable,
+		IsJSON:           col.IsJSON || col.OracleType == C.DPI_ORACLE_TYPE_JSON,
+		IsOSON:           col.IsOSON,
+		IsVector:         col.OracleType == C.DPI_ORACLE_TYPE_VECTOR,
+		VectorDimensions: int(col.VectorDimensions),
+	}
+	switch col.OracleType {
+	case C.DPI_ORACLE_TYPE_NCHAR, C.DPI_ORACLE_TYPE_NVARCHAR, C.DPI_ORACLE_TYPE_NCLOB, C.DPI_ORACLE_TYPE_LONG_NVARCHAR:
+		ci.National = true
+	}
+	ci.CharsetForm = charsetFormOf(col.OrigOracleType)
+	if col.ObjectType != nil && r.statement != nil {
+		ci.ObjectTypeName, _ = r.statement.objectTypeName(col.ObjectType)
+	}
+	return ci
+}
+
+func (r *rows) columnInfos() []ColumnInfo {
+	infos := make([]ColumnInfo, len(r.columns))
+	for i := range infos {
+		infos[i] = r.ColumnInfo(i)
+	}
+	return infos
+}
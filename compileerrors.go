@@ -0,0 +1,199 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CompilationError is returned by the execution of a CREATE or ALTER ... COMPILE statement
+// of a PL/SQL object (procedure, function, package, type, trigger) or view
+// with the WarningAsError option, when it succeeded with compilation errors (ORA-24344).
+//
+// Errors are the entries of ALL_ERRORS of the object, warnings included.
+type CompilationError struct {
+	Err    error
+	Errors []CompileError
+}
+
+func (ce *CompilationError) Error() string {
+	var buf strings.Builder
+	buf.WriteString(ce.Err.Error())
+	for _, e := range ce.Errors {
+		buf.WriteString("\n")
+		buf.WriteString(e.Error())
+	}
+	return buf.String()
+}
+func (ce *CompilationError) Unwrap() error { return ce.Err }
+
+const compileErrorsQry = `SELECT owner, name, type, line, position, message_number, text, attribute
+  FROM all_errors
+  WHERE owner = NVL(:1, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')) AND name = :2 AND type = NVL(:3, type)
+  ORDER BY type, sequence`
+
+// GetObjectCompileErrors returns the compilation errors of the named ([schema.]name) object,
+// from ALL_ERRORS - of both the specification and the body of packages and types.
+// Names of package members and database links are refused.
+//
+// If all is false, only errors are returned; otherwise, warnings, too.
+func GetObjectCompileErrors(ctx context.Context, q Querier, name string, all bool) ([]CompileError, error) {
+	on, err := ParseObjectName(name)
+	if err != nil {
+		return nil, err
+	}
+	if on.Package != "" || on.DBLink != "" {
+		return nil, fmt.Errorf("%q: not a [schema.]name object name", name)
+	}
+	rows, err := q.QueryContext(ctx, compileErrorsQry, on.Schema, on.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", compileErrorsQry, err)
+	}
+	defer rows.Close()
+	var errs []CompileError
+	for rows.Next() {
+		var ce CompileError
+		var attr string
+		if err = rows.Scan(&ce.Owner, &ce.Name, &ce.Type, &ce.Line, &ce.Position, &ce.Code, &ce.Text, &attr); err != nil {
+			return errs, fmt.Errorf("%s: %w", compileErrorsQry, err)
+		}
+		if ce.Warning = attr == "WARNING"; !ce.Warning || all {
+			errs = append(errs, ce)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return errs, err
+	}
+	return errs, rows.Close()
+}
+
+// compilationError returns err with the compilation errors of the object
+// created or compiled by the statement, if err is ORA-24344.
+func (st *statement) compilationError(ctx context.Context, err error) error {
+	var oe *OraErr
+	if !errors.As(err, &oe) || oe.Code() != 24344 {
+		return err
+	}
+	on, typ := compiledObject(st.query)
+	if on.Name == "" {
+		return err
+	}
+	errs, qErr := st.conn.getCompileErrors(ctx, on, typ)
+	if qErr != nil {
+		if logger := getLogger(ctx); logger != nil {
+			logger.Warn("getCompileErrors", "object", on.String(), "error", qErr)
+		}
+		return err
+	}
+	return &CompilationError{Err: err, Errors: errs}
+}
+
+// getCompileErrors returns the ALL_ERRORS entries of the object.
+//
+// It is called while executing a statement, with c.mu held.
+func (c *conn) getCompileErrors(ctx context.Context, on ObjectName, typ string) ([]CompileError, error) {
+	stmt, err := c.prepareContextNotLocked(ctx, compileErrorsQry)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	rows, err := stmt.(*statement).queryContextNotLocked(ctx, []driver.NamedValue{
+		{Ordinal: 1, Value: on.Schema}, {Ordinal: 2, Value: on.Name}, {Ordinal: 3, Value: typ},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", compileErrorsQry, err)
+	}
+	defer rows.Close()
+	var errs []CompileError
+	dest := make([]driver.Value, 8)
+	for {
+		if err = rows.Next(dest); err != nil {
+			if err == io.EOF {
+				return errs, nil
+			}
+			return errs, fmt.Errorf("%s: %w", compileErrorsQry, err)
+		}
+		str := func(i int) string {
+			if dest[i] == nil {
+				return ""
+			}
+			return fmt.Sprint(dest[i])
+		}
+		num := func(i int) int64 { n, _ := strconv.ParseInt(str(i), 10, 64); return n }
+		errs = append(errs, CompileError{
+			Owner: str(0), Name: str(1), Type: str(2),
+			Line: num(3), Position: num(4), Code: num(5), Text: str(6),
+			Warning: str(7) == "WARNING",
+		})
+	}
+}
+
+// compiledObject returns the name and the ALL_ERRORS type of the object
+// created by the CREATE statement or compiled by the ALTER ... COMPILE statement,
+// the empty ObjectName for other statements.
+//
+// The type is empty for compiling both the specification and the body.
+func compiledObject(qry string) (ObjectName, string) {
+	toks := sqlTokens(qry)
+	isKw := func(i int, kws ...string) bool {
+		if i >= len(toks) {
+			return false
+		}
+		for _, kw := range kws {
+			if strings.EqualFold(toks[i], kw) {
+				return true
+			}
+		}
+		return false
+	}
+	if !isKw(0, "CREATE", "ALTER") {
+		return ObjectName{}, ""
+	}
+	create := isKw(0, "CREATE")
+	i := 1
+	for ; isKw(i, "OR", "REPLACE", "EDITIONABLE", "NONEDITIONABLE", "EDITIONING", "FORCE", "NO"); i++ {
+	}
+	if !isKw(i, "PROCEDURE", "FUNCTION", "PACKAGE", "TYPE", "TRIGGER", "VIEW") {
+		return ObjectName{}, ""
+	}
+	typ := strings.ToUpper(toks[i])
+	i++
+	if create && isKw(i, "BODY") {
+		typ += " BODY"
+		i++
+	}
+	if isKw(i, "IF") && isKw(i+1, "NOT") && isKw(i+2, "EXISTS") {
+		i += 3
+	}
+	if i >= len(toks) {
+		return ObjectName{}, ""
+	}
+	on, err := ParseObjectName(toks[i])
+	if err != nil || on.Package != "" || on.DBLink != "" {
+		return ObjectName{}, ""
+	}
+	if !create {
+		// ALTER PACKAGE name COMPILE [PACKAGE | SPECIFICATION | BODY]
+		if !isKw(i+1, "COMPILE") {
+			return ObjectName{}, ""
+		}
+		if typ == "PACKAGE" || typ == "TYPE" {
+			switch {
+			case isKw(i+2, "BODY"):
+				typ += " BODY"
+			case !isKw(i+2, "SPECIFICATION"):
+				typ = ""
+			}
+		}
+	}
+	return on, typ
+}
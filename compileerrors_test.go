@@ -0,0 +1,46 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCompiledObject(t *testing.T) {
+	for qry, want := range map[string]struct{ name, typ string }{
+		"CREATE OR REPLACE PROCEDURE scott.p IS BEGIN NULL; END;":          {"SCOTT.P", "PROCEDURE"},
+		"create or replace editionable package body pkg AS END;":           {"PKG", "PACKAGE BODY"},
+		"CREATE TYPE IF NOT EXISTS t_obj AS OBJECT (a NUMBER)":             {"T_OBJ", "TYPE"},
+		`CREATE OR REPLACE FORCE VIEW "MyView" AS SELECT * FROM nothing`:   {`"MyView"`, "VIEW"},
+		"CREATE FUNCTION f(a IN NUMBER) RETURN NUMBER IS BEGIN NULL; END;": {"F", "FUNCTION"},
+		"ALTER PACKAGE pkg COMPILE":                                        {"PKG", ""},
+		"ALTER PACKAGE pkg COMPILE BODY":                                   {"PKG", "PACKAGE BODY"},
+		"ALTER TYPE t COMPILE SPECIFICATION":                               {"T", "TYPE"},
+		"ALTER TRIGGER trg COMPILE":                                        {"TRG", "TRIGGER"},
+		"ALTER TRIGGER trg DISABLE":                                        {"", ""},
+		"CREATE TABLE t (a NUMBER)":                                        {"", ""},
+		"BEGIN NULL; END;":                                                 {"", ""},
+	} {
+		on, typ := compiledObject(qry)
+		var name string
+		if on.Name != "" {
+			name = on.String()
+		}
+		if name != want.name || typ != want.typ {
+			t.Errorf("%q: got %q %q, wanted %q %q", qry, name, typ, want.name, want.typ)
+		}
+	}
+}
+
+func TestGetObjectCompileErrorsName(t *testing.T) {
+	for _, name := range []string{"scott.pkg.proc", "pkg@remote", "a;b"} {
+		// refused before querying
+		if _, err := GetObjectCompileErrors(context.Background(), nil, name, false); err == nil {
+			t.Errorf("%q: wanted error", name)
+		}
+	}
+}
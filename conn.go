@@ -57,17 +57,29 @@ type conn struct {
 	drv                 *drv
 	dpiConn             *C.dpiConn
 	currentTT           atomic.Value
-	tranParams          tranParams
 	poolKey             string
+	proxyUser           string
 	Edition, DomainName string
 	DBName, ServiceName string
 	Server              VersionInfo
 	params              dsn.ConnectionParams
 	mu                  sync.RWMutex
 	objTypes            map[string]*ObjectType
+	stmtCache           stmtCacheMirror
+	cursors             cursorTracker
+	tracer              Tracer
+	txCtx               context.Context // the context of BeginTx, for tracing and auditing
+	audit               auditTx         // the DML of the transaction, for the audit hook
+	ecid                string
+	sid                 SessionID // cached by sessionID
+	traceOpts           TraceOptions
+	callTimeoutDefault  time.Duration
 	tzOffSecs           int
 	inTransaction       bool
+	commitMode          CommitMode
 	released            bool
+	containerSwitched   bool   // by SetContainer, away from acquiredContainer
+	acquiredContainer   string // the container of the session when acquired, queried by SetContainer
 	tzValid             bool
 }
 
@@ -98,6 +110,14 @@ func (c *conn) checkExecWithWarning(f func() C.int) error {
 
 // used before an ODPI call to force it to return within the context deadline
 func (c *conn) handleDeadline(ctx context.Context) (cleanup func(), err error) {
+	return c.handleCallTimeout(ctx, 0)
+}
+
+// handleCallTimeout sets the call timeout (OCI_ATTR_CALL_TIMEOUT) for the ODPI calls to the lesser of
+// the context deadline and the given timeout (or the connection's default call timeout, if zero).
+//
+// The returned cleanup function restores the connection's default call timeout.
+func (c *conn) handleCallTimeout(ctx context.Context, timeout time.Duration) (cleanup func(), err error) {
 	cleanup = func() {}
 	logger := c.getLogger(ctx)
 	if err := ctx.Err(); err != nil {
@@ -106,17 +126,35 @@ func (c *conn) handleDeadline(ctx context.Context) (cleanup func(), err error) {
 		}
 		return cleanup, err
 	}
-	if dl, hasDeadline := ctx.Deadline(); hasDeadline && func() bool {
+	dl, hasDeadline := ctx.Deadline()
+	if !hasDeadline && timeout == 0 {
+		return cleanup, nil
+	}
+	var defaultMs C.uint32_t
+	if func() bool {
 		c.mu.RLock()
 		defer c.mu.RUnlock()
 		if c.drv.clientVersion.Version < 18 {
 			// nosemgrep: trailofbits.go.missing-runlock-on-rwmutex.missing-runlock-on-rwmutex
 			return false
 		}
-		dur := time.Until(dl)
-		const minDur = 100 * time.Millisecond
-		if dur < minDur {
-			dur = 100 * time.Millisecond
+		defaultMs = C.uint32_t(c.callTimeoutDefault / time.Millisecond)
+		dur := timeout
+		if dur == 0 {
+			dur = c.callTimeoutDefault
+		}
+		if hasDeadline {
+			dlDur := time.Until(dl)
+			const minDur = 100 * time.Millisecond
+			if dlDur < minDur {
+				dlDur = 100 * time.Millisecond
+			}
+			if dur == 0 || dlDur < dur {
+				dur = dlDur
+			}
+		}
+		if dur < time.Millisecond {
+			dur = time.Millisecond
 		}
 		ms := C.uint32_t(dur / time.Millisecond)
 		if logger != nil {
@@ -129,12 +167,12 @@ func (c *conn) handleDeadline(ctx context.Context) (cleanup func(), err error) {
 		if logger != nil {
 			logger.Warn("setCallTimeout failed!")
 		}
-		_ = C.dpiConn_setCallTimeout(c.dpiConn, 0)
+		_ = C.dpiConn_setCallTimeout(c.dpiConn, defaultMs)
 		// nosemgrep: trailofbits.go.missing-runlock-on-rwmutex.missing-runlock-on-rwmutex
 		return false
 	}() {
 		return func() {
-			_ = C.dpiConn_setCallTimeout(c.dpiConn, 0)
+			_ = C.dpiConn_setCallTimeout(c.dpiConn, defaultMs)
 		}, nil
 
 	}
@@ -142,6 +180,47 @@ func (c *conn) handleDeadline(ctx context.Context) (cleanup func(), err error) {
 	return cleanup, nil
 }
 
+// CallTimeoutConn is the optional interface of a Conn for its default round-trip timeout.
+type CallTimeoutConn interface {
+	SetCallTimeout(time.Duration) error
+	CallTimeout() time.Duration
+}
+
+var _ CallTimeoutConn = (*conn)(nil)
+
+// SetCallTimeout sets the default round-trip timeout (OCI_ATTR_CALL_TIMEOUT) of the connection:
+// each call to the database (execute, fetch...) fails with an error which
+// IsCallTimeout recognizes, if it takes longer than d.
+//
+// Zero means no timeout. The CallTimeout statement option and context deadlines take precedence,
+// if they are shorter. Needs Oracle Client 18 or newer.
+//
+// The timeout is reset when the connection is closed (returned to the pool).
+func (c *conn) SetCallTimeout(d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.drv.clientVersion.Version < 18 {
+		return fmt.Errorf("call timeout needs client version 18 or newer: %w", ErrNotSupported)
+	}
+	if d < 0 {
+		d = 0
+	}
+	if err := c.checkExec(func() C.int {
+		return C.dpiConn_setCallTimeout(c.dpiConn, C.uint32_t(d/time.Millisecond))
+	}); err != nil {
+		return err
+	}
+	c.callTimeoutDefault = d
+	return nil
+}
+
+// CallTimeout returns the default round-trip timeout of the connection, as set by SetCallTimeout.
+func (c *conn) CallTimeout() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.callTimeoutDefault
+}
+
 // Break signals the server to stop the execution on the connection.
 //
 // The execution should fail with ORA-1013: "user requested cancel of current operation".
@@ -241,9 +320,48 @@ func (c *conn) closeNotLocking() error {
 		return nil
 	}
 	c.dpiConn = nil
+	resourceCounters.conns.Add(-1)
+	dropped := c.containerSwitched && c.poolKey != ""
+	c.containerSwitched, c.acquiredContainer = false, ""
+	if dropped {
+		// do not return a session switched to another container to the pool
+		if C.dpiConn_close(dpiConn, C.DPI_MODE_CONN_CLOSE_DROP, nil, 0) == C.DPI_FAILURE {
+			if logger := getLogger(context.TODO()); logger != nil {
+				logger.Warn("drop container switched session", "error", c.getError())
+			}
+		}
+	}
+	if c.poolKey != "" && c.drv != nil {
+		c.poolEvent(PoolReturn, c.drv.tracker.release(c))
+		kind := PoolSessionDestroyed
+		if !dropped && dpiConn.refCount <= 1 && c.drv.poolEvents.Load() != nil {
+			// ODPI-C drops the session on release if its server connection is broken
+			var isHealthy C.int
+			dropped = C.dpiConn_getIsHealthy(dpiConn, &isHealthy) == C.DPI_FAILURE || isHealthy == 0
+			if dropped && c.params.EnableEvents {
+				// FAN marks the server connections of a down instance or service
+				kind = PoolSessionCulled
+			}
+		}
+		if dropped {
+			c.poolEvent(kind, 0)
+		}
+	}
+	if c.ecid != "" {
+		// do not pass on the execution context ID to the next user of the pooled session
+		c.ecid = ""
+		_ = C.dpiConn_setEcontextId(dpiConn, nil, 0)
+	}
+	if c.callTimeoutDefault != 0 {
+		// do not pass on the call timeout to the next user of the pooled session
+		c.callTimeoutDefault = 0
+		_ = C.dpiConn_setCallTimeout(dpiConn, 0)
+	}
 	if dpiConn.refCount <= 1 {
 		c.tzOffSecs, c.tzValid, c.params.Timezone = 0, false, nil
 	}
+	c.stmtCache.reset()
+	c.cursors.reset()
 	for k, v := range c.objTypes {
 		_ = v.Close()
 		delete(c.objTypes, k)
@@ -276,68 +394,166 @@ func (c *conn) Begin() (driver.Tx, error) {
 // This must also check opts.ReadOnly to determine if the read-only
 // value is true to either set the read-only transaction property if supported
 // or return an error if it is not supported.
+//
+// The isolation levels are mapped to Oracle's: LevelReadCommitted is READ COMMITTED,
+// LevelRepeatableRead, LevelSnapshot and LevelSerializable are SERIALIZABLE
+// (which is snapshot isolation in Oracle). The other levels return an error.
+// A ReadOnly transaction is READ ONLY, which is always transaction-level read consistent.
+//
+// See ContextWithRollbackSegment for assigning the transaction to a rollback segment,
+// and ContextWithCommitMode for relaxing the durability of its commit.
 func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	qry, err := setTransaction(sql.IsolationLevel(opts.Isolation), opts.ReadOnly, rollbackSegment(ctx))
+	if err != nil {
+		return nil, err
+	}
 
-	const (
-		trRO = "SET TRANSACTION READ ONLY"
-		trRW = "SET TRANSACTION READ WRITE"
-		trLC = "ALTER SESSION SET ISOLATION_LEVEL=READ COMMIT" + "TED" // against misspell check
-		trLS = "ALTER SESSION SET ISOLATION_LEVEL=SERIALIZABLE"
-	)
-
-	var todo tranParams
-	if opts.ReadOnly {
-		todo.RW = trRO
-	} else {
-		todo.RW = trRW
+	c.mu.Lock()
+	if c.inTransaction {
+		c.mu.Unlock()
+		return nil, errors.New("already in transaction")
+	}
+	// the SET TRANSACTION must not be committed on success
+	c.inTransaction = true
+	c.commitMode = commitMode(ctx)
+	if c.tracer != nil || c.auditHook() != nil {
+		c.txCtx = ctx
+	}
+	c.mu.Unlock()
+	if tt, ok := ctx.Value(traceTagCtxKey{}).(TraceTag); ok {
+		_ = c.setTraceTag(tt)
+	}
+	if qry != "" {
+		st, err := c.PrepareContext(ctx, qry)
+		if err == nil {
+			_, err = st.(driver.StmtExecContext).ExecContext(ctx, nil)
+			st.Close()
+		}
+		if err != nil {
+			c.mu.Lock()
+			c.inTransaction = false
+			c.mu.Unlock()
+			return nil, maybeBadConn(fmt.Errorf("%s: %w", qry, err), c)
+		}
 	}
-	switch level := sql.IsolationLevel(opts.Isolation); level {
+	return c, nil
+}
+
+// setTransaction returns the SET TRANSACTION statement for the isolation level,
+// read-only mode and rollback segment - empty if none is needed.
+//
+// As SET TRANSACTION must be the first statement of the transaction,
+// only one of them can be set.
+func setTransaction(level sql.IsolationLevel, readOnly bool, rollbackSegment string) (string, error) {
+	var isolation string
+	switch level {
 	case sql.LevelDefault:
 	case sql.LevelReadCommitted:
-		todo.Level = trLC
-	case sql.LevelSerializable:
-		todo.Level = trLS
+		isolation = "READ COMMIT" + "TED" // against misspell check
+	case sql.LevelRepeatableRead, sql.LevelSnapshot, sql.LevelSerializable:
+		isolation = "SERIALIZABLE"
 	default:
-		return nil, fmt.Errorf("isolation level is not supported: %s", sql.IsolationLevel(opts.Isolation))
+		return "", fmt.Errorf("isolation level is not supported: %s", level)
+	}
+	if readOnly {
+		if rollbackSegment != "" {
+			return "", errors.New("rollback segment cannot be set for a read-only transaction")
+		}
+		// READ ONLY transactions see the data as of their start, satisfying all the levels
+		return "SET TRANSACTION READ ONLY", nil
+	}
+	if rollbackSegment != "" {
+		if isolation != "" {
+			return "", fmt.Errorf("isolation level %s cannot be set together with a rollback segment", level)
+		}
+		if !isRollbackSegmentName(rollbackSegment) {
+			return "", fmt.Errorf("invalid rollback segment name %q", rollbackSegment)
+		}
+		return "SET TRANSACTION USE ROLLBACK SEGMENT " + rollbackSegment, nil
 	}
+	if isolation != "" {
+		return "SET TRANSACTION ISOLATION LEVEL " + isolation, nil
+	}
+	return "", nil
+}
 
-	if todo != c.tranParams {
-		for _, qry := range []string{todo.RW, todo.Level} {
-			if qry == "" {
-				continue
-			}
-			st, err := c.PrepareContext(ctx, qry)
-			if err == nil {
-				_, err = st.(driver.StmtExecContext).ExecContext(ctx, nil)
-				st.Close()
-			}
-			if err != nil {
-				return nil, maybeBadConn(fmt.Errorf("%s: %w", qry, err), c)
-			}
+// isRollbackSegmentName reports whether name is a plain (unquoted) identifier:
+// a letter followed by letters, digits, _, $ and #, at most 128 bytes.
+func isRollbackSegmentName(name string) bool {
+	if name == "" || len(name) > 128 {
+		return false
+	}
+	for i, r := range name {
+		letter := 'A' <= r && r <= 'Z' || 'a' <= r && r <= 'z'
+		if !letter && (i == 0 || !('0' <= r && r <= '9') && r != '_' && r != '$' && r != '#') {
+			return false
 		}
-		c.tranParams = todo
 	}
+	return true
+}
 
-	c.mu.RLock()
-	inTran := c.inTransaction
-	c.mu.RUnlock()
-	if inTran {
-		return nil, errors.New("already in transaction")
+type rollbackSegmentCtxKey struct{}
+
+// ContextWithRollbackSegment returns a context which assigns the transactions begun with it
+// (BeginTx) to the named rollback segment (SET TRANSACTION USE ROLLBACK SEGMENT).
+//
+// This is meaningful only with manual undo management, and cannot be used with
+// read-only transactions or non-default isolation levels.
+// The name must be a plain identifier: a letter followed by letters, digits, _, $ and #.
+func ContextWithRollbackSegment(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, rollbackSegmentCtxKey{}, name)
+}
+
+func rollbackSegment(ctx context.Context) string {
+	name, _ := ctx.Value(rollbackSegmentCtxKey{}).(string)
+	return name
+}
+
+// CommitMode relaxes the durability of the commit of a transaction (COMMIT WRITE ...),
+// trading it for throughput, e.g. for loaders which can reload the last few transactions.
+//
+// The default is COMMIT WRITE IMMEDIATE WAIT: the commit returns after the redo is written to disk.
+type CommitMode uint8
+
+const (
+	// CommitDefault is the COMMIT of the session (COMMIT_LOGGING and COMMIT_WAIT parameters).
+	CommitDefault CommitMode = 0
+	// CommitWriteBatch buffers the redo, to be written with other transactions' (WRITE BATCH).
+	CommitWriteBatch CommitMode = 1
+	// CommitWriteNoWait returns without waiting for the redo to be written (WRITE NOWAIT),
+	// so a committed transaction may be lost on an instance failure.
+	CommitWriteNoWait CommitMode = 2
+)
+
+// statement returns the COMMIT statement of the mode.
+func (m CommitMode) statement() string {
+	if m == CommitDefault {
+		return "COMMIT"
 	}
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.inTransaction = true
-	if tt, ok := ctx.Value(traceTagCtxKey{}).(TraceTag); ok {
-		_ = c.setTraceTag(tt)
+	qry := "COMMIT WRITE IMMEDIATE"
+	if m&CommitWriteBatch != 0 {
+		qry = "COMMIT WRITE BATCH"
 	}
-	return c, nil
+	if m&CommitWriteNoWait != 0 {
+		return qry + " NOWAIT"
+	}
+	return qry + " WAIT"
+}
+
+type commitModeCtxKey struct{}
+
+// ContextWithCommitMode returns a context which makes the transactions begun with it (BeginTx)
+// commit with the given CommitMode, e.g. CommitWriteBatch|CommitWriteNoWait.
+func ContextWithCommitMode(ctx context.Context, mode CommitMode) context.Context {
+	return context.WithValue(ctx, commitModeCtxKey{}, mode)
 }
 
-type tranParams struct {
-	RW, Level string
+func commitMode(ctx context.Context) CommitMode {
+	mode, _ := ctx.Value(commitModeCtxKey{}).(CommitMode)
+	return mode
 }
 
 // PrepareContext returns a prepared statement, bound to this connection.
@@ -347,6 +563,44 @@ func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, e
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	if query == getConnection {
+		return c.prepareContext(ctx, query)
+	}
+	if !c.intercepted() {
+		return c.prepareInstrumented(ctx, query)
+	}
+	op := &Op{Kind: OpPrepare, Query: query}
+	var stmt driver.Stmt
+	err := c.intercept(ctx, op, func(ctx context.Context, op *Op) (err error) {
+		stmt, err = c.prepareInstrumented(ctx, op.Query)
+		return err
+	})
+	if err == nil && stmt == nil {
+		err = fmt.Errorf("%s: %w", OpPrepare, ErrInterceptedNoResult)
+	}
+	return stmt, err
+}
+
+// prepareInstrumented prepares the statement with the slow query hook and tracing.
+func (c *conn) prepareInstrumented(ctx context.Context, query string) (driver.Stmt, error) {
+	if c.tracer == nil && (c.drv == nil || c.drv.slowQuery.Load() == nil) {
+		return c.prepareContext(ctx, query)
+	}
+	var span Span
+	if c.tracer != nil {
+		ctx, span = c.startSpan(ctx, "prepare", TraceAttr{Key: "db.query.text", Value: query})
+	}
+	start := time.Now()
+	stmt, err := c.prepareContext(ctx, query)
+	if st, ok := stmt.(*statement); ok {
+		st.prepareDur = time.Since(start)
+	}
+	if span != nil {
+		span.End(err)
+	}
+	return stmt, err
+}
+func (c *conn) prepareContext(ctx context.Context, query string) (driver.Stmt, error) {
 
 	if tt, ok := ctx.Value(traceTagCtxKey{}).(TraceTag); ok {
 		_ = c.setTraceTag(tt)
@@ -374,32 +628,89 @@ func (c *conn) prepareContextNotLocked(ctx context.Context, query string) (drive
 		C.free(unsafe.Pointer(cSQL))
 	}()
 	st := &statement{conn: c, query: query}
+	st.stmtOptions.applyContextOptions(ctx)
 	err := c.checkExec(func() C.int {
 		return C.dpiConn_prepareStmt(c.dpiConn, 0, cSQL, C.uint32_t(len(query)), nil, 0,
 			(**C.dpiStmt)(unsafe.Pointer(&st.dpiStmt)))
 	})
 	if err != nil {
-		return nil, maybeBadConn(fmt.Errorf("prepare: %s: %w", query, err), c)
+		return nil, maybeBadConn(fmt.Errorf("prepare: %s: %w", query, withSQL(err, query)), c)
 	}
+	resourceCounters.stmts.Add(1)
 	if err := c.checkExec(func() C.int { return C.dpiStmt_getInfo(st.dpiStmt, &st.dpiStmtInfo) }); err != nil {
 		err = maybeBadConn(fmt.Errorf("getStmtInfo: %w", err), c)
 		st.Close()
 		return nil, err
 	}
+	c.stmtCachePrepared(query)
+	if st.dpiStmtInfo.isDDL == 1 {
+		// one-off DDL should not push out the cached DML
+		C.dpiStmt_deleteFromCache(st.dpiStmt)
+		c.stmtCache.remove(query)
+	}
 	stmtSetFinalizer(ctx, st, "prepareContext")
 	return st, nil
 }
 func (c *conn) Commit() error {
-	return c.endTran(true)
+	return c.traceTxEnd(true, c.commit)
+}
+func (c *conn) commit() error {
+	c.mu.RLock()
+	mode := c.commitMode
+	c.mu.RUnlock()
+	if mode == CommitDefault {
+		return c.endTran(true)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// database/sql does not call Rollback after a failed Commit, so the transaction ends either way
+	c.inTransaction, c.commitMode = false, CommitDefault
+	// the COMMIT statement commits itself
+	err := c.execDirect(mode.statement())
+	if err != nil && !errors.Is(err, driver.ErrBadConn) {
+		// do not leave the uncommitted changes to the next autocommitted statement
+		_ = c.checkExec(func() C.int { return C.dpiConn_rollback(c.dpiConn) })
+	}
+	return err
 }
 func (c *conn) Rollback() error {
-	return c.endTran(false)
+	return c.traceTxEnd(false, func() error { return c.endTran(false) })
+}
+
+// traceTxEnd calls end (commit or rollback) through the interceptors, in a span if traced.
+func (c *conn) traceTxEnd(isCommit bool, end func() error) error {
+	c.mu.Lock()
+	ctx, tx := c.txCtx, c.audit
+	c.txCtx, c.audit = nil, auditTx{}
+	c.mu.Unlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	op := &Op{Kind: OpRollback}
+	if isCommit {
+		op.Kind = OpCommit
+	}
+	if c.drv != nil {
+		c.drv.roundTrips.transaction.Add(1)
+	}
+	err := c.intercept(ctx, op, func(ctx context.Context, op *Op) error {
+		if !c.traceSampled() {
+			return end()
+		}
+		_, span := c.startSpan(ctx, op.Kind.String())
+		err := end()
+		span.End(err)
+		return err
+	})
+	if err == nil && isCommit {
+		c.auditCommit(ctx, tx)
+	}
+	return err
 }
 func (c *conn) endTran(isCommit bool) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.inTransaction = false
-	c.tranParams = tranParams{}
+	c.inTransaction, c.commitMode = false, CommitDefault
 
 	var err error
 	//msg := "Commit"
@@ -486,7 +797,8 @@ func (c *conn) init(ctx context.Context, isNew bool, onInit func(ctx context.Con
 		logger.Debug("init connection", "params", c.params)
 	}
 
-	if err := c.initTZ(); err != nil || onInit == nil {
+	// Prelim connections cannot execute anything, OnInit would fail.
+	if err := c.initTZ(); err != nil || onInit == nil || c.params.IsPrelim {
 		return err
 	}
 	if logger != nil {
@@ -1010,6 +1322,32 @@ func ContextWithUserPassw(ctx context.Context, user, password, connClass string)
 			ConnClass: connClass})
 }
 
+type proxyUserCtxKey struct{}
+
+// ContextWithProxyUser returns a context which makes the connection acquired with it
+// to be a proxy session of the given user, authenticated with the credentials
+// of the connector (so the connector's user must have been granted with
+// "ALTER USER proxyUser GRANT CONNECT THROUGH user").
+//
+// For session pools, all such connections come from the heterogeneous variant of
+// the pool (created with the same parameters on first use),
+// so per-request impersonation does not need a pool per user.
+// For standalone connections, the "user[proxyUser]" syntax is used.
+//
+// A connection acquired for another (or no) proxy user is not reused by database/sql:
+// ResetSession rejects it, so a new one is acquired with this context.
+func ContextWithProxyUser(ctx context.Context, proxyUser string) context.Context {
+	return context.WithValue(ctx, proxyUserCtxKey{}, proxyUser)
+}
+
+func getProxyUser(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	s, _ := ctx.Value(proxyUserCtxKey{}).(string)
+	return s
+}
+
 // StartupMode for the database.
 type StartupMode C.dpiStartupMode
 
@@ -1033,6 +1371,32 @@ func (c *conn) Startup(mode StartupMode) error {
 	return nil
 }
 
+// PfileStartupConn is the optional interface of a Conn for starting the database with a parameter file.
+type PfileStartupConn interface {
+	StartupWithPfile(StartupMode, string) error
+}
+
+var _ PfileStartupConn = (*conn)(nil)
+
+// StartupWithPfile starts the database with the parameter file, equivalent to
+// "startup nomount pfile=<pfile>" in SQL*Plus.
+// The pfile is the path of the file on the client, which may name the SPFILE
+// on the server with a single SPFILE=... line.
+// This should be called on PRELIM_AUTH (prelim=1) connection!
+func (c *conn) StartupWithPfile(mode StartupMode, pfile string) error {
+	if pfile == "" {
+		return c.Startup(mode)
+	}
+	cPfile := C.CString(pfile)
+	defer C.free(unsafe.Pointer(cPfile))
+	if err := c.checkExec(func() C.int {
+		return C.dpiConn_startupDatabaseWithPfile(c.dpiConn, cPfile, C.uint32_t(len(pfile)), C.dpiStartupMode(mode))
+	}); err != nil {
+		return fmt.Errorf("startup(%v, pfile=%q): %w", mode, pfile, err)
+	}
+	return nil
+}
+
 // ShutdownMode for the database.
 type ShutdownMode C.dpiShutdownMode
 
@@ -1062,6 +1426,165 @@ func (c *conn) Shutdown(mode ShutdownMode) error {
 	return nil
 }
 
+// StartupDatabase starts the database instance, mounts and opens it,
+// like "startup" in SQL*Plus.
+//
+// The instance is started on a preliminary authentication (prelim=1) connection,
+// then mounted and opened on a normal connection.
+// P.AdminRole defaults to SysDBA.
+func StartupDatabase(ctx context.Context, P dsn.ConnectionParams, mode StartupMode) error {
+	return startupDatabase(ctx, P, StartupOptions{Mode: mode}, nil)
+}
+
+// startupDatabase starts, mounts and opens the database, then calls after (if not nil)
+// on the normal connection.
+func startupDatabase(ctx context.Context, P dsn.ConnectionParams, opts StartupOptions, after func(*sql.DB) error) error {
+	if P.AdminRole == dsn.NoRole {
+		P.AdminRole = dsn.SysDBA
+	}
+	P.StandaloneConnection = sql.NullBool{Valid: true, Bool: true}
+	P.IsPrelim = true
+	if err := withDatabase(P, func(db *sql.DB) error {
+		return Raw(ctx, db, func(conn Conn) error {
+			ps, ok := conn.(PfileStartupConn)
+			if !ok {
+				return fmt.Errorf("%T cannot start up with a pfile: %w", conn, ErrNotSupported)
+			}
+			return ps.StartupWithPfile(opts.Mode, opts.Pfile)
+		})
+	}); err != nil {
+		return err
+	}
+	// You cannot alter database on the prelim_auth connection.
+	P.IsPrelim = false
+	open := "ALTER DATABASE OPEN"
+	if opts.ReadOnly {
+		open += " READ ONLY"
+	}
+	return withDatabase(P, func(db *sql.DB) error {
+		for _, qry := range []string{"ALTER DATABASE MOUNT", open} {
+			if _, err := db.ExecContext(ctx, qry); err != nil {
+				return fmt.Errorf("%s: %w", qry, err)
+			}
+		}
+		if after == nil {
+			return nil
+		}
+		return after(db)
+	})
+}
+
+// ShutdownDatabase closes, dismounts and shuts down the database instance,
+// like "shutdown" in SQL*Plus.
+//
+// ShutdownAbort terminates the instance immediately, without closing and dismounting.
+// P.AdminRole defaults to SysDBA.
+func ShutdownDatabase(ctx context.Context, P dsn.ConnectionParams, mode ShutdownMode) error {
+	if P.AdminRole == dsn.NoRole {
+		P.AdminRole = dsn.SysDBA
+	}
+	P.StandaloneConnection = sql.NullBool{Valid: true, Bool: true}
+	P.IsPrelim = false
+	return withDatabase(P, func(db *sql.DB) error {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		if err = conn.Raw(func(dc any) error { return dc.(Conn).Shutdown(mode) }); err != nil || mode == ShutdownAbort {
+			return err
+		}
+		for _, qry := range []string{"ALTER DATABASE CLOSE NORMAL", "ALTER DATABASE DISMOUNT"} {
+			if _, err = conn.ExecContext(ctx, qry); err != nil {
+				return fmt.Errorf("%s: %w", qry, err)
+			}
+		}
+		return conn.Raw(func(dc any) error { return dc.(Conn).Shutdown(ShutdownFinal) })
+	})
+}
+
+func withDatabase(P dsn.ConnectionParams, f func(*sql.DB) error) error {
+	db := sql.OpenDB(NewConnector(P))
+	defer db.Close()
+	return f(db)
+}
+
+// setSessionTimezone sets the session time zone to tz (if not empty) with ALTER SESSION SET TIME_ZONE,
+// and returns the function restoring the time zone the session had before.
+func (c *conn) setSessionTimezone(tz string) (restore func() error, err error) {
+	if tz == "" {
+		return func() error { return nil }, nil
+	}
+	prev, err := c.queryDirect("SELECT SESSIONTIMEZONE FROM DUAL")
+	if err != nil {
+		return nil, err
+	}
+	if err = c.alterSessionTimezone(tz); err != nil {
+		return nil, err
+	}
+	return func() error { return c.alterSessionTimezone(prev) }, nil
+}
+
+func (c *conn) alterSessionTimezone(tz string) error {
+	qry := "ALTER SESSION SET TIME_ZONE = '" + strings.ReplaceAll(tz, "'", "''") + "'"
+	if strings.EqualFold(tz, "LOCAL") || strings.EqualFold(tz, "DBTIMEZONE") {
+		qry = "ALTER SESSION SET TIME_ZONE = " + tz
+	}
+	return c.execDirect(qry)
+}
+
+// execDirect executes the statement without bind variables, bypassing the statement cache mirror.
+func (c *conn) execDirect(qry string) error {
+	cSQL := C.CString(qry)
+	defer C.free(unsafe.Pointer(cSQL))
+	var dpiStmt *C.dpiStmt
+	if err := c.checkExec(func() C.int {
+		return C.dpiConn_prepareStmt(c.dpiConn, 0, cSQL, C.uint32_t(len(qry)), nil, 0, &dpiStmt)
+	}); err != nil {
+		return maybeBadConn(fmt.Errorf("prepare: %s: %w", qry, err), c)
+	}
+	defer C.dpiStmt_release(dpiStmt)
+	if err := c.checkExec(func() C.int { return C.dpiStmt_execute(dpiStmt, C.DPI_MODE_EXEC_DEFAULT, nil) }); err != nil {
+		return maybeBadConn(fmt.Errorf("%s: %w", qry, err), c)
+	}
+	return nil
+}
+
+// queryDirect returns the first column of the first row of the query as string,
+// bypassing the statement cache mirror.
+func (c *conn) queryDirect(qry string) (string, error) {
+	cSQL := C.CString(qry)
+	defer C.free(unsafe.Pointer(cSQL))
+	var dpiStmt *C.dpiStmt
+	if err := c.checkExec(func() C.int {
+		return C.dpiConn_prepareStmt(c.dpiConn, 0, cSQL, C.uint32_t(len(qry)), nil, 0, &dpiStmt)
+	}); err != nil {
+		return "", maybeBadConn(fmt.Errorf("prepare: %s: %w", qry, err), c)
+	}
+	defer C.dpiStmt_release(dpiStmt)
+	var found C.int
+	var bufferRowIndex C.uint32_t
+	var nativeTypeNum C.dpiNativeTypeNum
+	var data *C.dpiData
+	if err := c.checkExec(func() C.int {
+		if C.dpiStmt_execute(dpiStmt, C.DPI_MODE_EXEC_DEFAULT, nil) == C.DPI_FAILURE ||
+			C.dpiStmt_fetch(dpiStmt, &found, &bufferRowIndex) == C.DPI_FAILURE {
+			return C.DPI_FAILURE
+		}
+		if found == 0 {
+			return C.DPI_SUCCESS
+		}
+		return C.dpiStmt_getQueryValue(dpiStmt, 1, &nativeTypeNum, &data)
+	}); err != nil {
+		return "", maybeBadConn(fmt.Errorf("%s: %w", qry, err), c)
+	}
+	if found == 0 || data == nil || data.isNull == 1 || nativeTypeNum != C.DPI_NATIVE_TYPE_BYTES {
+		return "", nil
+	}
+	b := (*C.dpiBytes)(unsafe.Pointer(&data.value))
+	return C.GoStringN(b.ptr, C.int(b.length)), nil
+}
+
 // Timezone returns the connection's timezone.
 func (c *conn) Timezone() *time.Location { return c.params.Timezone }
 
@@ -1083,6 +1606,11 @@ func (c *conn) ResetSession(ctx context.Context) error {
 	if !dpiConnOK {
 		return driver.ErrBadConn
 	}
+	// Do not reuse a connection acquired for another proxy user.
+	if getProxyUser(ctx) != c.proxyUser {
+		return driver.ErrBadConn
+	}
+	c.applyContextTags(ctx)
 	return nil
 }
 
@@ -1116,7 +1644,10 @@ func (c *conn) IsValid() bool {
 	}
 	if !pooled {
 		// not pooled connection
-		return dpiConnOK
+		c.mu.RLock()
+		switched := c.containerSwitched
+		c.mu.RUnlock()
+		return dpiConnOK && !switched
 	}
 
 	// FIXME(tgulacsi): Prepared statements hold the previous session,
@@ -1142,6 +1673,9 @@ func (c *conn) isHealthy() bool {
 		dpiConnOK = isHealthy == 1
 	}
 	c.mu.Unlock()
+	if !dpiConnOK {
+		c.poolEvent(PoolValidationFailed, 0)
+	}
 	return dpiConnOK
 }
 
@@ -1155,16 +1689,17 @@ func (c *conn) String() string {
 		"&edition=" + url.QueryEscape(c.Edition) + "&domainName=" + url.QueryEscape(c.DomainName)
 }
 
+// getLogger returns the logger of the ctx (see ContextWithLogger), or the per-pool logger, or the global logger.
 func (c *conn) getLogger(ctx context.Context) *slog.Logger {
-	if ctx != nil {
-		if lgr := getLogger(ctx); lgr != nil {
+	if ctx != nil && ctx != context.TODO() {
+		if lgr, ok := ctx.Value(logCtxKey{}).(*slog.Logger); ok {
 			return lgr
 		}
 	}
-	if c != nil {
+	if c != nil && c.params.Logger != nil {
 		return c.params.Logger
 	}
-	return nil
+	return getLogger(ctx)
 }
 
 func (c *conn) GetCurrentSchema(name string) (string, error) {
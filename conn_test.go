@@ -6,6 +6,7 @@
 package godror
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
 	"io"
@@ -21,6 +22,50 @@ func TestMaybeBadConn(t *testing.T) {
 	}
 }
 
+func TestSetTransaction(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		Want, Segment string
+		Level         sql.IsolationLevel
+		ReadOnly      bool
+		Err           bool
+	}{
+		{},
+		{Level: sql.LevelReadCommitted, Want: "SET TRANSACTION ISOLATION LEVEL READ COMMITTED"},
+		{Level: sql.LevelSnapshot, Want: "SET TRANSACTION ISOLATION LEVEL SERIALIZABLE"},
+		{Level: sql.LevelSerializable, Want: "SET TRANSACTION ISOLATION LEVEL SERIALIZABLE"},
+		{Level: sql.LevelSerializable, ReadOnly: true, Want: "SET TRANSACTION READ ONLY"},
+		{ReadOnly: true, Want: "SET TRANSACTION READ ONLY"},
+		{Segment: "rbs1", Want: "SET TRANSACTION USE ROLLBACK SEGMENT rbs1"},
+		{Segment: "rbs1; DROP TABLE t", Err: true},
+		{Segment: "rbs1", ReadOnly: true, Err: true},
+		{Segment: "rbs1", Level: sql.LevelSerializable, Err: true},
+		{Level: sql.LevelReadUncommitted, Err: true},
+		{Level: sql.LevelLinearizable, Err: true},
+	} {
+		got, err := setTransaction(tc.Level, tc.ReadOnly, tc.Segment)
+		if (err != nil) != tc.Err {
+			t.Errorf("%+v: got error %v", tc, err)
+		} else if got != tc.Want {
+			t.Errorf("%+v: got %q, wanted %q", tc, got, tc.Want)
+		}
+	}
+}
+
+func TestCommitModeStatement(t *testing.T) {
+	t.Parallel()
+	for mode, want := range map[CommitMode]string{
+		CommitDefault:                        "COMMIT",
+		CommitWriteBatch:                     "COMMIT WRITE BATCH WAIT",
+		CommitWriteNoWait:                    "COMMIT WRITE IMMEDIATE NOWAIT",
+		CommitWriteBatch | CommitWriteNoWait: "COMMIT WRITE BATCH NOWAIT",
+	} {
+		if got := mode.statement(); got != want {
+			t.Errorf("%d: got %q, wanted %q", mode, got, want)
+		}
+	}
+}
+
 func TestCalculateTZ(t *testing.T) {
 	t.Parallel()
 	const bdpstName = "Europe/Budapest"
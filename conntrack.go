@@ -0,0 +1,116 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var trackCheckoutStack atomic.Bool
+
+// TrackCheckoutStack sets whether to record the stack of the checkout site
+// of each connection acquired from a session pool.
+//
+// This helps finding the missing rows.Close() or tx.Commit() calls that keep
+// sessions busy, but costs a few kiB and a runtime.Stack call per checkout,
+// so enable it only for debugging!
+func TrackCheckoutStack(b bool) { trackCheckoutStack.Store(b) }
+
+// HeldConn describes a connection checked out from a session pool
+// and not released yet.
+type HeldConn struct {
+	// Acquired is the time of the checkout.
+	Acquired time.Time
+	// TraceTag is the last TraceTag set on the connection.
+	TraceTag TraceTag
+	// Stack is the checkout site, only filled if TrackCheckoutStack(true) was set.
+	Stack string
+	// Held is the duration the connection has been held for, at the time of the report.
+	Held time.Duration
+}
+
+type heldConn struct {
+	acquired time.Time
+	stack    []byte
+}
+
+// connTracker records the checkout time (and optionally stack)
+// of the pooled connections.
+type connTracker struct {
+	held map[*conn]heldConn
+	mu   sync.Mutex
+}
+
+func (t *connTracker) checkout(c *conn) {
+	hc := heldConn{acquired: time.Now()}
+	if trackCheckoutStack.Load() {
+		var a [4096]byte
+		hc.stack = append([]byte(nil), a[:runtime.Stack(a[:], false)]...)
+	}
+	t.mu.Lock()
+	if t.held == nil {
+		t.held = make(map[*conn]heldConn)
+	}
+	t.held[c] = hc
+	t.mu.Unlock()
+}
+
+// release forgets the connection, and returns the duration it has been held for.
+func (t *connTracker) release(c *conn) time.Duration {
+	t.mu.Lock()
+	hc, ok := t.held[c]
+	delete(t.held, c)
+	t.mu.Unlock()
+	if !ok {
+		return 0
+	}
+	return time.Since(hc.acquired)
+}
+
+// heldLongerThan returns the connections held longer than threshold, longest first.
+func (t *connTracker) heldLongerThan(threshold time.Duration) []HeldConn {
+	now := time.Now()
+	t.mu.Lock()
+	conns := make([]HeldConn, 0, len(t.held))
+	for c, hc := range t.held {
+		dur := now.Sub(hc.acquired)
+		if dur < threshold {
+			continue
+		}
+		tt, _ := c.currentTT.Load().(TraceTag)
+		conns = append(conns, HeldConn{
+			Acquired: hc.acquired, Held: dur,
+			TraceTag: tt, Stack: string(hc.stack),
+		})
+	}
+	t.mu.Unlock()
+	sort.Slice(conns, func(i, j int) bool { return conns[i].Held > conns[j].Held })
+	return conns
+}
+
+// HeldConns returns the connections checked out from the driver's session pools
+// and held for longer than threshold, the longest held first.
+//
+// A connection is held from the acquisition till it is returned to the
+// database/sql pool (rows.Close, tx.Commit/Rollback, conn.Close).
+func (d *drv) HeldConns(threshold time.Duration) []HeldConn {
+	if d == nil {
+		return nil
+	}
+	return d.tracker.heldLongerThan(threshold)
+}
+
+// HeldConns returns the connections held longer than threshold
+// from the session pools of the default driver.
+//
+// See TrackCheckoutStack for recording the checkout sites, too.
+func HeldConns(threshold time.Duration) []HeldConn {
+	return defaultDrv.HeldConns(threshold)
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConnTracker(t *testing.T) {
+	TrackCheckoutStack(true)
+	defer TrackCheckoutStack(false)
+	var tr connTracker
+	c1, c2 := &conn{}, &conn{}
+	c1.currentTT.Store(TraceTag{Module: "leaky"})
+	tr.checkout(c1)
+	tr.checkout(c2)
+	tr.held[c1] = heldConn{acquired: time.Now().Add(-time.Hour), stack: tr.held[c1].stack}
+
+	held := tr.heldLongerThan(time.Minute)
+	if len(held) != 1 {
+		t.Fatalf("got %d held conns, wanted 1: %+v", len(held), held)
+	}
+	if got := held[0]; got.TraceTag.Module != "leaky" || got.Held < time.Hour ||
+		!strings.Contains(got.Stack, "TestConnTracker") {
+		t.Errorf("got %+v", got)
+	}
+	if held = tr.heldLongerThan(0); len(held) != 2 || held[0].Held < held[1].Held {
+		t.Errorf("wanted 2, longest first, got %+v", held)
+	}
+
+	tr.release(c1)
+	tr.release(c2)
+	if held = tr.heldLongerThan(0); len(held) != 0 {
+		t.Errorf("released, but got %+v", held)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SetContainer switches the session to the container (pluggable database, or CDB$ROOT),
+// with ALTER SESSION SET CONTAINER.
+//
+// Warning! The switch applies to the session of ex, so use a *sql.Conn (or *sql.Tx)!
+// A session switched away from the container it was acquired in
+// (with the "container" connection parameter, by default the container of the service)
+// is not returned to the pool, but dropped on close.
+func SetContainer(ctx context.Context, ex Execer, name string) error {
+	pdb, err := containerName(name)
+	if err != nil {
+		return err
+	}
+	if pdb == "" {
+		return fmt.Errorf("container %q: %w", name, ErrInvalidIdentifier)
+	}
+	return Raw(ctx, ex, func(dc Conn) error {
+		c, ok := dc.(*conn)
+		if !ok {
+			return fmt.Errorf("SetContainer: %T is not a godror connection", dc)
+		}
+		return c.setContainer(pdb)
+	})
+}
+
+// setContainer switches the session to the (valid) container,
+// and records whether it is switched away from the container it was acquired in.
+func (c *conn) setContainer(pdb string) error {
+	c.mu.RLock()
+	acquired := c.acquiredContainer
+	c.mu.RUnlock()
+	if acquired == "" {
+		name, err := c.queryDirect("SELECT SYS_CONTEXT('USERENV', 'CON_NAME') FROM DUAL")
+		if err != nil {
+			return err
+		}
+		acquired = quoteIfNeeded(name)
+	}
+	if err := c.execDirect("ALTER SESSION SET CONTAINER = " + pdb); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.acquiredContainer = acquired
+	c.containerSwitched = pdb != acquired
+	c.mu.Unlock()
+	return nil
+}
+
+// containerName returns the name of the container usable in SQL, "" for an empty name.
+func containerName(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	if strings.EqualFold(name, AllPDBs) {
+		return "", fmt.Errorf("container %q: %w", name, ErrInvalidIdentifier)
+	}
+	return pdbName(name)
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestContainerName(t *testing.T) {
+	for in, want := range map[string]string{
+		"":         "",
+		"orclpdb1": "ORCLPDB1",
+		"cdb$root": "CDB$ROOT",
+		`"MyPdb"`:  `"MyPdb"`,
+	} {
+		if got, err := containerName(in); err != nil || got != want {
+			t.Errorf("%q: got %q, %v, wanted %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"all", "pdb1 OPEN", "x'y"} {
+		if got, err := containerName(in); !errors.Is(err, ErrInvalidIdentifier) {
+			t.Errorf("%q: got %q, %v, wanted ErrInvalidIdentifier", in, got, err)
+		}
+	}
+}
+
+func TestGetOnInitContainer(t *testing.T) {
+	var P CommonParams
+	P.Container = "pdb1 OPEN"
+	onInit := getOnInit(&P)
+	if onInit == nil {
+		t.Fatal("no OnInit for the container")
+	}
+	if err := onInit(context.Background(), nil); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("got %v, wanted ErrInvalidIdentifier", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import "context"
+
+// ContextTags are the session attributes extracted from a context by a TagExtractor.
+type ContextTags struct {
+	// TraceTag is set on the session - its ClientIdentifier is ignored (see TraceTag),
+	// so map the end user to ClientInfo.
+	TraceTag
+	// ECID is set as the execution context ID of the session, such as the trace ID of the request.
+	ECID string
+}
+
+// TagExtractor extracts the session attributes from the context of the checkout,
+// returning false if there are none.
+//
+// For example, to set the trace ID, the user and the tenant of the request:
+//
+//	godror.SetTagExtractor(func(ctx context.Context) (godror.ContextTags, bool) {
+//		req, ok := ctx.Value(requestKey{}).(*Request)
+//		if !ok {
+//			return godror.ContextTags{}, false
+//		}
+//		return godror.ContextTags{
+//			ECID: req.TraceID,
+//			TraceTag: godror.TraceTag{ClientInfo: req.User, Module: req.Tenant, Action: req.Route},
+//		}, true
+//	})
+type TagExtractor func(context.Context) (ContextTags, bool)
+
+// SetTagExtractor sets the extractor of the session attributes, applied on each checkout of a connection
+// (the first use of a new connection and each reuse by database/sql) with the context of the call.
+// The attributes are sent piggybacked on the next round trip.
+// A TraceTag set with ContextWithTraceTag takes precedence over the extracted one.
+//
+// A nil extractor switches it off.
+func (d *drv) SetTagExtractor(f TagExtractor) {
+	if f == nil {
+		d.tagExtractor.Store(nil)
+		return
+	}
+	d.tagExtractor.Store(&f)
+}
+
+// SetTagExtractor sets the session attribute extractor of the default driver.
+func SetTagExtractor(f TagExtractor) { defaultDrv.SetTagExtractor(f) }
+
+// applyContextTags sets the session attributes extracted from ctx.
+func (c *conn) applyContextTags(ctx context.Context) {
+	if c == nil || c.drv == nil || ctx == nil {
+		return
+	}
+	p := c.drv.tagExtractor.Load()
+	if p == nil {
+		return
+	}
+	tags, ok := (*p)(ctx)
+	if !ok {
+		return
+	}
+	if _, ok := ctx.Value(traceTagCtxKey{}).(TraceTag); !ok {
+		_ = c.setTraceTag(tags.TraceTag)
+	}
+	if tags.ECID != "" {
+		c.setECID(tags.ECID)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"testing"
+)
+
+type tenantKey struct{}
+
+func TestApplyContextTags(t *testing.T) {
+	d := &drv{}
+	c := &conn{drv: d}
+	c.applyContextTags(context.Background()) // no extractor
+
+	var got []string
+	d.SetTagExtractor(func(ctx context.Context) (ContextTags, bool) {
+		tenant, ok := ctx.Value(tenantKey{}).(string)
+		got = append(got, tenant)
+		return ContextTags{TraceTag: TraceTag{Module: tenant}, ECID: "trace-" + tenant}, ok
+	})
+	c.applyContextTags(context.WithValue(context.Background(), tenantKey{}, "acme"))
+	c.applyContextTags(context.Background())
+	if len(got) != 2 || got[0] != "acme" || got[1] != "" {
+		t.Errorf("got %q", got)
+	}
+	if c.ecid != "" {
+		t.Errorf("ECID set without a session: %q", c.ecid)
+	}
+
+	d.SetTagExtractor(nil)
+	c.applyContextTags(context.Background())
+	if len(got) != 2 {
+		t.Errorf("extractor called after switching off")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include "dpiImpl.h"
+*/
+import "C"
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// typeConverter is a pair of conversion functions registered with RegisterConverter.
+type typeConverter struct {
+	toGo   func(*Data) (interface{}, error)
+	fromGo func(interface{}, *Data) error
+}
+
+var (
+	convertersMu sync.Mutex
+	// converters is replaced (copy-on-write) on registration, so lookups need no locking.
+	converters atomic.Pointer[map[string]typeConverter]
+)
+
+// RegisterConverter registers the conversion functions of a database type, so domain types
+// (money, coordinates...) are converted in one central place.
+//
+// The typeName is either an Oracle type name as returned by ColumnTypeDatabaseTypeName
+// (such as "NUMBER" or "DATE"), or the name of a user-defined type, with or without the schema.
+// Names are uppercased, unless enclosed in "-s.
+//
+// toGo is called with the fetched value (NULLs included) of the columns, the Object attributes
+// and collection elements of that type, and its result is returned instead of the default
+// conversion - by rows.Next, Object.Get, Object.AsMap and ToJSON.
+// The Data is valid only during the call.
+// For user-defined types, the Object returned by Data.GetObject must be closed by the converter
+// (unless returned).
+//
+// fromGo is called by Object.Set and ObjectCollection.Set/Append (for values other than *Data)
+// to set the Data (already typed for the attribute or element) from the Go value.
+//
+// Either function can be nil; both nil removes the registration.
+func RegisterConverter(typeName string, toGo func(*Data) (interface{}, error), fromGo func(interface{}, *Data) error) {
+	name := converterName(typeName)
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	var old map[string]typeConverter
+	if p := converters.Load(); p != nil {
+		old = *p
+	}
+	m := make(map[string]typeConverter, len(old)+1)
+	for k, v := range old {
+		m[k] = v
+	}
+	if toGo == nil && fromGo == nil {
+		delete(m, name)
+	} else {
+		m[name] = typeConverter{toGo: toGo, fromGo: fromGo}
+	}
+	if len(m) == 0 {
+		converters.Store(nil)
+		return
+	}
+	converters.Store(&m)
+}
+
+func converterName(name string) string {
+	if len(name) > 2 && name[0] == '"' && name[len(name)-1] == '"' {
+		return name[1 : len(name)-1]
+	}
+	return strings.ToUpper(name)
+}
+
+// haveConverters reports whether any converter is registered.
+func haveConverters() bool { return converters.Load() != nil }
+
+// lookupConverter returns the converter registered for the first matching name.
+func lookupConverter(names ...string) typeConverter {
+	p := converters.Load()
+	if p == nil {
+		return typeConverter{}
+	}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if tc, ok := (*p)[name]; ok {
+			return tc
+		}
+	}
+	return typeConverter{}
+}
+
+// converterOf returns the converter of the type of an attribute or collection element.
+func converterOf(ot *ObjectType) typeConverter {
+	if ot == nil || !haveConverters() {
+		return typeConverter{}
+	}
+	if ot.IsObject() {
+		return lookupConverter(ot.FullName(), ot.Name)
+	}
+	return lookupConverter(oracleTypeName(ot.OracleTypeNum))
+}
+
+// columnConverters returns the converters of the columns, or nil if there is none.
+func (r *rows) columnConverters() []typeConverter {
+	if !haveConverters() {
+		return nil
+	}
+	var found bool
+	cvs := make([]typeConverter, len(r.columns))
+	for i, col := range r.columns {
+		name := r.ColumnTypeDatabaseTypeName(i)
+		var short string
+		if col.OrigOracleType == C.DPI_ORACLE_TYPE_OBJECT {
+			if j := strings.LastIndexByte(name, '.'); j >= 0 {
+				short = name[j+1:]
+			}
+		}
+		if cvs[i] = lookupConverter(name, short); cvs[i].toGo != nil {
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return cvs
+}
+
+// convertColumn calls toGo with the fetched data of the column.
+func (r *rows) convertColumn(toGo func(*Data) (interface{}, error), col Column, d *C.dpiData) (interface{}, error) {
+	// the Data is reused for each column, so toGo must not retain it
+	data := r.fetchArena().converterData(col, d)
+	if col.ObjectType != nil && d.isNull == 0 {
+		// data.GetObject takes its own reference, to be released by closing the returned Object
+		ot := &ObjectType{dpiObjectType: col.ObjectType, drv: r.conn.drv}
+		r.conn.mu.RLock()
+		err := ot.init(r.conn.objTypes)
+		r.conn.mu.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+		data.ObjectType = ot
+	}
+	return toGo(data)
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import "testing"
+
+func TestRegisterConverter(t *testing.T) {
+	toGo := func(*Data) (interface{}, error) { return "money", nil }
+	fromGo := func(interface{}, *Data) error { return nil }
+	RegisterConverter("scott.money_ot", toGo, nil)
+	RegisterConverter(`"Coord_ot"`, nil, fromGo)
+	defer func() {
+		RegisterConverter("scott.money_ot", nil, nil)
+		RegisterConverter(`"Coord_ot"`, nil, nil)
+		if haveConverters() {
+			t.Error("converters remained registered")
+		}
+	}()
+
+	if cv := lookupConverter("SCOTT.MONEY_OT"); cv.toGo == nil || cv.fromGo != nil {
+		t.Errorf("SCOTT.MONEY_OT: got %+v", cv)
+	}
+	if cv := lookupConverter("", "OTHER.COORD_OT", "Coord_ot"); cv.fromGo == nil || cv.toGo != nil {
+		t.Errorf("Coord_ot: got %+v", cv)
+	}
+	if cv := lookupConverter("COORD_OT", "NUMBER"); cv.toGo != nil || cv.fromGo != nil {
+		t.Errorf("COORD_OT: got %+v, wanted none", cv)
+	}
+}
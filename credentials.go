@@ -0,0 +1,173 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include <stdlib.h>
+#include "dpiImpl.h"
+*/
+import "C"
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/godror/godror/dsn"
+)
+
+// Credentials are the secrets of a connector, to be rotated with UpdateCredentials.
+// The empty fields are left unchanged.
+type Credentials struct {
+	Password dsn.Password
+	// Token and PrivateKey are for the token based authentication.
+	Token, PrivateKey string
+	// ConnectString is for changing the wallet location (MY_WALLET_DIRECTORY),
+	// or any other part of the connect string.
+	ConnectString string
+}
+
+// drainInterval is the period of checking whether the replaced pool has any busy sessions.
+const drainInterval = time.Second
+
+// ErrNotGodrorConnector is returned by UpdateCredentials for a connector not returned by NewConnector.
+var ErrNotGodrorConnector = errors.New("not a godror connector")
+
+type rotatedParams struct {
+	params atomic.Pointer[dsn.ConnectionParams]
+	mu     sync.Mutex
+}
+
+// params returns the connection parameters with the rotated credentials.
+func (c connector) params() dsn.ConnectionParams {
+	if c.rotated != nil {
+		if P := c.rotated.params.Load(); P != nil {
+			return *P
+		}
+	}
+	return c.ConnectionParams
+}
+
+// UpdateCredentials rotates the credentials of the connector (as returned by NewConnector)
+// at runtime, so the new physical sessions use them, without reopening the sql.DB.
+//
+// A new token is set on the existing pool.
+// A new password or connect string means a new pool:
+// the sessions of the old pool are used till they are returned (database/sql returns them when idle),
+// then the old pool is closed.
+// The sessions already authenticated are not affected.
+func UpdateCredentials(connector driver.Connector, cred Credentials) error {
+	c, ok := asConnector(connector)
+	if !ok || c.rotated == nil {
+		return fmt.Errorf("%T: %w", connector, ErrNotGodrorConnector)
+	}
+	c.rotated.mu.Lock()
+	defer c.rotated.mu.Unlock()
+	old := c.params()
+	P := old
+	if !cred.Password.IsZero() {
+		P.Password = cred.Password
+	}
+	if cred.ConnectString != "" {
+		P.ConnectString = cred.ConnectString
+	}
+	if cred.Token != "" {
+		P.Token, P.PrivateKey = cred.Token, cred.PrivateKey
+	}
+	if !P.IsStandalone() {
+		oldKey := commonAndPoolParams{CommonParams: old.CommonParams, PoolParams: old.PoolParams}.poolKey()
+		newKey := commonAndPoolParams{CommonParams: P.CommonParams, PoolParams: P.PoolParams}.poolKey()
+		if oldKey != newKey {
+			c.drv.drainPool(oldKey)
+		} else if cred.Token != "" {
+			if err := c.drv.setPoolAccessToken(oldKey, P.Token, P.PrivateKey); err != nil {
+				return err
+			}
+		}
+	}
+	c.rotated.params.Store(&P)
+	return nil
+}
+
+func asConnector(dc driver.Connector) (connector, bool) {
+	switch c := dc.(type) {
+	case connector:
+		return c, true
+	case *connector:
+		if c != nil {
+			return *c, true
+		}
+	}
+	return connector{}, false
+}
+
+// setPoolAccessToken sets the token on the pool with the key, if it exists.
+func (d *drv) setPoolAccessToken(key, token, privateKey string) error {
+	d.mu.RLock()
+	pool := d.pools[key]
+	d.mu.RUnlock()
+	if pool == nil || pool.dpiPool == nil {
+		// not created yet, will be created with the new token
+		return nil
+	}
+	accessToken := (*C.dpiAccessToken)(C.malloc(C.sizeof_dpiAccessToken))
+	accessToken.token = C.CString(token)
+	accessToken.tokenLength = C.uint32_t(len(token))
+	accessToken.privateKey, accessToken.privateKeyLength = nil, 0
+	if privateKey != "" {
+		accessToken.privateKey = C.CString(privateKey)
+		accessToken.privateKeyLength = C.uint32_t(len(privateKey))
+	}
+	defer freeAccessToken(accessToken)
+	if err := d.checkExec(func() C.int { return C.dpiPool_setAccessToken(pool.dpiPool, accessToken) }); err != nil {
+		return fmt.Errorf("setAccessToken: %w", err)
+	}
+	return nil
+}
+
+// drainPool removes the pool with the key, so no new session is acquired from it,
+// and closes it when it has no busy sessions.
+//
+// The pool is kept in d.pools under another key till then, to be closed with the driver.
+func (d *drv) drainPool(key string) {
+	d.mu.Lock()
+	pool := d.pools[key]
+	if pool == nil {
+		d.mu.Unlock()
+		return
+	}
+	drainKey := fmt.Sprintf("%s\tdraining %p", key, pool)
+	delete(d.pools, key)
+	d.pools[drainKey] = pool
+	d.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(drainInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			d.mu.RLock()
+			current := d.pools[drainKey]
+			d.mu.RUnlock()
+			if current != pool {
+				// the driver has been closed
+				return
+			}
+			if stats, err := d.getPoolStats(pool); err == nil && stats.Busy != 0 {
+				continue
+			}
+			d.mu.Lock()
+			if d.pools[drainKey] == pool {
+				delete(d.pools, drainKey)
+				pool.Purge()
+			}
+			d.mu.Unlock()
+			return
+		}
+	}()
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godror/godror/dsn"
+)
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return nil, errors.New("fake") }
+func (fakeConnector) Driver() driver.Driver                        { return nil }
+
+func TestUpdateCredentials(t *testing.T) {
+	if err := UpdateCredentials(fakeConnector{}, Credentials{}); !errors.Is(err, ErrNotGodrorConnector) {
+		t.Errorf("fake connector: got %v, wanted ErrNotGodrorConnector", err)
+	}
+
+	var P dsn.ConnectionParams
+	P.Username, P.Password, P.ConnectString = "scott", dsn.NewPassword("tiger"), "db1"
+	P.StandaloneConnection = dsn.Bool(true)
+	c := (&drv{}).NewConnector(P)
+	if err := UpdateCredentials(c, Credentials{Password: dsn.NewPassword("lion")}); err != nil {
+		t.Fatal(err)
+	}
+	got := c.(connector).params()
+	if got.Password.Secret() != "lion" || got.ConnectString != "db1" || got.Username != "scott" {
+		t.Errorf("got %q/%q@%q, wanted scott/lion@db1", got.Username, got.Password.Secret(), got.ConnectString)
+	}
+	if err := UpdateCredentials(c, Credentials{ConnectString: "db2", Token: "tok"}); err != nil {
+		t.Fatal(err)
+	}
+	got = c.(connector).params()
+	if got.Password.Secret() != "lion" || got.ConnectString != "db2" || got.Token != "tok" {
+		t.Errorf("got %q@%q token=%q, wanted lion@db2 token=tok", got.Password.Secret(), got.ConnectString, got.Token)
+	}
+}
+
+func TestDrainPool(t *testing.T) {
+	pool := &connPool{key: "key"}
+	d := &drv{pools: map[string]*connPool{"key": pool}}
+	d.drainPool("key")
+	d.mu.RLock()
+	_, ok := d.pools["key"]
+	n := len(d.pools)
+	d.mu.RUnlock()
+	if ok || n != 1 {
+		t.Fatalf("pool not moved: %v", d.pools)
+	}
+	for deadline := time.Now().Add(3 * drainInterval); time.Now().Before(deadline); time.Sleep(drainInterval / 10) {
+		d.mu.RLock()
+		n = len(d.pools)
+		d.mu.RUnlock()
+		if n == 0 {
+			return
+		}
+	}
+	t.Errorf("drained pool not closed: %v", d.pools)
+}
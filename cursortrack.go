@@ -0,0 +1,155 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var openCursorsThreshold atomic.Int64
+
+// TrackOpenCursors sets the number of open cursors of a connection,
+// which when reached, logs the open statements as a warning, with their preparation sites.
+//
+// The preparation sites are recorded only when the threshold is positive,
+// as this costs a runtime.Stack call per statement, so set it only for debugging!
+//
+// The open statements are listed (by OpenCursors) only while the threshold is positive,
+// otherwise only counted - so they are not kept reachable (see GuardWithFinalizers).
+//
+// Set it below the OPEN_CURSORS database parameter to diagnose ORA-01000 before it happens.
+// Note that the statement cache (see StmtCacheSize) holds open cursors, too.
+func TrackOpenCursors(threshold int) { openCursorsThreshold.Store(int64(threshold)) }
+
+// OpenCursor describes a statement (cursor) of the connection, not closed yet.
+type OpenCursor struct {
+	// Opened is the time of the preparation.
+	Opened time.Time
+	// Query is the statement text, empty for REF CURSORs and implicit results.
+	Query string
+	// Kind is "prepared", "ref cursor" (OUT parameter), "cursor column" or "implicit result".
+	Kind string
+	// Stack is the preparation site, only filled if TrackOpenCursors was set.
+	Stack string
+}
+
+type openCursor struct {
+	opened      time.Time
+	query, kind string
+	stack       []byte
+}
+
+// cursorTracker counts the open statements of a connection,
+// and records them when TrackOpenCursors is set.
+type cursorTracker struct {
+	open map[*statement]openCursor
+	n    int
+	mu   sync.Mutex
+}
+
+func cursorKind(tag string) string {
+	switch tag {
+	case "prepareContext":
+		return "prepared"
+	case "dataGetStmtC":
+		return "ref cursor"
+	case "Next":
+		return "cursor column"
+	case "NextResultSet":
+		return "implicit result"
+	}
+	return tag
+}
+
+// opened counts the statement, records it if tracked,
+// and logs the open statements when the threshold is reached.
+func (t *cursorTracker) opened(ctx context.Context, c *conn, st *statement, tag string) {
+	threshold := openCursorsThreshold.Load()
+	if threshold <= 0 {
+		t.mu.Lock()
+		t.n++
+		t.mu.Unlock()
+		return
+	}
+	oc := openCursor{opened: time.Now(), query: st.query, kind: cursorKind(tag)}
+	var a [4096]byte
+	oc.stack = append([]byte(nil), a[:runtime.Stack(a[:], false)]...)
+	t.mu.Lock()
+	if t.open == nil {
+		t.open = make(map[*statement]openCursor)
+	}
+	t.open[st] = oc
+	t.n++
+	n := t.n
+	t.mu.Unlock()
+	if int64(n) != threshold {
+		return
+	}
+	if logger := c.getLogger(ctx); logger != nil {
+		for _, oc := range t.list() {
+			logger.Warn("open cursors threshold reached", "threshold", threshold,
+				"kind", oc.Kind, "opened", oc.Opened, "query", oc.Query, "stack", oc.Stack)
+		}
+	}
+}
+
+func (t *cursorTracker) closed(st *statement) {
+	t.mu.Lock()
+	delete(t.open, st)
+	if t.n > 0 {
+		t.n--
+	}
+	t.mu.Unlock()
+}
+
+func (t *cursorTracker) reset() {
+	t.mu.Lock()
+	clear(t.open)
+	t.n = 0
+	t.mu.Unlock()
+}
+
+func (t *cursorTracker) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.n
+}
+
+// list returns the open cursors, oldest first.
+func (t *cursorTracker) list() []OpenCursor {
+	t.mu.Lock()
+	cursors := make([]OpenCursor, 0, len(t.open))
+	for _, oc := range t.open {
+		cursors = append(cursors, OpenCursor{
+			Opened: oc.opened, Query: oc.query,
+			Kind: oc.kind, Stack: string(oc.stack),
+		})
+	}
+	t.mu.Unlock()
+	sort.Slice(cursors, func(i, j int) bool { return cursors[i].Opened.Before(cursors[j].Opened) })
+	return cursors
+}
+
+// OpenCursorsConn is the optional interface of a Conn for listing its open statements.
+type OpenCursorsConn interface {
+	OpenCursorCount() int
+	OpenCursors() []OpenCursor
+}
+
+var _ OpenCursorsConn = (*conn)(nil)
+
+// OpenCursorCount returns the number of statements (cursors) of the connection not closed yet,
+// including REF CURSORs and implicit results.
+func (c *conn) OpenCursorCount() int { return c.cursors.count() }
+
+// OpenCursors returns the statements (cursors) of the connection not closed yet, oldest first -
+// only those opened while TrackOpenCursors was set.
+func (c *conn) OpenCursors() []OpenCursor { return c.cursors.list() }
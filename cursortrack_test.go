@@ -0,0 +1,56 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/godror/godror/slog"
+)
+
+func TestCursorTracker(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := ContextWithLogger(context.Background(), slog.New(slog.NewTextHandler(&buf, nil)))
+	TrackOpenCursors(2)
+	defer TrackOpenCursors(0)
+	c := &conn{}
+	st1, st2 := &statement{conn: c, query: "SELECT 1 FROM DUAL"}, &statement{conn: c}
+	c.cursors.opened(ctx, c, st1, "prepareContext")
+	if buf.Len() != 0 {
+		t.Errorf("logged before reaching the threshold: %s", buf.String())
+	}
+	c.cursors.opened(ctx, c, st2, "dataGetStmtC")
+	if !strings.Contains(buf.String(), "open cursors threshold reached") {
+		t.Errorf("threshold not logged: %s", buf.String())
+	}
+
+	cursors := c.OpenCursors()
+	if len(cursors) != 2 || c.OpenCursorCount() != 2 {
+		t.Fatalf("got %d cursors, wanted 2: %+v", len(cursors), cursors)
+	}
+	if got := cursors[0]; got.Kind != "prepared" || got.Query != st1.query || !strings.Contains(got.Stack, "TestCursorTracker") {
+		t.Errorf("got %+v", got)
+	}
+	if got := cursors[1].Kind; got != "ref cursor" {
+		t.Errorf("got kind %q, wanted ref cursor", got)
+	}
+
+	c.cursors.closed(st1)
+	c.cursors.closed(st2)
+	if n := c.OpenCursorCount(); n != 0 {
+		t.Errorf("closed, but got %d", n)
+	}
+
+	TrackOpenCursors(0)
+	c.cursors.opened(ctx, c, st1, "prepareContext")
+	if n, cursors := c.OpenCursorCount(), c.OpenCursors(); n != 1 || len(cursors) != 0 {
+		t.Errorf("not tracked: got %d and %+v, wanted 1 and none", n, cursors)
+	}
+	c.cursors.closed(st1)
+}
@@ -17,8 +17,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -50,8 +52,8 @@ const (
 	NativeTypeJSON       = C.DPI_NATIVE_TYPE_JSON
 	NativeTypeJSONOBject = C.DPI_NATIVE_TYPE_JSON_OBJECT
 	NativeTypeJSONArray  = C.DPI_NATIVE_TYPE_JSON_ARRAY
+	NativeTypeVector     = C.DPI_NATIVE_TYPE_VECTOR
 	// NativeTypeNULL       = C.DPI_NATIVE_TYPE_NULL
-	// NativeTypeVector     = C.DPI_NATIVE_TYPE_VECTOR
 )
 
 var ErrNotSupported = errors.New("not supported")
@@ -203,6 +205,25 @@ func (d *Data) SetIntervalDS(dur time.Duration) {
 	C.dpiData_setIntervalDS(&d.dpiData, days, hrs, mins, secs, fsecs)
 }
 
+// GetIntervalDSExact gets the IntervalDS from the data - unlike GetIntervalDS, it cannot overflow.
+func (d *Data) GetIntervalDSExact() IntervalDS {
+	if d.IsNull() {
+		return IntervalDS{}
+	}
+	ds := *((*C.dpiIntervalDS)(unsafe.Pointer(&d.dpiData.value)))
+	return IntervalDS{
+		Days: int(ds.days), Hours: int(ds.hours), Minutes: int(ds.minutes),
+		Seconds: int(ds.seconds), Nanoseconds: int(ds.fseconds),
+	}
+}
+
+// SetIntervalDSExact sets the IntervalDS to the data.
+func (d *Data) SetIntervalDSExact(ds IntervalDS) {
+	d.NativeTypeNum = C.DPI_NATIVE_TYPE_INTERVAL_DS
+	C.dpiData_setIntervalDS(&d.dpiData, C.int32_t(ds.Days), C.int32_t(ds.Hours), C.int32_t(ds.Minutes),
+		C.int32_t(ds.Seconds), C.int32_t(ds.Nanoseconds))
+}
+
 // GetIntervalYM gets IntervalYM from the data.
 func (d *Data) GetIntervalYM() IntervalYM {
 	if d.IsNull() {
@@ -224,6 +245,7 @@ func (d *Data) GetLob() *Lob {
 	if d.IsNull() {
 		return nil
 	}
+	resourceCounters.lobs.Add(1) // released by the Close of the reader
 	return &Lob{Reader: &dpiLobReader{dpiLob: C.dpiData_getLOB(&d.dpiData)}}
 }
 
@@ -256,6 +278,7 @@ func (d *Data) GetObject() *Object {
 		}
 	}
 	obj := &Object{dpiObject: o, ObjectType: d.ObjectType}
+	resourceCounters.objects.Add(1)
 	if err := obj.init(nil); err != nil {
 		panic(err)
 	}
@@ -278,6 +301,7 @@ func (d *Data) GetStmt() driver.Stmt {
 	if d.IsNull() {
 		return nil
 	}
+	resourceCounters.stmts.Add(1)
 	return &statement{dpiStmt: C.dpiData_getStmt(&d.dpiData)}
 }
 
@@ -378,11 +402,149 @@ func (d *Data) SetUint64(u uint64) {
 	C.dpiData_setUint64(&d.dpiData, C.uint64_t(u))
 }
 
-// IntervalYM holds Years and Months as interval.
+// IntervalYM holds Years and Months as interval, for INTERVAL YEAR TO MONTH.
+//
+// Both are negative for a negative interval.
 type IntervalYM struct {
 	Years, Months int
 }
 
+// String returns the interval in Oracle's format, such as "1-2" or "-1-2".
+//
+// The parts are normalized, so {Years: 1, Months: -2} is "0-10".
+func (ym IntervalYM) String() string {
+	months := 12*ym.Years + ym.Months
+	sign := ""
+	if months < 0 {
+		sign, months = "-", -months
+	}
+	return sign + strconv.Itoa(months/12) + "-" + strconv.Itoa(months%12)
+}
+
+// AddTo returns t + ym.
+func (ym IntervalYM) AddTo(t time.Time) time.Time { return t.AddDate(ym.Years, ym.Months, 0) }
+
+// ParseIntervalYM parses the interval from Oracle's format, such as "1-2", "+01-02" or "-1-2".
+func ParseIntervalYM(s string) (IntervalYM, error) {
+	var ym IntervalYM
+	t := strings.TrimSpace(s)
+	neg := strings.HasPrefix(t, "-")
+	t = strings.TrimLeft(t, "+-")
+	y, m, ok := strings.Cut(t, "-")
+	if !ok {
+		return ym, fmt.Errorf("%q is not an interval year to month", s)
+	}
+	var err error
+	if ym.Years, err = strconv.Atoi(y); err != nil {
+		return ym, fmt.Errorf("%q: years: %w", s, err)
+	}
+	if ym.Months, err = strconv.Atoi(m); err != nil {
+		return ym, fmt.Errorf("%q: months: %w", s, err)
+	}
+	if ym.Years < 0 || ym.Months < 0 || ym.Months > 11 {
+		return ym, fmt.Errorf("%q is not an interval year to month", s)
+	}
+	if neg {
+		ym.Years, ym.Months = -ym.Years, -ym.Months
+	}
+	return ym, nil
+}
+
+// Scan the value (IntervalYM, or string in Oracle's format) into the IntervalYM.
+// NULL is scanned as the zero interval.
+func (ym *IntervalYM) Scan(src interface{}) error {
+	switch x := src.(type) {
+	case nil:
+		*ym = IntervalYM{}
+	case IntervalYM:
+		*ym = x
+	case string:
+		v, err := ParseIntervalYM(x)
+		if err != nil {
+			return err
+		}
+		*ym = v
+	case []byte:
+		return ym.Scan(string(x))
+	default:
+		return fmt.Errorf("cannot scan %T into IntervalYM", src)
+	}
+	return nil
+}
+
+var _ sql.Scanner = (*IntervalYM)(nil)
+
+// IntervalDS holds an INTERVAL DAY TO SECOND losslessly,
+// also the values exceeding the range of time.Duration (about 292 years).
+//
+// All the parts are negative for a negative interval.
+type IntervalDS struct {
+	Days, Hours, Minutes, Seconds, Nanoseconds int
+}
+
+// IntervalDSFromDuration returns the time.Duration as an IntervalDS.
+func IntervalDSFromDuration(dur time.Duration) IntervalDS {
+	const day = 24 * time.Hour
+	return IntervalDS{
+		Days:        int(dur / day),
+		Hours:       int(dur % day / time.Hour),
+		Minutes:     int(dur % time.Hour / time.Minute),
+		Seconds:     int(dur % time.Minute / time.Second),
+		Nanoseconds: int(dur % time.Second),
+	}
+}
+
+// errIntervalOverflow is returned when an IntervalDS does not fit into a time.Duration.
+var errIntervalOverflow = errors.New("interval overflows time.Duration")
+
+// Duration returns the interval as time.Duration, or an error if it does not fit.
+func (ds IntervalDS) Duration() (time.Duration, error) {
+	const day = 24 * time.Hour
+	if ds.Days > int(math.MaxInt64/day) || ds.Days < int(math.MinInt64/day) {
+		return 0, fmt.Errorf("%s: %w", ds, errIntervalOverflow)
+	}
+	d := time.Duration(ds.Days) * day
+	rest := time.Duration(ds.Hours)*time.Hour + time.Duration(ds.Minutes)*time.Minute +
+		time.Duration(ds.Seconds)*time.Second + time.Duration(ds.Nanoseconds)
+	sum := d + rest
+	if rest > 0 && sum < d || rest < 0 && sum > d {
+		return 0, fmt.Errorf("%s: %w", ds, errIntervalOverflow)
+	}
+	return sum, nil
+}
+
+// String returns the interval in Oracle's format, such as "1 02:03:04.5" or "-1 02:03:04".
+func (ds IntervalDS) String() string {
+	var sign string
+	if ds.Days < 0 || ds.Hours < 0 || ds.Minutes < 0 || ds.Seconds < 0 || ds.Nanoseconds < 0 {
+		sign = "-"
+		ds = IntervalDS{Days: -ds.Days, Hours: -ds.Hours, Minutes: -ds.Minutes, Seconds: -ds.Seconds, Nanoseconds: -ds.Nanoseconds}
+	}
+	s := fmt.Sprintf("%s%d %02d:%02d:%02d", sign, ds.Days, ds.Hours, ds.Minutes, ds.Seconds)
+	if ds.Nanoseconds != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", ds.Nanoseconds), "0")
+	}
+	return s
+}
+
+// Scan the value (IntervalDS or time.Duration) into the IntervalDS.
+// NULL is scanned as the zero interval.
+func (ds *IntervalDS) Scan(src interface{}) error {
+	switch x := src.(type) {
+	case nil:
+		*ds = IntervalDS{}
+	case IntervalDS:
+		*ds = x
+	case time.Duration:
+		*ds = IntervalDSFromDuration(x)
+	default:
+		return fmt.Errorf("cannot scan %T into IntervalDS", src)
+	}
+	return nil
+}
+
+var _ sql.Scanner = (*IntervalDS)(nil)
+
 // Get returns the contents of Data.
 func (d *Data) Get() interface{} {
 	// if logger := getLogger(context.TODO()); logger != nil && logger.Enabled(context.TODO(), slog.LevelDebug) {
@@ -415,6 +577,8 @@ func (d *Data) Get() interface{} {
 		return d.GetTime()
 	case C.DPI_NATIVE_TYPE_UINT64:
 		return d.GetUint64()
+	case C.DPI_NATIVE_TYPE_JSON:
+		return d.GetJSON()
 	case C.DPI_NATIVE_TYPE_JSON_ARRAY:
 		return d.GetJSONArray()
 	case C.DPI_NATIVE_TYPE_JSON_OBJECT:
@@ -474,6 +638,8 @@ func (d *Data) Set(v interface{}) error {
 		}
 	case string:
 		d.SetBytes([]byte(x))
+	case NString:
+		d.SetBytes([]byte(x))
 	case []byte:
 		d.SetBytes(x)
 	case time.Time:
@@ -485,8 +651,14 @@ func (d *Data) Set(v interface{}) error {
 		}
 	case time.Duration:
 		d.SetIntervalDS(x)
+	case IntervalDS:
+		d.SetIntervalDSExact(x)
 	case IntervalYM:
 		d.SetIntervalYM(x)
+	case JSONString:
+		return d.SetJSON(x)
+	case Vector:
+		return d.SetVector(x)
 	case *Lob:
 		b, err := io.ReadAll(x.Reader)
 		if err != nil {
@@ -515,6 +687,17 @@ func (d *Data) Set(v interface{}) error {
 	//case rowid:
 	//d.NativeTypeNum = C.DPI_NATIVE_TYPE_ROWID
 	//d.SetRowid(x)
+	case driver.Valuer:
+		// such as sql.Null[T]
+		w, err := x.Value()
+		if err != nil {
+			return fmt.Errorf("%T.Value(): %w", v, err)
+		}
+		if w == nil {
+			d.SetNull()
+			return nil
+		}
+		return d.Set(w)
 	default:
 		if logger := getLogger(context.TODO()); logger != nil && logger.Enabled(context.TODO(), slog.LevelDebug) {
 			logger.Debug("Set", "data", d, "type", fmt.Sprintf("%T", v))
@@ -647,9 +830,74 @@ func (d *Data) dpiDataGetBytesUnsafe() *C.dpiBytes {
 	return ((*C.dpiBytes)(unsafe.Pointer(&d.dpiData.value)))
 }
 
+// GetJSON gets the JSON from the data (the zero JSON for NULL).
 func (d *Data) GetJSON() JSON {
-	return JSON{dpiJson: ((*C.dpiJson)(unsafe.Pointer(&d.dpiData.value)))}
+	if d.IsNull() {
+		return JSON{}
+	}
+	return JSON{dpiJson: *((**C.dpiJson)(unsafe.Pointer(&d.dpiData.value)))}
 }
+
+// SetJSON sets the JSON text to the data, which must already hold a JSON value
+// (such as a JSON attribute of an Object, or a Data from NewData).
+func (d *Data) SetJSON(js JSONString) error {
+	dj := *((**C.dpiJson)(unsafe.Pointer(&d.dpiData.value)))
+	if dj == nil {
+		return fmt.Errorf("SetJSON on data without JSON: %w", ErrNotSupported)
+	}
+	cs := C.CString(js.Value)
+	defer C.free(unsafe.Pointer(cs))
+	if err := d.checkExec(func() C.int {
+		return C.dpiJson_setFromText(dj, cs, C.uint64_t(len(js.Value)), C.uint32_t(js.Flags))
+	}); err != nil {
+		return fmt.Errorf("SetJSON: %w", err)
+	}
+	d.NativeTypeNum, d.dpiData.isNull = C.DPI_NATIVE_TYPE_JSON, 0
+	return nil
+}
+
+// GetVector gets the Vector from the data (the zero Vector for NULL).
+func (d *Data) GetVector() (Vector, error) {
+	if d.IsNull() {
+		return Vector{}, nil
+	}
+	var vectorInfo C.dpiVectorInfo
+	if err := d.checkExec(func() C.int {
+		return C.dpiVector_getValue(C.dpiData_getVector(&d.dpiData), &vectorInfo)
+	}); err != nil {
+		return Vector{}, fmt.Errorf("GetVector: %w", err)
+	}
+	return GetVectorValue(&vectorInfo)
+}
+
+// SetVector sets the Vector to the data, which must already hold a VECTOR value
+// (such as a VECTOR attribute of an Object, or a Data from NewData).
+// A Vector without Values is set as NULL.
+func (d *Data) SetVector(v Vector) error {
+	if v.Values == nil {
+		d.SetNull()
+		return nil
+	}
+	if *((**C.dpiVector)(unsafe.Pointer(&d.dpiData.value))) == nil {
+		return fmt.Errorf("SetVector on data without VECTOR: %w", ErrNotSupported)
+	}
+	if err := SetVectorValue(&conn{drv: d.driver()}, &v, &d.dpiData); err != nil {
+		return err
+	}
+	d.NativeTypeNum, d.dpiData.isNull = C.DPI_NATIVE_TYPE_VECTOR, 0
+	return nil
+}
+
+// driver returns the driver of the ObjectType, or the default.
+func (d *Data) driver() *drv {
+	if d.ObjectType != nil && d.ObjectType.drv != nil {
+		return d.ObjectType.drv
+	}
+	return defaultDrv
+}
+
+func (d *Data) checkExec(f func() C.int) error { return d.driver().checkExec(f) }
+
 func (d *Data) GetJSONObject() JSONObject {
 	return JSONObject{dpiJsonObject: ((*C.dpiJsonObject)(unsafe.Pointer(&d.dpiData.value)))}
 }
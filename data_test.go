@@ -6,6 +6,8 @@
 package godror
 
 import (
+	"errors"
+	"math"
 	"testing"
 	"time"
 )
@@ -101,3 +103,148 @@ func BenchmarkDataGetBytes(b *testing.B) {
 		b.Log("n:", n)
 	})
 }
+
+func TestDataTimeNanosecond(t *testing.T) {
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	if err != nil {
+		vienna = time.FixedZone("CET", 3600)
+	}
+	var d Data
+	for _, want := range []time.Time{
+		time.Date(2025, 1, 2, 3, 4, 5, 1, time.UTC),
+		time.Date(2025, 1, 2, 3, 4, 5, 999999999, time.UTC),
+		time.Date(2025, 12, 31, 23, 59, 59, 999999999, vienna),
+		time.Date(1, 1, 2, 0, 0, 0, 123456789, time.UTC),
+		time.Date(2025, 3, 30, 1, 59, 59, 500000000, vienna),
+	} {
+		d.SetTime(want)
+		if got := d.GetTime(); !got.Equal(want) || got.Nanosecond() != want.Nanosecond() {
+			t.Errorf("set %v, got %v", want, got)
+		}
+	}
+}
+
+func TestDataTypedAccessors(t *testing.T) {
+	var d Data
+	d.SetFloat32(1.5)
+	if got := d.GetFloat32(); got != 1.5 || d.Get() != float32(1.5) {
+		t.Errorf("float32: got %v", got)
+	}
+	for _, want := range []IntervalDS{
+		{Days: 999999999, Hours: 23, Minutes: 59, Seconds: 59, Nanoseconds: 999999999},
+		{Days: -3, Hours: -4, Nanoseconds: -5},
+	} {
+		if err := d.Set(want); err != nil {
+			t.Fatal(err)
+		}
+		if got := d.GetIntervalDSExact(); got != want {
+			t.Errorf("IntervalDS: got %+v, wanted %+v", got, want)
+		}
+	}
+	d.SetIntervalYM(IntervalYM{Years: -1, Months: -2})
+	if got := d.GetIntervalYM(); got != (IntervalYM{Years: -1, Months: -2}) {
+		t.Errorf("IntervalYM: got %+v", got)
+	}
+
+	d = Data{NativeTypeNum: NativeTypeJSON}
+	if err := d.SetJSON(JSONString{Value: "{}"}); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("SetJSON without JSON: got %+v", err)
+	}
+	if err := d.SetVector(Vector{Values: []float32{1}}); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("SetVector without VECTOR: got %+v", err)
+	}
+	if err := d.SetVector(Vector{}); err != nil || !d.IsNull() {
+		t.Errorf("SetVector(nil): got %+v, null=%t", err, d.IsNull())
+	}
+	if got := d.GetJSON(); got != (JSON{}) {
+		t.Errorf("NULL JSON: got %#v", got)
+	}
+	if got, err := d.GetVector(); err != nil || got.Values != nil {
+		t.Errorf("NULL Vector: got %#v, %+v", got, err)
+	}
+	if got := d.GetIntervalDSExact(); got != (IntervalDS{}) {
+		t.Errorf("NULL IntervalDS: got %+v", got)
+	}
+}
+
+func TestIntervalYM(t *testing.T) {
+	for s, want := range map[string]IntervalYM{
+		"1-2":     {Years: 1, Months: 2},
+		"+01-02":  {Years: 1, Months: 2},
+		"-1-2":    {Years: -1, Months: -2},
+		"0-0":     {},
+		" 10-11 ": {Years: 10, Months: 11},
+	} {
+		got, err := ParseIntervalYM(s)
+		if err != nil {
+			t.Errorf("%q: %+v", s, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: got %+v, wanted %+v", s, got, want)
+		}
+		var scanned IntervalYM
+		if err = scanned.Scan([]byte(s)); err != nil || scanned != want {
+			t.Errorf("scan %q: got %+v (%v), wanted %+v", s, scanned, err, want)
+		}
+		if back, err := ParseIntervalYM(got.String()); err != nil || back != got {
+			t.Errorf("%q: String()=%q parsed back as %+v (%v)", s, got.String(), back, err)
+		}
+	}
+	for ym, want := range map[IntervalYM]string{
+		{Years: 1, Months: -2}:  "0-10",
+		{Years: -1, Months: 2}:  "-0-10",
+		{Years: 0, Months: -14}: "-1-2",
+		{Years: 1, Months: 13}:  "2-1",
+	} {
+		if got := ym.String(); got != want {
+			t.Errorf("%+v: got %q, wanted %q", ym, got, want)
+		}
+	}
+	for _, s := range []string{"", "1", "a-b", "1-12", "1--2"} {
+		if got, err := ParseIntervalYM(s); err == nil {
+			t.Errorf("%q: got %+v, wanted error", s, got)
+		}
+	}
+}
+
+func TestIntervalDS(t *testing.T) {
+	for _, tc := range []struct {
+		String string
+		DS     IntervalDS
+		D      time.Duration
+	}{
+		{String: "0 00:00:00"},
+		{DS: IntervalDS{Days: 1, Hours: 2, Minutes: 3, Seconds: 4, Nanoseconds: 500000000},
+			D: 26*time.Hour + 3*time.Minute + 4*time.Second + 500*time.Millisecond, String: "1 02:03:04.5"},
+		{DS: IntervalDS{Days: -1, Hours: -2, Seconds: -3, Nanoseconds: -1},
+			D: -(26*time.Hour + 3*time.Second + 1), String: "-1 02:00:03.000000001"},
+	} {
+		if got := IntervalDSFromDuration(tc.D); got != tc.DS {
+			t.Errorf("%s: got %+v, wanted %+v", tc.D, got, tc.DS)
+		}
+		if got, err := tc.DS.Duration(); err != nil || got != tc.D {
+			t.Errorf("%+v: got %s (%+v), wanted %s", tc.DS, got, err, tc.D)
+		}
+		if got := tc.DS.String(); got != tc.String {
+			t.Errorf("%+v: got %q, wanted %q", tc.DS, got, tc.String)
+		}
+		var scanned IntervalDS
+		if err := scanned.Scan(tc.D); err != nil || scanned != tc.DS {
+			t.Errorf("scan %s: got %+v (%v), wanted %+v", tc.D, scanned, err, tc.DS)
+		}
+	}
+	for _, ds := range []IntervalDS{
+		{Days: 999999999},
+		{Days: -999999999},
+		{Days: 106751, Hours: 23, Minutes: 47, Seconds: 16, Nanoseconds: 854775808},
+	} {
+		if got, err := ds.Duration(); err == nil {
+			t.Errorf("%+v: got %s, wanted overflow error", ds, got)
+		}
+	}
+	maxDS := IntervalDSFromDuration(math.MaxInt64)
+	if got, err := maxDS.Duration(); err != nil || got != math.MaxInt64 {
+		t.Errorf("%+v: got %d (%+v), wanted MaxInt64", maxDS, got, err)
+	}
+}
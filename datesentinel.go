@@ -0,0 +1,110 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include "dpiImpl.h"
+*/
+import "C"
+import "time"
+
+// DateSentinelFetch returns an option for legacy schemas storing magic dates (such as 0001-01-01
+// or 9999-12-31) instead of NULL: the fetched DATE and TIMESTAMP values equal to any of the sentinels
+// are returned as NULL - so they scan as invalid sql.NullTime, or time.Time{} with NullDateAsZeroTime.
+//
+// The values are compared by their wall clock (date and time of day), regardless of the time zone.
+//
+// Use it "naked", without sql.Named!
+func DateSentinelFetch(sentinels ...time.Time) Option {
+	ss := make([]dateSentinel, len(sentinels))
+	for i, t := range sentinels {
+		ss[i] = newDateSentinel(t)
+	}
+	return func(o *stmtOptions) { o.dateSentinels = ss }
+}
+
+// DateSentinelBind returns an option to bind the zero time.Time and the invalid sql.NullTime
+// IN parameters (and elements of slices of them) as the sentinel, instead of NULL
+// - for legacy NOT NULL columns with a magic date.
+//
+// Use it "naked", without sql.Named!
+func DateSentinelBind(sentinel time.Time) Option {
+	return func(o *stmtOptions) { o.bindDateSentinel, o.bindDateSentinelSet = sentinel, true }
+}
+
+// dateSentinel is the wall clock of a sentinel time.
+type dateSentinel struct {
+	year               int16
+	month, day         uint8
+	hour, minute, secs uint8
+	fsecond            uint32
+}
+
+func newDateSentinel(t time.Time) dateSentinel {
+	Y, M, D := t.Date()
+	h, m, s := t.Clock()
+	return dateSentinel{
+		year: int16(Y), month: uint8(M), day: uint8(D),
+		hour: uint8(h), minute: uint8(m), secs: uint8(s), fsecond: uint32(t.Nanosecond()),
+	}
+}
+
+// isDateSentinel reports whether the timestamp equals to any of the sentinels.
+func isDateSentinel(ts C.dpiTimestamp, sentinels []dateSentinel) bool {
+	for _, s := range sentinels {
+		if int16(ts.year) == s.year && uint8(ts.month) == s.month && uint8(ts.day) == s.day &&
+			uint8(ts.hour) == s.hour && uint8(ts.minute) == s.minute && uint8(ts.second) == s.secs &&
+			uint32(ts.fsecond) == s.fsecond {
+			return true
+		}
+	}
+	return false
+}
+
+// bindDateSentinel returns the value with the zero times replaced by the sentinel,
+// or nil if there is nothing to replace.
+//
+// The replaced values are valid NullTimes, to be bound as is, even if the sentinel is the zero time.
+func bindDateSentinel(value interface{}, sentinel time.Time) interface{} {
+	replaced := NullTime{Time: sentinel, Valid: true}
+	switch x := value.(type) {
+	case time.Time:
+		if x.IsZero() {
+			return replaced
+		}
+	case NullTime:
+		if !x.Valid || x.Time.IsZero() {
+			return replaced
+		}
+	case []time.Time:
+		for i, t := range x {
+			if t.IsZero() {
+				ts := make([]NullTime, len(x))
+				for j, t := range x {
+					if j < i || !t.IsZero() {
+						ts[j] = NullTime{Time: t, Valid: true}
+					} else {
+						ts[j] = replaced
+					}
+				}
+				return ts
+			}
+		}
+	case []NullTime:
+		for i, t := range x {
+			if !t.Valid || t.Time.IsZero() {
+				ts := append(make([]NullTime, 0, len(x)), x...)
+				for j := i; j < len(ts); j++ {
+					if !ts[j].Valid || ts[j].Time.IsZero() {
+						ts[j] = replaced
+					}
+				}
+				return ts
+			}
+		}
+	}
+	return nil
+}
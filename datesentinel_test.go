@@ -0,0 +1,50 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBindDateSentinel(t *testing.T) {
+	sentinel := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	want := NullTime{Time: sentinel, Valid: true}
+	if got := bindDateSentinel(time.Time{}, sentinel); got != want {
+		t.Errorf("zero: got %#v", got)
+	}
+	if got := bindDateSentinel(now, sentinel); got != nil {
+		t.Errorf("now: got %#v", got)
+	}
+	if got := bindDateSentinel(NullTime{}, sentinel); got != want {
+		t.Errorf("invalid NullTime: got %#v", got)
+	}
+	if got := bindDateSentinel(NullTime{Time: now, Valid: true}, sentinel); got != nil {
+		t.Errorf("valid NullTime: got %#v", got)
+	}
+	if got := bindDateSentinel("a", sentinel); got != nil {
+		t.Errorf("string: got %#v", got)
+	}
+
+	times := []time.Time{now, {}, now, {}}
+	got, ok := bindDateSentinel(times, sentinel).([]NullTime)
+	if !ok || len(got) != len(times) || got[0].Time != now || got[1] != want || got[2].Time != now || got[3] != want {
+		t.Errorf("[]time.Time: got %#v", got)
+	}
+	if !times[1].IsZero() {
+		t.Error("the original slice is modified")
+	}
+	if got := bindDateSentinel([]time.Time{now}, sentinel); got != nil {
+		t.Errorf("[]time.Time without zero: got %#v", got)
+	}
+
+	nts, ok := bindDateSentinel([]NullTime{{Time: now, Valid: true}, {}}, sentinel).([]NullTime)
+	if !ok || len(nts) != 2 || nts[0].Time != now || !nts[1].Valid || nts[1].Time != sentinel {
+		t.Errorf("[]NullTime: got %#v", nts)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DBLink is a database link, as in ALL_DB_LINKS.
+type DBLink struct {
+	Created time.Time
+	// Owner is PUBLIC for the public database links.
+	Owner, Name string
+	// Username is empty for the connected user links (which use the current credentials).
+	Username, Host string
+}
+
+// GetDBLinks returns the database links available to the current user.
+func GetDBLinks(ctx context.Context, q Querier) ([]DBLink, error) {
+	const qry = `SELECT owner, db_link, username, host, created FROM all_db_links ORDER BY owner, db_link`
+	rows, err := q.QueryContext(ctx, qry)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	var links []DBLink
+	for rows.Next() {
+		var l DBLink
+		var username, host sql.NullString
+		if err = rows.Scan(&l.Owner, &l.Name, &username, &host, &l.Created); err != nil {
+			return links, fmt.Errorf("%s: %w", qry, err)
+		}
+		l.Username, l.Host = username.String, host.String
+		links = append(links, l)
+	}
+	if err = rows.Err(); err != nil {
+		return links, err
+	}
+	return links, rows.Close()
+}
+
+// PingDBLink checks the database link by selecting from DUAL through it.
+//
+// Note that this opens the link in the session, and starts a (distributed) transaction,
+// which holds the remote session till the commit or rollback (see CloseDBLink).
+func PingDBLink(ctx context.Context, q Querier, link string) error {
+	name, err := QualifyDBLink("DUAL", link)
+	if err != nil {
+		return err
+	}
+	qry := "SELECT 1 FROM " + name
+	rows, err := q.QueryContext(ctx, qry)
+	if err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err == nil {
+			err = sql.ErrNoRows
+		}
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return rows.Close()
+}
+
+// CloseDBLink closes the database link in the session, releasing the remote session.
+// The transaction using the link must be ended (committed or rolled back) before.
+func CloseDBLink(ctx context.Context, ex Execer, link string) error {
+	name, err := dbLinkName(link)
+	if err != nil {
+		return err
+	}
+	qry := "ALTER SESSION CLOSE DATABASE LINK " + name
+	if _, err = ex.ExecContext(ctx, qry); err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return nil
+}
+
+// QualifyDBLink returns the [schema.]name qualified with @link, quoted as needed,
+// to be used in dynamic SQL.
+// A name already qualified with another link is an error.
+func QualifyDBLink(name, link string) (string, error) {
+	on, err := ParseObjectName(name)
+	if err != nil {
+		return "", err
+	}
+	if link, err = parseDBLink(link); err != nil {
+		return "", err
+	}
+	if on.DBLink != "" && on.DBLink != link {
+		return "", fmt.Errorf("%q is already qualified with another database link than %q: %w", name, link, ErrInvalidIdentifier)
+	}
+	on.DBLink = link
+	return on.String(), nil
+}
+
+// parseDBLink returns the name of the database link as stored in the data dictionary.
+func parseDBLink(link string) (string, error) {
+	// ParseObjectName validates the link after the @
+	on, err := ParseObjectName("X@" + link)
+	if err != nil {
+		return "", fmt.Errorf("database link %q: %w", link, ErrInvalidIdentifier)
+	}
+	return on.DBLink, nil
+}
+
+// dbLinkName returns the validated name of the database link, quoted as needed.
+func dbLinkName(link string) (string, error) {
+	l, err := parseDBLink(link)
+	if err != nil {
+		return "", err
+	}
+	return ObjectName{Name: "X", DBLink: l}.String()[len("X@"):], nil
+}
+
+// IsDistributedTxError reports whether err is an error of a distributed transaction
+// (a transaction using database links): lock wait timeouts, in-doubt or rolled back
+// distributed transactions, and lost remote sessions.
+//
+// After such an error, the local transaction must be rolled back.
+func IsDistributedTxError(err error) bool {
+	var cd interface{ Code() int }
+	if err == nil || !errors.As(err, &cd) {
+		return false
+	}
+	switch cd.Code() {
+	case 1591, // lock held by in-doubt distributed transaction
+		2049, // timeout: distributed transaction waiting for lock
+		2050, // transaction rolled back, some remote DBs may be in-doubt
+		2051, // another session or branch in same transaction failed or finalized
+		2054, // transaction in-doubt
+		2068, // following severe error from database link
+		2091, // transaction rolled back
+		2092: // out of transaction table slots for distributed transaction
+		return true
+	}
+	return false
+}
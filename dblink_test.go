@@ -0,0 +1,58 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestQualifyDBLink(t *testing.T) {
+	for _, tc := range []struct {
+		name, link, want string
+	}{
+		{"emp", "remote", "EMP@REMOTE"},
+		{"scott.emp", "remote.example.com", "SCOTT.EMP@REMOTE.EXAMPLE.COM"},
+		{`"MyTab"`, `"my-link"`, `"MyTab"@"my-link"`},
+		{"emp@remote", "REMOTE", "EMP@REMOTE"},
+	} {
+		if got, err := QualifyDBLink(tc.name, tc.link); err != nil || got != tc.want {
+			t.Errorf("%q@%q: got %q, %v, wanted %q", tc.name, tc.link, got, err, tc.want)
+		}
+	}
+	for _, tc := range []struct{ name, link string }{
+		{"emp", ""},
+		{"emp", "a b"},
+		{"emp", "x@y"},
+		{"emp@other", "remote"},
+		{"emp;drop", "remote"},
+	} {
+		if got, err := QualifyDBLink(tc.name, tc.link); !errors.Is(err, ErrInvalidIdentifier) {
+			t.Errorf("%q@%q: got %q, %v, wanted ErrInvalidIdentifier", tc.name, tc.link, got, err)
+		}
+	}
+	if got, err := dbLinkName("remote.example.com"); err != nil || got != "REMOTE.EXAMPLE.COM" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestIsDistributedTxError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{codeErr(1), false},
+		{codeErr(1591), true},
+		{fmt.Errorf("wrapped: %w", codeErr(2049)), true},
+		{codeErr(2091), true},
+	} {
+		if got := IsDistributedTxError(tc.err); got != tc.want {
+			t.Errorf("%v: got %t, wanted %t", tc.err, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DDLOptions are the options of GetDDL, the DBMS_METADATA transform parameters.
+type DDLOptions struct {
+	// Schema of the object, the current schema by default.
+	Schema string
+	// Terminator ends each statement with the SQL terminator (; or /).
+	Terminator bool
+	// NoStorage omits the STORAGE clauses.
+	NoStorage bool
+	// NoSegmentAttributes omits the physical attributes, tablespace and logging clauses, too.
+	NoSegmentAttributes bool
+}
+
+// GetDDL returns the DDL of the object, with DBMS_METADATA.GET_DDL.
+// The objectType is as in DBMS_METADATA, such as TABLE, INDEX, VIEW, PACKAGE or PACKAGE_BODY;
+// the name is as stored in the data dictionary.
+//
+// The DDL is streamed from the returned CLOB, which must be closed.
+// For a *sql.DB, a connection is held from the pool till then.
+//
+// The transform parameters are reset to their defaults after the call.
+func GetDDL(ctx context.Context, ex Execer, objectType, name string, opts DDLOptions) (io.ReadCloser, error) {
+	closer := func() error { return nil }
+	if conner, ok := ex.(interface {
+		Conn(context.Context) (*sql.Conn, error)
+	}); ok {
+		// the LOB must be read on the same session
+		conn, err := conner.Conn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ex, closer = conn, conn.Close
+	}
+	const qry = `DECLARE
+  v_h CONSTANT NUMBER := DBMS_METADATA.session_transform;
+BEGIN
+  DBMS_METADATA.set_transform_param(v_h, 'SQLTERMINATOR', :1 = 1);
+  DBMS_METADATA.set_transform_param(v_h, 'STORAGE', :2 = 1);
+  DBMS_METADATA.set_transform_param(v_h, 'SEGMENT_ATTRIBUTES', :3 = 1);
+  BEGIN
+    :4 := DBMS_METADATA.get_ddl(:5, :6, :7);
+  EXCEPTION WHEN OTHERS THEN
+    DBMS_METADATA.set_transform_param(v_h, 'DEFAULT');
+    RAISE;
+  END;
+  DBMS_METADATA.set_transform_param(v_h, 'DEFAULT');
+END;`
+	lob := Lob{IsClob: true}
+	if _, err := ex.ExecContext(ctx, qry,
+		boolToInt(opts.Terminator), boolToInt(!opts.NoStorage), boolToInt(!opts.NoSegmentAttributes),
+		sql.Out{Dest: &lob}, strings.ToUpper(objectType), name, opts.Schema,
+	); err != nil {
+		_ = closer()
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	r := ddlReader{Reader: lob.Reader, close: closer}
+	if r.Reader == nil {
+		r.Reader = strings.NewReader("")
+	}
+	return r, nil
+}
+
+type ddlReader struct {
+	io.Reader
+	close func() error
+}
+
+// Close closes the LOB reader first, then releases the session it is read on.
+func (r ddlReader) Close() error {
+	var err error
+	if c, ok := r.Reader.(io.Closer); ok {
+		err = c.Close()
+	}
+	if closeErr := r.close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// Decimal128 is an IEEE 754-2008 decimal128 floating point number, in the binary integer
+// decimal (BID) encoding, as Hi and Lo 64 bits - for analytics consumers (Arrow, Parquet).
+//
+// NUMBERs are fetched as Decimal128 with the NumberAsDecimal128 option.
+// It has 34 significant digits, so NUMBERs with more digits are rounded (half to even).
+type Decimal128 struct {
+	Hi, Lo uint64
+}
+
+const (
+	dec128MaxDigits = 34
+	dec128Bias      = 6176
+	dec128MaxExp    = 6111
+	dec128MinExp    = -dec128Bias
+	dec128SignBit   = 1 << 63
+	dec128Inf       = 0x7800000000000000
+	dec128NaN       = 0x7c00000000000000
+	dec128CoeffBits = 113 - 64
+)
+
+// Decompose returns the internal decimal state into parts (see database/sql/driver's decimalDecompose).
+//
+// form is 0 for finite, 1 for infinite and 2 for NaN numbers.
+func (d Decimal128) Decompose(buf []byte) (form byte, negative bool, coefficient []byte, exponent int32) {
+	negative = d.Hi&dec128SignBit != 0
+	switch {
+	case d.Hi&dec128NaN == dec128NaN:
+		return 2, negative, nil, 0
+	case d.Hi&dec128Inf == dec128Inf:
+		return 1, negative, nil, 0
+	case d.Hi&0x6000000000000000 == 0x6000000000000000:
+		// the large coefficient form is always non-canonical (zero) for decimal128
+		return 0, negative, nil, int32((d.Hi>>47)&0x3fff) - dec128Bias
+	}
+	exponent = int32((d.Hi>>49)&0x3fff) - dec128Bias
+	hi := d.Hi & (1<<dec128CoeffBits - 1)
+	if buf != nil && cap(buf) >= 16 {
+		buf = buf[:16]
+	} else {
+		buf = make([]byte, 16)
+	}
+	for i := 0; i < 8; i++ {
+		buf[i] = byte(hi >> (56 - 8*i))
+		buf[8+i] = byte(d.Lo >> (56 - 8*i))
+	}
+	for len(buf) > 0 && buf[0] == 0 {
+		buf = buf[1:]
+	}
+	return 0, negative, buf, exponent
+}
+
+// Compose sets the Decimal128 from the parts, rounding the coefficient to 34 digits (half to even).
+// Exponents out of range yield infinity (or zero).
+func (d *Decimal128) Compose(form byte, negative bool, coefficient []byte, exponent int32) error {
+	switch form {
+	case 0:
+	case 1:
+		*d = Decimal128{Hi: dec128Inf}
+		if negative {
+			d.Hi |= dec128SignBit
+		}
+		return nil
+	case 2:
+		*d = Decimal128{Hi: dec128NaN}
+		return nil
+	default:
+		return fmt.Errorf("unknown form %d", form)
+	}
+	digits := []byte(new(big.Int).SetBytes(coefficient).String())
+	for i := range digits {
+		digits[i] -= '0'
+	}
+	*d = newDecimal128(negative, digits, int(exponent))
+	return nil
+}
+
+// newDecimal128 returns the Decimal128 of the decimal digits (values 0-9, most significant first)
+// multiplied by 10^exponent.
+func newDecimal128(negative bool, digits []byte, exponent int) Decimal128 {
+	for len(digits) > 0 && digits[0] == 0 {
+		digits = digits[1:]
+	}
+	if n := len(digits) - dec128MaxDigits; n > 0 {
+		// round half to even
+		rest, last := digits[dec128MaxDigits:], digits[dec128MaxDigits-1]
+		digits, exponent = digits[:dec128MaxDigits], exponent+n
+		up := rest[0] > 5
+		if rest[0] == 5 {
+			up = last%2 == 1
+			for _, c := range rest[1:] {
+				if c != 0 {
+					up = true
+					break
+				}
+			}
+		}
+		if up {
+			digits = append([]byte(nil), digits...)
+			i := len(digits) - 1
+			for ; i >= 0 && digits[i] == 9; i-- {
+				digits[i] = 0
+			}
+			if i >= 0 {
+				digits[i]++
+			} else {
+				// 999...9 rounded to 1000...0
+				digits[0], exponent = 1, exponent+1
+			}
+		}
+	}
+	for len(digits) > 0 && exponent < dec128MinExp {
+		digits, exponent = digits[:len(digits)-1], exponent+1
+	}
+	var d Decimal128
+	if negative {
+		d.Hi = dec128SignBit
+	}
+	if len(digits) == 0 {
+		exponent = 0
+	}
+	for ; exponent > dec128MaxExp && len(digits) < dec128MaxDigits; exponent-- {
+		digits = append(digits, 0)
+	}
+	if exponent > dec128MaxExp {
+		d.Hi |= dec128Inf
+		return d
+	}
+	var hi, lo uint64
+	for _, c := range digits {
+		// (hi, lo) = (hi, lo) * 10 + c
+		h, l := bits.Mul64(lo, 10)
+		hi = hi*10 + h
+		var carry uint64
+		lo, carry = bits.Add64(l, uint64(c), 0)
+		hi += carry
+	}
+	d.Hi |= uint64(exponent+dec128Bias)<<dec128CoeffBits | hi
+	d.Lo = lo
+	return d
+}
+
+// decimal128FromOCINumber converts the Oracle NUMBER in its internal format to Decimal128.
+func decimal128FromOCINumber(b ociNumber) Decimal128 {
+	var buf [20]byte
+	negative, pairs, exp100, inf := b.parts(&buf)
+	switch inf {
+	case -1:
+		return Decimal128{Hi: dec128SignBit | dec128Inf}
+	case 1:
+		return Decimal128{Hi: dec128Inf}
+	}
+	var arr [40]byte
+	digits := arr[:0]
+	for _, p := range pairs {
+		digits = append(digits, p/10, p%10)
+	}
+	exponent := 2 * (exp100 - len(pairs) + 1)
+	for len(digits) > 1 && digits[len(digits)-1] == 0 {
+		digits, exponent = digits[:len(digits)-1], exponent+1
+	}
+	return newDecimal128(negative, digits, exponent)
+}
+
+// String returns the Decimal128 in plain (not scientific) notation.
+func (d Decimal128) String() string {
+	form, negative, coefficient, exponent := d.Decompose(nil)
+	switch form {
+	case 1:
+		if negative {
+			return "-Inf"
+		}
+		return "+Inf"
+	case 2:
+		return "NaN"
+	}
+	if len(coefficient) == 0 {
+		return "0"
+	}
+	var n Number
+	_ = n.Compose(form, negative, coefficient, exponent)
+	return string(n)
+}
+
+// Scan a Decimal128, Number or string (and nil, as zero) into the Decimal128.
+func (d *Decimal128) Scan(src interface{}) error {
+	switch x := src.(type) {
+	case nil:
+		*d = newDecimal128(false, nil, 0)
+	case Decimal128:
+		*d = x
+	case Number:
+		return d.scanNumber(x)
+	case string:
+		return d.scanNumber(Number(x))
+	case []byte:
+		return d.scanNumber(Number(x))
+	default:
+		return fmt.Errorf("cannot scan %T into Decimal128", src)
+	}
+	return nil
+}
+
+func (d *Decimal128) scanNumber(n Number) error {
+	form, negative, coefficient, exponent := n.Decompose(nil)
+	if form == 2 {
+		return fmt.Errorf("%q is not a number", string(n))
+	}
+	return d.Compose(form, negative, coefficient, exponent)
+}
+
+var _ sql.Scanner = (*Decimal128)(nil)
@@ -0,0 +1,68 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecimal128FromOCINumber(t *testing.T) {
+	for _, tc := range []struct {
+		Want string
+		B    []byte
+		Dec  Decimal128
+	}{
+		{B: []byte{1, 0x80}, Want: "0", Dec: Decimal128{Hi: 0x3040000000000000}},
+		{B: []byte{2, 0xc1, 2}, Want: "1", Dec: Decimal128{Hi: 0x3040000000000000, Lo: 1}},
+		{B: []byte{4, 0xc2, 2, 24, 46}, Want: "123.45", Dec: Decimal128{Hi: 0x303c000000000000, Lo: 12345}},
+		{B: []byte{5, 0x3d, 100, 78, 56, 102}, Want: "-123.45", Dec: Decimal128{Hi: 0xb03c000000000000, Lo: 12345}},
+		{B: []byte{2, 0xc0, 51}, Want: "0.5", Dec: Decimal128{Hi: 0x303e000000000000, Lo: 5}},
+		{B: []byte{2, 0xc3, 2}, Want: "10000", Dec: Decimal128{Hi: 0x3048000000000000, Lo: 1}},
+		{B: []byte{2, 0xff, 101}, Want: "+Inf"},
+		{B: []byte{1, 0}, Want: "-Inf"},
+	} {
+		d := decimal128FromOCINumber(tc.B)
+		if got := d.String(); got != tc.Want {
+			t.Errorf("%v: got %q, wanted %q", tc.B, got, tc.Want)
+		}
+		if tc.Dec != (Decimal128{}) && d != tc.Dec {
+			t.Errorf("%v: got %#v, wanted %#v", tc.B, d, tc.Dec)
+		}
+	}
+}
+
+func TestDecimal128Scan(t *testing.T) {
+	for in, want := range map[string]string{
+		"0":                                     "0",
+		"-42":                                   "-42",
+		"3.14159":                               "3.14159",
+		"0.000001":                              "0.000001",
+		"1234567890123456789012345678901234.5":  "1234567890123456789012345678901234",
+		"1234567890123456789012345678901235.5":  "1234567890123456789012345678901236",
+		"12345678901234567890123456789012345.1": "12345678901234567890123456789012350",
+		strings.Repeat("9", 35):                 "1" + strings.Repeat("0", 35),
+	} {
+		var d Decimal128
+		if err := d.Scan(in); err != nil {
+			t.Errorf("%q: %+v", in, err)
+			continue
+		}
+		if got := d.String(); got != want {
+			t.Errorf("%q: got %q, wanted %q", in, got, want)
+		}
+		var n Number
+		if err := n.Compose(d.Decompose(nil)); err != nil {
+			t.Errorf("%q: %+v", in, err)
+		} else if want != "0" && string(n) != want {
+			t.Errorf("%q: composed %q, wanted %q", in, n, want)
+		}
+	}
+	var d Decimal128
+	if err := d.Scan("x"); err == nil {
+		t.Errorf("got %s, wanted error", d)
+	}
+}
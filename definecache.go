@@ -0,0 +1,123 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include "dpiImpl.h"
+*/
+import "C"
+
+import "sync/atomic"
+
+// DefineStats holds the statistics of the define (fetch) buffers of queries.
+type DefineStats struct {
+	// Allocated is the number of define buffers allocated.
+	Allocated uint64
+	// Reused is the number of define buffers reused from a previous execution of the same statement.
+	Reused uint64
+}
+
+type defineStats struct {
+	allocated, reused atomic.Uint64
+}
+
+func (s *defineStats) get() DefineStats {
+	return DefineStats{Allocated: s.allocated.Load(), Reused: s.reused.Load()}
+}
+
+// DefineStats returns the statistics of the define buffers.
+func (d *drv) DefineStats() DefineStats {
+	if d == nil {
+		return DefineStats{}
+	}
+	return d.defineStats.get()
+}
+
+// GetDefineStats returns the statistics of the define buffers, for the default driver.
+func GetDefineStats() DefineStats { return defaultDrv.DefineStats() }
+
+// cachedDefine is a define variable kept for the next execution of the statement.
+type cachedDefine struct {
+	v    *C.dpiVar
+	data []C.dpiData
+	vi   varInfo
+}
+
+// reusableDefine reports whether a define variable of this kind can be reused:
+// the fetched values are copied into Go values, no handles (LOB, object, cursor, rowid) are kept.
+func reusableDefine(vi varInfo) bool {
+	switch vi.Typ {
+	case C.DPI_ORACLE_TYPE_LONG_VARCHAR, C.DPI_ORACLE_TYPE_LONG_NVARCHAR, C.DPI_ORACLE_TYPE_LONG_RAW:
+		return false
+	}
+	switch vi.NatTyp {
+	case C.DPI_NATIVE_TYPE_BYTES, C.DPI_NATIVE_TYPE_INT64, C.DPI_NATIVE_TYPE_UINT64,
+		C.DPI_NATIVE_TYPE_FLOAT, C.DPI_NATIVE_TYPE_DOUBLE, C.DPI_NATIVE_TYPE_TIMESTAMP,
+		C.DPI_NATIVE_TYPE_INTERVAL_DS, C.DPI_NATIVE_TYPE_INTERVAL_YM, C.DPI_NATIVE_TYPE_BOOLEAN:
+		return vi.ObjectType == nil
+	}
+	return false
+}
+
+// takeDefine returns the cached define variable for the column, if it matches vi.
+// The caller owns the returned variable.
+func (st *statement) takeDefine(i int, vi varInfo) (*C.dpiVar, []C.dpiData, bool) {
+	if i >= len(st.defines) || st.defines[i].v == nil {
+		return nil, nil, false
+	}
+	cd := st.defines[i]
+	st.defines[i] = cachedDefine{}
+	if cd.vi != vi {
+		C.dpiVar_release(cd.v)
+		return nil, nil, false
+	}
+	return cd.v, cd.data, true
+}
+
+// keepDefines keeps the reusable define variables of the rows for the next execution,
+// and returns the rest, to be released.
+func (st *statement) keepDefines(vars []*C.dpiVar, data [][]C.dpiData, infos []varInfo) []*C.dpiVar {
+	if len(infos) != len(vars) {
+		return vars
+	}
+	if cap(st.defines) < len(vars) {
+		st.releaseDefines()
+		st.defines = make([]cachedDefine, len(vars))
+	} else {
+		st.defines = st.defines[:len(vars)]
+	}
+	// a new slice, as the caller releases all of rest, up to its capacity
+	rest := make([]*C.dpiVar, 0, len(vars))
+	for _, v := range vars[len(vars):cap(vars)] {
+		if v != nil {
+			rest = append(rest, v)
+		}
+	}
+	for i, v := range vars {
+		if v == nil {
+			continue
+		}
+		if !reusableDefine(infos[i]) {
+			rest = append(rest, v)
+			continue
+		}
+		if old := st.defines[i].v; old != nil {
+			C.dpiVar_release(old)
+		}
+		st.defines[i] = cachedDefine{v: v, data: data[i], vi: infos[i]}
+	}
+	return rest
+}
+
+// releaseDefines releases the cached define variables.
+func (st *statement) releaseDefines() {
+	for _, cd := range st.defines {
+		if cd.v != nil {
+			C.dpiVar_release(cd.v)
+		}
+	}
+	st.defines = st.defines[:0]
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include "dpiImpl.h"
+*/
+import "C"
+
+import (
+	"context"
+	"fmt"
+)
+
+// Description is the description of a statement, as returned by Describe.
+type Description struct {
+	// Params are the bind variables, in the order of their first occurrence.
+	Params []DescribedParam
+	// Columns are the result columns of a query.
+	Columns []DescribedColumn
+	// StatementType is the OCI statement type (DPI_STMT_TYPE_*).
+	StatementType                  int
+	IsQuery, IsPLSQL, IsDDL, IsDML bool
+	IsReturning                    bool
+}
+
+// DescribedParam is a bind variable of a described statement.
+//
+// Oracle does not describe the type of bind variables before they're bound,
+// so only the name and position is known.
+type DescribedParam struct {
+	Name     string
+	Position int
+}
+
+// DescribedColumn is a result column of a described query.
+type DescribedColumn struct {
+	QueryColumn
+	// TypeName is the database type name, as ColumnTypeDatabaseTypeName returns.
+	TypeName string
+	// ObjectTypeName is the full name (schema.[package.]name) of the user-defined type of the column.
+	ObjectTypeName string
+	// SizeInChars is the size of character columns in characters,
+	// DBSize is their size in bytes in the database character set.
+	SizeInChars, DBSize int
+	// CharSemantics is true for character columns with CHAR length semantics (VARCHAR2(10 CHAR)).
+	CharSemantics bool
+	DomainAnnotation
+}
+
+// Describe prepares the statement without executing it,
+// and returns its bind variables and result columns.
+func Describe(ctx context.Context, ex Execer, qry string) (Description, error) {
+	var desc Description
+	err := Raw(ctx, ex, func(c Conn) error {
+		stmt, err := c.PrepareContext(ctx, qry)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		st := stmt.(*statement)
+		info := st.dpiStmtInfo
+		desc.StatementType = int(info.statementType)
+		desc.IsQuery, desc.IsPLSQL = info.isQuery == 1, info.isPLSQL == 1
+		desc.IsDDL, desc.IsDML = info.isDDL == 1, info.isDML == 1
+		desc.IsReturning = info.isReturning == 1
+
+		names, err := st.bindNames()
+		if err != nil {
+			return err
+		}
+		desc.Params = make([]DescribedParam, len(names))
+		for i, nm := range names {
+			desc.Params[i] = DescribedParam{Name: nm, Position: i + 1}
+		}
+		if !desc.IsQuery {
+			return nil
+		}
+
+		describeOnly(&st.stmtOptions)
+		dR, err := st.QueryContext(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer dR.Close()
+		r := dR.(*rows)
+		desc.Columns = make([]DescribedColumn, len(r.columns))
+		for i, col := range r.columns {
+			dc := DescribedColumn{
+				QueryColumn: QueryColumn{
+					Name:      col.Name,
+					Type:      int(col.OracleType),
+					Length:    int(col.Size),
+					Precision: int(col.Precision),
+					Scale:     int(col.Scale),
+					Nullable:  col.Nullable,
+				},
+				TypeName:         r.ColumnTypeDatabaseTypeName(i),
+				SizeInChars:      int(col.SizeInChars),
+				DBSize:           int(col.DBSize),
+				CharSemantics:    col.CharSemantics,
+				DomainAnnotation: col.DomainAnnotation,
+			}
+			if col.ObjectType != nil {
+				if dc.ObjectTypeName, err = st.objectTypeName(col.ObjectType); err != nil {
+					return fmt.Errorf("%s: %w", col.Name, err)
+				}
+			}
+			desc.Columns[i] = dc
+		}
+		return nil
+	})
+	return desc, err
+}
+
+// objectTypeName returns the full name of the object type.
+func (st *statement) objectTypeName(ot *C.dpiObjectType) (string, error) {
+	var info C.dpiObjectTypeInfo
+	if err := st.checkExec(func() C.int { return C.dpiObjectType_getInfo(ot, &info) }); err != nil {
+		return "", err
+	}
+	name := C.GoStringN(info.schema, C.int(info.schemaLength))
+	if info.packageNameLength != 0 {
+		name += "." + C.GoStringN(info.packageName, C.int(info.packageNameLength))
+	}
+	return name + "." + C.GoStringN(info.name, C.int(info.nameLength)), nil
+}
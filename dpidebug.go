@@ -0,0 +1,137 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include "dpiImpl.h"
+
+int godror_dpiDebugSetFd(int fd);
+*/
+import "C"
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// DPIDebugLevel is the bit mask of the ODPI-C debug messages, as the DPI_DEBUG_LEVEL environment variable.
+type DPIDebugLevel uint32
+
+// The ODPI-C debug levels, see https://odpi-c.readthedocs.io/en/latest/user_guide/debugging.html
+const (
+	DPIDebugUnreportedErrors = DPIDebugLevel(C.DPI_DEBUG_LEVEL_UNREPORTED_ERRORS)
+	DPIDebugRefs             = DPIDebugLevel(C.DPI_DEBUG_LEVEL_REFS)
+	DPIDebugFns              = DPIDebugLevel(C.DPI_DEBUG_LEVEL_FNS)
+	DPIDebugErrors           = DPIDebugLevel(C.DPI_DEBUG_LEVEL_ERRORS)
+	DPIDebugSQL              = DPIDebugLevel(C.DPI_DEBUG_LEVEL_SQL)
+	DPIDebugMem              = DPIDebugLevel(C.DPI_DEBUG_LEVEL_MEM)
+	DPIDebugLoadLib          = DPIDebugLevel(C.DPI_DEBUG_LEVEL_LOAD_LIB)
+)
+
+// ErrDPIDebugOutput is returned by SetDPIDebugOutput if the output cannot be redirected.
+var ErrDPIDebugOutput = errors.New("cannot redirect the ODPI-C debug output")
+
+var dpiDebug struct {
+	out      atomic.Pointer[io.Writer]
+	pipe     *os.File // the write end, must be kept open
+	mu       sync.Mutex
+	level    DPIDebugLevel
+	levelSet bool
+}
+
+// SetDPIDebugLevel sets the ODPI-C debug level, overriding the DPI_DEBUG_LEVEL environment variable.
+// 0 switches the debug messages off.
+func SetDPIDebugLevel(level DPIDebugLevel) {
+	dpiDebug.mu.Lock()
+	dpiDebug.level, dpiDebug.levelSet = level, true
+	C.dpiDebugLevel = C.ulong(level)
+	dpiDebug.mu.Unlock()
+}
+
+// GetDPIDebugLevel returns the current ODPI-C debug level.
+func GetDPIDebugLevel() DPIDebugLevel {
+	dpiDebug.mu.Lock()
+	defer dpiDebug.mu.Unlock()
+	return DPIDebugLevel(C.dpiDebugLevel)
+}
+
+// SetDPIDebugOutput redirects the ODPI-C debug messages (by default written to stderr) to w, line by line.
+// A nil w switches back to stderr.
+//
+// The messages are copied through a pipe by a goroutine, so w should not block. Not supported on Windows.
+func SetDPIDebugOutput(w io.Writer) error {
+	dpiDebug.mu.Lock()
+	defer dpiDebug.mu.Unlock()
+	if w == nil {
+		dpiDebug.out.Store(nil)
+		C.godror_dpiDebugSetFd(-1)
+		return nil
+	}
+	if runtime.GOOS == "windows" {
+		return ErrDPIDebugOutput
+	}
+	if dpiDebug.pipe == nil {
+		r, pw, err := os.Pipe()
+		if err != nil {
+			return err
+		}
+		if C.godror_dpiDebugSetFd(C.int(pw.Fd())) != 0 {
+			r.Close()
+			pw.Close()
+			return ErrDPIDebugOutput
+		}
+		dpiDebug.pipe = pw
+		go copyDPIDebug(r)
+	} else {
+		C.godror_dpiDebugSetFd(C.int(dpiDebug.pipe.Fd()))
+	}
+	dpiDebug.out.Store(&w)
+	return nil
+}
+
+// SetDPIDebugToLogger sends the ODPI-C debug messages to the logger set with SetLogger, at Debug level.
+// The messages are dropped while no logger is set.
+func SetDPIDebugToLogger() error { return SetDPIDebugOutput(dpiDebugLogWriter{}) }
+
+func copyDPIDebug(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if w := dpiDebug.out.Load(); w != nil {
+			_, _ = (*w).Write(append(scanner.Bytes(), '\n'))
+		}
+	}
+}
+
+type dpiDebugLogWriter struct{}
+
+func (dpiDebugLogWriter) Write(p []byte) (int, error) {
+	if logger := getLogger(context.TODO()); logger != nil {
+		n := len(p)
+		if n != 0 && p[n-1] == '\n' {
+			n--
+		}
+		logger.Debug(string(p[:n]), "source", "odpi")
+	}
+	return len(p), nil
+}
+
+// reapplyDPIDebug sets the debug level and output again, after ODPI-C initialized them from the environment.
+func reapplyDPIDebug() {
+	dpiDebug.mu.Lock()
+	defer dpiDebug.mu.Unlock()
+	if dpiDebug.levelSet {
+		C.dpiDebugLevel = C.ulong(dpiDebug.level)
+	}
+	if dpiDebug.pipe != nil && dpiDebug.out.Load() != nil {
+		C.godror_dpiDebugSetFd(C.int(dpiDebug.pipe.Fd()))
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godror/godror/slog"
+)
+
+type chanWriter chan string
+
+func (w chanWriter) Write(p []byte) (int, error) { w <- string(p); return len(p), nil }
+
+func TestDPIDebug(t *testing.T) {
+	old := GetDPIDebugLevel()
+	defer SetDPIDebugLevel(old)
+	SetDPIDebugLevel(DPIDebugErrors | DPIDebugSQL)
+	if got := GetDPIDebugLevel(); got != DPIDebugErrors|DPIDebugSQL {
+		t.Errorf("got %d, wanted %d", got, DPIDebugErrors|DPIDebugSQL)
+	}
+
+	if runtime.GOOS == "windows" {
+		t.Skip("SetDPIDebugOutput is not supported on Windows")
+	}
+	ch := make(chanWriter, 1)
+	if err := SetDPIDebugOutput(ch); err != nil {
+		t.Fatal(err)
+	}
+	defer SetDPIDebugOutput(nil)
+	if _, err := dpiDebug.pipe.WriteString("ODPI [1] test message\n"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case s := <-ch:
+		if s != "ODPI [1] test message\n" {
+			t.Errorf("got %q", s)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message")
+	}
+
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer SetLogger(slog.Default())
+	if _, err := (dpiDebugLogWriter{}).Write([]byte("ODPI [1] logged\n")); err != nil {
+		t.Fatal(err)
+	}
+	if s := buf.String(); !strings.Contains(s, `msg="ODPI [1] logged"`) || !strings.Contains(s, "source=odpi") {
+		t.Errorf("got %q", s)
+	}
+}
@@ -30,6 +30,8 @@
 //	timezone=
 //	noTimezoneCheck=
 //	perSessionTimezone=
+//	sessionTimezone=
+//	container=
 //	newPassword=
 //	onInit="ALTER SESSION SET current_schema=my_schema"
 //	configDir=
@@ -72,6 +74,51 @@ package godror
 
 #include "dpi.c"
 
+// godror_dpiVar_getTimeZoneName returns the time zone (region name or offset)
+// of the TIMESTAMP WITH TIME ZONE at pos of the variable, as ODPI-C has only the offset.
+int godror_dpiVar_getTimeZoneName(dpiVar *var, uint32_t pos, char *buf, uint32_t *bufLen) {
+	static int (*fnDateTimeGetTimeZoneName)(void*, void*, const void*, uint8_t*, uint32_t*);
+	dpiError error;
+	int status;
+
+	if (dpiGen__startPublicFn(var, DPI_HTYPE_VAR, __func__, &error) < 0)
+		return dpiGen__endPublicFn(var, DPI_FAILURE, &error);
+	if (var->type->oracleTypeNum != DPI_ORACLE_TYPE_TIMESTAMP_TZ ||
+			pos >= var->buffer.maxArraySize) {
+		dpiError__set(&error, "check timestamp tz", DPI_ERR_NOT_SUPPORTED);
+		return dpiGen__endPublicFn(var, DPI_FAILURE, &error);
+	}
+	if (!fnDateTimeGetTimeZoneName && dpiOci__loadSymbol("OCIDateTimeGetTimeZoneName",
+			(void**) &fnDateTimeGetTimeZoneName, &error) < 0)
+		return dpiGen__endPublicFn(var, DPI_FAILURE, &error);
+	if (!error.handle && dpiError__initHandle(&error) < 0)
+		return dpiGen__endPublicFn(var, DPI_FAILURE, &error);
+	status = (*fnDateTimeGetTimeZoneName)(var->env->handle, error.handle,
+			var->buffer.data.asTimestamp[pos], (uint8_t*) buf, bufLen);
+	if (status != DPI_OCI_SUCCESS)
+		dpiError__setFromOCI(&error, status, NULL, "get time zone name");
+	return dpiGen__endPublicFn(var, status == DPI_OCI_SUCCESS ? DPI_SUCCESS : DPI_FAILURE, &error);
+}
+
+static FILE *godror_dpiDebugPipe = NULL;
+
+// godror_dpiDebugSetFd sends the ODPI-C debug messages to the file descriptor fd
+// (opened only at the first call, then reused), or back to stderr if fd is negative.
+// dpiDebugStream is static in dpiDebug.c, so this must be here.
+int godror_dpiDebugSetFd(int fd) {
+	if (fd < 0) {
+		dpiDebugStream = stderr;
+		return 0;
+	}
+	if (!godror_dpiDebugPipe) {
+		if (!(godror_dpiDebugPipe = fdopen(fd, "w")))
+			return -1;
+		setvbuf(godror_dpiDebugPipe, NULL, _IOLBF, 0);
+	}
+	dpiDebugStream = godror_dpiDebugPipe;
+	return 0;
+}
+
 */
 import "C"
 
@@ -91,6 +138,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 	"unsafe"
 
 	"github.com/godror/godror/dsn"
@@ -207,6 +255,16 @@ type drv struct {
 	pools         map[string]*connPool
 	timezones     map[string]locationWithOffSecs
 	clientVersion VersionInfo
+	tracker       connTracker
+	cancelStats   cancelStats
+	defineStats   defineStats
+	metrics       driverMetrics
+	slowQuery     atomic.Pointer[slowQueryHook]
+	interceptors  atomic.Pointer[[]Interceptor]
+	roundTrips    roundTripStats
+	poolEvents    atomic.Pointer[func(PoolEvent)]
+	tagExtractor  atomic.Pointer[TagExtractor]
+	audit         atomic.Pointer[func(context.Context, AuditEvent)]
 	mu            sync.RWMutex
 }
 
@@ -346,6 +404,8 @@ func (d *drv) init(configDir, libDir string) error {
 		return fmt.Errorf("getClientVersion: %w", d.getError())
 	}
 	d.clientVersion.set(&v)
+	// the first context creation initialized the ODPI-C debugging from the environment
+	reapplyDPIDebug()
 	return nil
 }
 
@@ -482,6 +542,14 @@ func (d *drv) createConn(pool *connPool, P commonAndConnParams) (*conn, bool, er
 		}
 		return nil, false, fmt.Errorf("init: %w", err)
 	}
+	resourceCounters.conns.Add(1)
+	if pool != nil {
+		d.tracker.checkout(&c)
+		if isNew {
+			c.poolEvent(PoolSessionCreated, 0)
+		}
+		c.poolEvent(PoolCheckout, 0)
+	}
 
 	if !guardWithFinalizers.Load() {
 		return &c, isNew, nil
@@ -729,7 +797,19 @@ func (d *drv) acquireConn(pool *connPool, P commonAndConnParams) (*C.dpiConn, bo
 // standalone connection is created instead. The connection parameters are used
 // to acquire a connection from the pool specified by the pool parameters or
 // are used to create a standalone connection.
+//
+// If the context has a proxy user (see ContextWithProxyUser), the connection is
+// a proxy session of that user: from the heterogeneous variant of the pool,
+// or with the "user[proxyUser]" syntax for standalone connections.
 func (d *drv) createConnFromParams(ctx context.Context, P dsn.ConnectionParams) (*conn, error) {
+	proxyUser := getProxyUser(ctx)
+	if proxyUser != "" {
+		if P.IsStandalone() {
+			P.Username += "[" + proxyUser + "]"
+		} else {
+			P.Heterogeneous = dsn.Bool(true)
+		}
+	}
 	var err error
 	var pool *connPool
 	if !P.IsStandalone() {
@@ -738,10 +818,17 @@ func (d *drv) createConnFromParams(ctx context.Context, P dsn.ConnectionParams)
 			return nil, err
 		}
 	}
-	conn, isNew, err := d.createConn(pool, commonAndConnParams{CommonParams: P.CommonParams, ConnParams: P.ConnParams})
+	connP := commonAndConnParams{CommonParams: P.CommonParams, ConnParams: P.ConnParams}
+	if proxyUser != "" && pool != nil {
+		// the heterogeneous pool authenticates the proxy user without password
+		connP.Username, connP.Password = proxyUser, dsn.Password{}
+	}
+	conn, isNew, err := d.createConn(pool, connP)
 	if err != nil {
 		return conn, err
 	}
+	conn.proxyUser = proxyUser
+	conn.applyContextTags(ctx)
 
 	if P.CommonParams.InitOnNewConn && !isNew {
 		return conn, nil
@@ -771,21 +858,7 @@ func (d *drv) getPool(P commonAndPoolParams) (*connPool, error) {
 		return nil, err
 	}
 
-	var usernameKey string
-	var passwordHash [sha256.Size]byte
-	if !(P.Heterogeneous.Valid && P.Heterogeneous.Bool) &&
-		!(P.ExternalAuth.Valid && P.ExternalAuth.Bool) {
-		// skip username being part of key in heterogeneous pools
-		usernameKey = P.Username
-		passwordHash = sha256.Sum256([]byte(P.Password.Secret())) // See issue #245
-	}
-	// determine key to use for pool
-	poolKey := fmt.Sprintf("%s\t%x\t%s\t%d\t%d\t%d\t%s\t%s\t%s\t%t\t%t\t%t\t%s\t%d\t%s",
-		usernameKey, passwordHash[:4], P.ConnectString, P.MinSessions, P.MaxSessions,
-		P.SessionIncrement, P.WaitTimeout, P.MaxLifeTime, P.SessionTimeout,
-		P.Heterogeneous.Bool, P.EnableEvents, P.ExternalAuth.Bool,
-		P.Timezone, P.MaxSessionsPerShard, P.PingInterval,
-	)
+	poolKey := P.poolKey()
 	logger := P.Logger
 	if logger != nil {
 		logger.Debug("getPool", "key", poolKey)
@@ -1030,6 +1103,25 @@ type commonAndPoolParams struct {
 	dsn.PoolParams
 }
 
+// poolKey returns the key of the pool in drv.pools.
+func (P commonAndPoolParams) poolKey() string {
+	var usernameKey string
+	var passwordHash [sha256.Size]byte
+	if !(P.Heterogeneous.Valid && P.Heterogeneous.Bool) &&
+		!(P.ExternalAuth.Valid && P.ExternalAuth.Bool) {
+		// skip username being part of key in heterogeneous pools
+		usernameKey = P.Username
+		passwordHash = sha256.Sum256([]byte(P.Password.Secret())) // See issue #245
+	}
+	// determine key to use for pool
+	return fmt.Sprintf("%s\t%x\t%s\t%d\t%d\t%d\t%s\t%s\t%s\t%t\t%t\t%t\t%s\t%d\t%s",
+		usernameKey, passwordHash[:4], P.ConnectString, P.MinSessions, P.MaxSessions,
+		P.SessionIncrement, P.WaitTimeout, P.MaxLifeTime, P.SessionTimeout,
+		P.Heterogeneous.Bool, P.EnableEvents, P.ExternalAuth.Bool,
+		P.Timezone, P.MaxSessionsPerShard, P.PingInterval,
+	)
+}
+
 func (P commonAndPoolParams) String() string {
 	return P.CommonParams.String() + " " + P.PoolParams.String()
 }
@@ -1037,6 +1129,7 @@ func (P commonAndPoolParams) String() string {
 // OraErr is an error holding the ORA-01234 code and the message.
 type OraErr struct {
 	message, funName, action, sqlState string
+	sql                                string
 	code, offset                       int
 	recoverable, warning               bool
 }
@@ -1075,6 +1168,52 @@ func (oe *OraErr) Error() string {
 	}
 	return fmt.Sprintf("ORA-%05d: %s", oe.code, oe.message)
 }
+
+// ErrCallTimeout is matched (with errors.Is) by the errors of calls exceeding the call timeout
+// (see the CallTimeout option and CallTimeoutConn.SetCallTimeout).
+var ErrCallTimeout = errors.New("call timeout exceeded")
+
+// Is reports whether the OraErr matches target: ErrCallTimeout for
+// ORA-03156 (OCI call timed out) and DPI-1067 (call timeout exceeded).
+func (oe *OraErr) Is(target error) bool {
+	if oe == nil || target != ErrCallTimeout {
+		return false
+	}
+	return oe.code == 3156 || strings.HasPrefix(oe.message, "DPI-1067:")
+}
+
+// IsCallTimeout reports whether the error is because of exceeding the call timeout.
+func IsCallTimeout(err error) bool { return errors.Is(err, ErrCallTimeout) }
+
+// IsUniqueViolation reports whether the error is ORA-00001 (unique constraint violated).
+func IsUniqueViolation(err error) bool {
+	oe, ok := AsOraErr(err)
+	return ok && oe.Code() == 1
+}
+
+// IsDeadlock reports whether the error is ORA-00060 (deadlock detected while waiting for resource).
+func IsDeadlock(err error) bool {
+	oe, ok := AsOraErr(err)
+	return ok && oe.Code() == 60
+}
+
+// IsTimeout reports whether the error is because of a timeout: the call timeout (see IsCallTimeout),
+// a lock wait timeout (ORA-00054, ORA-04021, ORA-30006) or a network timeout (ORA-12170, ORA-12535).
+func IsTimeout(err error) bool {
+	if IsCallTimeout(err) {
+		return true
+	}
+	oe, ok := AsOraErr(err)
+	if !ok {
+		return false
+	}
+	switch oe.Code() {
+	case 54, 4021, 30006, 12170, 12535:
+		return true
+	}
+	return false
+}
+
 func fromErrorInfo(errInfo C.dpiErrorInfo) error {
 	oe := OraErr{
 		code:        int(errInfo.code),
@@ -1133,14 +1272,72 @@ func (oe *OraErr) SQLState() string { return oe.sqlState }
 // Recoverable indicates if the error is recoverable. This is always false unless both client and server are at release 12.1 or higher.
 func (oe *OraErr) Recoverable() bool { return oe.recoverable }
 
+// IsWarning indicates if the error is a warning (such as ORA-24344 success with compilation error).
 func (oe *OraErr) IsWarning() bool { return oe.warning }
 
+// SQL returns the text of the statement which failed, if the error is from a prepare or an execution.
+func (oe *OraErr) SQL() string { return oe.sql }
+
+// snippetContext is the number of bytes of SQL text before and after the offset in Snippet.
+const snippetContext = 32
+
+// Snippet returns the part of the SQL text around the error Offset (such as a parse error),
+// with the offset marked by "<*>", or "" if the offset is not within the SQL text.
+func (oe *OraErr) Snippet() string {
+	if oe.sql == "" || oe.offset <= 0 || oe.offset > len(oe.sql) {
+		return ""
+	}
+	start, end := oe.offset-snippetContext, oe.offset+snippetContext
+	if start < 0 {
+		start = 0
+	}
+	if end > len(oe.sql) {
+		end = len(oe.sql)
+	}
+	// do not cut the multi-byte characters
+	for start > 0 && !utf8.RuneStart(oe.sql[start]) {
+		start--
+	}
+	for end < len(oe.sql) && !utf8.RuneStart(oe.sql[end]) {
+		end++
+	}
+	off := oe.offset
+	for off > start && off < len(oe.sql) && !utf8.RuneStart(oe.sql[off]) {
+		off--
+	}
+	return oe.sql[start:off] + "<*>" + oe.sql[off:end]
+}
+
+// withSQL sets the SQL text of the OraErr in err, if it has none yet.
+func withSQL(err error, sql string) error {
+	if oe, ok := AsOraErr(err); ok && oe.sql == "" {
+		oe.sql = sql
+	}
+	return err
+}
+
 var _ error = (*BatchErrors)(nil)
 
 // BatchErrors is returned as Batch errors.
+//
+// Errs contains the per-row errors, use OraErr.Offset for the row index
+// and OraErr.Code for the ORA code.
 type BatchErrors struct {
 	Affected, Unaffected []int
 	Errs                 []*OraErr
+	// RowCounts is the number of rows affected by each row of the batch,
+	// filled only with the ContinueOnError option.
+	RowCounts []int64
+}
+
+// RowError returns the error for the given row of the batch, or nil if that row succeeded.
+func (be *BatchErrors) RowError(row int) *OraErr {
+	for _, oe := range be.Errs {
+		if oe.offset == row {
+			return oe
+		}
+	}
+	return nil
 }
 
 func (be *BatchErrors) Error() string {
@@ -1256,6 +1453,8 @@ var _ io.Closer = (*connector)(nil)
 
 type connector struct {
 	drv *drv
+	// rotated holds the parameters with the credentials set by UpdateCredentials.
+	rotated *rotatedParams
 	dsn.ConnectionParams
 }
 
@@ -1263,7 +1462,7 @@ type connector struct {
 //
 // ConnectionParams must be complete, so start with what ParseDSN returns!
 func (d *drv) NewConnector(params dsn.ConnectionParams) driver.Connector {
-	return connector{drv: d, ConnectionParams: params}
+	return connector{drv: d, ConnectionParams: params, rotated: &rotatedParams{}}
 }
 
 // NewConnector returns a driver.Connector to be used with sql.OpenDB,
@@ -1299,7 +1498,7 @@ func (d *drv) OpenConnector(name string) (driver.Connector, error) {
 // The returned connection is only used by one goroutine at a
 // time.
 func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
-	params := c.ConnectionParams
+	params := c.params()
 	logger := c.CommonParams.Logger
 	if ctxValue := ctx.Value(paramsCtxKey{}); ctxValue != nil {
 		if cc, ok := ctxValue.(commonAndConnParams); ok {
@@ -1366,10 +1565,22 @@ func getOnInit(P *CommonParams) func(context.Context, driver.ConnPrepareContext)
 	}
 	stmts := P.OnInitStmts
 	stmts = stmts[:len(stmts):len(stmts)]
-	if len(P.AlterSession) != 0 {
+	if P.Container != "" {
+		pdb, err := containerName(P.Container)
+		if err != nil {
+			return func(context.Context, driver.ConnPrepareContext) error { return err }
+		}
+		// switch first, so the other settings apply in the container
+		stmts = append([]string{"ALTER SESSION SET CONTAINER = " + pdb}, stmts...)
+	}
+	alterSession := P.AlterSession
+	if P.SessionTimezone != "" {
+		alterSession = append(alterSession[:len(alterSession):len(alterSession)], [2]string{"TIME_ZONE", P.SessionTimezone})
+	}
+	if len(alterSession) != 0 {
 		var buf strings.Builder
 		buf.WriteString("ALTER SESSION SET")
-		for _, kv := range P.AlterSession {
+		for _, kv := range alterSession {
 			buf.WriteByte(' ')
 			buf.WriteString(kv[0])
 			buf.WriteByte('=')
@@ -12,16 +12,20 @@ package godror
 
 /*
 #cgo nocallback dpiConn_breakExecution
+#cgo nocallback dpiConn_close
 #cgo nocallback dpiConn_commit
 #cgo nocallback dpiConn_create
 #cgo nocallback dpiConn_getCurrentSchema
 #cgo nocallback dpiConn_getDbDomain
 #cgo nocallback dpiConn_getDbName
 #cgo nocallback dpiConn_getEdition
+#cgo nocallback dpiConn_getEncodingInfo
 #cgo nocallback dpiConn_getIsHealthy
 #cgo nocallback dpiConn_getObjectType
 #cgo nocallback dpiConn_getServerVersion
 #cgo nocallback dpiConn_getServiceName
+#cgo nocallback dpiConn_getSodaDb
+#cgo nocallback dpiConn_getStmtCacheSize
 #cgo nocallback dpiConn_newMsgProps
 #cgo nocallback dpiConn_newQueue
 #cgo nocallback dpiConn_newTempLob
@@ -36,11 +40,15 @@ package godror
 #cgo nocallback dpiConn_setClientInfo
 #cgo nocallback dpiConn_setCurrentSchema
 #cgo nocallback dpiConn_setDbOp
+#cgo nocallback dpiConn_setEcontextId
 #cgo nocallback dpiConn_setModule
+#cgo nocallback dpiConn_setStmtCacheSize
 #cgo nocallback dpiConn_shutdownDatabase
 #cgo nocallback dpiConn_startupDatabase
+#cgo nocallback dpiConn_startupDatabaseWithPfile
 #cgo nocallback dpiContext_createWithParams
 #cgo nocallback dpiContext_destroy
+#cgo nocallback dpiContext_freeStringList
 #cgo nocallback dpiContext_getClientVersion
 #cgo nocallback dpiContext_getError
 #cgo nocallback dpiContext_initCommonCreateParams
@@ -166,6 +174,7 @@ package godror
 #cgo nocallback dpiPool_getTimeout
 #cgo nocallback dpiPool_getWaitTimeout
 #cgo nocallback dpiPool_release
+#cgo nocallback dpiPool_setAccessToken
 #cgo nocallback dpiPool_setStmtCacheSize
 #cgo nocallback dpiQueue_deqMany
 #cgo nocallback dpiQueue_deqOne
@@ -175,6 +184,33 @@ package godror
 #cgo nocallback dpiQueue_getEnqOptions
 #cgo nocallback dpiQueue_release
 #cgo nocallback dpiRowid_getStringValue
+#cgo nocallback dpiSodaColl_createIndex
+#cgo nocallback dpiSodaColl_drop
+#cgo nocallback dpiSodaColl_dropIndex
+#cgo nocallback dpiSodaColl_find
+#cgo nocallback dpiSodaColl_getDocCount
+#cgo nocallback dpiSodaColl_insertMany
+#cgo nocallback dpiSodaColl_insertOne
+#cgo nocallback dpiSodaColl_listIndexes
+#cgo nocallback dpiSodaColl_release
+#cgo nocallback dpiSodaColl_remove
+#cgo nocallback dpiSodaDb_createCollection
+#cgo nocallback dpiSodaDb_createDocument
+#cgo nocallback dpiSodaDb_freeCollectionNames
+#cgo nocallback dpiSodaDb_getCollectionNames
+#cgo nocallback dpiSodaDb_openCollection
+#cgo nocallback dpiSodaDb_release
+#cgo nocallback dpiSodaDocCursor_getNext
+#cgo nocallback dpiSodaDocCursor_release
+#cgo nocallback dpiSodaDoc_getContent
+#cgo nocallback dpiSodaDoc_getCreatedOn
+#cgo nocallback dpiSodaDoc_getIsJson
+#cgo nocallback dpiSodaDoc_getJsonContent
+#cgo nocallback dpiSodaDoc_getKey
+#cgo nocallback dpiSodaDoc_getLastModified
+#cgo nocallback dpiSodaDoc_getMediaType
+#cgo nocallback dpiSodaDoc_getVersion
+#cgo nocallback dpiSodaDoc_release
 #cgo nocallback dpiStmt_addRef
 #cgo nocallback dpiStmt_bindByName
 #cgo nocallback dpiStmt_bindByPos
@@ -182,6 +218,7 @@ package godror
 #cgo nocallback dpiStmt_deleteFromCache
 #cgo nocallback dpiStmt_execute
 #cgo nocallback dpiStmt_executeMany
+#cgo nocallback dpiStmt_fetch
 #cgo nocallback dpiStmt_fetchRows
 #cgo nocallback dpiStmt_getBatchErrorCount
 #cgo nocallback dpiStmt_getBatchErrors
@@ -191,7 +228,9 @@ package godror
 #cgo nocallback dpiStmt_getInfo
 #cgo nocallback dpiStmt_getNumQueryColumns
 #cgo nocallback dpiStmt_getQueryInfo
+#cgo nocallback dpiStmt_getQueryValue
 #cgo nocallback dpiStmt_getRowCount
+#cgo nocallback dpiStmt_getRowCounts
 #cgo nocallback dpiStmt_getSubscrQueryId
 #cgo nocallback dpiStmt_release
 #cgo nocallback dpiStmt_setFetchArraySize
@@ -205,6 +244,7 @@ package godror
 #cgo nocallback dpiVar_setFromObject
 #cgo nocallback dpiVar_setNumElementsInArray
 #cgo nocallback godror_allocate_dpiNode
+#cgo nocallback godror_dpiDebugSetFd
 #cgo nocallback godror_dpiasJsonArray
 #cgo nocallback godror_dpiasJsonObject
 #cgo nocallback godror_dpiJsonArray_initialize
@@ -221,7 +261,11 @@ package godror
 #cgo nocallback godror_dpiJson_setTime
 #cgo nocallback godror_dpiJson_setUint64
 #cgo nocallback godror_getAnnotation
+#cgo nocallback godror_getAttributeValues
+#cgo nocallback godror_getCharUsed
+#cgo nocallback godror_getSqlID
 #cgo nocallback godror_setArrayElements
+#cgo nocallback godror_setAttributeValues
 #cgo nocallback godror_setFromString
 #cgo nocallback godror_setObjectFields
 */
@@ -8,7 +8,10 @@ package godror
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestNewDriverSepContext(t *testing.T) {
@@ -66,6 +69,57 @@ func TestFromErrorInfo(t *testing.T) {
 	}
 }
 
+func TestOraErrPredicates(t *testing.T) {
+	for _, tC := range []struct {
+		err                       error
+		unique, deadlock, timeout bool
+	}{
+		{err: &OraErr{code: 1}, unique: true},
+		{err: fmt.Errorf("wrapped: %w", &OraErr{code: 60}), deadlock: true},
+		{err: &OraErr{code: 3156}, timeout: true},
+		{err: &OraErr{message: "DPI-1067: call timeout of 1 ms exceeded"}, timeout: true},
+		{err: &OraErr{code: 30006}, timeout: true},
+		{err: &OraErr{code: 942}},
+		{err: errors.New("ORA-00001: unique constraint violated")},
+	} {
+		if got := IsUniqueViolation(tC.err); got != tC.unique {
+			t.Errorf("%v: IsUniqueViolation=%t", tC.err, got)
+		}
+		if got := IsDeadlock(tC.err); got != tC.deadlock {
+			t.Errorf("%v: IsDeadlock=%t", tC.err, got)
+		}
+		if got := IsTimeout(tC.err); got != tC.timeout {
+			t.Errorf("%v: IsTimeout=%t", tC.err, got)
+		}
+	}
+}
+
+func TestOraErrSnippet(t *testing.T) {
+	const qry = "SELECT árvíztűrő, tükörfúrógép FROM dual WHERE 1 = 1 AND dummy = 'X' AND 2 > 1"
+	for _, tC := range []struct {
+		offset int
+		want   string
+	}{
+		{offset: 0, want: ""},
+		{offset: len(qry) + 1, want: ""},
+		{offset: 7, want: "SELECT <*>árvíztűrő, tükörfúrógép"},
+		{offset: strings.Index(qry, "dual"), want: "ztűrő, tükörfúrógép FROM <*>dual WHERE 1 = 1 AND dummy = 'X'"},
+		{offset: len(qry), want: " 1 = 1 AND dummy = 'X' AND 2 > 1<*>"},
+	} {
+		oe := withSQL(fmt.Errorf("wrap: %w", &OraErr{code: 942, offset: tC.offset}), qry).(interface{ Unwrap() error }).Unwrap().(*OraErr)
+		if oe.SQL() != qry {
+			t.Errorf("SQL: got %q", oe.SQL())
+		}
+		got := oe.Snippet()
+		if got != tC.want {
+			t.Errorf("%d: got %q, wanted %q", tC.offset, got, tC.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("%d: invalid UTF-8: %q", tC.offset, got)
+		}
+	}
+}
+
 func TestMarshalJSON(t *testing.T) {
 	n := Number("12345.6789")
 	b, err := (&n).MarshalJSON()
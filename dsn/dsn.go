@@ -57,6 +57,13 @@ type CommonSimpleParams struct {
 	Token, PrivateKey       string
 	Password                Password
 	Charset                 string
+	// SessionTimezone is set as the session time zone (ALTER SESSION SET TIME_ZONE) on session init,
+	// such as "+00:00" or "Europe/Budapest", iff OnInit is nil.
+	// This is the time zone the TIMESTAMP WITH LOCAL TIME ZONE values are converted to and from.
+	SessionTimezone string
+	// Container pins the sessions to the container (pluggable database) with
+	// ALTER SESSION SET CONTAINER on session init, iff OnInit is nil.
+	Container string
 	// StmtCacheSize of 0 means the default, -1 to disable the stmt cache completely
 	StmtCacheSize int
 	// true: OnInit will be called only by the new session / false: OnInit will called by new or pooled connection
@@ -69,8 +76,9 @@ type CommonSimpleParams struct {
 //
 // For details, see https://oracle.github.io/odpi/doc/structs/dpiCommonCreateParams.html#dpicommoncreateparams
 type CommonParams struct {
-	// Logger is the per-pool or per-connection logger.
-	// The default nil logger does not log.
+	// Logger is the per-pool or per-connection logger, overriding the global logger (see godror.SetLogger).
+	// For sampling the debug lines of the hot paths, see godror.NewSampledLogger.
+	// The default nil logger means the global logger.
 	Logger *slog.Logger
 	// OnInit is executed on session init. Overrides AlterSession and OnInitStmts!
 	OnInit func(context.Context, driver.ConnPrepareContext) error `json:"-"`
@@ -129,6 +137,12 @@ func (P CommonSimpleParams) String() string {
 	if P.PerSessionTimezone {
 		q.Add("perSessionTimezone", "1")
 	}
+	if P.SessionTimezone != "" {
+		q.Add("sessionTimezone", P.SessionTimezone)
+	}
+	if P.Container != "" {
+		q.Add("container", P.Container)
+	}
 	if P.StmtCacheSize != 0 {
 		q.Add("stmtCacheSize", strconv.Itoa(int(P.StmtCacheSize)))
 	}
@@ -252,7 +266,7 @@ func (P ConnectionParams) IsStandalone() bool {
 	return P.StandaloneConnection.Valid && P.StandaloneConnection.Bool ||
 		(!P.StandaloneConnection.Valid && DefaultStandaloneConnection) ||
 		P.ConnClass == NoConnectionPoolingConnectionClass ||
-		P.AdminRole != NoRole
+		P.AdminRole != NoRole || P.IsPrelim
 }
 
 func (P *ConnectionParams) comb() {
@@ -343,6 +357,12 @@ func (P ConnectionParams) string(class, withPassword bool) string {
 	if P.PerSessionTimezone {
 		q.Add("perSessionTimezone", "1")
 	}
+	if P.SessionTimezone != "" {
+		q.Add("sessionTimezone", P.SessionTimezone)
+	}
+	if P.Container != "" {
+		q.Add("container", P.Container)
+	}
 	if P.StmtCacheSize != 0 {
 		q.Add("stmtCacheSize", strconv.Itoa(int(P.StmtCacheSize)))
 	}
@@ -697,6 +717,8 @@ func Parse(dataSourceName string) (ConnectionParams, error) {
 	P.NewPassword.Set(q.Get("newPassword"))
 	P.ConfigDir = q.Get("configDir")
 	P.LibDir = q.Get("libDir")
+	P.SessionTimezone = q.Get("sessionTimezone")
+	P.Container = q.Get("container")
 
 	// fmt.Printf("cs1=%q\n", P)
 	P.comb()
@@ -0,0 +1,75 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// DurationMode says how a time.Duration parameter is bound, see BindDurationAs.
+type DurationMode uint8
+
+const (
+	// DurationAsInterval binds time.Duration as INTERVAL DAY TO SECOND - the default.
+	// For historical reasons, a zero Duration is bound as NULL.
+	DurationAsInterval = DurationMode(iota)
+	// DurationAsSeconds binds time.Duration as a NUMBER of seconds (with nanosecond fraction).
+	DurationAsSeconds
+	// DurationAsDays binds time.Duration as a NUMBER of days, as used in DATE arithmetic (SYSDATE + :1).
+	DurationAsDays
+	// DurationAsError refuses to bind a time.Duration, as its meaning for a NUMBER parameter is ambiguous.
+	// Bind IntervalDS, or the number of seconds or days instead.
+	DurationAsError
+)
+
+// ErrAmbiguousDuration is returned for binding a time.Duration with DurationAsError.
+var ErrAmbiguousDuration = errors.New("ambiguous time.Duration bind: use BindDurationAs, IntervalDS or a number")
+
+// daysPrecision is the number of decimal digits of the days, enough for nanoseconds.
+const daysPrecision = 30
+
+func (m DurationMode) unit() time.Duration {
+	if m == DurationAsDays {
+		return 24 * time.Hour
+	}
+	return time.Second
+}
+
+// durationToNumber returns the Duration as a Number of seconds or days.
+func durationToNumber(d time.Duration, mode DurationMode) Number {
+	prec := 9
+	if mode == DurationAsDays {
+		prec = daysPrecision
+	}
+	s := big.NewRat(int64(d), int64(mode.unit())).FloatString(prec)
+	if strings.IndexByte(s, '.') >= 0 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return Number(s)
+}
+
+// numberToDuration returns the Duration of the Number of seconds or days, rounded to nanoseconds.
+func numberToDuration(n Number, mode DurationMode) (time.Duration, error) {
+	r, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return 0, fmt.Errorf("%q is not a number", string(n))
+	}
+	r.Mul(r, new(big.Rat).SetInt64(int64(mode.unit())))
+	// round half away from zero
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(m), big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+	if !q.IsInt64() || q.Int64() == math.MinInt64 {
+		return 0, fmt.Errorf("%s: %w", string(n), errIntervalOverflow)
+	}
+	return time.Duration(q.Int64()), nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDurationNumber(t *testing.T) {
+	for _, tc := range []struct {
+		Mode DurationMode
+		Want Number
+		D    time.Duration
+	}{
+		{D: 0, Mode: DurationAsSeconds, Want: "0"},
+		{D: 90 * time.Second, Mode: DurationAsSeconds, Want: "90"},
+		{D: -1500 * time.Millisecond, Mode: DurationAsSeconds, Want: "-1.5"},
+		{D: time.Nanosecond, Mode: DurationAsSeconds, Want: "0.000000001"},
+		{D: 36 * time.Hour, Mode: DurationAsDays, Want: "1.5"},
+		{D: -6 * time.Hour, Mode: DurationAsDays, Want: "-0.25"},
+		{D: time.Hour, Mode: DurationAsDays, Want: "0.041666666666666666666666666667"},
+		{D: time.Second + time.Nanosecond, Mode: DurationAsDays, Want: "0.000011574074085648148148148148"},
+	} {
+		got := durationToNumber(tc.D, tc.Mode)
+		if got != tc.Want {
+			t.Errorf("%s/%d: got %q, wanted %q", tc.D, tc.Mode, got, tc.Want)
+		}
+		back, err := numberToDuration(got, tc.Mode)
+		if err != nil {
+			t.Errorf("%q/%d: %+v", got, tc.Mode, err)
+		} else if back != tc.D {
+			t.Errorf("%q/%d: got %s, wanted %s", got, tc.Mode, back, tc.D)
+		}
+	}
+
+	if d, err := numberToDuration("0.0000000015", DurationAsSeconds); err != nil || d != 2 {
+		t.Errorf("rounding: got %d (%+v), wanted 2", d, err)
+	}
+	if d, err := numberToDuration("1e6", DurationAsDays); !errors.Is(err, errIntervalOverflow) {
+		t.Errorf("overflow: got %s (%+v), wanted %v", d, err, errIntervalOverflow)
+	}
+	if d, err := numberToDuration(durationToNumber(math.MaxInt64, DurationAsSeconds), DurationAsSeconds); err != nil || d != math.MaxInt64 {
+		t.Errorf("max: got %d (%+v)", d, err)
+	}
+	if _, err := numberToDuration("x", DurationAsSeconds); err == nil {
+		t.Error("wanted error for not a number")
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownEnumValue is returned by EnumMap for values without a mapping.
+var ErrUnknownEnumValue = errors.New("unknown enum value")
+
+// AllowedValues returns the values allowed by the "column IN (...)" check constraints
+// of the column of the table ([schema.]table, in the current schema if not qualified).
+//
+// If more constraints restrict the column, the intersection of their lists is returned;
+// nil means there's no such restriction.
+func AllowedValues(ctx context.Context, q Querier, table, column string) ([]string, error) {
+	on, err := ParseObjectName(table)
+	if err != nil {
+		return nil, err
+	}
+	if column, err = normalizeIdentifier(column); err != nil {
+		return nil, err
+	}
+	const qry = `SELECT A.search_condition_vc
+  FROM all_constraints A, all_cons_columns B
+  WHERE B.owner = A.owner AND B.constraint_name = A.constraint_name AND
+        A.constraint_type = 'C' AND A.status = 'ENABLED' AND
+        A.owner = NVL(:owner, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')) AND A.table_name = :name AND
+        B.column_name = :col
+  ORDER BY A.constraint_name`
+	return allowedValues(ctx, q, qry, column,
+		sql.Named("owner", on.Schema), sql.Named("name", on.Name), sql.Named("col", column))
+}
+
+// AllowedValues returns the values allowed by the "VALUE IN (...)" check constraints
+// of the usage domain (Oracle 23ai), nil if there's no domain or no such constraint.
+//
+// As ColumnInfo and DescribedColumn embeds DomainAnnotation,
+// this is usable on the metadata of the result columns, too.
+func (da DomainAnnotation) AllowedValues(ctx context.Context, q Querier) ([]string, error) {
+	if da.DomainName == "" {
+		return nil, nil
+	}
+	const qry = `SELECT search_condition_vc FROM all_domain_constraints
+  WHERE owner = :owner AND domain_name = :name AND constraint_type = 'C'
+  ORDER BY constraint_name`
+	return allowedValues(ctx, q, qry, "",
+		sql.Named("owner", da.DomainSchema), sql.Named("name", da.DomainName))
+}
+
+// allowedValues returns the intersection of the IN-lists of the check conditions returned by qry.
+// Conditions not on ident (any identifier if empty), or not of "ident IN (...)" form are skipped.
+func allowedValues(ctx context.Context, q Querier, qry, ident string, args ...interface{}) ([]string, error) {
+	rows, err := q.QueryContext(ctx, qry, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	var values []string
+	var found bool
+	for rows.Next() {
+		var cond sql.NullString
+		if err = rows.Scan(&cond); err != nil {
+			return nil, err
+		}
+		name, list, ok := parseInList(cond.String)
+		if !ok || ident != "" && name != ident {
+			continue
+		}
+		if !found {
+			values, found = list, true
+			continue
+		}
+		filtered := values[:0]
+		for _, v := range values {
+			for _, w := range list {
+				if v == w {
+					filtered = append(filtered, v)
+					break
+				}
+			}
+		}
+		values = filtered
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if found && values == nil {
+		values = []string{}
+	}
+	return values, rows.Close()
+}
+
+// parseInList parses the "ident IN (literal, ...)" condition, returning the normalized
+// identifier and the literals' values.
+func parseInList(cond string) (ident string, values []string, ok bool) {
+	s := strings.TrimSpace(cond)
+	for len(s) > 1 && s[0] == '(' && s[len(s)-1] == ')' {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	var i int
+	if strings.HasPrefix(s, `"`) {
+		if i = strings.IndexByte(s[1:], '"') + 2; i < 2 {
+			return "", nil, false
+		}
+	} else {
+		for i < len(s) && (s[i] == '_' || s[i] == '$' || s[i] == '#' || s[i] >= 0x80 ||
+			'a' <= s[i]|0x20 && s[i]|0x20 <= 'z' || '0' <= s[i] && s[i] <= '9') {
+			i++
+		}
+	}
+	var err error
+	if ident, err = normalizeIdentifier(s[:i]); err != nil {
+		return "", nil, false
+	}
+	s = strings.TrimSpace(s[i:])
+	if len(s) < 3 || !strings.EqualFold(s[:2], "IN") || s[2] != '(' && s[2] != ' ' {
+		return "", nil, false
+	}
+	s = strings.TrimSpace(s[2:])
+	if len(s) < 2 || s[0] != '(' || s[len(s)-1] != ')' {
+		return "", nil, false
+	}
+	s = s[1 : len(s)-1]
+	for {
+		s = strings.TrimSpace(s)
+		if len(s) > 1 && (s[0] == 'N' || s[0] == 'n') && s[1] == '\'' {
+			s = s[1:]
+		}
+		var v string
+		if strings.HasPrefix(s, "'") {
+			var buf strings.Builder
+			i = 1
+			for {
+				j := strings.IndexByte(s[i:], '\'')
+				if j < 0 {
+					return "", nil, false
+				}
+				buf.WriteString(s[i : i+j])
+				i += j + 1
+				if i < len(s) && s[i] == '\'' { // escaped '
+					buf.WriteByte('\'')
+					i++
+					continue
+				}
+				break
+			}
+			v, s = buf.String(), s[i:]
+		} else {
+			if i = strings.IndexByte(s, ','); i < 0 {
+				i = len(s)
+			}
+			v, s = strings.TrimSpace(s[:i]), s[i:]
+			var n Number
+			if v == "" || n.UnmarshalText([]byte(v)) != nil {
+				return "", nil, false
+			}
+		}
+		values = append(values, v)
+		if s = strings.TrimSpace(s); s == "" {
+			return ident, values, true
+		}
+		if s[0] != ',' {
+			return "", nil, false
+		}
+		s = s[1:]
+	}
+}
+
+// EnumMap maps the allowed database values to the constants of a Go enum type, and back.
+type EnumMap[E comparable] struct {
+	toGo map[string]E
+	toDB map[E]string
+}
+
+// NewEnumMap returns an EnumMap for the allowed values (see AllowedValues),
+// checking that each of them is mapped, and each mapping is allowed.
+// With nil allowed (no restriction), the mapping is used as is.
+func NewEnumMap[E comparable](allowed []string, mapping map[string]E) (*EnumMap[E], error) {
+	em := EnumMap[E]{toGo: make(map[string]E, len(mapping)), toDB: make(map[E]string, len(mapping))}
+	for k, v := range mapping {
+		if w, ok := em.toDB[v]; ok {
+			return nil, fmt.Errorf("%v is mapped from both %q and %q", v, w, k)
+		}
+		em.toGo[k], em.toDB[v] = v, k
+	}
+	if allowed == nil {
+		return &em, nil
+	}
+	isAllowed := make(map[string]struct{}, len(allowed))
+	for _, a := range allowed {
+		if _, ok := em.toGo[a]; !ok {
+			return nil, fmt.Errorf("allowed value %q is not mapped: %w", a, ErrUnknownEnumValue)
+		}
+		isAllowed[a] = struct{}{}
+	}
+	for k := range em.toGo {
+		if _, ok := isAllowed[k]; !ok {
+			return nil, fmt.Errorf("mapped value %q is not allowed: %w", k, ErrUnknownEnumValue)
+		}
+	}
+	return &em, nil
+}
+
+// Enum returns the Go constant for the database value.
+func (em *EnumMap[E]) Enum(value string) (E, error) {
+	e, ok := em.toGo[value]
+	if !ok {
+		return e, fmt.Errorf("%q: %w", value, ErrUnknownEnumValue)
+	}
+	return e, nil
+}
+
+// DBValue returns the database value of the Go constant.
+func (em *EnumMap[E]) DBValue(e E) (string, error) {
+	s, ok := em.toDB[e]
+	if !ok {
+		return s, fmt.Errorf("%v: %w", e, ErrUnknownEnumValue)
+	}
+	return s, nil
+}
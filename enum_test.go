@@ -0,0 +1,76 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseInList(t *testing.T) {
+	for _, tc := range []struct {
+		In, Ident string
+		Values    []string
+	}{
+		{In: "status IN ('A','B', 'C')", Ident: "STATUS", Values: []string{"A", "B", "C"}},
+		{In: `("Status" in ('it''s', N'x'))`, Ident: "Status", Values: []string{"it's", "x"}},
+		{In: "yesno IN('Y','N')", Ident: "YESNO", Values: []string{"Y", "N"}},
+		{In: "lvl in (1, 2,-3, 4.5)", Ident: "LVL", Values: []string{"1", "2", "-3", "4.5"}},
+		{In: "a IN ('x,y', ')')", Ident: "A", Values: []string{"x,y", ")"}},
+	} {
+		ident, values, ok := parseInList(tc.In)
+		if !ok || ident != tc.Ident || !reflect.DeepEqual(values, tc.Values) {
+			t.Errorf("%q: got %q, %q, %t", tc.In, ident, values, ok)
+		}
+	}
+	for _, s := range []string{
+		"", `"STATUS" IS NOT NULL`, "a BETWEEN 1 AND 2", "a IN (SELECT 1 FROM DUAL)",
+		"a IN ('x'", "a IN ('x' 'y')", "a NOT IN ('x')", "a IN ()", "a INx ('b')", "LENGTH(a) IN (1)",
+	} {
+		if ident, values, ok := parseInList(s); ok {
+			t.Errorf("%q: got %q, %q", s, ident, values)
+		}
+	}
+}
+
+func TestEnumMap(t *testing.T) {
+	type status int
+	const (
+		active status = iota + 1
+		inactive
+	)
+	mapping := map[string]status{"A": active, "I": inactive}
+	em, err := NewEnumMap([]string{"A", "I"}, mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, err := em.Enum("I"); err != nil || e != inactive {
+		t.Errorf("Enum: got %v, %+v", e, err)
+	}
+	if s, err := em.DBValue(active); err != nil || s != "A" {
+		t.Errorf("DBValue: got %q, %+v", s, err)
+	}
+	if _, err := em.Enum("X"); !errors.Is(err, ErrUnknownEnumValue) {
+		t.Errorf("Enum(X): got %+v", err)
+	}
+	if _, err := em.DBValue(0); !errors.Is(err, ErrUnknownEnumValue) {
+		t.Errorf("DBValue(0): got %+v", err)
+	}
+
+	if _, err := NewEnumMap(nil, mapping); err != nil {
+		t.Errorf("nil allowed: %+v", err)
+	}
+	if _, err := NewEnumMap([]string{"A", "I", "X"}, mapping); !errors.Is(err, ErrUnknownEnumValue) {
+		t.Errorf("unmapped: got %+v", err)
+	}
+	if _, err := NewEnumMap([]string{"A"}, mapping); !errors.Is(err, ErrUnknownEnumValue) {
+		t.Errorf("not allowed: got %+v", err)
+	}
+	if _, err := NewEnumMap(nil, map[string]status{"A": active, "B": active}); err == nil {
+		t.Error("ambiguous: wanted error")
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ExportFormat is the output format of Export.
+type ExportFormat uint8
+
+const (
+	// ExportCSV is RFC 4180 CSV, with a header line of the column names.
+	ExportCSV = ExportFormat(iota)
+	// ExportJSONL is JSON Lines: one JSON object per row, keyed by the column names.
+	ExportJSONL
+)
+
+// ExportOptions are the options of Export.
+type ExportOptions struct {
+	// Null is the CSV representation of NULL, the empty string by default.
+	// JSON Lines always uses null.
+	Null string
+	// TimeFormat is the layout of dates and timestamps, time.RFC3339Nano by default.
+	TimeFormat string
+	// FormatNumber formats the NUMBER values, which are written as is by default.
+	// For JSON Lines, the result is written as a number if it is valid as one, as a string otherwise.
+	FormatNumber func(Number) string
+	// Comma is the CSV field delimiter, ',' by default.
+	Comma rune
+	// NoHeader omits the CSV header line.
+	NoHeader bool
+}
+
+// Export executes the query and streams its rows into w, in the given format.
+//
+// LOBs are streamed, not read into memory: CLOBs as text, BLOBs (and RAWs) hex encoded for CSV
+// and base64 encoded for JSON Lines.
+//
+// It returns the number of rows written.
+func Export(ctx context.Context, q Querier, w io.Writer, format ExportFormat, opts ExportOptions, qry string, args ...interface{}) (int64, error) {
+	rows, err := q.QueryContext(ctx, qry, append(args[:len(args):len(args)], LobAsReader())...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	e := newExporter(w, format, opts, columns)
+	if err = e.writeHeader(); err != nil {
+		return 0, err
+	}
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	var n int64
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return n, err
+		}
+		if err = e.writeRow(values); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err = rows.Err(); err != nil {
+		return n, err
+	}
+	return n, e.w.Flush()
+}
+
+type exporter struct {
+	w       *bufio.Writer
+	opts    ExportOptions
+	columns []string
+	format  ExportFormat
+}
+
+func newExporter(w io.Writer, format ExportFormat, opts ExportOptions, columns []string) *exporter {
+	if opts.TimeFormat == "" {
+		opts.TimeFormat = time.RFC3339Nano
+	}
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	return &exporter{w: bufio.NewWriterSize(w, 1<<16), format: format, opts: opts, columns: columns}
+}
+
+func (e *exporter) writeHeader() error {
+	if e.format != ExportCSV || e.opts.NoHeader {
+		return nil
+	}
+	for i, c := range e.columns {
+		if i != 0 {
+			e.w.WriteRune(e.opts.Comma)
+		}
+		e.writeCSVField(c)
+	}
+	_, err := e.w.WriteString("\r\n")
+	return err
+}
+
+func (e *exporter) writeRow(values []interface{}) error {
+	if e.format == ExportJSONL {
+		e.w.WriteByte('{')
+	}
+	for i, v := range values {
+		if e.format == ExportJSONL {
+			if i != 0 {
+				e.w.WriteByte(',')
+			}
+			writeJSONString(e.w, strings.NewReader(e.columns[i]))
+			e.w.WriteByte(':')
+		} else if i != 0 {
+			e.w.WriteRune(e.opts.Comma)
+		}
+		if err := e.writeValue(v); err != nil {
+			return fmt.Errorf("%s: %w", e.columns[i], err)
+		}
+	}
+	var err error
+	if e.format == ExportJSONL {
+		_, err = e.w.WriteString("}\n")
+	} else {
+		_, err = e.w.WriteString("\r\n")
+	}
+	return err
+}
+
+func (e *exporter) writeValue(v interface{}) error {
+	jsonl := e.format == ExportJSONL
+	var s string
+	var isNumber bool
+	switch x := v.(type) {
+	case nil:
+		if jsonl {
+			_, err := e.w.WriteString("null")
+			return err
+		}
+		s = e.opts.Null
+	case string:
+		s = x
+	case Number:
+		if e.opts.FormatNumber != nil {
+			s = e.opts.FormatNumber(x)
+		} else {
+			s = string(x)
+		}
+		// a formatted number (such as 1,5) is written as a string
+		isNumber = json.Valid([]byte(s))
+	case int64:
+		s, isNumber = strconv.FormatInt(x, 10), true
+	case uint64:
+		s, isNumber = strconv.FormatUint(x, 10), true
+	case float32:
+		s, isNumber = strconv.FormatFloat(float64(x), 'g', -1, 32), true
+	case float64:
+		s, isNumber = strconv.FormatFloat(x, 'g', -1, 64), true
+	case bool:
+		s, isNumber = strconv.FormatBool(x), true
+	case time.Time:
+		s = x.Format(e.opts.TimeFormat)
+	case []byte:
+		if jsonl {
+			s = base64.StdEncoding.EncodeToString(x)
+		} else {
+			s = hex.EncodeToString(x)
+		}
+	case *Lob:
+		return e.writeLob(x)
+	case fmt.Stringer:
+		s = x.String()
+	default:
+		if !jsonl {
+			s = fmt.Sprint(x)
+			break
+		}
+		b, err := json.Marshal(x)
+		if err != nil {
+			return err
+		}
+		_, err = e.w.Write(b)
+		return err
+	}
+	switch {
+	case !jsonl:
+		return e.writeCSVField(s)
+	case isNumber:
+		_, err := e.w.WriteString(s)
+		return err
+	default:
+		return writeJSONString(e.w, strings.NewReader(s))
+	}
+}
+
+// writeLob streams the LOB: CLOBs as strings, BLOBs encoded.
+func (e *exporter) writeLob(lob *Lob) error {
+	jsonl := e.format == ExportJSONL
+	if lob.IsClob {
+		br := bufio.NewReaderSize(lob, 1<<16)
+		if jsonl {
+			return writeJSONString(e.w, br)
+		}
+		return writeCSVQuoted(e.w, br)
+	}
+	var enc io.WriteCloser
+	if jsonl {
+		e.w.WriteByte('"')
+		enc = base64.NewEncoder(base64.StdEncoding, e.w)
+	} else {
+		enc = nopWriteCloser{hex.NewEncoder(e.w)}
+	}
+	if _, err := lob.WriteTo(enc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	if jsonl {
+		return e.w.WriteByte('"')
+	}
+	return nil
+}
+
+// writeCSVField writes s, quoted if needed.
+func (e *exporter) writeCSVField(s string) error {
+	if s == "" || !(strings.ContainsRune(s, e.opts.Comma) || strings.ContainsAny(s, "\"\r\n") || s[0] == ' ' || s[0] == '\t') {
+		_, err := e.w.WriteString(s)
+		return err
+	}
+	return writeCSVQuoted(e.w, strings.NewReader(s))
+}
+
+// writeCSVQuoted writes the runes quoted, doubling the quotes.
+func writeCSVQuoted(w *bufio.Writer, rr io.RuneReader) error {
+	w.WriteByte('"')
+	for {
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			if err == io.EOF {
+				return w.WriteByte('"')
+			}
+			return err
+		}
+		if r == '"' {
+			w.WriteByte('"')
+		}
+		w.WriteRune(r)
+	}
+}
+
+// writeJSONString writes the runes as a JSON string.
+func writeJSONString(w *bufio.Writer, rr io.RuneReader) error {
+	w.WriteByte('"')
+	for {
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			if err == io.EOF {
+				return w.WriteByte('"')
+			}
+			return err
+		}
+		switch r {
+		case '"', '\\':
+			w.WriteByte('\\')
+			w.WriteByte(byte(r))
+		case '\n':
+			w.WriteString(`\n`)
+		case '\r':
+			w.WriteString(`\r`)
+		case '\t':
+			w.WriteString(`\t`)
+		case '\u2028', '\u2029':
+			fmt.Fprintf(w, `\u%04x`, r)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(w, `\u%04x`, r)
+			} else if r == utf8.RuneError {
+				w.WriteString(`\ufffd`)
+			} else {
+				w.WriteRune(r)
+			}
+		}
+	}
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,54 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExporter(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	values := []interface{}{
+		Number("1.5"), nil, "a,\"b\"", ts, []byte{0xca, 0xfe},
+		nil, nil, // LOBs, set for each case, as they are consumed
+	}
+	columns := []string{"N", "NIL", "S", "T", "R", "C", "B"}
+	for _, tc := range []struct {
+		format ExportFormat
+		opts   ExportOptions
+		want   string
+	}{
+		{ExportCSV, ExportOptions{Null: `\N`, TimeFormat: time.DateOnly},
+			"N,NIL,S,T,R,C,B\r\n" +
+				"1.5,\\N,\"a,\"\"b\"\"\",2025-01-02,cafe,\"line1\nline2 \"\"q\"\"\",0102\r\n"},
+		{ExportCSV, ExportOptions{FormatNumber: func(n Number) string { return strings.Replace(string(n), ".", ",", 1) }},
+			"N,NIL,S,T,R,C,B\r\n" +
+				"\"1,5\",,\"a,\"\"b\"\"\",2025-01-02T03:04:05Z,cafe,\"line1\nline2 \"\"q\"\"\",0102\r\n"},
+		{ExportJSONL, ExportOptions{},
+			`{"N":1.5,"NIL":null,"S":"a,\"b\"","T":"2025-01-02T03:04:05Z","R":"yv4=","C":"line1\nline2 \"q\"","B":"AQI="}` + "\n"},
+		{ExportJSONL, ExportOptions{FormatNumber: func(n Number) string { return strings.Replace(string(n), ".", ",", 1) }},
+			`{"N":"1,5","NIL":null,"S":"a,\"b\"","T":"2025-01-02T03:04:05Z","R":"yv4=","C":"line1\nline2 \"q\"","B":"AQI="}` + "\n"},
+	} {
+		values[5] = &Lob{Reader: strings.NewReader("line1\nline2 \"q\""), IsClob: true}
+		values[6] = &Lob{Reader: strings.NewReader("\x01\x02")}
+		var buf strings.Builder
+		e := newExporter(&buf, tc.format, tc.opts, columns)
+		if err := e.writeHeader(); err != nil {
+			t.Fatal(err)
+		}
+		if err := e.writeRow(values); err != nil {
+			t.Fatal(err)
+		}
+		if err := e.w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != tc.want {
+			t.Errorf("%d: got\n%q\nwanted\n%q", tc.format, got, tc.want)
+		}
+	}
+}
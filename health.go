@@ -0,0 +1,88 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// HealthReport is the result of HealthCheck.
+type HealthReport struct {
+	// Server is the version of the database.
+	Server VersionInfo
+	// Pool is the statistics of the session pool (zero for standalone connections).
+	Pool PoolStats
+	// Errs are the errors of the optional diagnostics (OpenCursors and FailoverType),
+	// which need SELECT privilege on V$MYSTAT, V$STATNAME and V$SESSION.
+	Errs []error
+	// FailoverType is the FAILOVER_TYPE of the session from V$SESSION:
+	// NONE, SESSION, SELECT, TRANSACTION (Application Continuity) or AUTO (Transparent Application Continuity).
+	FailoverType string
+	// Ping is the round trip time of the ping.
+	Ping time.Duration
+	// OpenCursors is the number of the cursors opened by the session ('opened cursors current').
+	OpenCursors int64
+	// Events reports whether the connection has the events (and thus FAN) enabled.
+	Events bool
+}
+
+// TAC reports whether Transparent Application Continuity is active for the session.
+func (h HealthReport) TAC() bool { return h.FailoverType == "AUTO" }
+
+// HealthCheck checks the database with a ping, and returns diagnostics for readiness probes:
+// the ping latency, the server version, the pool statistics, the open cursors of the session
+// and whether FAN and (Transparent) Application Continuity is active.
+//
+// The returned error is only for the ping and the basic information, the errors of the
+// optional diagnostics are in HealthReport.Errs.
+func HealthCheck(ctx context.Context, db *sql.DB) (HealthReport, error) {
+	var h HealthReport
+	sc, err := db.Conn(ctx)
+	if err != nil {
+		return h, err
+	}
+	defer sc.Close()
+	start := time.Now()
+	if err = sc.PingContext(ctx); err != nil {
+		return h, fmt.Errorf("ping: %w", err)
+	}
+	h.Ping = time.Since(start)
+	if err = sc.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*conn)
+		if !ok {
+			return fmt.Errorf("%T is not a godror connection", driverConn)
+		}
+		var err error
+		if h.Server, err = c.ServerVersion(); err != nil {
+			return fmt.Errorf("serverVersion: %w", err)
+		}
+		if h.Pool, err = c.GetPoolStats(); err != nil {
+			return fmt.Errorf("getPoolStats: %w", err)
+		}
+		h.Events = c.params.EnableEvents
+		return nil
+	}); err != nil {
+		return h, err
+	}
+
+	for _, q := range []struct {
+		dest interface{}
+		qry  string
+	}{
+		{qry: `SELECT s.value FROM v$mystat s JOIN v$statname n ON n.statistic# = s.statistic# WHERE n.name = 'opened cursors current'`,
+			dest: &h.OpenCursors},
+		{qry: `SELECT failover_type FROM v$session WHERE sid = SYS_CONTEXT('USERENV', 'SID')`,
+			dest: &h.FailoverType},
+	} {
+		if err := sc.QueryRowContext(ctx, q.qry).Scan(q.dest); err != nil {
+			h.Errs = append(h.Errs, fmt.Errorf("%s: %w", q.qry, err))
+		}
+	}
+	return h, nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxIdentifierLength is the maximum length of an identifier in bytes (since Oracle 12.2).
+const MaxIdentifierLength = 128
+
+// ErrInvalidIdentifier is returned for names that are not valid Oracle identifiers.
+var ErrInvalidIdentifier = errors.New("invalid identifier")
+
+// ValidateIdentifier checks that name is a valid identifier: enclosed in "-s, anything
+// but " and NUL; otherwise a letter followed by letters, digits, _, $ and #.
+// It must not be longer than MaxIdentifierLength bytes (without the quotes).
+//
+// Reserved words are not checked - quote them with QuoteIdentifier.
+func ValidateIdentifier(name string) error {
+	_, err := normalizeIdentifier(name)
+	return err
+}
+
+// QuoteIdentifier returns the name enclosed in "-s, to be used as is (case sensitive) in dynamic SQL.
+// A name already enclosed in "-s is returned unchanged.
+func QuoteIdentifier(name string) (string, error) {
+	if isQuoted(name) {
+		if _, err := normalizeIdentifier(name); err != nil {
+			return "", err
+		}
+		return name, nil
+	}
+	if err := checkQuotable(name); err != nil {
+		return "", err
+	}
+	return `"` + name + `"`, nil
+}
+
+// ObjectName is a schema.object@dblink name, with the parts in the case as stored in the data dictionary.
+type ObjectName struct {
+	// Schema is empty if the name is not qualified. With two parts, it may name a package, too (PKG.TYPE).
+	Schema string
+	// Package is set for the three-part schema.package.name names.
+	Package string
+	Name    string
+	// DBLink is the database link name after the @, which may contain dots.
+	DBLink string
+}
+
+// ParseObjectName splits the [schema.[package.]]name[@dblink] name to its parts,
+// uppercasing the parts not enclosed in "-s, and validating each with ValidateIdentifier.
+func ParseObjectName(s string) (ObjectName, error) {
+	var on ObjectName
+	name, link := s, ""
+	if i := indexUnquoted(s, '@'); i >= 0 {
+		name, link = s[:i], s[i+1:]
+		if link == "" {
+			return on, fmt.Errorf("%q: empty database link: %w", s, ErrInvalidIdentifier)
+		}
+		if isQuoted(link) {
+			link = link[1 : len(link)-1]
+		} else {
+			// the domain of the link is separated by dots
+			for _, p := range strings.Split(link, ".") {
+				if !isPlainIdentifier(p) {
+					return on, fmt.Errorf("%q: database link %q: %w", s, link, ErrInvalidIdentifier)
+				}
+			}
+			link = strings.ToUpper(link)
+		}
+		if err := checkQuotable(link); err != nil {
+			return on, fmt.Errorf("%q: %w", s, err)
+		}
+		on.DBLink = link
+	}
+	var parts []string
+	for name != "" {
+		part := name
+		if i := indexUnquoted(name, '.'); i >= 0 {
+			part, name = name[:i], name[i+1:]
+			if name == "" {
+				return on, fmt.Errorf("%q: empty name: %w", s, ErrInvalidIdentifier)
+			}
+		} else {
+			name = ""
+		}
+		p, err := normalizeIdentifier(part)
+		if err != nil {
+			return on, fmt.Errorf("%q: %w", s, err)
+		}
+		parts = append(parts, p)
+	}
+	switch len(parts) {
+	case 1:
+		on.Name = parts[0]
+	case 2:
+		on.Schema, on.Name = parts[0], parts[1]
+	case 3:
+		on.Schema, on.Package, on.Name = parts[0], parts[1], parts[2]
+	default:
+		return on, fmt.Errorf("%q: %d parts: %w", s, len(parts), ErrInvalidIdentifier)
+	}
+	return on, nil
+}
+
+// String returns the name usable in SQL, with the parts quoted only if needed.
+func (on ObjectName) String() string {
+	var buf strings.Builder
+	for _, p := range []string{on.Schema, on.Package, on.Name} {
+		if p == "" {
+			continue
+		}
+		if buf.Len() != 0 {
+			buf.WriteByte('.')
+		}
+		buf.WriteString(quoteIfNeeded(p))
+	}
+	if on.DBLink != "" {
+		buf.WriteByte('@')
+		link := on.DBLink
+		for _, p := range strings.Split(on.DBLink, ".") {
+			if quoteIfNeeded(p) != p {
+				link = `"` + on.DBLink + `"`
+				break
+			}
+		}
+		buf.WriteString(link)
+	}
+	return buf.String()
+}
+
+// normalizeIdentifier returns the identifier as stored in the data dictionary:
+// without the quotes if quoted, uppercased otherwise.
+func normalizeIdentifier(name string) (string, error) {
+	if isQuoted(name) {
+		name = name[1 : len(name)-1]
+		return name, checkQuotable(name)
+	}
+	if !isPlainIdentifier(name) {
+		return "", fmt.Errorf("%q: %w", name, ErrInvalidIdentifier)
+	}
+	if len(name) > MaxIdentifierLength {
+		return "", fmt.Errorf("%q is longer than %d bytes: %w", name, MaxIdentifierLength, ErrInvalidIdentifier)
+	}
+	return strings.ToUpper(name), nil
+}
+
+// checkQuotable checks whether the name can be enclosed in "-s.
+func checkQuotable(name string) error {
+	if name == "" || strings.ContainsAny(name, "\"\x00") || !utf8.ValidString(name) {
+		return fmt.Errorf("%q: %w", name, ErrInvalidIdentifier)
+	}
+	if len(name) > MaxIdentifierLength {
+		return fmt.Errorf("%q is longer than %d bytes: %w", name, MaxIdentifierLength, ErrInvalidIdentifier)
+	}
+	return nil
+}
+
+// isPlainIdentifier reports whether name is a nonquoted identifier (case insensitive).
+func isPlainIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r) && r != '_' && r != '$' && r != '#') {
+			return false
+		}
+	}
+	return true
+}
+
+// quoteIfNeeded returns the identifier (as stored in the data dictionary) quoted
+// if it is not the same unquoted.
+func quoteIfNeeded(name string) string {
+	if isPlainIdentifier(name) && strings.ToUpper(name) == name {
+		return name
+	}
+	return `"` + name + `"`
+}
+
+func isQuoted(s string) bool { return len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' }
+
+// indexUnquoted returns the index of the first c not between "-s, or -1.
+func indexUnquoted(s string, c byte) int {
+	var quoted bool
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case c:
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseObjectName(t *testing.T) {
+	for _, tc := range []struct {
+		In, String string
+		Want       ObjectName
+	}{
+		{In: "my_type", String: "MY_TYPE", Want: ObjectName{Name: "MY_TYPE"}},
+		{In: "scott.emp", String: "SCOTT.EMP", Want: ObjectName{Schema: "SCOTT", Name: "EMP"}},
+		{In: `scott."MyType"`, String: `SCOTT."MyType"`, Want: ObjectName{Schema: "SCOTT", Name: "MyType"}},
+		{In: `"SCOTT"."EMP"`, String: "SCOTT.EMP", Want: ObjectName{Schema: "SCOTT", Name: "EMP"}},
+		{In: `"a.b"."c@d"`, String: `"a.b"."c@d"`, Want: ObjectName{Schema: "a.b", Name: "c@d"}},
+		{In: "sys.pkg.rec_typ", String: "SYS.PKG.REC_TYP", Want: ObjectName{Schema: "SYS", Package: "PKG", Name: "REC_TYP"}},
+		{In: "emp@remote.example.com", String: "EMP@REMOTE.EXAMPLE.COM", Want: ObjectName{Name: "EMP", DBLink: "REMOTE.EXAMPLE.COM"}},
+		{In: `hr.emp$#1@"my link"`, String: `HR.EMP$#1@"my link"`, Want: ObjectName{Schema: "HR", Name: "EMP$#1", DBLink: "my link"}},
+	} {
+		got, err := ParseObjectName(tc.In)
+		if err != nil {
+			t.Errorf("%q: %+v", tc.In, err)
+			continue
+		}
+		if got != tc.Want {
+			t.Errorf("%q: got %#v, wanted %#v", tc.In, got, tc.Want)
+		}
+		if s := got.String(); s != tc.String {
+			t.Errorf("%q: got String %q, wanted %q", tc.In, s, tc.String)
+		}
+	}
+
+	for _, s := range []string{
+		"", "a.", ".a", "a..b", "a.b.c.d", "a@", "1abc", "a-b", `"a"b"`, `"`, `""`, "a@b..c",
+		strings.Repeat("x", MaxIdentifierLength+1),
+	} {
+		if got, err := ParseObjectName(s); !errors.Is(err, ErrInvalidIdentifier) {
+			t.Errorf("%q: got %#v, %+v, wanted %v", s, got, err, ErrInvalidIdentifier)
+		}
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	for in, want := range map[string]string{
+		"emp":                                    `"emp"`,
+		"MyTable":                                `"MyTable"`,
+		"select":                                 `"select"`,
+		`"Already"`:                              `"Already"`,
+		"a b":                                    `"a b"`,
+		"árvíz":                                  `"árvíz"`,
+		strings.Repeat("x", MaxIdentifierLength): `"` + strings.Repeat("x", MaxIdentifierLength) + `"`,
+	} {
+		if got, err := QuoteIdentifier(in); err != nil || got != want {
+			t.Errorf("%q: got %q, %+v, wanted %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", `a"b`, "a\x00b", strings.Repeat("é", MaxIdentifierLength/2+1)} {
+		if got, err := QuoteIdentifier(in); !errors.Is(err, ErrInvalidIdentifier) {
+			t.Errorf("%q: got %q, wanted %v", in, got, ErrInvalidIdentifier)
+		}
+	}
+
+	if err := ValidateIdentifier("emp_1"); err != nil {
+		t.Error(err)
+	}
+	if err := ValidateIdentifier("_emp"); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("_emp: got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// insertIDBind is the name of the bind variable of the RETURNING clause InsertReturningIDs appends.
+const insertIDBind = "godror_insert_id"
+
+// InsertReturningIDs executes the INSERT statement with the args (as ExecContext would),
+// and returns the values of idColumn of the inserted rows, such as the generated
+// identity column (GENERATED AS IDENTITY), or one filled from a sequence by a DEFAULT or a trigger.
+//
+// This is the LastInsertId of database/sql for Oracle (with array inserts, too):
+// the statement is appended with "RETURNING idColumn INTO :godror_insert_id".
+func InsertReturningIDs(ctx context.Context, ex Execer, qry, idColumn string, args ...interface{}) ([]int64, error) {
+	if idColumn == "" {
+		return nil, errors.New("InsertReturningIDs: empty idColumn")
+	}
+	qry = strings.TrimRight(strings.TrimSpace(qry), ";") +
+		" RETURNING " + idColumn + " INTO :" + insertIDBind
+	var ids []int64
+	out := sql.Out{Dest: &ids}
+	var named bool
+	for _, a := range args {
+		if _, named = a.(sql.NamedArg); named {
+			break
+		}
+	}
+	if named {
+		args = append(args[:len(args):len(args)], sql.Named(insertIDBind, out))
+	} else {
+		args = append(args[:len(args):len(args)], out)
+	}
+	if _, err := ex.ExecContext(ctx, qry, args...); err != nil {
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	return ids, nil
+}
+
+// InsertReturningID is InsertReturningIDs for a single row insert,
+// returning the value of idColumn of the inserted row.
+func InsertReturningID(ctx context.Context, ex Execer, qry, idColumn string, args ...interface{}) (int64, error) {
+	ids, err := InsertReturningIDs(ctx, ex, qry, idColumn, args...)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) != 1 {
+		return 0, fmt.Errorf("%s: %d rows inserted, wanted 1", qry, len(ids))
+	}
+	return ids[0], nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+type recordingExecer struct {
+	qry  string
+	args []interface{}
+}
+
+func (ex *recordingExecer) ExecContext(_ context.Context, qry string, args ...interface{}) (sql.Result, error) {
+	ex.qry, ex.args = qry, args
+	if out, ok := args[len(args)-1].(sql.Out); ok {
+		*out.Dest.(*[]int64) = []int64{1}
+	} else if na, ok := args[len(args)-1].(sql.NamedArg); ok {
+		*na.Value.(sql.Out).Dest.(*[]int64) = []int64{1}
+	}
+	return nil, nil
+}
+
+func TestInsertReturningID(t *testing.T) {
+	ctx := context.Background()
+	var ex recordingExecer
+	id, err := InsertReturningID(ctx, &ex, "INSERT INTO tbl (a) VALUES (:1);", "id", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 1 {
+		t.Errorf("got %d, wanted 1", id)
+	}
+	if want := "INSERT INTO tbl (a) VALUES (:1) RETURNING id INTO :godror_insert_id"; ex.qry != want {
+		t.Errorf("got %q, wanted %q", ex.qry, want)
+	}
+	if _, ok := ex.args[1].(sql.Out); !ok {
+		t.Errorf("positional: got %#v, wanted sql.Out", ex.args[1])
+	}
+
+	if _, err = InsertReturningID(ctx, &ex, "INSERT INTO tbl (a) VALUES (:a)", "id", sql.Named("a", "a")); err != nil {
+		t.Fatal(err)
+	}
+	if na, ok := ex.args[1].(sql.NamedArg); !ok || na.Name != insertIDBind {
+		t.Errorf("named: got %#v, wanted sql.Named(%q)", ex.args[1], insertIDBind)
+	}
+}
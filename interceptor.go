@@ -0,0 +1,122 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+// ErrInterceptedNoResult is returned when the interceptors neither called next,
+// nor set the result (Result, Rows) of the operation, nor returned an error.
+var ErrInterceptedNoResult = errors.New("interceptor did not call next nor set the result")
+
+// OpKind is the kind of an intercepted operation.
+type OpKind uint8
+
+// The kinds of the intercepted operations.
+const (
+	OpPrepare = OpKind(iota + 1)
+	OpExec
+	OpQuery
+	OpFetch
+	OpCommit
+	OpRollback
+)
+
+func (k OpKind) String() string {
+	switch k {
+	case OpPrepare:
+		return "prepare"
+	case OpExec:
+		return "exec"
+	case OpQuery:
+		return "query"
+	case OpFetch:
+		return "fetch"
+	case OpCommit:
+		return "commit"
+	case OpRollback:
+		return "rollback"
+	}
+	return "unknown"
+}
+
+// Op is an operation passed through the interceptors.
+type Op struct {
+	// Result is the result of OpExec, Rows is the result of OpQuery - set after next returned.
+	// An interceptor may set them without calling next (e.g. for caching),
+	// but then it must set them (ErrInterceptedNoResult is returned otherwise).
+	Result driver.Result
+	Rows   driver.Rows
+	// Query is the statement text. Rewriting it is effective only for OpPrepare,
+	// as the other operations are on the already prepared statement.
+	Query string
+	// Args are the bind variables of OpExec and OpQuery, which can be modified.
+	Args []driver.NamedValue
+	// Fetched is the number of rows fetched, set after next returned for OpFetch.
+	Fetched int
+	Kind    OpKind
+}
+
+// Interceptor intercepts an operation: it must call next to proceed (possibly with a modified ctx or op),
+// and may inspect or modify op after next returned.
+// Returning an error without calling next refuses the operation.
+// OpPrepare cannot be served without calling next.
+//
+// OpFetch, OpCommit and OpRollback are called with the context of the statement or the transaction.
+// The interceptors must not use the connection of the operation.
+type Interceptor func(ctx context.Context, op *Op, next func(context.Context, *Op) error) error
+
+// RegisterInterceptor appends the interceptor to the chain of the driver,
+// the first registered interceptor is the outermost.
+func (d *drv) RegisterInterceptor(ic Interceptor) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var ics []Interceptor
+	if p := d.interceptors.Load(); p != nil {
+		ics = append(ics, *p...)
+	}
+	ics = append(ics, ic)
+	d.interceptors.Store(&ics)
+}
+
+// RegisterInterceptor appends the interceptor to the chain of the default driver.
+func RegisterInterceptor(ic Interceptor) { defaultDrv.RegisterInterceptor(ic) }
+
+// intercept calls f through the interceptors, if any.
+func (c *conn) intercept(ctx context.Context, op *Op, f func(context.Context, *Op) error) error {
+	var ics []Interceptor
+	if c != nil && c.drv != nil {
+		if p := c.drv.interceptors.Load(); p != nil {
+			ics = *p
+		}
+	}
+	if len(ics) == 0 {
+		return f(ctx, op)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var call func(int, context.Context, *Op) error
+	call = func(i int, ctx context.Context, op *Op) error {
+		if i == len(ics) {
+			return f(ctx, op)
+		}
+		return ics[i](ctx, op, func(ctx context.Context, op *Op) error { return call(i+1, ctx, op) })
+	}
+	return call(0, ctx, op)
+}
+
+// intercepted reports whether there are interceptors.
+func (c *conn) intercepted() bool {
+	if c == nil || c.drv == nil {
+		return false
+	}
+	p := c.drv.interceptors.Load()
+	return p != nil && len(*p) != 0
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInterceptorChain(t *testing.T) {
+	c := &conn{drv: &drv{}}
+	if c.intercepted() {
+		t.Error("intercepted without interceptors")
+	}
+	var calls []string
+	c.drv.RegisterInterceptor(func(ctx context.Context, op *Op, next func(context.Context, *Op) error) error {
+		calls = append(calls, "outer "+op.Kind.String())
+		op.Query = strings.ToUpper(op.Query)
+		err := next(ctx, op)
+		calls = append(calls, "outer done")
+		return err
+	})
+	errRefused := errors.New("refused")
+	c.drv.RegisterInterceptor(func(ctx context.Context, op *Op, next func(context.Context, *Op) error) error {
+		calls = append(calls, "inner "+op.Query)
+		if op.Kind == OpCommit {
+			return errRefused
+		}
+		return next(ctx, op)
+	})
+	if !c.intercepted() {
+		t.Fatal("not intercepted")
+	}
+
+	var got string
+	err := c.intercept(context.Background(), &Op{Kind: OpPrepare, Query: "select 1 from dual"}, func(_ context.Context, op *Op) error {
+		got = op.Query
+		calls = append(calls, "prepare")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "SELECT 1 FROM DUAL" {
+		t.Errorf("got %q", got)
+	}
+	if want := "outer prepare|inner SELECT 1 FROM DUAL|prepare|outer done"; strings.Join(calls, "|") != want {
+		t.Errorf("got %q, wanted %q", calls, want)
+	}
+
+	var called bool
+	if err = c.intercept(context.Background(), &Op{Kind: OpCommit}, func(context.Context, *Op) error {
+		called = true
+		return nil
+	}); !errors.Is(err, errRefused) || called {
+		t.Errorf("commit: got %v, called=%t", err, called)
+	}
+}
@@ -111,6 +111,7 @@ var _ = io.ReaderAt((*dpiLobReader)(nil))
 
 type dpiLobReader struct {
 	*drv
+	roundTrips          *RoundTrips // of the query fetching the LOB
 	dpiLob              *C.dpiLob
 	buf                 []byte
 	offset, sizePlusOne C.uint64_t
@@ -235,6 +236,7 @@ func (dlr *dpiLobReader) getSize() error {
 	}
 	var err error
 	runtime.LockOSThread()
+	dlr.countLOB()
 	if err = dlr.checkExecNoLOT(func() C.int {
 		return C.dpiLob_getSize(dlr.dpiLob, &dlr.sizePlusOne)
 	}); err != nil {
@@ -301,6 +303,7 @@ func (dlr *dpiLobReader) read(p []byte) (int, error) {
 		return 0, io.EOF
 	}
 	rd := func() error {
+		dlr.countLOB()
 		return dlr.drv.checkExecNoLOT(func() C.int {
 			return C.dpiLob_readBytes(dlr.dpiLob, dlr.offset+1, amount, (*C.char)(unsafe.Pointer(&p[0])), &n)
 		})
@@ -379,6 +382,7 @@ func (dlr *dpiLobReader) ReadAt(p []byte, off int64) (int, error) {
 		return 0, ErrCLOB
 	}
 	n := C.uint64_t(len(p))
+	dlr.countLOB()
 	err := dlr.checkExec(func() C.int {
 		return C.dpiLob_readBytes(dlr.dpiLob, C.uint64_t(off+1), n, (*C.char)(unsafe.Pointer(&p[0])), &n)
 	})
@@ -461,6 +465,7 @@ func closeLob(d interface{ getError() error }, lob *C.dpiLob) error {
 		}
 	}
 	C.dpiLob_release(lob)
+	resourceCounters.lobs.Add(-1)
 	return nil
 }
 
@@ -484,6 +489,7 @@ func (c *conn) NewTempLob(isClob bool) (*DirectLob, error) {
 	if err := c.checkExec(func() C.int { return C.dpiConn_newTempLob(c.dpiConn, typ, &lob.dpiLob) }); err != nil {
 		return nil, fmt.Errorf("newTempLob: %w", err)
 	}
+	resourceCounters.lobs.Add(1)
 	return &lob, nil
 }
 
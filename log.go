@@ -7,7 +7,9 @@ package godror
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/godror/godror/slog"
 )
@@ -39,3 +41,106 @@ func getLogger(ctx context.Context) *slog.Logger {
 	}
 	return nil
 }
+
+// LogSampling are the options of NewSampledLogger.
+type LogSampling struct {
+	// Level is the level below which the records are sampled and rate limited.
+	// The zero value is slog.LevelInfo, so only the Debug records are.
+	Level slog.Level
+	// Every logs only every Every-th record of the same message (values less than 2 mean all).
+	Every int
+	// PerSecond is the maximum number of records logged per second on average (0 means unlimited),
+	// with bursts of Burst records (at least 1).
+	PerSecond float64
+	Burst     int
+}
+
+// NewSampledLogger returns a logger which samples and rate limits the records of the logger below opts.Level,
+// to be set with SetLogger or as the per-pool CommonParams.Logger, keeping the debug lines of the hot paths
+// (such as the object attribute get/set) affordable in production.
+//
+// The first record logged after dropping some has a "godror.dropped" attribute with the number of dropped records.
+func NewSampledLogger(logger *slog.Logger, opts LogSampling) *slog.Logger {
+	if logger == nil {
+		return nil
+	}
+	if opts.Burst < 1 {
+		opts.Burst = 1
+	}
+	return slog.New(sampledHandler{
+		Handler: logger.Handler(),
+		state:   &sampleState{opts: opts, tokens: float64(opts.Burst)},
+	})
+}
+
+// maxSampledMessages limits the number of messages counted for LogSampling.Every.
+const maxSampledMessages = 1024
+
+type sampleState struct {
+	last    time.Time
+	counts  map[string]uint64
+	opts    LogSampling
+	tokens  float64
+	dropped int
+	mu      sync.Mutex
+}
+
+// allow reports whether the record of msg can be logged, and the number of records dropped before it.
+func (s *sampleState) allow(msg string, now time.Time) (bool, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.opts.Every > 1 {
+		if s.counts == nil || len(s.counts) >= maxSampledMessages {
+			s.counts = make(map[string]uint64)
+		}
+		n := s.counts[msg]
+		s.counts[msg] = n + 1
+		if n%uint64(s.opts.Every) != 0 {
+			s.dropped++
+			return false, 0
+		}
+	}
+	if s.opts.PerSecond > 0 {
+		if !s.last.IsZero() {
+			s.tokens += now.Sub(s.last).Seconds() * s.opts.PerSecond
+			if max := float64(s.opts.Burst); s.tokens > max {
+				s.tokens = max
+			}
+		}
+		s.last = now
+		if s.tokens < 1 {
+			s.dropped++
+			return false, 0
+		}
+		s.tokens--
+	}
+	dropped := s.dropped
+	s.dropped = 0
+	return true, dropped
+}
+
+type sampledHandler struct {
+	slog.Handler
+	state *sampleState
+}
+
+func (h sampledHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= h.state.opts.Level {
+		return h.Handler.Handle(ctx, r)
+	}
+	ok, dropped := h.state.allow(r.Message, time.Now())
+	if !ok {
+		return nil
+	}
+	if dropped != 0 {
+		r = r.Clone()
+		r.AddAttrs(slog.Int("godror.dropped", dropped))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+func (h sampledHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return sampledHandler{Handler: h.Handler.WithAttrs(attrs), state: h.state}
+}
+func (h sampledHandler) WithGroup(name string) slog.Handler {
+	return sampledHandler{Handler: h.Handler.WithGroup(name), state: h.state}
+}
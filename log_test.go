@@ -0,0 +1,71 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godror/godror/slog"
+)
+
+func TestSampledLogger(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	logger := NewSampledLogger(base, LogSampling{Every: 3}).With("k", "v")
+	for i := 0; i < 7; i++ {
+		logger.Debug("hot")
+	}
+	logger.Info("info")
+	out := buf.String()
+	t.Log(out)
+	if n := strings.Count(out, "msg=hot"); n != 3 {
+		t.Errorf("got %d hot lines, wanted 3", n)
+	}
+	if !strings.Contains(out, "msg=info") {
+		t.Error("info is missing")
+	}
+	if !strings.Contains(out, "godror.dropped=2") {
+		t.Error("dropped count is missing")
+	}
+
+	s := &sampleState{opts: LogSampling{PerSecond: 10, Burst: 2}, tokens: 2}
+	now := time.Now()
+	var allowed int
+	for i := 0; i < 5; i++ {
+		if ok, _ := s.allow("x", now); ok {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("allowed %d of the burst, wanted 2", allowed)
+	}
+	if ok, dropped := s.allow("x", now.Add(100*time.Millisecond)); !ok || dropped != 3 {
+		t.Errorf("after 100ms: got %t, %d dropped, wanted true, 3", ok, dropped)
+	}
+}
+
+func TestConnLoggerPrecedence(t *testing.T) {
+	var global, pool, local bytes.Buffer
+	newLogger := func(buf *bytes.Buffer) *slog.Logger { return slog.New(slog.NewTextHandler(buf, nil)) }
+	SetLogger(newLogger(&global))
+	defer SetLogger(slog.Default())
+	c := &conn{}
+	if got := c.getLogger(context.Background()); got != globalLogger.Load().(*slog.Logger) {
+		t.Error("wanted the global logger")
+	}
+	c.params.Logger = newLogger(&pool)
+	if got := c.getLogger(context.Background()); got != c.params.Logger {
+		t.Error("wanted the pool logger")
+	}
+	lgr := newLogger(&local)
+	if got := c.getLogger(ContextWithLogger(context.Background(), lgr)); got != lgr {
+		t.Error("wanted the context logger")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SessionID identifies a database session.
+type SessionID struct {
+	SID, Serial int64
+}
+
+func (s SessionID) String() string { return fmt.Sprintf("%d,%d", s.SID, s.Serial) }
+
+// CurrentSessionID returns the SID and SERIAL# of the session of the connection.
+//
+// The q must be a single connection (*sql.Conn or *sql.Tx), not a pool.
+func CurrentSessionID(ctx context.Context, q Querier) (SessionID, error) {
+	const qry = `SELECT DBMS_DEBUG_JDWP.current_session_id, DBMS_DEBUG_JDWP.current_session_serial FROM DUAL`
+	var sid SessionID
+	rows, err := q.QueryContext(ctx, qry)
+	if err != nil {
+		return sid, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err == nil {
+			err = sql.ErrNoRows
+		}
+		return sid, fmt.Errorf("%s: %w", qry, err)
+	}
+	if err = rows.Scan(&sid.SID, &sid.Serial); err != nil {
+		return sid, fmt.Errorf("%s: %w", qry, err)
+	}
+	return sid, rows.Close()
+}
+
+// LongOpProgress is the progress of a long-running operation of a session, as in V$SESSION_LONGOPS.
+type LongOpProgress struct {
+	StartTime, LastUpdate time.Time
+	// Err is the error of the polling, the last value sent on the channel.
+	Err                            error
+	OpName, Target, Units, Message string
+	SoFar, TotalWork               int64
+	Elapsed, TimeRemaining         time.Duration
+}
+
+// Done reports whether the operation has finished.
+func (p LongOpProgress) Done() bool { return p.TotalWork > 0 && p.SoFar >= p.TotalWork }
+
+// Percent returns the finished part of the work, in percents.
+func (p LongOpProgress) Percent() float64 {
+	if p.TotalWork <= 0 {
+		return 0
+	}
+	return float64(p.SoFar) * 100 / float64(p.TotalWork)
+}
+
+// WatchLongOps polls V$SESSION_LONGOPS for the operations of the session in every interval,
+// and sends their progress on the returned channel, when changed.
+// The channel is closed when the ctx is canceled, or after sending an error.
+//
+// The q must NOT be the connection of the session, as that is busy executing the long statement:
+// use the pool (*sql.DB), and get the session of the executing connection with CurrentSessionID.
+// Operations already finished at the first poll are not reported.
+//
+// This needs SELECT privilege on V$SESSION_LONGOPS.
+func WatchLongOps(ctx context.Context, q Querier, session SessionID, interval time.Duration) <-chan LongOpProgress {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ch := make(chan LongOpProgress)
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		type opKey struct {
+			start          time.Time
+			opName, target string
+		}
+		seen := make(map[opKey]int64)
+		for first := true; ; first = false {
+			progs, err := getLongOps(ctx, q, session)
+			if err != nil {
+				if ctx.Err() == nil {
+					select {
+					case ch <- LongOpProgress{Err: err}:
+					case <-ctx.Done():
+					}
+				}
+				return
+			}
+			for _, p := range progs {
+				k := opKey{start: p.StartTime, opName: p.OpName, target: p.Target}
+				if soFar, ok := seen[k]; ok && soFar == p.SoFar {
+					continue
+				}
+				seen[k] = p.SoFar
+				if first && p.Done() {
+					continue
+				}
+				select {
+				case ch <- p:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func getLongOps(ctx context.Context, q Querier, session SessionID) ([]LongOpProgress, error) {
+	const qry = `SELECT opname, NVL(target, target_desc), units, message, sofar, totalwork,
+       start_time, last_update_time, elapsed_seconds, time_remaining
+  FROM v$session_longops
+  WHERE sid = :1 AND serial# = :2
+  ORDER BY start_time, opname`
+	rows, err := q.QueryContext(ctx, qry, session.SID, session.Serial)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	var progs []LongOpProgress
+	for rows.Next() {
+		var p LongOpProgress
+		var opName, target, units, message sql.NullString
+		var soFar, totalWork, elapsed, remaining sql.NullInt64
+		var start, last sql.NullTime
+		if err = rows.Scan(&opName, &target, &units, &message, &soFar, &totalWork,
+			&start, &last, &elapsed, &remaining,
+		); err != nil {
+			return progs, fmt.Errorf("%s: %w", qry, err)
+		}
+		p.OpName, p.Target, p.Units, p.Message = opName.String, target.String, units.String, message.String
+		p.SoFar, p.TotalWork = soFar.Int64, totalWork.Int64
+		p.StartTime, p.LastUpdate = start.Time, last.Time
+		p.Elapsed = time.Duration(elapsed.Int64) * time.Second
+		p.TimeRemaining = time.Duration(remaining.Int64) * time.Second
+		progs = append(progs, p)
+	}
+	if err = rows.Err(); err != nil {
+		return progs, err
+	}
+	return progs, rows.Close()
+}
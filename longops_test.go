@@ -0,0 +1,30 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import "testing"
+
+func TestLongOpProgress(t *testing.T) {
+	for _, tc := range []struct {
+		P       LongOpProgress
+		Percent float64
+		Done    bool
+	}{
+		{P: LongOpProgress{}},
+		{P: LongOpProgress{SoFar: 1, TotalWork: 4}, Percent: 25},
+		{P: LongOpProgress{SoFar: 4, TotalWork: 4}, Percent: 100, Done: true},
+	} {
+		if got := tc.P.Percent(); got != tc.Percent {
+			t.Errorf("%+v: got %f%%, wanted %f%%", tc.P, got, tc.Percent)
+		}
+		if got := tc.P.Done(); got != tc.Done {
+			t.Errorf("%+v: got done=%t, wanted %t", tc.P, got, tc.Done)
+		}
+	}
+	if got := (SessionID{SID: 12, Serial: 345}).String(); got != "12,345" {
+		t.Errorf("got %q, wanted 12,345", got)
+	}
+}
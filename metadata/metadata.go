@@ -0,0 +1,349 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+// Package metadata reads the schema metadata (tables, columns, constraints, indexes,
+// sequences and object types) from the data dictionary (the ALL_ views),
+// with one query per kind for a whole schema or table.
+//
+// The owner and table names are as stored in the data dictionary (usually uppercase),
+// an empty owner means the current schema, an empty table name all the tables of the owner.
+//
+// It needs only a database/sql connection, so it does not depend on cgo.
+package metadata
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Querier is the QueryContext of *sql.DB, *sql.Conn and *sql.Tx.
+type Querier interface {
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+}
+
+// Table is a table, as in ALL_TABLES.
+type Table struct {
+	Owner, Name, Comment string
+	// NumRows is the number of rows at the last statistics gathering, -1 if unknown.
+	NumRows     int64
+	Temporary   bool
+	Partitioned bool
+}
+
+// Column is a column of a table or view, as in ALL_TAB_COLUMNS.
+type Column struct {
+	Table, Name string
+	// DataType is such as VARCHAR2, NUMBER, TIMESTAMP(6) or the name of the object type.
+	DataType, DataTypeOwner string
+	// Default is the text of the default expression.
+	Default, Comment string
+	// ID is the position of the column in the table, from 1.
+	ID int
+	// Length is the length in bytes, CharLength in characters (for character types).
+	Length, CharLength int
+	// Precision and Scale are -1 if not specified.
+	Precision, Scale int
+	Nullable         bool
+}
+
+// ConstraintType is the type of a constraint.
+type ConstraintType string
+
+// The constraint types.
+const (
+	PrimaryKey = ConstraintType("P")
+	Unique     = ConstraintType("U")
+	ForeignKey = ConstraintType("R")
+	Check      = ConstraintType("C")
+)
+
+// Constraint is a constraint of a table, as in ALL_CONSTRAINTS.
+type Constraint struct {
+	Owner, Name, Table string
+	// Condition is the search condition of a check constraint.
+	Condition string
+	// RefOwner, RefConstraint, RefTable and RefColumns are the referenced unique/primary key of a foreign key.
+	RefOwner, RefConstraint, RefTable string
+	// DeleteRule is the ON DELETE rule of a foreign key: CASCADE, SET NULL or NO ACTION.
+	DeleteRule string
+	Type       ConstraintType
+	Columns    []string
+	RefColumns []string
+	Enabled    bool
+	Validated  bool
+}
+
+// Index is an index of a table, as in ALL_INDEXES.
+type Index struct {
+	Owner, Name, TableOwner, Table string
+	// Type is such as NORMAL, BITMAP or FUNCTION-BASED NORMAL.
+	Type    string
+	Columns []IndexColumn
+	Unique  bool
+}
+
+// IndexColumn is a column of an index. The expression of a function-based index
+// is not included, only its hidden column name.
+type IndexColumn struct {
+	Name       string
+	Descending bool
+}
+
+// Sequence is a sequence, as in ALL_SEQUENCES.
+// The limits are strings, as they may not fit in an int64.
+type Sequence struct {
+	Owner, Name                    string
+	MinValue, MaxValue, LastNumber string
+	Increment, CacheSize           int64
+	Cycle, Order                   bool
+}
+
+// ObjectType is a user defined type, as in ALL_TYPES.
+type ObjectType struct {
+	Owner, Name string
+	// TypeCode is OBJECT or COLLECTION.
+	TypeCode string
+	// CollectionType is VARYING ARRAY or TABLE, for collections.
+	CollectionType string
+	// ElemType and ElemTypeOwner are the type of the elements of a collection.
+	ElemTypeOwner, ElemType string
+	// Attributes of an object type, in order.
+	Attributes []TypeAttribute
+	// UpperBound is the maximum size of a VARYING ARRAY.
+	UpperBound int64
+}
+
+// TypeAttribute is an attribute of an object type, as in ALL_TYPE_ATTRS.
+type TypeAttribute struct {
+	Name, TypeOwner, Type string
+	// Precision and Scale are -1 if not specified.
+	Length, Precision, Scale int
+}
+
+const currentSchema = "NVL(:1, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))"
+
+// Tables returns the tables of the owner.
+func Tables(ctx context.Context, q Querier, owner string) ([]Table, error) {
+	const qry = `SELECT t.owner, t.table_name, NVL(t.num_rows, -1), t.temporary, t.partitioned, c.comments
+  FROM all_tables t
+  LEFT OUTER JOIN all_tab_comments c ON c.owner = t.owner AND c.table_name = t.table_name
+  WHERE t.owner = ` + currentSchema + ` AND t.dropped = 'NO' AND t.nested = 'NO' AND t.secondary = 'N'
+  ORDER BY t.table_name`
+	var tables []Table
+	err := query(ctx, q, qry, []interface{}{owner}, func(rows *sql.Rows) error {
+		var t Table
+		var temporary, partitioned string
+		var comment sql.NullString
+		if err := rows.Scan(&t.Owner, &t.Name, &t.NumRows, &temporary, &partitioned, &comment); err != nil {
+			return err
+		}
+		t.Temporary, t.Partitioned, t.Comment = temporary == "Y", partitioned == "YES", comment.String
+		tables = append(tables, t)
+		return nil
+	})
+	return tables, err
+}
+
+// Columns returns the columns of the table (all the tables and views of the owner if empty),
+// ordered by table and position.
+func Columns(ctx context.Context, q Querier, owner, table string) ([]Column, error) {
+	const qry = `SELECT c.table_name, c.column_id, c.column_name, c.data_type, c.data_type_owner,
+       c.data_length, NVL(c.char_length, 0), NVL(c.data_precision, -1), NVL(c.data_scale, -1),
+       c.nullable, cc.comments, c.data_default
+  FROM all_tab_columns c
+  LEFT OUTER JOIN all_col_comments cc ON
+    cc.owner = c.owner AND cc.table_name = c.table_name AND cc.column_name = c.column_name
+  WHERE c.owner = ` + currentSchema + ` AND c.table_name = NVL(:2, c.table_name)
+  ORDER BY c.table_name, c.column_id`
+	var cols []Column
+	err := query(ctx, q, qry, []interface{}{owner, table}, func(rows *sql.Rows) error {
+		var c Column
+		var typeOwner, nullable, comment, dflt sql.NullString
+		if err := rows.Scan(&c.Table, &c.ID, &c.Name, &c.DataType, &typeOwner,
+			&c.Length, &c.CharLength, &c.Precision, &c.Scale,
+			&nullable, &comment, &dflt,
+		); err != nil {
+			return err
+		}
+		c.DataTypeOwner, c.Nullable = typeOwner.String, nullable.String == "Y"
+		c.Comment, c.Default = comment.String, dflt.String
+		cols = append(cols, c)
+		return nil
+	})
+	return cols, err
+}
+
+// Constraints returns the primary key, unique, foreign key and check constraints
+// of the table (all the tables of the owner if empty), including the NOT NULL constraints.
+func Constraints(ctx context.Context, q Querier, owner, table string) ([]Constraint, error) {
+	const qry = `SELECT c.owner, c.constraint_name, c.constraint_type, c.table_name,
+       c.status, c.validated, c.delete_rule, c.r_owner, c.r_constraint_name, r.table_name,
+       cc.column_name, rc.column_name, c.search_condition
+  FROM all_constraints c
+  LEFT OUTER JOIN all_cons_columns cc ON
+    cc.owner = c.owner AND cc.constraint_name = c.constraint_name AND cc.table_name = c.table_name
+  LEFT OUTER JOIN all_constraints r ON r.owner = c.r_owner AND r.constraint_name = c.r_constraint_name
+  LEFT OUTER JOIN all_cons_columns rc ON
+    rc.owner = c.r_owner AND rc.constraint_name = c.r_constraint_name AND rc.position = cc.position
+  WHERE c.owner = ` + currentSchema + ` AND c.table_name = NVL(:2, c.table_name) AND
+        c.constraint_type IN ('P', 'U', 'R', 'C') AND c.constraint_name NOT LIKE 'BIN$%'
+  ORDER BY c.table_name, c.constraint_name, cc.position`
+	var cons []Constraint
+	err := query(ctx, q, qry, []interface{}{owner, table}, func(rows *sql.Rows) error {
+		var c Constraint
+		var typ, status, validated string
+		var deleteRule, refOwner, refCons, refTable, col, refCol, cond sql.NullString
+		if err := rows.Scan(&c.Owner, &c.Name, &typ, &c.Table,
+			&status, &validated, &deleteRule, &refOwner, &refCons, &refTable,
+			&col, &refCol, &cond,
+		); err != nil {
+			return err
+		}
+		if n := len(cons); n != 0 && cons[n-1].Owner == c.Owner && cons[n-1].Name == c.Name {
+			// the next column of the same constraint
+			last := &cons[n-1]
+			if col.Valid {
+				last.Columns = append(last.Columns, col.String)
+			}
+			if refCol.Valid {
+				last.RefColumns = append(last.RefColumns, refCol.String)
+			}
+			return nil
+		}
+		c.Type, c.Enabled, c.Validated = ConstraintType(typ), status == "ENABLED", validated == "VALIDATED"
+		c.DeleteRule, c.Condition = deleteRule.String, cond.String
+		c.RefOwner, c.RefConstraint, c.RefTable = refOwner.String, refCons.String, refTable.String
+		if col.Valid {
+			c.Columns = []string{col.String}
+		}
+		if refCol.Valid {
+			c.RefColumns = []string{refCol.String}
+		}
+		cons = append(cons, c)
+		return nil
+	})
+	return cons, err
+}
+
+// Indexes returns the indexes of the table (all the tables of the owner if empty).
+func Indexes(ctx context.Context, q Querier, owner, table string) ([]Index, error) {
+	const qry = `SELECT i.owner, i.index_name, i.table_owner, i.table_name, i.uniqueness, i.index_type,
+       ic.column_name, ic.descend
+  FROM all_indexes i
+  JOIN all_ind_columns ic ON ic.index_owner = i.owner AND ic.index_name = i.index_name
+  WHERE i.table_owner = ` + currentSchema + ` AND i.table_name = NVL(:2, i.table_name) AND
+        i.index_name NOT LIKE 'BIN$%'
+  ORDER BY i.table_name, i.owner, i.index_name, ic.column_position`
+	var idxs []Index
+	err := query(ctx, q, qry, []interface{}{owner, table}, func(rows *sql.Rows) error {
+		var idx Index
+		var uniqueness string
+		var col IndexColumn
+		var descend sql.NullString
+		if err := rows.Scan(&idx.Owner, &idx.Name, &idx.TableOwner, &idx.Table, &uniqueness, &idx.Type,
+			&col.Name, &descend,
+		); err != nil {
+			return err
+		}
+		col.Descending = descend.String == "DESC"
+		if n := len(idxs); n != 0 && idxs[n-1].Owner == idx.Owner && idxs[n-1].Name == idx.Name {
+			idxs[n-1].Columns = append(idxs[n-1].Columns, col)
+			return nil
+		}
+		idx.Unique, idx.Columns = uniqueness == "UNIQUE", []IndexColumn{col}
+		idxs = append(idxs, idx)
+		return nil
+	})
+	return idxs, err
+}
+
+// Sequences returns the sequences of the owner.
+func Sequences(ctx context.Context, q Querier, owner string) ([]Sequence, error) {
+	const qry = `SELECT sequence_owner, sequence_name,
+       TO_CHAR(min_value, 'TM9'), TO_CHAR(max_value, 'TM9'), TO_CHAR(last_number, 'TM9'),
+       increment_by, cache_size, cycle_flag, order_flag
+  FROM all_sequences
+  WHERE sequence_owner = ` + currentSchema + `
+  ORDER BY sequence_name`
+	var seqs []Sequence
+	err := query(ctx, q, qry, []interface{}{owner}, func(rows *sql.Rows) error {
+		var s Sequence
+		var cycle, order string
+		if err := rows.Scan(&s.Owner, &s.Name, &s.MinValue, &s.MaxValue, &s.LastNumber,
+			&s.Increment, &s.CacheSize, &cycle, &order,
+		); err != nil {
+			return err
+		}
+		s.Cycle, s.Order = cycle == "Y", order == "Y"
+		seqs = append(seqs, s)
+		return nil
+	})
+	return seqs, err
+}
+
+// ObjectTypes returns the object and collection types of the owner, with their attributes.
+func ObjectTypes(ctx context.Context, q Querier, owner string) ([]ObjectType, error) {
+	const qry = `SELECT t.owner, t.type_name, t.typecode,
+       ct.coll_type, NVL(ct.upper_bound, 0), ct.elem_type_owner, ct.elem_type_name
+  FROM all_types t
+  LEFT OUTER JOIN all_coll_types ct ON ct.owner = t.owner AND ct.type_name = t.type_name
+  WHERE t.owner = ` + currentSchema + `
+  ORDER BY t.type_name`
+	var types []ObjectType
+	idx := make(map[string]int)
+	if err := query(ctx, q, qry, []interface{}{owner}, func(rows *sql.Rows) error {
+		var t ObjectType
+		var typeCode, collType, elemOwner, elemType sql.NullString
+		if err := rows.Scan(&t.Owner, &t.Name, &typeCode, &collType, &t.UpperBound, &elemOwner, &elemType); err != nil {
+			return err
+		}
+		t.TypeCode, t.CollectionType = typeCode.String, collType.String
+		t.ElemTypeOwner, t.ElemType = elemOwner.String, elemType.String
+		idx[t.Name] = len(types)
+		types = append(types, t)
+		return nil
+	}); err != nil || len(types) == 0 {
+		return types, err
+	}
+
+	const attrQry = `SELECT type_name, attr_name, attr_type_owner, attr_type_name,
+       NVL(length, 0), NVL(precision, -1), NVL(scale, -1)
+  FROM all_type_attrs
+  WHERE owner = ` + currentSchema + `
+  ORDER BY type_name, attr_no`
+	err := query(ctx, q, attrQry, []interface{}{owner}, func(rows *sql.Rows) error {
+		var typeName string
+		var a TypeAttribute
+		var typeOwner sql.NullString
+		if err := rows.Scan(&typeName, &a.Name, &typeOwner, &a.Type, &a.Length, &a.Precision, &a.Scale); err != nil {
+			return err
+		}
+		a.TypeOwner = typeOwner.String
+		if i, ok := idx[typeName]; ok {
+			types[i].Attributes = append(types[i].Attributes, a)
+		}
+		return nil
+	})
+	return types, err
+}
+
+// query calls scan for each row of the query.
+func query(ctx context.Context, q Querier, qry string, args []interface{}, scan func(*sql.Rows) error) error {
+	rows, err := q.QueryContext(ctx, qry, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return fmt.Errorf("%s: %w", qry, err)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return rows.Close()
+}
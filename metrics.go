@@ -0,0 +1,254 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxMetricsStatements is the maximum number of distinct statements with their own latency metrics,
+// the rest are counted under the "other" hash.
+const maxMetricsStatements = 1000
+
+// metricsBuckets are the upper bounds of the latency histogram buckets, in seconds.
+var metricsBuckets = [...]float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 60}
+
+// StatementHash returns the hash of the statement text, as used in the stmt_hash label of the metrics.
+func StatementHash(query string) string {
+	h := fnv.New64a()
+	_, _ = io.WriteString(h, query)
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+type stmtMetrics struct {
+	buckets [len(metricsBuckets)]uint64
+	count   uint64
+	errors  uint64
+	sum     time.Duration
+}
+
+// driverMetrics are the client-side statistics of the driver, collected once a MetricsCollector is created.
+type driverMetrics struct {
+	stmts                map[[2]string]*stmtMetrics // op, stmt_hash
+	errors               map[int]uint64
+	mu                   sync.Mutex
+	rowsFetched          atomic.Uint64
+	cacheHits, cacheMiss atomic.Uint64
+	enabled              atomic.Bool
+}
+
+// observe records the duration and the error of the op (exec or query) of the statement.
+func (m *driverMetrics) observe(op, query string, dur time.Duration, err error) {
+	hash := StatementHash(query)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stmts == nil {
+		m.stmts = make(map[[2]string]*stmtMetrics)
+	}
+	key := [2]string{op, hash}
+	sm := m.stmts[key]
+	if sm == nil {
+		if len(m.stmts) >= maxMetricsStatements {
+			key[1] = "other"
+			sm = m.stmts[key]
+		}
+		if sm == nil {
+			sm = new(stmtMetrics)
+			m.stmts[key] = sm
+		}
+	}
+	sm.count++
+	sm.sum += dur
+	secs := dur.Seconds()
+	for i, le := range metricsBuckets {
+		if secs <= le {
+			sm.buckets[i]++
+		}
+	}
+	if err != nil {
+		sm.errors++
+		m.addErrorNotLocked(err)
+	}
+}
+
+// addError counts the error by its ORA code (0 for non-Oracle errors).
+func (m *driverMetrics) addError(err error) {
+	m.mu.Lock()
+	m.addErrorNotLocked(err)
+	m.mu.Unlock()
+}
+
+func (m *driverMetrics) addErrorNotLocked(err error) {
+	var code int
+	if oerr, ok := AsOraErr(err); ok {
+		code = oerr.Code()
+	}
+	if m.errors == nil {
+		m.errors = make(map[int]uint64)
+	}
+	m.errors[code]++
+}
+
+// observe starts measuring the op (exec or query) of the statement,
+// the returned function records the duration and the error.
+func (st *statement) observe(op string) func(error) {
+	if st.conn == nil || st.drv == nil || !st.drv.metrics.enabled.Load() {
+		return func(error) {}
+	}
+	m, query, start := &st.drv.metrics, st.query, time.Now()
+	return func(err error) { m.observe(op, query, time.Since(start), err) }
+}
+
+// MetricsCollector exposes the client-side statistics of a godror *sql.DB
+// in the Prometheus text exposition format, as an http.Handler:
+//
+//	http.Handle("/metrics", godror.NewMetricsCollector(db))
+//
+// The metrics are:
+//
+//   - godror_pool_sessions{state="open|busy|max"}: the session pools of the driver,
+//   - godror_db_connections{state="open|in_use|idle"} and godror_db_wait_count_total: the sql.DB pool,
+//   - godror_stmt_cache_hits_total and godror_stmt_cache_misses_total: the (estimated) statement cache hits,
+//   - godror_statement_duration_seconds{op="exec|query",stmt_hash="..."} histogram, and
+//     godror_statement_errors_total{op,stmt_hash} - see StatementHash,
+//   - godror_rows_fetched_total,
+//   - godror_round_trips_total{kind="execute|fetch|lob|transaction"}: the (estimated) round trips - see RoundTrips,
+//   - godror_errors_total{code="ORA-00942"}: the errors of the executions and fetches.
+//
+// The statistics are collected since the first MetricsCollector is created for the driver.
+type MetricsCollector struct {
+	db  *sql.DB
+	drv *drv
+}
+
+// NewMetricsCollector returns a MetricsCollector for the db, and starts collecting the statistics of its driver.
+func NewMetricsCollector(db *sql.DB) *MetricsCollector {
+	d, _ := db.Driver().(*drv)
+	if d != nil {
+		d.metrics.enabled.Store(true)
+	}
+	return &MetricsCollector{db: db, drv: d}
+}
+
+// ServeHTTP writes the metrics.
+func (mc *MetricsCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = mc.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (mc *MetricsCollector) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	metric := func(name, typ, help string) {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	}
+
+	if d := mc.drv; d != nil {
+		var ps PoolStats
+		d.mu.RLock()
+		pools := make([]*connPool, 0, len(d.pools))
+		for _, p := range d.pools {
+			pools = append(pools, p)
+		}
+		d.mu.RUnlock()
+		for _, p := range pools {
+			if s, err := d.getPoolStats(p); err == nil {
+				ps.Open, ps.Busy, ps.Max = ps.Open+s.Open, ps.Busy+s.Busy, ps.Max+s.Max
+			}
+		}
+		metric("godror_pool_sessions", "gauge", "Sessions of the driver's session pools.")
+		fmt.Fprintf(bw, "godror_pool_sessions{state=\"open\"} %d\ngodror_pool_sessions{state=\"busy\"} %d\ngodror_pool_sessions{state=\"max\"} %d\n",
+			ps.Open, ps.Busy, ps.Max)
+	}
+
+	if mc.db != nil {
+		s := mc.db.Stats()
+		metric("godror_db_connections", "gauge", "Connections of the sql.DB pool.")
+		fmt.Fprintf(bw, "godror_db_connections{state=\"open\"} %d\ngodror_db_connections{state=\"in_use\"} %d\ngodror_db_connections{state=\"idle\"} %d\n",
+			s.OpenConnections, s.InUse, s.Idle)
+		metric("godror_db_wait_count_total", "counter", "Number of waits for a connection of the sql.DB pool.")
+		fmt.Fprintf(bw, "godror_db_wait_count_total %d\n", s.WaitCount)
+	}
+
+	if d := mc.drv; d != nil {
+		m := &d.metrics
+		metric("godror_stmt_cache_hits_total", "counter", "Prepares finding the statement in the statement cache (estimated).")
+		fmt.Fprintf(bw, "godror_stmt_cache_hits_total %d\n", m.cacheHits.Load())
+		metric("godror_stmt_cache_misses_total", "counter", "Prepares parsing the statement (estimated).")
+		fmt.Fprintf(bw, "godror_stmt_cache_misses_total %d\n", m.cacheMiss.Load())
+		metric("godror_rows_fetched_total", "counter", "Rows fetched.")
+		fmt.Fprintf(bw, "godror_rows_fetched_total %d\n", m.rowsFetched.Load())
+		rt := d.RoundTripStats()
+		metric("godror_round_trips_total", "counter", "Network round trips (estimated).")
+		fmt.Fprintf(bw, "godror_round_trips_total{kind=\"execute\"} %d\ngodror_round_trips_total{kind=\"fetch\"} %d\ngodror_round_trips_total{kind=\"lob\"} %d\ngodror_round_trips_total{kind=\"transaction\"} %d\n",
+			rt.Execute, rt.Fetch, rt.LOB, rt.Transaction)
+
+		type stmtKV struct {
+			key [2]string
+			sm  stmtMetrics
+		}
+		m.mu.Lock()
+		stmts := make([]stmtKV, 0, len(m.stmts))
+		for k, sm := range m.stmts {
+			stmts = append(stmts, stmtKV{key: k, sm: *sm})
+		}
+		codes := make([]int, 0, len(m.errors))
+		errs := make(map[int]uint64, len(m.errors))
+		for code, n := range m.errors {
+			codes = append(codes, code)
+			errs[code] = n
+		}
+		m.mu.Unlock()
+		sort.Slice(stmts, func(i, j int) bool {
+			return stmts[i].key[0] < stmts[j].key[0] || stmts[i].key[0] == stmts[j].key[0] && stmts[i].key[1] < stmts[j].key[1]
+		})
+		sort.Ints(codes)
+
+		metric("godror_statement_duration_seconds", "histogram", "Duration of the statement executions.")
+		for _, s := range stmts {
+			labels := fmt.Sprintf("op=%q,stmt_hash=%q", s.key[0], s.key[1])
+			for i, le := range metricsBuckets {
+				fmt.Fprintf(bw, "godror_statement_duration_seconds_bucket{%s,le=\"%s\"} %d\n",
+					labels, strconv.FormatFloat(le, 'g', -1, 64), s.sm.buckets[i])
+			}
+			fmt.Fprintf(bw, "godror_statement_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, s.sm.count)
+			fmt.Fprintf(bw, "godror_statement_duration_seconds_sum{%s} %s\n", labels, strconv.FormatFloat(s.sm.sum.Seconds(), 'g', -1, 64))
+			fmt.Fprintf(bw, "godror_statement_duration_seconds_count{%s} %d\n", labels, s.sm.count)
+		}
+		metric("godror_statement_errors_total", "counter", "Failed statement executions.")
+		for _, s := range stmts {
+			fmt.Fprintf(bw, "godror_statement_errors_total{op=%q,stmt_hash=%q} %d\n", s.key[0], s.key[1], s.sm.errors)
+		}
+		metric("godror_errors_total", "counter", "Errors by ORA code (ORA-00000 for the non-Oracle errors).")
+		for _, code := range codes {
+			fmt.Fprintf(bw, "godror_errors_total{code=\"ORA-%05d\"} %d\n", code, errs[code])
+		}
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	d := &drv{}
+	d.metrics.enabled.Store(true)
+	const qry = "SELECT 1 FROM DUAL"
+	d.metrics.observe("query", qry, 3*time.Millisecond, nil)
+	d.metrics.observe("query", qry, 2*time.Second, &OraErr{code: 942})
+	d.metrics.addError(errors.New("not oracle"))
+	d.metrics.rowsFetched.Add(10)
+	d.metrics.cacheHits.Add(2)
+
+	var buf bytes.Buffer
+	n, err := (&MetricsCollector{drv: d}).WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("got %d, wrote %d", n, buf.Len())
+	}
+	out := buf.String()
+	t.Log(out)
+	labels := `op="query",stmt_hash="` + StatementHash(qry) + `"`
+	for _, want := range []string{
+		"# TYPE godror_statement_duration_seconds histogram\n",
+		"godror_statement_duration_seconds_bucket{" + labels + `,le="0.001"} 0` + "\n",
+		"godror_statement_duration_seconds_bucket{" + labels + `,le="0.005"} 1` + "\n",
+		"godror_statement_duration_seconds_bucket{" + labels + `,le="5"} 2` + "\n",
+		"godror_statement_duration_seconds_bucket{" + labels + `,le="+Inf"} 2` + "\n",
+		"godror_statement_duration_seconds_count{" + labels + "} 2\n",
+		"godror_statement_errors_total{" + labels + "} 1\n",
+		`godror_errors_total{code="ORA-00000"} 1` + "\n",
+		`godror_errors_total{code="ORA-00942"} 1` + "\n",
+		"godror_rows_fetched_total 10\n",
+		"godror_stmt_cache_hits_total 2\n",
+		`godror_pool_sessions{state="open"} 0` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+}
+
+func TestMetricsStatementLimit(t *testing.T) {
+	var m driverMetrics
+	for i := 0; i < maxMetricsStatements+10; i++ {
+		m.observe("exec", strings.Repeat("x", i), time.Millisecond, nil)
+	}
+	if len(m.stmts) != maxMetricsStatements+1 {
+		t.Errorf("got %d statements, wanted %d", len(m.stmts), maxMetricsStatements+1)
+	}
+	if sm := m.stmts[[2]string{"exec", "other"}]; sm == nil || sm.count != 10 {
+		t.Errorf("other: got %+v", sm)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// NonFiniteMode says how the NaN and ±Inf values of BINARY_FLOAT and BINARY_DOUBLE are handled.
+type NonFiniteMode uint8
+
+const (
+	// NonFiniteKeep keeps NaN and ±Inf as is - the default.
+	NonFiniteKeep = NonFiniteMode(iota)
+	// NonFiniteError returns ErrNonFinite for NaN and ±Inf.
+	NonFiniteError
+	// NonFiniteNull converts NaN and ±Inf to NULL.
+	NonFiniteNull
+)
+
+// ErrNonFinite is returned for NaN and ±Inf values with NonFiniteError.
+var ErrNonFinite = errors.New("NaN or infinite float")
+
+// NonFiniteFetch returns an option for handling the NaN and ±Inf BINARY_FLOAT/BINARY_DOUBLE values
+// of the fetched rows - for example to not break the JSON serialization of the results.
+//
+// Use it "naked", without sql.Named!
+func NonFiniteFetch(mode NonFiniteMode) Option {
+	return func(o *stmtOptions) { o.nonFiniteFetch = mode }
+}
+
+// NonFiniteBind returns an option for handling the NaN and ±Inf values of the bound
+// float32, float64 and sql.NullFloat64 (and slices of them) parameters.
+// NonFiniteNull applies to IN parameters only, NonFiniteError to IN OUT parameters, too.
+//
+// Use it "naked", without sql.Named!
+func NonFiniteBind(mode NonFiniteMode) Option {
+	return func(o *stmtOptions) { o.nonFiniteBind = mode }
+}
+
+func isNonFinite(f float64) bool { return math.IsNaN(f) || math.IsInf(f, 0) }
+
+// nonFiniteBind returns the value with the NaN and ±Inf values replaced by NULL (NullFloat64),
+// or nil if there is nothing to replace.
+//
+// With NonFiniteError, it returns ErrNonFinite for such values.
+func nonFiniteBind(value interface{}, mode NonFiniteMode) (interface{}, error) {
+	var fs []float64
+	var valids []bool
+	switch x := value.(type) {
+	case float32:
+		fs = []float64{float64(x)}
+	case float64:
+		fs = []float64{x}
+	case sql.NullFloat64:
+		fs, valids = []float64{x.Float64}, []bool{x.Valid}
+	case []float32:
+		fs = make([]float64, len(x))
+		for i, f := range x {
+			fs[i] = float64(f)
+		}
+	case []float64:
+		fs = x
+	case []sql.NullFloat64:
+		fs, valids = make([]float64, len(x)), make([]bool, len(x))
+		for i, f := range x {
+			fs[i], valids[i] = f.Float64, f.Valid
+		}
+	default:
+		return nil, nil
+	}
+	var found bool
+	for i, f := range fs {
+		if (valids == nil || valids[i]) && isNonFinite(f) {
+			if mode == NonFiniteError {
+				return nil, fmt.Errorf("%d. %v: %w", i+1, f, ErrNonFinite)
+			}
+			found = true
+		}
+	}
+	if !found || mode != NonFiniteNull {
+		return nil, nil
+	}
+	nfs := make([]sql.NullFloat64, len(fs))
+	for i, f := range fs {
+		if (valids == nil || valids[i]) && !isNonFinite(f) {
+			nfs[i] = sql.NullFloat64{Float64: f, Valid: true}
+		}
+	}
+	switch value.(type) {
+	case float32, float64, sql.NullFloat64:
+		return nfs[0], nil
+	}
+	return nfs, nil
+}
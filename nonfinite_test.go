@@ -0,0 +1,42 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNonFiniteBind(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(-1)
+	for _, tc := range []struct {
+		In, Want interface{}
+	}{
+		{In: 1.5},
+		{In: "x"},
+		{In: nan, Want: sql.NullFloat64{}},
+		{In: float32(inf), Want: sql.NullFloat64{}},
+		{In: sql.NullFloat64{Float64: nan}},
+		{In: []float64{1, 2}},
+		{In: []float64{1, inf}, Want: []sql.NullFloat64{{Float64: 1, Valid: true}, {}}},
+		{In: []sql.NullFloat64{{Float64: 1, Valid: true}, {Float64: inf, Valid: true}, {}},
+			Want: []sql.NullFloat64{{Float64: 1, Valid: true}, {}, {}}},
+	} {
+		got, err := nonFiniteBind(tc.In, NonFiniteNull)
+		if err != nil {
+			t.Errorf("%#v: %+v", tc.In, err)
+		} else if !reflect.DeepEqual(got, tc.Want) {
+			t.Errorf("%#v: got %#v, wanted %#v", tc.In, got, tc.Want)
+		}
+		_, err = nonFiniteBind(tc.In, NonFiniteError)
+		if wantErr := tc.Want != nil; wantErr != errors.Is(err, ErrNonFinite) {
+			t.Errorf("%#v: got error %+v, wanted error: %t", tc.In, err, wantErr)
+		}
+	}
+}
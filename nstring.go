@@ -0,0 +1,41 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// NString is a string in the national character set (NCHAR, NVARCHAR2, NCLOB).
+//
+// Bound as NString, the value is sent as NVARCHAR2, so characters not representable
+// in the database character set (when that is not AL32UTF8) are not lost
+// before reaching an N-column or a PL/SQL NVARCHAR2 parameter.
+//
+// NCHAR and NVARCHAR2 columns are fetched as string, which can be scanned into an NString.
+type NString string
+
+func (s NString) String() string { return string(s) }
+
+// Scan the (string) value into the NString. NULL is scanned as the empty string.
+func (s *NString) Scan(src interface{}) error {
+	switch x := src.(type) {
+	case nil:
+		*s = ""
+	case string:
+		*s = NString(x)
+	case []byte:
+		*s = NString(x)
+	case NString:
+		*s = x
+	default:
+		return fmt.Errorf("cannot scan %T into NString", src)
+	}
+	return nil
+}
+
+var _ sql.Scanner = (*NString)(nil)
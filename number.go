@@ -7,6 +7,7 @@
 package godror
 
 import (
+	"fmt"
 	"math/big"
 	"strings"
 )
@@ -108,19 +109,227 @@ var _ = decimal((*Number)(nil))
 //
 // NOTE(kardianos): This is an experimental interface. See https://golang.org/issue/30870
 type decimal interface {
-	decimalDecompose
-	decimalCompose
+	DecimalDecomposer
+	DecimalComposer
 }
 
-type decimalDecompose interface {
+// DecimalDecomposer is implemented by the decimal types (e.g. github.com/cockroachdb/apd.Decimal)
+// which can be bound as NUMBER without a round trip through string.
+//
+// The parts are the form (finite=0, infinite=1, NaN=2), the sign, the big-endian
+// base-2 coefficient and the base-10 exponent: (neg) coefficient * 10 ^ exponent.
+//
+// For other decimal libraries (e.g. github.com/shopspring/decimal),
+// implement it (and DecimalComposer) on a wrapper type.
+type DecimalDecomposer interface {
 	// Decompose returns the internal decimal state into parts.
 	// If the provided buf has sufficient capacity, buf may be returned as the coefficient with
 	// the value set and length set as appropriate.
 	Decompose(buf []byte) (form byte, negative bool, coefficient []byte, exponent int32)
 }
 
-type decimalCompose interface {
+// DecimalComposer is implemented by the pointers of the decimal types (e.g. *apd.Decimal)
+// which can be scanned from NUMBER directly, see DecimalDecomposer.
+type DecimalComposer interface {
 	// Compose sets the internal decimal value from parts. If the value cannot be
 	// represented then an error should be returned.
 	Compose(form byte, negative bool, coefficient []byte, exponent int32) error
 }
+
+// BigRat returns the Number as a *big.Rat, without loss of precision.
+func (N Number) BigRat() (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(string(N)))
+	if !ok {
+		return nil, fmt.Errorf("%q is not a number", string(N))
+	}
+	return r, nil
+}
+
+// BigInt returns the Number as a *big.Int, or an error if it has a fractional part.
+func (N Number) BigInt() (*big.Int, error) {
+	r, err := N.BigRat()
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsInt() {
+		return nil, fmt.Errorf("%q is not an integer", string(N))
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// NumberPrec is the precision of big.Float (in bits) needed for the 38 decimal digits of NUMBER.
+const NumberPrec = 128
+
+// BigFloat returns the Number as a *big.Float with the given precision (in bits),
+// NumberPrec if 0.
+func (N Number) BigFloat(prec uint) (*big.Float, error) {
+	if prec == 0 {
+		prec = NumberPrec
+	}
+	f, _, err := big.ParseFloat(strings.TrimSpace(string(N)), 10, prec, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %w", string(N), err)
+	}
+	return f, nil
+}
+
+// NumberFromBigInt returns the *big.Int as a Number.
+func NumberFromBigInt(i *big.Int) Number { return Number(i.String()) }
+
+// NumberFromBigFloat returns the *big.Float as a Number, in decimal (not exponential) form,
+// with the minimal number of digits needed to represent it exactly.
+func NumberFromBigFloat(f *big.Float) Number { return Number(f.Text('f', -1)) }
+
+// NumberFromBigRat returns the *big.Rat as a Number, or an error if it does not have
+// a finite decimal representation (such as 1/3).
+func NumberFromBigRat(r *big.Rat) (Number, error) {
+	prec, exact := r.FloatPrec()
+	if !exact {
+		return "", fmt.Errorf("%s has no finite decimal representation", r)
+	}
+	return Number(r.FloatString(prec)), nil
+}
+
+// Cmp compares N and M, returning -1 if N < M, 0 if N == M and +1 if N > M.
+func (N Number) Cmp(M Number) (int, error) {
+	a, b, err := N.bigRats(M)
+	if err != nil {
+		return 0, err
+	}
+	return a.Cmp(b), nil
+}
+
+// Add returns the exact N + M.
+func (N Number) Add(M Number) (Number, error) {
+	a, b, err := N.bigRats(M)
+	if err != nil {
+		return "", err
+	}
+	return NumberFromBigRat(a.Add(a, b))
+}
+
+// Sub returns the exact N - M.
+func (N Number) Sub(M Number) (Number, error) {
+	a, b, err := N.bigRats(M)
+	if err != nil {
+		return "", err
+	}
+	return NumberFromBigRat(a.Sub(a, b))
+}
+
+// Mul returns the exact N * M.
+func (N Number) Mul(M Number) (Number, error) {
+	a, b, err := N.bigRats(M)
+	if err != nil {
+		return "", err
+	}
+	return NumberFromBigRat(a.Mul(a, b))
+}
+
+// DivPrec is the number of significant digits Div rounds the inexact quotients to, as Oracle NUMBER does.
+const DivPrec = 40
+
+// Div returns N / M, exact if it has a finite decimal representation,
+// rounded (half away from zero) to DivPrec significant digits otherwise.
+func (N Number) Div(M Number) (Number, error) {
+	a, b, err := N.bigRats(M)
+	if err != nil {
+		return "", err
+	}
+	if b.Sign() == 0 {
+		return "", fmt.Errorf("%s / %s: division by zero", string(N), string(M))
+	}
+	q := a.Quo(a, b)
+	if n, err := NumberFromBigRat(q); err == nil {
+		return n, nil
+	}
+	// the number of digits before the decimal point
+	intDigits := len(new(big.Int).Quo(new(big.Int).Abs(q.Num()), q.Denom()).String())
+	if intDigits == 1 && new(big.Int).Abs(q.Num()).Cmp(q.Denom()) < 0 {
+		// 0.000ddd: count the leading zeros of the fraction
+		intDigits = 0
+		for t := new(big.Rat).Abs(q); t.Cmp(big.NewRat(1, 10)) < 0; t.Mul(t, big.NewRat(10, 1)) {
+			intDigits--
+		}
+	}
+	return roundRat(q, DivPrec-intDigits, roundHalfUp), nil
+}
+
+// Sign returns -1, 0 or +1 as N is negative, zero or positive (and 0 if N is not a number).
+func (N Number) Sign() int {
+	r, err := N.BigRat()
+	if err != nil {
+		return 0
+	}
+	return r.Sign()
+}
+
+// IsInt reports whether N is an integer (false if N is not a number).
+func (N Number) IsInt() bool {
+	r, err := N.BigRat()
+	return err == nil && r.IsInt()
+}
+
+// Round returns N rounded half away from zero to places decimal places
+// (to the left of the decimal point if negative), as Oracle's ROUND.
+func (N Number) Round(places int) (Number, error) { return N.round(places, roundHalfUp) }
+
+// RoundHalfEven returns N rounded half to even ("banker's rounding") to places decimal places.
+func (N Number) RoundHalfEven(places int) (Number, error) { return N.round(places, roundHalfEven) }
+
+// Trunc returns N truncated (rounded toward zero) to places decimal places, as Oracle's TRUNC.
+func (N Number) Trunc(places int) (Number, error) { return N.round(places, roundDown) }
+
+type roundingMode uint8
+
+const (
+	roundDown = roundingMode(iota)
+	roundHalfUp
+	roundHalfEven
+)
+
+func (N Number) round(places int, mode roundingMode) (Number, error) {
+	r, err := N.BigRat()
+	if err != nil {
+		return "", err
+	}
+	return roundRat(r, places, mode), nil
+}
+
+// roundRat returns r rounded to places decimal places.
+func roundRat(r *big.Rat, places int, mode roundingMode) Number {
+	shift := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(places))), nil))
+	if places < 0 {
+		shift.Inv(shift)
+	}
+	scaled := new(big.Rat).Mul(r, shift)
+	q, m := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if m.Sign() != 0 && mode != roundDown {
+		// compare 2*|m| with the denominator
+		switch c := new(big.Int).Lsh(new(big.Int).Abs(m), 1).Cmp(scaled.Denom()); {
+		case c > 0, c == 0 && (mode == roundHalfUp || q.Bit(0) == 1):
+			q.Add(q, big.NewInt(int64(scaled.Sign())))
+		}
+	}
+	n, _ := NumberFromBigRat(new(big.Rat).Quo(new(big.Rat).SetInt(q), shift))
+	return n
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+func (N Number) bigRats(M Number) (*big.Rat, *big.Rat, error) {
+	a, err := N.BigRat()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := M.BigRat()
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, b, nil
+}
@@ -42,3 +42,111 @@ func TestNumberDeCompose(t *testing.T) {
 		}
 	}
 }
+
+func TestNumberBig(t *testing.T) {
+	for _, s := range []string{"0", "-2", "3.14", "-0.09", "12345678901234567890123456789012345678", "0.0000000001"} {
+		n := godror.Number(s)
+		r, err := n.BigRat()
+		if err != nil {
+			t.Fatalf("%q: %+v", s, err)
+		}
+		if got, err := godror.NumberFromBigRat(r); err != nil {
+			t.Errorf("%q: %+v", s, err)
+		} else if string(got) != s {
+			t.Errorf("rat: got %q, wanted %q", got, s)
+		}
+		f, err := n.BigFloat(0)
+		if err != nil {
+			t.Fatalf("%q: %+v", s, err)
+		}
+		t.Logf("%q: rat=%s float=%s", s, r, godror.NumberFromBigFloat(f))
+
+		i, err := n.BigInt()
+		if r.IsInt() != (err == nil) {
+			t.Errorf("%q: BigInt error %v, but IsInt=%t", s, err, r.IsInt())
+		} else if err == nil && string(godror.NumberFromBigInt(i)) != s {
+			t.Errorf("int: got %q, wanted %q", godror.NumberFromBigInt(i), s)
+		}
+	}
+	if _, err := godror.Number("x").BigRat(); err == nil {
+		t.Error("wanted error for x")
+	}
+}
+
+func TestNumberArith(t *testing.T) {
+	for _, tc := range []struct {
+		A, B               godror.Number
+		Cmp                int
+		Add, Sub, Mul, Div godror.Number
+	}{
+		{A: "0.1", B: "0.2", Cmp: -1, Add: "0.3", Sub: "-0.1", Mul: "0.02", Div: "0.5"},
+		{A: "19.99", B: "3", Cmp: 1, Add: "22.99", Sub: "16.99", Mul: "59.97",
+			Div: "6.663333333333333333333333333333333333333"},
+		{A: "-1", B: "3", Cmp: -1, Add: "2", Sub: "-4", Mul: "-3",
+			Div: "-0.3333333333333333333333333333333333333333"},
+		{A: "2", B: "0.03", Cmp: 1, Add: "2.03", Sub: "1.97", Mul: "0.06",
+			Div: "66.66666666666666666666666666666666666667"},
+		{A: "1E+3", B: "1000", Cmp: 0, Add: "2000", Sub: "0", Mul: "1000000", Div: "1"},
+	} {
+		if got, err := tc.A.Cmp(tc.B); err != nil || got != tc.Cmp {
+			t.Errorf("%s cmp %s: got %d, %+v, wanted %d", tc.A, tc.B, got, err, tc.Cmp)
+		}
+		for _, op := range []struct {
+			Name string
+			F    func(godror.Number) (godror.Number, error)
+			Want godror.Number
+		}{
+			{"+", tc.A.Add, tc.Add}, {"-", tc.A.Sub, tc.Sub},
+			{"*", tc.A.Mul, tc.Mul}, {"/", tc.A.Div, tc.Div},
+		} {
+			if got, err := op.F(tc.B); err != nil || got != op.Want {
+				t.Errorf("%s %s %s: got %q, %+v, wanted %q", tc.A, op.Name, tc.B, got, err, op.Want)
+			}
+		}
+	}
+	if got, err := godror.Number("1").Div("0"); err == nil {
+		t.Errorf("1/0: got %q, wanted error", got)
+	}
+	if _, err := godror.Number("x").Add("1"); err == nil {
+		t.Error("x+1: wanted error")
+	}
+
+	for n, want := range map[godror.Number]struct {
+		Sign  int
+		IsInt bool
+	}{"0": {0, true}, "-0.5": {-1, false}, "120": {1, true}, "1.0": {1, true}, "x": {0, false}} {
+		if got := n.Sign(); got != want.Sign {
+			t.Errorf("%q.Sign: got %d, wanted %d", n, got, want.Sign)
+		}
+		if got := n.IsInt(); got != want.IsInt {
+			t.Errorf("%q.IsInt: got %t, wanted %t", n, got, want.IsInt)
+		}
+	}
+}
+
+func TestNumberRound(t *testing.T) {
+	for _, tc := range []struct {
+		N                  godror.Number
+		Places             int
+		Round, Even, Trunc godror.Number
+	}{
+		{N: "2.345", Places: 2, Round: "2.35", Even: "2.34", Trunc: "2.34"},
+		{N: "2.355", Places: 2, Round: "2.36", Even: "2.36", Trunc: "2.35"},
+		{N: "-2.345", Places: 2, Round: "-2.35", Even: "-2.34", Trunc: "-2.34"},
+		{N: "2.3451", Places: 2, Round: "2.35", Even: "2.35", Trunc: "2.34"},
+		{N: "1250", Places: -2, Round: "1300", Even: "1200", Trunc: "1200"},
+		{N: "0.5", Places: 0, Round: "1", Even: "0", Trunc: "0"},
+		{N: "-0.4", Places: 0, Round: "0", Even: "0", Trunc: "0"},
+		{N: "7", Places: 3, Round: "7", Even: "7", Trunc: "7"},
+	} {
+		for _, op := range []struct {
+			Name string
+			F    func(int) (godror.Number, error)
+			Want godror.Number
+		}{{"Round", tc.N.Round, tc.Round}, {"RoundHalfEven", tc.N.RoundHalfEven, tc.Even}, {"Trunc", tc.N.Trunc, tc.Trunc}} {
+			if got, err := op.F(tc.Places); err != nil || got != op.Want {
+				t.Errorf("%s(%s, %d): got %q, %+v, wanted %q", op.Name, tc.N, tc.Places, got, err, op.Want)
+			}
+		}
+	}
+}
@@ -99,26 +99,11 @@ func (O *Object) SetAttribute(name string, data *Data) error {
 		}
 		name = try
 	}
-	ctx := context.TODO()
-	logger := getLogger(ctx)
+	logger := getLogger(context.TODO())
 	if logger != nil {
 		logger = logger.With("object", O.Name, "name", name)
 	}
-	if data.NativeTypeNum == 0 {
-		data.NativeTypeNum = attr.NativeTypeNum
-		data.ObjectType = attr.ObjectType
-		data.dpiData.isNull = 1
-		if logger != nil && logger.Enabled(ctx, slog.LevelDebug) {
-			logger.Debug("SetAttribute data.NativeTypeNum from attr", "ntn", data.NativeTypeNum)
-		}
-	}
-
-	// FromJSON
-	if !data.IsNull() && data.NativeTypeNum == C.DPI_NATIVE_TYPE_BYTES && attr.OracleTypeNum == C.DPI_ORACLE_TYPE_DATE {
-		if t, err := time.Parse(time.RFC3339, string(data.GetBytes())); err == nil {
-			data.Set(t)
-		}
-	}
+	prepareSetAttribute(attr, data, logger)
 	if err := O.drv.checkExec(func() C.int {
 		return C.dpiObject_setAttributeValue(O.dpiObject, attr.dpiObjectAttr, data.NativeTypeNum, &data.dpiData)
 	}); err != nil {
@@ -135,6 +120,26 @@ func (O *Object) SetAttribute(name string, data *Data) error {
 	return nil
 }
 
+// prepareSetAttribute prepares data for setting the attribute:
+// sets the type of the untyped (NULL) data, and parses the RFC3339 dates (from JSON).
+func prepareSetAttribute(attr ObjectAttribute, data *Data, logger *slog.Logger) {
+	if data.NativeTypeNum == 0 {
+		data.NativeTypeNum = attr.NativeTypeNum
+		data.ObjectType = attr.ObjectType
+		data.dpiData.isNull = 1
+		if logger != nil && logger.Enabled(context.TODO(), slog.LevelDebug) {
+			logger.Debug("SetAttribute data.NativeTypeNum from attr", "ntn", data.NativeTypeNum)
+		}
+	}
+
+	// FromJSON
+	if !data.IsNull() && data.NativeTypeNum == C.DPI_NATIVE_TYPE_BYTES && attr.OracleTypeNum == C.DPI_ORACLE_TYPE_DATE {
+		if t, err := time.Parse(time.RFC3339, string(data.GetBytes())); err == nil {
+			data.Set(t)
+		}
+	}
+}
+
 // Set is a convenience function to set the named attribute with the given value.
 func (O *Object) Set(name string, v interface{}) error {
 	if data, ok := v.(*Data); ok {
@@ -142,28 +147,40 @@ func (O *Object) Set(name string, v interface{}) error {
 	}
 	d := scratch.Get()
 	defer scratch.Put(d)
-	if err := d.Set(v); err != nil {
+	if err := O.toData(d, name, v); err != nil {
 		return err
 	}
 	return O.SetAttribute(name, d)
 }
 
+// toData sets d to v, with the converter of the named attribute, if registered.
+func (O *Object) toData(d *Data, name string, v interface{}) error {
+	if attr, ok := O.Attributes[name]; ok {
+		if cv := converterOf(attr.ObjectType); cv.fromGo != nil {
+			d.NativeTypeNum, d.ObjectType = attr.NativeTypeNum, attr.ObjectType
+			if err := cv.fromGo(v, d); err != nil {
+				return fmt.Errorf("convert %s[%s]: %w", O.Name, name, err)
+			}
+			return nil
+		}
+	}
+	return d.Set(v)
+}
+
 // ResetAttributes prepare all attributes for use the object as IN parameter
 func (O *Object) ResetAttributes() error {
-	runtime.LockOSThread()
-	defer runtime.UnlockOSThread()
-
 	data := scratch.Get()
 	defer scratch.Put(data)
-	for _, attr := range O.Attributes {
+	var batch attrBatch
+	for name, attr := range O.Attributes {
 		data.reset()
 		data.NativeTypeNum = attr.NativeTypeNum
 		data.ObjectType = attr.ObjectType
-		if C.dpiObject_setAttributeValue(O.dpiObject, attr.dpiObjectAttr, data.NativeTypeNum, &data.dpiData) == C.DPI_FAILURE {
-			return fmt.Errorf("ResetAttributes(%q, ott=%+v, %+v): %w", attr.Name, attr.OracleTypeNum, data, O.drv.getError())
-		}
+		batch.add(name, attr, data)
+	}
+	if err := O.setAttributes(&batch); err != nil {
+		return fmt.Errorf("ResetAttributes: %w", err)
 	}
-
 	return nil
 }
 
@@ -174,8 +191,11 @@ func (O *Object) Get(name string) (interface{}, error) {
 	if err := O.GetAttribute(d, name); err != nil {
 		return nil, err
 	}
-	isObject := d.IsObject()
 	ot := O.Attributes[name].ObjectType
+	if cv := converterOf(ot); cv.toGo != nil {
+		return cv.toGo(d)
+	}
+	isObject := d.IsObject()
 	if isObject {
 		d.ObjectType = ot
 	}
@@ -267,6 +287,7 @@ func (O *Object) Close() error {
 	if err := O.drv.checkExec(func() C.int { return C.dpiObject_release(obj) }); err != nil {
 		return fmt.Errorf("error on close object: %w", err)
 	}
+	resourceCounters.objects.Add(-1)
 
 	return nil
 }
@@ -281,11 +302,24 @@ func (O *Object) AsMap(recursive bool) (map[string]interface{}, error) {
 	}
 	logger := getLogger(context.TODO())
 	m := make(map[string]interface{}, len(O.ObjectType.Attributes))
-	data := scratch.Get()
-	defer scratch.Put(data)
-	for a, ot := range O.ObjectType.Attributes {
-		if err := O.GetAttribute(data, a); err != nil {
-			return m, fmt.Errorf("%q: %w", a, err)
+	names := O.AttributeNames()
+	datas := make([]Data, len(names))
+	batch, err := O.getAttributes(datas, names)
+	if err != nil {
+		return m, err
+	}
+	defer runtime.KeepAlive(batch)
+	for i, a := range names {
+		ot, data := O.ObjectType.Attributes[a], &datas[i]
+		if cv := converterOf(ot.ObjectType); cv.toGo != nil {
+			v, err := cv.toGo(data)
+			if err != nil {
+				return m, fmt.Errorf("%q: %w", a, err)
+			}
+			if v != nil {
+				m[a] = v
+			}
+			continue
 		}
 		d := data.Get()
 		if d == nil {
@@ -354,14 +388,21 @@ func (O *Object) ToJSON(w io.Writer) error {
 			}
 		}
 		fmt.Fprintf(bw, "%q:", a)
-		d := data.Get()
-		if data.IsObject() {
+		ot := O.ObjectType.Attributes[a].ObjectType
+		var d interface{}
+		if cv := converterOf(ot); cv.toGo != nil {
+			var err error
+			if d, err = cv.toGo(data); err != nil {
+				return fmt.Errorf("%q: %w", a, err)
+			}
+		} else if d = data.Get(); data.IsObject() {
 			if err := d.(*Object).ToJSON(bw); err != nil {
 				return fmt.Errorf("%q: %w", a, err)
 			}
 			continue
+		} else {
+			d = maybeString(d, ot)
 		}
-		d = maybeString(d, O.ObjectType.Attributes[a].ObjectType)
 		buf.Reset()
 		if err := enc.Encode(d); err != nil {
 			return fmt.Errorf("%q: %#v: %w", a, d, err)
@@ -452,6 +493,9 @@ func (O *Object) FromMap(recursive bool, m map[string]interface{}) error {
 	}
 	logger := getLogger(context.TODO())
 
+	// the plain typed attributes are set with one cgo call
+	var batch attrBatch
+	datas := make([]Data, 0, len(O.ObjectType.Attributes))
 	for a, ot := range O.ObjectType.Attributes {
 		v := m[a]
 		if v == nil {
@@ -517,10 +561,22 @@ func (O *Object) FromMap(recursive bool, m map[string]interface{}) error {
 			if err != nil {
 				return fmt.Errorf("%q.Set(%v): %w", a, newO.ObjectType, err)
 			}
-		} else if err := O.Set(a, v); err != nil { // Plain type case
-			return err
+		} else { // Plain type case
+			data, ok := v.(*Data)
+			if !ok {
+				datas = append(datas, Data{})
+				data = &datas[len(datas)-1]
+				if err := O.toData(data, a, v); err != nil {
+					return err
+				}
+			}
+			prepareSetAttribute(ot, data, logger)
+			batch.add(a, ot, data)
 		}
 	}
+	if err := O.setAttributes(&batch); err != nil {
+		return fmt.Errorf("%s.FromMap: %w", O.Name, err)
+	}
 	return nil
 }
 
@@ -734,6 +790,14 @@ func (O ObjectCollection) ToJSON(w io.Writer) error {
 			if err = o.ToJSON(bw); err != nil {
 				return err
 			}
+		} else {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return fmt.Errorf("Get(%v): %#v: %w", curr, v, err)
+			}
+			if _, err = bw.Write(b); err != nil {
+				return err
+			}
 		}
 	}
 	return bw.WriteByte(']')
@@ -766,12 +830,24 @@ func (O ObjectCollection) Append(v interface{}) error {
 	}
 	d := scratch.Get()
 	defer scratch.Put(d)
-	if err := d.Set(v); err != nil {
+	if err := O.setElem(d, v); err != nil {
 		return err
 	}
 	return O.AppendData(d)
 }
 
+// setElem sets d from v, with the converter of the element type, if registered.
+func (O ObjectCollection) setElem(d *Data, v interface{}) error {
+	if cv := converterOf(O.CollectionOf); cv.fromGo != nil {
+		d.NativeTypeNum, d.ObjectType = O.CollectionOf.NativeTypeNum, O.CollectionOf
+		if err := cv.fromGo(v, d); err != nil {
+			return fmt.Errorf("convert %s: %w", O.CollectionOf.Name, err)
+		}
+		return nil
+	}
+	return d.Set(v)
+}
+
 // AppendObject adds an Object to the collection.
 func (O ObjectCollection) AppendObject(obj *Object) error {
 	d := scratch.Get()
@@ -827,6 +903,11 @@ func (O ObjectCollection) Get(i int) (interface{}, error) {
 	data := scratch.Get()
 	defer scratch.Put(data)
 	err := O.GetItem(data, i)
+	if err == nil {
+		if cv := converterOf(O.CollectionOf); cv.toGo != nil {
+			return cv.toGo(data)
+		}
+	}
 	return data.Get(), err
 }
 
@@ -847,7 +928,7 @@ func (O ObjectCollection) Set(i int, v interface{}) error {
 	}
 	d := scratch.Get()
 	defer scratch.Put(d)
-	if err := d.Set(v); err != nil {
+	if err := O.setElem(d, v); err != nil {
 		return err
 	}
 	return O.SetItem(i, d)
@@ -966,7 +1047,7 @@ func (t *ObjectType) FullName() string { return t.String() }
 // GetObjectType returns the ObjectType of a name.
 //
 // The name is uppercased! Because here Oracle seems to be case-sensitive.
-// To leave it as is, enclose it in "-s!
+// To leave it as is, enclose it in "-s! (Each part separately, as ParseObjectName parses it.)
 func (c *conn) GetObjectType(name string) (*ObjectType, error) {
 	if name == "" {
 		return nil, errors.New("empty name")
@@ -978,7 +1059,12 @@ func (c *conn) GetObjectType(name string) (*ObjectType, error) {
 	}
 
 	var nameU string
-	if !strings.Contains(name, "\"") {
+	if on, err := ParseObjectName(name); err == nil {
+		if s := on.String(); s != name {
+			nameU = s
+		}
+	} else if !strings.Contains(name, "\"") {
+		// such as PKG.TYPE%ROWTYPE
 		nameU = strings.ToUpper(name)
 	}
 	t := c.objTypes[name]
@@ -1060,6 +1146,7 @@ func (t *ObjectType) NewObject() (*Object, error) {
 		return nil, fmt.Errorf("NewObject(%q [%+v]: %w", t.Name, t, err)
 	}
 	O := &Object{ObjectType: t, dpiObject: obj}
+	resourceCounters.objects.Add(1)
 
 	if warnMissingObjectClose && guardWithFinalizers.Load() {
 		runtime.SetFinalizer(O, func(O *Object) {
@@ -1139,6 +1226,7 @@ func wrapObject(c *conn, objectType *C.dpiObjectType, object *C.dpiObject) (*Obj
 		ObjectType: &ObjectType{dpiObjectType: objectType, drv: c.drv},
 		dpiObject:  object,
 	}
+	resourceCounters.objects.Add(1)
 	c.mu.RLock()
 	err := o.ObjectType.init(c.objTypes)
 	c.mu.RUnlock()
@@ -1363,3 +1451,94 @@ END;`
 	}
 	return fmt.Errorf("%s [%#v]: %w: %w", qry, val, xErr, err)
 }
+
+var collectionTypes sync.Map // reflect.Type -> collection type name
+
+// RegisterCollectionType registers the collection type for slices of the struct type of elem
+// (which has an ObjectTypeName field), so a []T or []*T argument is bound as that collection.
+//
+// The collection type can be given with the "collection" option of the ObjectTypeName field's tag, too:
+//
+//	type Item struct {
+//		godror.ObjectTypeName `godror:"PKG.ITEM_OT,collection=PKG.ITEM_TT"`
+//		ID                    int `godror:"ID"`
+//	}
+func RegisterCollectionType(elem interface{}, collectionTypeName string) {
+	rt := reflect.TypeOf(elem)
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	collectionTypes.Store(rt, collectionTypeName)
+}
+
+// structCollectionTypeName returns the collection type name of the slice of structs type, if known.
+func structCollectionTypeName(rt reflect.Type) string {
+	if rt.Kind() != reflect.Slice {
+		return ""
+	}
+	if rt = rt.Elem(); rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return ""
+	}
+	if v, ok := collectionTypes.Load(rt); ok {
+		return v.(string)
+	}
+	for i, n := 0, rt.NumField(); i < n; i++ {
+		if f := rt.Field(i); fieldIsObjectTypeName(f) {
+			_, _, opts := parseStructTag(f.Tag)
+			return opts["collection"]
+		}
+	}
+	return ""
+}
+
+// structCollections converts the IN slices of structs with a known collection type
+// (see RegisterCollectionType) into collection Objects.
+//
+// The returned function closes those Objects, call it after they've been bound.
+func (c *conn) structCollections(ctx context.Context, args []driver.NamedValue) ([]driver.NamedValue, func(), error) {
+	var objs []*Object
+	closeObjs := func() {
+		for _, o := range objs {
+			_ = o.Close()
+		}
+	}
+	var converted bool
+	for i, a := range args {
+		rv := reflect.ValueOf(a.Value)
+		if rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().Kind() == reflect.Slice {
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Slice {
+			continue
+		}
+		name := structCollectionTypeName(rv.Type())
+		if name == "" {
+			continue
+		}
+		ot, err := c.GetObjectType(name)
+		if err != nil {
+			closeObjs()
+			return args, func() {}, fmt.Errorf("%s: %w", name, err)
+		}
+		if ot.CollectionOf == nil {
+			closeObjs()
+			return args, func() {}, fmt.Errorf("%s: %w", name, ErrNotCollection)
+		}
+		obj := &Object{ObjectType: ot}
+		if !rv.IsNil() {
+			if obj, err = c.dataSetObjectStructObj(ctx, ot, rv); err != nil {
+				closeObjs()
+				return args, func() {}, fmt.Errorf("%d. %s: %w", i, name, err)
+			}
+			objs = append(objs, obj)
+		}
+		if !converted {
+			args, converted = append(make([]driver.NamedValue, 0, len(args)), args...), true
+		}
+		args[i].Value = obj
+	}
+	return args, closeObjs, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include "dpiImpl.h"
+
+// godror_getAttributeValues gets the values of n attributes of the object, with one cgo call.
+// On failure, failed is set to the index of the failed attribute.
+int godror_getAttributeValues(dpiObject *obj, uint32_t n, dpiObjectAttr **attrs,
+		dpiNativeTypeNum *nativeTypeNums, dpiData *values, uint32_t *failed) {
+	for (uint32_t i = 0; i < n; i++) {
+		if (dpiObject_getAttributeValue(obj, attrs[i], nativeTypeNums[i], &values[i]) < 0) {
+			*failed = i;
+			return DPI_FAILURE;
+		}
+	}
+	return DPI_SUCCESS;
+}
+
+// godror_setAttributeValues sets the values of n attributes of the object, with one cgo call.
+// On failure, failed is set to the index of the failed attribute.
+int godror_setAttributeValues(dpiObject *obj, uint32_t n, dpiObjectAttr **attrs,
+		dpiNativeTypeNum *nativeTypeNums, dpiData *values, uint32_t *failed) {
+	for (uint32_t i = 0; i < n; i++) {
+		if (dpiObject_setAttributeValue(obj, attrs[i], nativeTypeNums[i], &values[i]) < 0) {
+			*failed = i;
+			return DPI_FAILURE;
+		}
+	}
+	return DPI_SUCCESS;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// numberBufLen is the length of the buffer of a NUMBER got as bytes.
+const numberBufLen = 39
+
+// attrBatch transfers the values of several attributes of an object
+// with one cgo call, instead of one for each attribute.
+type attrBatch struct {
+	names  []string
+	attrs  []*C.dpiObjectAttr
+	types  []C.dpiNativeTypeNum
+	values []C.dpiData
+	// numBuf holds the buffers of the NUMBERs got as bytes,
+	// and must be kept alive while the values are used.
+	numBuf []byte
+	// keep references the byte values, as the dpiData union hides them from the GC.
+	keep [][]byte
+}
+
+// add appends the attribute with the value of data (copied).
+func (b *attrBatch) add(name string, attr ObjectAttribute, data *Data) {
+	b.names = append(b.names, name)
+	b.attrs = append(b.attrs, attr.dpiObjectAttr)
+	b.types = append(b.types, data.NativeTypeNum)
+	b.values = append(b.values, data.dpiData)
+	if data.NativeTypeNum == C.DPI_NATIVE_TYPE_BYTES && !data.IsNull() {
+		if p := data.dpiDataGetBytesUnsafe(); p.ptr != nil {
+			b.keep = append(b.keep, unsafe.Slice((*byte)(unsafe.Pointer(p.ptr)), p.length))
+		}
+	}
+}
+
+// getAttributes gets the named attributes into data, as GetAttribute does, with one cgo call.
+func (O *Object) getAttributes(data []Data, names []string) (*attrBatch, error) {
+	var b attrBatch
+	var numbers int
+	for _, name := range names {
+		attr, ok := O.Attributes[name]
+		if !ok {
+			return nil, fmt.Errorf("get %s[%s]: %w (have: %q)", O.Name, name, ErrNoSuchKey, O.AttributeNames())
+		}
+		if attr.NativeTypeNum == C.DPI_NATIVE_TYPE_BYTES && attr.OracleTypeNum == C.DPI_ORACLE_TYPE_NUMBER {
+			numbers++
+		}
+	}
+	b.numBuf = make([]byte, numbers*numberBufLen)
+	numbers = 0
+	for i, name := range names {
+		attr := O.Attributes[name]
+		d := &data[i]
+		d.reset()
+		d.NativeTypeNum, d.ObjectType, d.implicitObj = attr.NativeTypeNum, attr.ObjectType, true
+		if O.dpiObject == nil {
+			d.SetNull()
+			continue
+		}
+		// see prepare
+		if attr.NativeTypeNum == C.DPI_NATIVE_TYPE_BYTES && attr.OracleTypeNum == C.DPI_ORACLE_TYPE_NUMBER {
+			C.dpiData_setBytes(&d.dpiData, (*C.char)(unsafe.Pointer(&b.numBuf[numbers*numberBufLen])), numberBufLen)
+			numbers++
+		}
+		b.add(name, attr, d)
+	}
+	if O.dpiObject == nil || len(b.values) == 0 {
+		return &b, nil
+	}
+	var failed C.uint32_t
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	if C.godror_getAttributeValues(O.dpiObject, C.uint32_t(len(b.values)),
+		&b.attrs[0], &b.types[0], &b.values[0], &failed,
+	) == C.DPI_FAILURE {
+		return nil, fmt.Errorf("getAttributeValue(%q, obj=%s, typ=%d): %w", b.names[failed], O.Name, b.types[failed], O.drv.getError())
+	}
+	for i := range b.values {
+		data[i].dpiData = b.values[i]
+	}
+	return &b, nil
+}
+
+// setAttributes sets the attributes added to the batch, with one cgo call.
+func (O *Object) setAttributes(b *attrBatch) error {
+	if len(b.values) == 0 {
+		return nil
+	}
+	var failed C.uint32_t
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	if C.godror_setAttributeValues(O.dpiObject, C.uint32_t(len(b.values)),
+		&b.attrs[0], &b.types[0], &b.values[0], &failed,
+	) == C.DPI_FAILURE {
+		return fmt.Errorf("dpiObject_setAttributeValue(%q) NativeTypeNum=%d: %w", b.names[failed], b.types[failed], O.drv.getError())
+	}
+	return nil
+}
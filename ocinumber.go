@@ -0,0 +1,85 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql/driver"
+	"math"
+	"math/bits"
+)
+
+// ociNumber is an Oracle NUMBER in its internal format:
+// length, exponent and the base-100 mantissa.
+type ociNumber []byte
+
+// parts returns the sign, the base-100 digits (into buf) and the base-100 exponent of the first digit.
+// inf is -1 or +1 for the infinities.
+func (b ociNumber) parts(buf *[20]byte) (negative bool, digits []byte, exponent, inf int) {
+	if len(b) < 2 || b[0] == 0 {
+		return false, nil, 0, 0
+	}
+	n := int(b[0])
+	if n > len(b)-1 {
+		n = len(b) - 1
+	}
+	exp, mant := b[1], b[2:1+n]
+	negative = exp&0x80 == 0
+	switch {
+	case exp == 0x80 && len(mant) == 0: // zero
+		return false, nil, 0, 0
+	case negative && exp == 0 && len(mant) == 0:
+		return true, nil, 0, -1
+	case exp == 0xff && len(mant) == 1 && mant[0] == 101:
+		return false, nil, 0, 1
+	}
+	if negative {
+		exp = ^exp
+		if len(mant) != 0 && mant[len(mant)-1] == 102 {
+			mant = mant[:len(mant)-1]
+		}
+	}
+	digits = buf[:0]
+	for _, m := range mant {
+		if negative {
+			digits = append(digits, 101-m)
+		} else {
+			digits = append(digits, m-1)
+		}
+	}
+	return negative, digits, int(exp&0x7f) - 65, 0
+}
+
+// integer returns the NUMBER as int64 (or uint64 if it only fits into that),
+// and false if it is not integral, or does not fit.
+func (b ociNumber) integer() (driver.Value, bool) {
+	var buf [20]byte
+	negative, digits, exponent, inf := b.parts(&buf)
+	if inf != 0 || len(digits) > exponent+1 {
+		return nil, false
+	}
+	var u uint64
+	for i := 0; i <= exponent; i++ {
+		var d uint64
+		if i < len(digits) {
+			d = uint64(digits[i])
+		}
+		hi, lo := bits.Mul64(u, 100)
+		var carry uint64
+		u, carry = bits.Add64(lo, d, 0)
+		if hi != 0 || carry != 0 {
+			return nil, false
+		}
+	}
+	switch {
+	case !negative && u <= math.MaxInt64:
+		return int64(u), true
+	case !negative:
+		return u, true
+	case u <= 1<<63:
+		return -int64(u-1) - 1, true
+	}
+	return nil, false
+}
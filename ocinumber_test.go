@@ -0,0 +1,61 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql/driver"
+	"math"
+	"testing"
+)
+
+func TestOCINumberInteger(t *testing.T) {
+	for _, tc := range []struct {
+		Want driver.Value
+		B    ociNumber
+		OK   bool
+	}{
+		{B: ociNumber{1, 0x80}, Want: int64(0), OK: true},
+		{B: ociNumber{2, 0xc1, 2}, Want: int64(1), OK: true},
+		{B: ociNumber{2, 0xc3, 2}, Want: int64(10000), OK: true},
+		{B: ociNumber{3, 0xc2, 2, 24}, Want: int64(123), OK: true},
+		{B: ociNumber{4, 0x3d, 100, 78, 102}, Want: int64(-123), OK: true},
+		// 9223372036854775807
+		{B: ociNumber{11, 0xca, 10, 23, 34, 73, 4, 69, 55, 78, 59, 8}, Want: int64(math.MaxInt64), OK: true},
+		// -9223372036854775808
+		{B: ociNumber{12, 0x35, 92, 79, 68, 29, 98, 33, 47, 24, 43, 93, 102}, Want: int64(math.MinInt64), OK: true},
+		// 18446744073709551615
+		{B: ociNumber{11, 0xca, 19, 45, 68, 45, 8, 38, 10, 56, 17, 16}, Want: uint64(math.MaxUint64), OK: true},
+		// 18446744073709551616
+		{B: ociNumber{11, 0xca, 19, 45, 68, 45, 8, 38, 10, 56, 17, 17}},
+		// 1.5
+		{B: ociNumber{3, 0xc1, 2, 51}},
+		{B: ociNumber{2, 0xff, 101}},
+	} {
+		got, ok := tc.B.integer()
+		if ok != tc.OK || got != tc.Want {
+			t.Errorf("%v: got %#v/%t, wanted %#v/%t", []byte(tc.B), got, ok, tc.Want, tc.OK)
+		}
+	}
+}
+
+var benchSink driver.Value
+
+func BenchmarkOCINumberInteger(b *testing.B) {
+	on := ociNumber{6, 0xc5, 13, 35, 57, 79, 91} // 1234567890
+	b.Run("ociNumber", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchSink, _ = on.integer()
+		}
+	})
+	buf := []byte("1234567890")
+	b.Run("Number", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchSink = Number(buf)
+		}
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"math"
 	"strconv"
 	"strings"
@@ -174,7 +175,7 @@ func (numType) ConvertValue(v interface{}) (driver.Value, error) {
 		return strconv.FormatFloat(float64(x), 'f', -1, 32), nil
 	case float64:
 		return strconv.FormatFloat(x, 'f', -1, 64), nil
-	case decimalDecompose:
+	case DecimalDecomposer:
 		if x == nil {
 			return "", fmt.Errorf("nil Decomposer")
 		}
@@ -227,7 +228,7 @@ func (n *Number) Scan(v interface{}) error {
 		*n = Number(strconv.FormatFloat(float64(x), 'f', -1, 32))
 	case float64:
 		*n = Number(strconv.FormatFloat(x, 'f', -1, 64))
-	case decimalDecompose:
+	case DecimalDecomposer:
 		return n.Compose(x.Decompose(nil))
 	default:
 		return fmt.Errorf("%T: %w", v, errUnknownType)
@@ -340,6 +341,68 @@ func DescribeQuery(ctx context.Context, db Execer, qry string) ([]QueryColumn, e
 	return cols, err
 }
 
+// QueryMap executes the query and yields each row as a map of column name to value,
+// as the driver returns them (Number, time.Time, string, []byte...), with the error.
+//
+// Each row is a new map, so it can be retained.
+// On error, (nil, err) is yielded as the last element.
+func QueryMap(ctx context.Context, q Querier, qry string, args ...interface{}) iter.Seq2[map[string]interface{}, error] {
+	return func(yield func(map[string]interface{}, error) bool) {
+		rows, err := q.QueryContext(ctx, qry, args...)
+		if err != nil {
+			yield(nil, fmt.Errorf("%s: %w", qry, err))
+			return
+		}
+		defer rows.Close()
+		columns, err := rows.Columns()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		values := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		for rows.Next() {
+			if err = rows.Scan(dest...); err != nil {
+				yield(nil, err)
+				return
+			}
+			m := make(map[string]interface{}, len(columns))
+			for i, c := range columns {
+				m[c] = values[i]
+			}
+			if !yield(m, nil) {
+				return
+			}
+		}
+		if err = rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// ForEachRow executes the query and calls f for each row, with a scan function for the row.
+//
+// The rows are closed when f returns an error (which is returned), or when all rows are consumed.
+func ForEachRow(ctx context.Context, q Querier, qry string, args []interface{}, f func(scan func(dest ...interface{}) error) error) error {
+	rows, err := q.QueryContext(ctx, qry, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err = f(rows.Scan); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
 // CompileError represents a compile-time error as in user_errors view.
 type CompileError struct {
 	Owner, Name, Type, Text string
@@ -359,6 +422,8 @@ func (ce CompileError) Error() string {
 // GetCompileErrors returns the slice of the errors in user_errors.
 //
 // If all is false, only errors are returned; otherwise, warnings, too.
+//
+// See GetObjectCompileErrors for the errors of one object.
 func GetCompileErrors(ctx context.Context, queryer Querier, all bool) ([]CompileError, error) {
 	if queryer == nil {
 		return nil, fmt.Errorf("nil queryer")
@@ -0,0 +1,173 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// QuerySplit is a piece of a query, to be run by ParallelQuery.
+type QuerySplit struct {
+	Query string
+	Args  []interface{}
+}
+
+// PartitionSplits returns a QuerySplit for each partition of the table,
+// selecting the given columns ("*" if empty).
+//
+// A non-partitioned table results in one split of the whole table.
+func PartitionSplits(ctx context.Context, q Querier, table, columns string) ([]QuerySplit, error) {
+	if columns == "" {
+		columns = "*"
+	}
+	owner, name := splitTableName(table)
+	const qry = `SELECT partition_name FROM all_tab_partitions
+  WHERE table_owner = NVL(:owner, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')) AND table_name = :name
+  ORDER BY partition_position`
+	rows, err := q.QueryContext(ctx, qry, sql.Named("owner", owner), sql.Named("name", name))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	var splits []QuerySplit
+	for rows.Next() {
+		var part string
+		if err = rows.Scan(&part); err != nil {
+			return splits, err
+		}
+		splits = append(splits, QuerySplit{
+			Query: "SELECT " + columns + " FROM " + table + ` PARTITION ("` + part + `")`,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return splits, err
+	}
+	if len(splits) == 0 {
+		splits = append(splits, QuerySplit{Query: "SELECT " + columns + " FROM " + table})
+	}
+	return splits, rows.Close()
+}
+
+// RowidSplits splits the table into (at most) n ROWID ranges of roughly the same number of blocks,
+// based on the extents of the table segments, selecting the given columns ("*" if empty).
+//
+// The table must be in the current schema, as user_extents is used.
+func RowidSplits(ctx context.Context, q Querier, table, columns string, n int) ([]QuerySplit, error) {
+	if columns == "" {
+		columns = "*"
+	}
+	if n < 1 {
+		n = 1
+	}
+	_, name := splitTableName(table)
+	// the bounds are per segment (data_object_id), as a ROWID range cannot span segments
+	const qry = `SELECT grp, DBMS_ROWID.rowid_create(1, data_object_id, lo_fno, lo_block, 0) AS lo_rid,
+       DBMS_ROWID.rowid_create(1, data_object_id, hi_fno, hi_block, 32767) AS hi_rid
+  FROM (SELECT grp, data_object_id,
+               MIN(relative_fno) KEEP (DENSE_RANK FIRST ORDER BY relative_fno, block_id) AS lo_fno,
+               MIN(block_id) KEEP (DENSE_RANK FIRST ORDER BY relative_fno, block_id) AS lo_block,
+               MAX(relative_fno) KEEP (DENSE_RANK LAST ORDER BY relative_fno, block_id) AS hi_fno,
+               MAX(block_id + blocks - 1) KEEP (DENSE_RANK LAST ORDER BY relative_fno, block_id) AS hi_block
+          FROM (SELECT o.data_object_id, e.relative_fno, e.block_id, e.blocks,
+                       NTILE(:n) OVER (ORDER BY o.data_object_id, e.relative_fno, e.block_id) AS grp
+                  FROM user_extents e, user_objects o
+                  WHERE e.segment_name = :name AND o.object_name = e.segment_name AND
+                        NVL(o.subobject_name, '-') = NVL(e.partition_name, '-') AND
+                        o.object_type LIKE 'TABLE%' AND o.data_object_id IS NOT NULL)
+          GROUP BY grp, data_object_id)
+  ORDER BY grp, data_object_id`
+	rows, err := q.QueryContext(ctx, qry, sql.Named("n", n), sql.Named("name", name))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	var splits []QuerySplit
+	var where strings.Builder
+	lastGrp := int64(-1)
+	for rows.Next() {
+		var grp int64
+		var lo, hi string
+		if err = rows.Scan(&grp, &lo, &hi); err != nil {
+			return splits, err
+		}
+		if grp != lastGrp {
+			lastGrp = grp
+			splits = append(splits, QuerySplit{})
+			where.Reset()
+		}
+		// a group spanning several segments has a range for each
+		split := &splits[len(splits)-1]
+		if len(split.Args) != 0 {
+			where.WriteString(" OR ")
+		}
+		fmt.Fprintf(&where, "ROWID BETWEEN CHARTOROWID(:%d) AND CHARTOROWID(:%d)", len(split.Args)+1, len(split.Args)+2)
+		split.Args = append(split.Args, lo, hi)
+		split.Query = "SELECT " + columns + " FROM " + table + " WHERE " + where.String()
+	}
+	if err = rows.Err(); err != nil {
+		return splits, err
+	}
+	if len(splits) == 0 {
+		splits = append(splits, QuerySplit{Query: "SELECT " + columns + " FROM " + table})
+	}
+	return splits, rows.Close()
+}
+
+// splitTableName splits the (possibly schema qualified) table name, uppercasing the unquoted parts.
+func splitTableName(table string) (owner, name string) {
+	name = table
+	if i := strings.LastIndexByte(table, '.'); i >= 0 {
+		owner, name = table[:i], table[i+1:]
+	}
+	unquote := func(s string) string {
+		if len(s) > 1 && s[0] == '"' && s[len(s)-1] == '"' {
+			return s[1 : len(s)-1]
+		}
+		return strings.ToUpper(s)
+	}
+	if owner != "" {
+		owner = unquote(owner)
+	}
+	return owner, unquote(name)
+}
+
+// ParallelQuery runs the splits concurrently, at most concurrency (the number of splits by default) at once,
+// and calls f for each row of each split, with a scan function to read the row.
+//
+// The calls of f are serialized, so f needs no synchronization, but the rows of the splits are interleaved.
+// The first error (of a query or f) stops all the queries.
+//
+// q should be an *sql.DB, to run each split on its own pooled connection.
+func ParallelQuery(ctx context.Context, q Querier, splits []QuerySplit, concurrency int, f func(split int, scan func(dest ...interface{}) error) error) error {
+	if concurrency <= 0 || concurrency > len(splits) {
+		concurrency = len(splits)
+	}
+	var mu sync.Mutex
+	grp, grpCtx := errgroup.WithContext(ctx)
+	grp.SetLimit(concurrency)
+	for i, split := range splits {
+		if grpCtx.Err() != nil {
+			break
+		}
+		grp.Go(func() error {
+			return ForEachRow(grpCtx, q, split.Query, split.Args, func(scan func(dest ...interface{}) error) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if err := grpCtx.Err(); err != nil {
+					return err
+				}
+				return f(i, scan)
+			})
+		})
+	}
+	return grp.Wait()
+}
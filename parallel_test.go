@@ -0,0 +1,22 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import "testing"
+
+func TestSplitTableName(t *testing.T) {
+	for _, tc := range []struct {
+		in, owner, name string
+	}{
+		{"tbl", "", "TBL"},
+		{"scott.emp", "SCOTT", "EMP"},
+		{`"Scott"."Mixed"`, "Scott", "Mixed"},
+	} {
+		if owner, name := splitTableName(tc.in); owner != tc.owner || name != tc.name {
+			t.Errorf("%q: got %q.%q, wanted %q.%q", tc.in, owner, name, tc.owner, tc.name)
+		}
+	}
+}
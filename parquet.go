@@ -0,0 +1,316 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// ParquetType is the Parquet (logical) type of a column.
+type ParquetType uint8
+
+const (
+	// ParquetString is a BYTE_ARRAY with STRING logical type, the value is a string.
+	ParquetString = ParquetType(iota)
+	// ParquetBytes is a plain BYTE_ARRAY, the value is a []byte.
+	ParquetBytes
+	// ParquetInt64 is an INT64, the value is an int64.
+	ParquetInt64
+	// ParquetFloat is a FLOAT, the value is a float32.
+	ParquetFloat
+	// ParquetDouble is a DOUBLE, the value is a float64.
+	ParquetDouble
+	// ParquetDecimal is a DECIMAL(Precision, Scale), the value is the unscaled *big.Int.
+	ParquetDecimal
+	// ParquetTimestamp is a TIMESTAMP(MICROS), adjusted to UTC; the value is a time.Time.
+	ParquetTimestamp
+	// ParquetBool is a BOOLEAN, the value is a bool.
+	ParquetBool
+)
+
+func (t ParquetType) String() string {
+	switch t {
+	case ParquetString:
+		return "STRING"
+	case ParquetBytes:
+		return "BYTE_ARRAY"
+	case ParquetInt64:
+		return "INT64"
+	case ParquetFloat:
+		return "FLOAT"
+	case ParquetDouble:
+		return "DOUBLE"
+	case ParquetDecimal:
+		return "DECIMAL"
+	case ParquetTimestamp:
+		return "TIMESTAMP"
+	case ParquetBool:
+		return "BOOLEAN"
+	default:
+		return fmt.Sprintf("ParquetType(%d)", uint8(t))
+	}
+}
+
+// ParquetColumn is the Parquet schema of a result column.
+type ParquetColumn struct {
+	Name             string
+	Type             ParquetType
+	Precision, Scale int // for ParquetDecimal
+	Nullable         bool
+}
+
+// ParquetWriter is what ExportParquet writes the rows into.
+//
+// It is easy to implement over any Parquet library, this package does not depend on any.
+type ParquetWriter interface {
+	// WriteRows writes the rows: each row has a value for each column of the schema,
+	// of the type described at ParquetType, or nil for NULL.
+	WriteRows(rows [][]interface{}) error
+	// Flush ends the current row group.
+	Flush() error
+}
+
+// ParquetOptions are the options of ExportParquet.
+type ParquetOptions struct {
+	// RowGroupRows is the number of rows in a row group; 64Ki if zero.
+	RowGroupRows int
+	// BatchRows is the number of rows given to one WriteRows call; 1024 if zero.
+	BatchRows int
+	// NumberScale is the scale of the DECIMAL(38, NumberScale) used for NUMBER columns
+	// without precision (such as computed columns).
+	NumberScale int
+}
+
+// ExportParquet executes the query and writes its rows into the ParquetWriter returned by newWriter,
+// called with the schema derived from the result columns:
+//
+//   - NUMBER(p,s) is DECIMAL(p,s), NUMBER(p) with p <= 18 is INT64, NUMBER and FLOAT is DECIMAL(38,NumberScale)
+//   - BINARY_FLOAT and BINARY_DOUBLE are FLOAT and DOUBLE
+//   - DATE and TIMESTAMPs are TIMESTAMP(MICROS), adjusted to UTC
+//   - RAW, LONG RAW and BLOB are BYTE_ARRAY
+//   - everything else is STRING
+//
+// It returns the number of rows written. NUMBER values not fitting into the scale result in an error,
+// as silently losing digits is not an option.
+func ExportParquet(ctx context.Context, q Querier, newWriter func([]ParquetColumn) (ParquetWriter, error), opts ParquetOptions, qry string, args ...interface{}) (int64, error) {
+	if opts.RowGroupRows <= 0 {
+		opts.RowGroupRows = 1 << 16
+	}
+	if opts.BatchRows <= 0 {
+		opts.BatchRows = 1024
+	}
+	if opts.BatchRows > opts.RowGroupRows {
+		opts.BatchRows = opts.RowGroupRows
+	}
+	rows, err := q.QueryContext(ctx, qry, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	cts, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	schema := make([]ParquetColumn, len(cts))
+	for i, ct := range cts {
+		precision, scale, _ := ct.DecimalSize()
+		nullable, _ := ct.Nullable()
+		schema[i] = parquetColumn(ct.Name(), ct.DatabaseTypeName(), int(precision), int(scale), nullable, opts.NumberScale)
+	}
+	w, err := newWriter(schema)
+	if err != nil {
+		return 0, err
+	}
+
+	dest := make([]interface{}, len(schema))
+	for i, col := range schema {
+		switch col.Type {
+		case ParquetString, ParquetDecimal:
+			dest[i] = new(sql.NullString)
+		case ParquetBytes:
+			dest[i] = new([]byte)
+		case ParquetInt64:
+			dest[i] = new(sql.NullInt64)
+		case ParquetFloat, ParquetDouble:
+			dest[i] = new(sql.NullFloat64)
+		case ParquetTimestamp:
+			dest[i] = new(sql.NullTime)
+		case ParquetBool:
+			dest[i] = new(sql.NullBool)
+		}
+	}
+	var n int64
+	var inGroup int
+	batch := make([][]interface{}, 0, opts.BatchRows)
+	flushBatch := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := w.WriteRows(batch)
+		batch = batch[:0]
+		return err
+	}
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return n, err
+		}
+		row := make([]interface{}, len(schema))
+		for i, col := range schema {
+			if row[i], err = parquetValue(col, dest[i]); err != nil {
+				return n, fmt.Errorf("%s: %w", col.Name, err)
+			}
+		}
+		batch = append(batch, row)
+		n++
+		inGroup++
+		if len(batch) == cap(batch) {
+			if err = flushBatch(); err != nil {
+				return n, err
+			}
+		}
+		if inGroup == opts.RowGroupRows {
+			if err = w.Flush(); err != nil {
+				return n, err
+			}
+			inGroup = 0
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return n, err
+	}
+	if err = flushBatch(); err != nil {
+		return n, err
+	}
+	if inGroup != 0 {
+		err = w.Flush()
+	}
+	return n, err
+}
+
+// parquetColumn returns the Parquet schema of a column of the given database type.
+func parquetColumn(name, dbType string, precision, scale int, nullable bool, numberScale int) ParquetColumn {
+	col := ParquetColumn{Name: name, Type: ParquetString, Nullable: nullable}
+	switch {
+	case dbType == "NUMBER":
+		switch {
+		case precision == 0 || scale == -127: // NUMBER, FLOAT
+			col.Type, col.Precision, col.Scale = ParquetDecimal, 38, numberScale
+		case scale == 0 && precision <= 18:
+			col.Type = ParquetInt64
+		default:
+			col.Type, col.Precision, col.Scale = ParquetDecimal, precision, scale
+		}
+	case dbType == "FLOAT":
+		col.Type = ParquetFloat
+	case dbType == "DOUBLE":
+		col.Type = ParquetDouble
+	case dbType == "BINARY_INTEGER":
+		col.Type = ParquetInt64
+	case dbType == "DATE", strings.HasPrefix(dbType, "TIMESTAMP"):
+		col.Type = ParquetTimestamp
+	case dbType == "RAW", dbType == "LONG RAW", dbType == "BLOB":
+		col.Type = ParquetBytes
+	case dbType == "BOOLEAN":
+		col.Type = ParquetBool
+	}
+	return col
+}
+
+// parquetValue converts the scanned value to the type of the Parquet column.
+func parquetValue(col ParquetColumn, v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case *sql.NullString:
+		if !x.Valid {
+			return nil, nil
+		}
+		if col.Type == ParquetDecimal {
+			return decimalUnscaled(x.String, col.Scale)
+		}
+		return x.String, nil
+	case *[]byte:
+		if *x == nil {
+			return nil, nil
+		}
+		return append([]byte(nil), *x...), nil
+	case *sql.NullInt64:
+		if !x.Valid {
+			return nil, nil
+		}
+		return x.Int64, nil
+	case *sql.NullFloat64:
+		if !x.Valid {
+			return nil, nil
+		}
+		if col.Type == ParquetFloat {
+			return float32(x.Float64), nil
+		}
+		return x.Float64, nil
+	case *sql.NullTime:
+		if !x.Valid {
+			return nil, nil
+		}
+		return x.Time.UTC().Truncate(time.Microsecond), nil
+	case *sql.NullBool:
+		if !x.Valid {
+			return nil, nil
+		}
+		return x.Bool, nil
+	}
+	return nil, fmt.Errorf("unknown scan type %T", v)
+}
+
+var errDecimalScale = errors.New("number does not fit into the scale")
+
+// decimalUnscaled returns the unscaled value of the decimal number string s, with the given scale.
+func decimalUnscaled(s string, scale int) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	var neg bool
+	if strings.HasPrefix(s, "-") {
+		neg, s = true, s[1:]
+	}
+	var exp int
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		if _, err = fmt.Sscanf(s[i+1:], "%d", &exp); err != nil {
+			return nil, fmt.Errorf("%q: %w", s, err)
+		}
+		s = s[:i]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	digits := intPart + frac
+	exp -= len(frac)
+	// value = digits * 10^exp, we need digits * 10^(exp+scale)
+	switch shift := exp + scale; {
+	case shift > 0:
+		digits += strings.Repeat("0", shift)
+	case shift < 0:
+		cut := len(digits) + shift
+		if cut < 0 {
+			cut = 0
+		}
+		if strings.TrimLeft(digits[cut:], "0") != "" {
+			return nil, fmt.Errorf("%s: scale %d: %w", s, scale, errDecimalScale)
+		}
+		digits = digits[:cut]
+	}
+	if digits == "" {
+		digits = "0"
+	}
+	var z big.Int
+	if _, ok := z.SetString(digits, 10); !ok {
+		return nil, fmt.Errorf("%q is not a number", s)
+	}
+	if neg {
+		z.Neg(&z)
+	}
+	return &z, nil
+}
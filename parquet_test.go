@@ -0,0 +1,61 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParquetColumn(t *testing.T) {
+	for _, tc := range []struct {
+		dbType           string
+		precision, scale int
+		want             ParquetColumn
+	}{
+		{"NUMBER", 10, 0, ParquetColumn{Type: ParquetInt64}},
+		{"NUMBER", 20, 0, ParquetColumn{Type: ParquetDecimal, Precision: 20}},
+		{"NUMBER", 10, 2, ParquetColumn{Type: ParquetDecimal, Precision: 10, Scale: 2}},
+		{"NUMBER", 0, -127, ParquetColumn{Type: ParquetDecimal, Precision: 38, Scale: 4}},
+		{"NUMBER", 126, -127, ParquetColumn{Type: ParquetDecimal, Precision: 38, Scale: 4}},
+		{"DATE", 0, 0, ParquetColumn{Type: ParquetTimestamp}},
+		{"TIMESTAMP WITH TIME ZONE", 0, 0, ParquetColumn{Type: ParquetTimestamp}},
+		{"DOUBLE", 0, 0, ParquetColumn{Type: ParquetDouble}},
+		{"RAW", 0, 0, ParquetColumn{Type: ParquetBytes}},
+		{"VARCHAR2", 0, 0, ParquetColumn{Type: ParquetString}},
+	} {
+		if got := parquetColumn("", tc.dbType, tc.precision, tc.scale, false, 4); got != tc.want {
+			t.Errorf("%s(%d,%d): got %+v, wanted %+v", tc.dbType, tc.precision, tc.scale, got, tc.want)
+		}
+	}
+}
+
+func TestDecimalUnscaled(t *testing.T) {
+	for _, tc := range []struct {
+		in    string
+		scale int
+		want  string
+	}{
+		{"0", 2, "0"},
+		{"12.3", 2, "1230"},
+		{"-12.34", 2, "-1234"},
+		{".5", 1, "5"},
+		{"1.50", 1, "15"},
+		{"1E+3", 0, "1000"},
+		{"1.25E-2", 4, "125"},
+		{"123456789012345678901234567890", 0, "123456789012345678901234567890"},
+	} {
+		got, err := decimalUnscaled(tc.in, tc.scale)
+		if err != nil {
+			t.Errorf("%q: %+v", tc.in, err)
+		} else if got.String() != tc.want {
+			t.Errorf("%q,%d: got %s, wanted %s", tc.in, tc.scale, got, tc.want)
+		}
+	}
+	if _, err := decimalUnscaled("1.234", 2); !errors.Is(err, errDecimalScale) {
+		t.Errorf("1.234 with scale 2: got %+v, wanted %v", err, errDecimalScale)
+	}
+}
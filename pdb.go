@@ -0,0 +1,155 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/godror/godror/dsn"
+)
+
+// OpenMode is the open mode of a database (V$DATABASE.OPEN_MODE)
+// or a pluggable database (V$PDBS.OPEN_MODE).
+type OpenMode string
+
+const (
+	OpenModeMounted   = OpenMode("MOUNTED")
+	OpenModeReadWrite = OpenMode("READ WRITE")
+	OpenModeReadOnly  = OpenMode("READ ONLY")
+	OpenModeMigrate   = OpenMode("MIGRATE")
+)
+
+// AllPDBs names all the pluggable databases for OpenPDB, ClosePDB and StartupOptions.PDBs.
+const AllPDBs = "ALL"
+
+// StartupOptions are the options of StartupDatabaseWithOptions.
+type StartupOptions struct {
+	// Pfile is the path of the parameter file on the client,
+	// which may name the SPFILE on the server with a single SPFILE=... line.
+	// The default parameter file of the instance is used if empty.
+	Pfile string
+	// PDBs are the pluggable databases to open after the container database, AllPDBs for all.
+	PDBs []string
+	Mode StartupMode
+	// ReadOnly opens the database and the pluggable databases read only.
+	ReadOnly bool
+}
+
+// OpenModes are the open modes of the database and its pluggable databases.
+type OpenModes struct {
+	// PDBs is empty for a non-CDB database.
+	PDBs     map[string]OpenMode
+	Database OpenMode
+}
+
+// StartupDatabaseWithOptions starts the database instance (with the parameter file, if given),
+// mounts and opens it, then opens the given pluggable databases,
+// and returns the resulting open modes.
+//
+// P.AdminRole defaults to SysDBA.
+func StartupDatabaseWithOptions(ctx context.Context, P dsn.ConnectionParams, opts StartupOptions) (OpenModes, error) {
+	var modes OpenModes
+	err := startupDatabase(ctx, P, opts, func(db *sql.DB) error {
+		for _, pdb := range opts.PDBs {
+			if err := OpenPDB(ctx, db, pdb, opts.ReadOnly); err != nil {
+				return err
+			}
+		}
+		var err error
+		modes, err = GetOpenModes(ctx, db)
+		return err
+	})
+	return modes, err
+}
+
+// OpenPDB opens the pluggable database (AllPDBs for all), like
+// "ALTER PLUGGABLE DATABASE name OPEN [READ ONLY]".
+// An already open pluggable database is not an error.
+//
+// This must be called in the root container (CDB$ROOT) or in the pluggable database itself.
+func OpenPDB(ctx context.Context, ex Execer, name string, readOnly bool) error {
+	pdb, err := pdbName(name)
+	if err != nil {
+		return err
+	}
+	qry := "ALTER PLUGGABLE DATABASE " + pdb + " OPEN"
+	if readOnly {
+		qry += " READ ONLY"
+	}
+	if _, err = ex.ExecContext(ctx, qry); err != nil {
+		// ORA-65019: pluggable database already open
+		if ec, ok := AsOraErr(err); ok && ec.Code() == 65019 {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return nil
+}
+
+// ClosePDB closes the pluggable database (AllPDBs for all), like
+// "ALTER PLUGGABLE DATABASE name CLOSE [IMMEDIATE]".
+// Without immediate, it waits for the sessions of the pluggable database to disconnect.
+func ClosePDB(ctx context.Context, ex Execer, name string, immediate bool) error {
+	pdb, err := pdbName(name)
+	if err != nil {
+		return err
+	}
+	qry := "ALTER PLUGGABLE DATABASE " + pdb + " CLOSE"
+	if immediate {
+		qry += " IMMEDIATE"
+	}
+	if _, err = ex.ExecContext(ctx, qry); err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return nil
+}
+
+// GetOpenModes returns the open mode of the database, and of its pluggable databases
+// visible from the current container.
+func GetOpenModes(ctx context.Context, q Querier) (OpenModes, error) {
+	var modes OpenModes
+	// the database's row has NULL name
+	const qry = "SELECT NULL, open_mode FROM v$database UNION ALL SELECT name, open_mode FROM v$pdbs"
+	rows, err := q.QueryContext(ctx, qry)
+	if err != nil {
+		return modes, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name sql.NullString
+		var mode OpenMode
+		if err = rows.Scan(&name, &mode); err != nil {
+			return modes, fmt.Errorf("%s: %w", qry, err)
+		}
+		if !name.Valid {
+			modes.Database = mode
+			continue
+		}
+		if modes.PDBs == nil {
+			modes.PDBs = make(map[string]OpenMode)
+		}
+		modes.PDBs[name.String] = mode
+	}
+	if err = rows.Err(); err != nil {
+		return modes, fmt.Errorf("%s: %w", qry, err)
+	}
+	return modes, rows.Close()
+}
+
+// pdbName returns the name of the pluggable database usable in SQL.
+func pdbName(name string) (string, error) {
+	if strings.EqualFold(name, AllPDBs) {
+		return AllPDBs, nil
+	}
+	p, err := normalizeIdentifier(name)
+	if err != nil {
+		return "", fmt.Errorf("pluggable database %q: %w", name, err)
+	}
+	return quoteIfNeeded(p), nil
+}
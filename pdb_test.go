@@ -0,0 +1,33 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPDBName(t *testing.T) {
+	for in, want := range map[string]string{
+		"all":       AllPDBs,
+		"orclpdb1":  "ORCLPDB1",
+		`"MyPdb"`:   `"MyPdb"`,
+		"pdb$seed":  "PDB$SEED",
+		"x; DROP y": "",
+		"":          "",
+	} {
+		got, err := pdbName(in)
+		if want == "" {
+			if !errors.Is(err, ErrInvalidIdentifier) {
+				t.Errorf("%q: got %q, %v, wanted ErrInvalidIdentifier", in, got, err)
+			}
+			continue
+		}
+		if err != nil || got != want {
+			t.Errorf("%q: got %q, %v, wanted %q", in, got, err, want)
+		}
+	}
+}
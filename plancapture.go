@@ -0,0 +1,85 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ExecutionPlan is the actual execution plan of a slow statement, captured by CapturePlan.
+type ExecutionPlan struct {
+	// Err is the error of retrieving the plan.
+	Err error
+	// Query is the SQL text, SQLID is its SQL_ID.
+	Query, SQLID string
+	// Plan is the output of DBMS_XPLAN.DISPLAY_CURSOR with the 'ALLSTATS LAST' format.
+	Plan string
+	// Elapsed is the time spent preparing, executing and fetching (till the rows are closed).
+	Elapsed time.Duration
+}
+
+type planCapture struct {
+	f         func(context.Context, ExecutionPlan)
+	threshold time.Duration
+}
+
+// CapturePlan returns an option to retrieve the actual execution plan of the statement
+// if its execution (with the fetches, till the rows are closed) takes longer than threshold,
+// and pass it to f.
+//
+// The plan is retrieved on the same connection, with DBMS_XPLAN.DISPLAY_CURSOR(sql_id, format=>'ALLSTATS LAST'),
+// which needs SELECT privilege on V$SQL_PLAN_STATISTICS_ALL, V$SQL and V$SQL_PLAN,
+// and the row source statistics are only available with STATISTICS_LEVEL=ALL or the GATHER_PLAN_STATISTICS hint.
+// Failed executions are not captured.
+//
+// Use it "naked", without sql.Named!
+func CapturePlan(threshold time.Duration, f func(context.Context, ExecutionPlan)) Option {
+	return func(o *stmtOptions) {
+		if f == nil {
+			o.planCapture = nil
+			return
+		}
+		o.planCapture = &planCapture{threshold: threshold, f: f}
+	}
+}
+
+// displayCursor returns the actual plan of the last execution of the cursor.
+func (c *conn) displayCursor(ctx context.Context, sqlID string) (string, error) {
+	if ctx == nil || ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	const qry = `SELECT plan_table_output FROM TABLE(DBMS_XPLAN.DISPLAY_CURSOR(sql_id=>:1, format=>'ALLSTATS LAST'))`
+	stmt, err := c.prepareContext(ctx, qry)
+	if err != nil {
+		return "", err
+	}
+	defer stmt.Close()
+	rows, err := stmt.(*statement).queryContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: sqlID}})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	var buf strings.Builder
+	dest := make([]driver.Value, 1)
+	for {
+		if err = rows.Next(dest); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return buf.String(), fmt.Errorf("%s: %w", qry, err)
+		}
+		if s, ok := dest[0].(string); ok {
+			buf.WriteString(s)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String(), nil
+}
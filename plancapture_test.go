@@ -0,0 +1,52 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCapturePlan(t *testing.T) {
+	var plans []ExecutionPlan
+	var o stmtOptions
+	CapturePlan(time.Second, func(_ context.Context, ep ExecutionPlan) { plans = append(plans, ep) })(&o)
+	st := &statement{conn: &conn{drv: &drv{}}, query: "SELECT 1 FROM DUAL", stmtOptions: o}
+
+	st.prepareDur = 2 * time.Second
+	slow := st.startSlowTimer(context.Background(), nil)
+	if slow == nil {
+		t.Fatal("no timer without slow query hook")
+	}
+	slow.execDone(st, nil)
+	slow.sqlID = "0123456789abc" // no cursor
+	slow.report(nil)
+	slow.report(nil)
+	if len(plans) != 1 {
+		t.Fatalf("got %d plans, wanted 1", len(plans))
+	}
+	if ep := plans[0]; ep.Query != st.query || ep.SQLID != "0123456789abc" || ep.Elapsed < 2*time.Second ||
+		!errors.Is(ep.Err, driver.ErrBadConn) {
+		t.Errorf("got %+v", ep)
+	}
+
+	// fast
+	slow = st.startSlowTimer(context.Background(), nil)
+	slow.execDone(st, nil)
+	slow.sqlID = "0123456789abc"
+	slow.report(nil)
+	if len(plans) != 1 {
+		t.Errorf("got %d plans, wanted 1", len(plans))
+	}
+
+	CapturePlan(0, nil)(&st.stmtOptions)
+	if slow = st.startSlowTimer(context.Background(), nil); slow != nil {
+		t.Error("timer without plan capture")
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StringIndexedTable binds a Go map as a string-indexed PL/SQL table
+// (a TABLE OF x INDEX BY VARCHAR2(n) parameter) of a PL/SQL block.
+//
+// OCI can bind only integer-indexed PL/SQL tables, so the statement is wrapped
+// in another PL/SQL block, which builds the TypeName table from the keys and the values
+// (bound as PL/SQL arrays) before running the statement, and reads it back after.
+//
+// TypeName is the name of the table type, e.g. "my_pkg.str_tab_typ".
+// Map is a map[string]T for an IN parameter, or a pointer to it for an IN OUT
+// (or, with a nil map, OUT) parameter, where T is string, Number, or an integer or float type.
+// The returned table can have at most ArraySize elements.
+type StringIndexedTable struct {
+	TypeName string
+	Map      interface{}
+}
+
+// stringIndexedTable is a StringIndexedTable bound in the wrapper block.
+type stringIndexedTable struct {
+	StringIndexedTable
+	name     string // the placeholder name in the statement
+	elemType string
+	keys     []string
+	vals     reflect.Value // pointer to the slice of the values
+}
+
+// hasStringIndexedTable reports whether any of the args is a StringIndexedTable.
+func hasStringIndexedTable(args []driver.NamedValue) bool {
+	for _, a := range args {
+		if _, ok := a.Value.(StringIndexedTable); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// execStringIndexedTables executes the statement wrapped in a PL/SQL block
+// which converts the StringIndexedTable args from and to PL/SQL arrays.
+//
+// Must be called with st locked.
+func (st *statement) execStringIndexedTables(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if st.dpiStmtInfo.isPLSQL == 0 {
+		return nil, fmt.Errorf("StringIndexedTable can only be bound to a PL/SQL block: %w", ErrStringIndexedTable)
+	}
+	names, err := st.bindNames()
+	if err != nil {
+		return nil, err
+	}
+	size := st.ArraySize()
+	wArgs := make([]driver.NamedValue, len(args))
+	var tables []*stringIndexedTable
+	for i, a := range args {
+		name := a.Name
+		if name == "" {
+			if a.Ordinal < 1 || a.Ordinal > len(names) {
+				return nil, fmt.Errorf("no placeholder for argument %d", a.Ordinal)
+			}
+			name = names[a.Ordinal-1]
+		}
+		wArgs[i] = driver.NamedValue{Name: name, Ordinal: i + 1, Value: a.Value}
+		sit, ok := a.Value.(StringIndexedTable)
+		if !ok {
+			continue
+		}
+		t, err := newStringIndexedTable(sit, strings.ToUpper(name), size)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		tables = append(tables, t)
+		j := len(tables)
+		wArgs[i].Name = "godror_k" + strconv.Itoa(j)
+		wArgs[i].Value = t.keysArg()
+		wArgs = append(wArgs, driver.NamedValue{
+			Name: "godror_v" + strconv.Itoa(j), Ordinal: len(wArgs) + 1,
+			Value: t.valsArg(),
+		})
+	}
+
+	qry := wrapStringIndexedTables(st.query, tables)
+	ws, err := st.conn.prepareContext(ctx, qry)
+	if err != nil {
+		return nil, err
+	}
+	wst := ws.(*statement)
+	defer wst.Close()
+	wst.stmtOptions = st.stmtOptions
+	wst.plSQLArrays = true
+	res, err := wst.execContext(ctx, wArgs)
+	if err != nil {
+		return res, err
+	}
+	for _, t := range tables {
+		if err = t.fill(); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+func newStringIndexedTable(sit StringIndexedTable, name string, size int) (*stringIndexedTable, error) {
+	if sit.TypeName == "" {
+		return nil, fmt.Errorf("StringIndexedTable without TypeName: %w", errUnknownType)
+	}
+	rv := reflect.ValueOf(sit.Map)
+	isOut := rv.Kind() == reflect.Pointer
+	if isOut {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("StringIndexedTable of nil %T: %w", sit.Map, errUnknownType)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("StringIndexedTable of %T: %w", sit.Map, errUnknownType)
+	}
+	t := stringIndexedTable{StringIndexedTable: sit, name: name}
+	et := rv.Type().Elem()
+	var sliceElem reflect.Type
+	switch et.Kind() {
+	case reflect.String:
+		if et == reflect.TypeOf(Number("")) {
+			t.elemType, sliceElem = "DBMS_SQL.NUMBER_TABLE", et
+		} else {
+			t.elemType, sliceElem = "DBMS_SQL.VARCHAR2A", reflect.TypeOf("")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		t.elemType, sliceElem = "DBMS_SQL.NUMBER_TABLE", reflect.TypeOf(int64(0))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		t.elemType, sliceElem = "DBMS_SQL.NUMBER_TABLE", reflect.TypeOf(uint64(0))
+	case reflect.Float32, reflect.Float64:
+		t.elemType, sliceElem = "DBMS_SQL.NUMBER_TABLE", reflect.TypeOf(float64(0))
+	default:
+		return nil, fmt.Errorf("StringIndexedTable of %T: %w", sit.Map, errUnknownType)
+	}
+
+	n, capacity := rv.Len(), rv.Len()
+	if isOut {
+		capacity = max(n, size)
+	}
+	t.keys = make([]string, 0, capacity)
+	for _, k := range rv.MapKeys() {
+		t.keys = append(t.keys, k.String())
+	}
+	sort.Strings(t.keys)
+	vals := reflect.MakeSlice(reflect.SliceOf(sliceElem), n, capacity)
+	for i, k := range t.keys {
+		vals.Index(i).Set(rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key())).Convert(sliceElem))
+	}
+	t.vals = reflect.New(vals.Type())
+	t.vals.Elem().Set(vals)
+	return &t, nil
+}
+
+func (t *stringIndexedTable) isOut() bool { return reflect.ValueOf(t.Map).Kind() == reflect.Pointer }
+
+func (t *stringIndexedTable) keysArg() interface{} {
+	if t.isOut() {
+		return sql.Out{Dest: &t.keys, In: true}
+	}
+	return t.keys
+}
+
+func (t *stringIndexedTable) valsArg() interface{} {
+	if t.isOut() {
+		return sql.Out{Dest: t.vals.Interface(), In: true}
+	}
+	return t.vals.Elem().Interface()
+}
+
+// fill replaces the elements of the OUT map with the returned keys and values.
+func (t *stringIndexedTable) fill() error {
+	if !t.isOut() {
+		return nil
+	}
+	vals := t.vals.Elem()
+	if len(t.keys) != vals.Len() {
+		return fmt.Errorf("%s: got %d keys but %d values", t.name, len(t.keys), vals.Len())
+	}
+	mp := reflect.ValueOf(t.Map).Elem()
+	typ := mp.Type()
+	if mp.IsNil() {
+		mp.Set(reflect.MakeMapWithSize(typ, len(t.keys)))
+	} else {
+		mp.Clear()
+	}
+	for i, k := range t.keys {
+		mp.SetMapIndex(reflect.ValueOf(k).Convert(typ.Key()), vals.Index(i).Convert(typ.Elem()))
+	}
+	return nil
+}
+
+// wrapStringIndexedTables returns the PL/SQL block qry wrapped in another block,
+// which fills the string-indexed tables from the godror_k and godror_v arrays,
+// and reads the OUT tables back into them.
+func wrapStringIndexedTables(qry string, tables []*stringIndexedTable) string {
+	var decl, pre, post strings.Builder
+	repl := make(map[string]string, len(tables))
+	for i, t := range tables {
+		j := strconv.Itoa(i + 1)
+		k, v, m := "godror_k"+j, "godror_v"+j, "godror_m"+j
+		repl[t.name] = m
+		fmt.Fprintf(&decl, "  %s DBMS_SQL.VARCHAR2A := :%s;\n  %s %s := :%s;\n  %s %s;\n",
+			k, k, v, t.elemType, v, m, t.TypeName)
+		fmt.Fprintf(&pre, "  FOR i IN 1..%s.COUNT LOOP %s(%s(i)) := %s(i); END LOOP;\n",
+			k, m, k, v)
+		if !t.isOut() {
+			continue
+		}
+		i := "godror_i" + j
+		fmt.Fprintf(&decl, "  %s VARCHAR2(32767);\n", i)
+		fmt.Fprintf(&post, `  %[1]s.DELETE; %[2]s.DELETE;
+  %[4]s := %[3]s.FIRST;
+  WHILE %[4]s IS NOT NULL LOOP
+    %[1]s(%[1]s.COUNT+1) := %[4]s; %[2]s(%[1]s.COUNT) := %[3]s(%[4]s);
+    %[4]s := %[3]s.NEXT(%[4]s);
+  END LOOP;
+  :%[1]s := %[1]s; :%[2]s := %[2]s;
+`, k, v, m, i)
+	}
+	qry = strings.TrimSpace(replacePlaceholders(qry, repl))
+	if !strings.HasSuffix(qry, ";") {
+		qry += ";"
+	}
+	return "DECLARE\n" + decl.String() + "BEGIN\n" + pre.String() + qry + "\n" + post.String() + "END;"
+}
+
+// replacePlaceholders replaces the placeholders of qry named as the (upper case) keys of repl,
+// skipping the string literals and comments.
+func replacePlaceholders(qry string, repl map[string]string) string {
+	var buf strings.Builder
+	state, p, last := 0, 0, 0
+	var prev rune
+
+	replace := func(i int) {
+		state = 0
+		if i-p <= 1 { // :=
+			return
+		}
+		if s, ok := repl[strings.ToUpper(qry[p+1:i])]; ok {
+			buf.WriteString(qry[last:p])
+			buf.WriteString(s)
+			last = i
+		}
+	}
+
+	for i, r := range qry {
+		switch state {
+		case 2:
+			if r == '\n' {
+				state = 0
+			}
+		case 3:
+			if prev == '*' && r == '/' {
+				state = 0
+			}
+		case 4:
+			if r == '\'' {
+				state = 0
+			}
+		case 1:
+			if 'A' <= r && r <= 'Z' || 'a' <= r && r <= 'z' || '0' <= r && r <= '9' ||
+				i-p > 1 && (r == '$' || r == '_' || r == '#') {
+				break
+			}
+			replace(i)
+			fallthrough
+		case 0:
+			switch r {
+			case '-':
+				if prev == '-' {
+					state = 2
+				}
+			case '*':
+				if prev == '/' {
+					state = 3
+				}
+			case '\'':
+				state = 4
+			case ':':
+				state = 1
+				p = i
+			}
+		}
+		prev = r
+	}
+	if state == 1 {
+		replace(len(qry))
+	}
+	buf.WriteString(qry[last:])
+	return buf.String()
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReplacePlaceholders(t *testing.T) {
+	repl := map[string]string{"P_MAP": "godror_m1", "2": "godror_m2"}
+	for _, tC := range []struct {
+		In, Want string
+	}{
+		{In: "BEGIN pkg.p(:p_map); END;", Want: "BEGIN pkg.p(godror_m1); END;"},
+		{In: "BEGIN pkg.p(:P_Map, :p_map2); END;", Want: "BEGIN pkg.p(godror_m1, :p_map2); END;"},
+		{In: "BEGIN :1 := pkg.f(:2); END;", Want: "BEGIN :1 := pkg.f(godror_m2); END;"},
+		{In: "BEGIN x := ':p_map'; -- :p_map\n /* :2 */ pkg.p(:2); END;", Want: "BEGIN x := ':p_map'; -- :p_map\n /* :2 */ pkg.p(godror_m2); END;"},
+		{In: "BEGIN pkg.p(:p_map)", Want: "BEGIN pkg.p(godror_m1)"},
+		{In: "BEGIN :p_map", Want: "BEGIN godror_m1"},
+	} {
+		if got := replacePlaceholders(tC.In, repl); got != tC.Want {
+			t.Errorf("%q: got %q, wanted %q", tC.In, got, tC.Want)
+		}
+	}
+}
+
+func TestStringIndexedTable(t *testing.T) {
+	in, err := newStringIndexedTable(StringIndexedTable{TypeName: "pkg.num_tab", Map: map[string]int{"b": 2, "a": 1}}, "P_IN", 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(in.keys, want) {
+		t.Errorf("got keys %q, wanted %q", in.keys, want)
+	}
+	if got, want := in.valsArg(), []int64{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got values %#v, wanted %#v", got, want)
+	}
+
+	var m map[string]string
+	out, err := newStringIndexedTable(StringIndexedTable{TypeName: "pkg.str_tab", Map: &m}, "P_OUT", 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cap(out.keys) != 8 {
+		t.Errorf("got cap %d, wanted 8", cap(out.keys))
+	}
+	out.keys = append(out.keys, "x", "y")
+	out.vals.Elem().Set(reflect.ValueOf([]string{"X", "Y"}))
+	if err = out.fill(); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"x": "X", "y": "Y"}; !reflect.DeepEqual(m, want) {
+		t.Errorf("got %v, wanted %v", m, want)
+	}
+
+	qry := wrapStringIndexedTables("BEGIN pkg.p(:p_in, :p_out); END;", []*stringIndexedTable{in, out})
+	t.Log(qry)
+	for _, want := range []string{
+		"godror_v1 DBMS_SQL.NUMBER_TABLE := :godror_v1;",
+		"godror_m2 pkg.str_tab;",
+		"BEGIN pkg.p(godror_m1, godror_m2); END;",
+		":godror_k2 := godror_k2;",
+	} {
+		if !strings.Contains(qry, want) {
+			t.Errorf("%q is missing from %s", want, qry)
+		}
+	}
+	if strings.Contains(qry, ":godror_k1 := godror_k1;") {
+		t.Errorf("IN table is read back: %s", qry)
+	}
+
+	for _, sit := range []StringIndexedTable{
+		{Map: map[string]int{}},
+		{TypeName: "t", Map: map[int]string{}},
+		{TypeName: "t", Map: map[string]bool{}},
+		{TypeName: "t", Map: (*map[string]string)(nil)},
+	} {
+		if _, err := newStringIndexedTable(sit, "P", 8); err == nil {
+			t.Errorf("%#v: wanted error", sit)
+		}
+	}
+}
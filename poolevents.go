@@ -0,0 +1,94 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import "time"
+
+// PoolEventKind is the kind of a PoolEvent.
+type PoolEventKind uint8
+
+// The kinds of the pool events.
+const (
+	// PoolSessionCreated is a new session created by the pool for a checkout.
+	PoolSessionCreated = PoolEventKind(iota + 1)
+	// PoolSessionDestroyed is a session dropped on its release, instead of returned to the pool:
+	// its server connection is broken, or it has been switched to another container.
+	// The idle sessions dropped by the pool itself (timeout, FAN) are not reported.
+	PoolSessionDestroyed
+	// PoolCheckout is a session checked out from the pool.
+	PoolCheckout
+	// PoolReturn is a session returned to the pool.
+	PoolReturn
+	// PoolValidationFailed is a session found unhealthy by the validation (ResetSession, IsValid),
+	// such as the sessions of a down instance or service marked by the Fast Application Notification (FAN)
+	// of an events-enabled pool.
+	PoolValidationFailed
+	// PoolSessionCulled is a session of an events-enabled pool dropped on its release,
+	// as its server connection has been marked down by FAN (instead of PoolSessionDestroyed).
+	//
+	// OCI culls the idle sessions of a down instance in the pool without a callback,
+	// so only the sessions checked out at the time of the FAN event are reported.
+	PoolSessionCulled
+)
+
+func (k PoolEventKind) String() string {
+	switch k {
+	case PoolSessionCreated:
+		return "created"
+	case PoolSessionDestroyed:
+		return "destroyed"
+	case PoolCheckout:
+		return "checkout"
+	case PoolReturn:
+		return "return"
+	case PoolValidationFailed:
+		return "validation_failed"
+	case PoolSessionCulled:
+		return "culled"
+	}
+	return "unknown"
+}
+
+// PoolEvent is an event of a session of a session pool.
+type PoolEvent struct {
+	Time time.Time
+	// Username and ConnectString identify the pool.
+	Username, ConnectString string
+	// Held is the duration the session was checked out, for PoolReturn.
+	Held time.Duration
+	Kind PoolEventKind
+}
+
+// SetPoolEventListener sets the listener called with the events of the session pools of the driver.
+// A nil listener switches it off.
+//
+// The listener is called synchronously on the hot path, so it must be fast,
+// and must not use the connection.
+func (d *drv) SetPoolEventListener(listener func(PoolEvent)) {
+	if listener == nil {
+		d.poolEvents.Store(nil)
+		return
+	}
+	d.poolEvents.Store(&listener)
+}
+
+// SetPoolEventListener sets the pool event listener of the default driver.
+func SetPoolEventListener(listener func(PoolEvent)) { defaultDrv.SetPoolEventListener(listener) }
+
+// poolEvent sends the event of the pooled connection to the listener, if any.
+func (c *conn) poolEvent(kind PoolEventKind, held time.Duration) {
+	if c == nil || c.drv == nil || c.poolKey == "" {
+		return
+	}
+	p := c.drv.poolEvents.Load()
+	if p == nil {
+		return
+	}
+	(*p)(PoolEvent{
+		Time: time.Now(), Kind: kind, Held: held,
+		Username: c.params.Username, ConnectString: c.params.ConnectString,
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPoolEvent(t *testing.T) {
+	d := &drv{}
+	var events []PoolEvent
+	d.SetPoolEventListener(func(ev PoolEvent) { events = append(events, ev) })
+	c := &conn{drv: d, poolKey: "key"}
+	c.params.Username, c.params.ConnectString = "scott", "localhost/db"
+
+	d.tracker.checkout(c)
+	c.poolEvent(PoolCheckout, 0)
+	time.Sleep(10 * time.Millisecond)
+	c.poolEvent(PoolReturn, d.tracker.release(c))
+	(&conn{drv: d}).poolEvent(PoolCheckout, 0) // standalone
+	if len(events) != 2 {
+		t.Fatalf("got %d events, wanted 2", len(events))
+	}
+	if ev := events[0]; ev.Kind != PoolCheckout || ev.Username != "scott" || ev.ConnectString != "localhost/db" || ev.Time.IsZero() {
+		t.Errorf("got %+v", ev)
+	}
+	if ev := events[1]; ev.Kind != PoolReturn || ev.Held < 10*time.Millisecond {
+		t.Errorf("got %+v", ev)
+	}
+	if s := PoolSessionDestroyed.String(); s != "destroyed" {
+		t.Errorf("got %q", s)
+	}
+	if s := PoolSessionCulled.String(); s != "culled" {
+		t.Errorf("got %q", s)
+	}
+
+	d.SetPoolEventListener(nil)
+	c.poolEvent(PoolCheckout, 0)
+	if len(events) != 2 {
+		t.Errorf("got %d events after switching off", len(events))
+	}
+}
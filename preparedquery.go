@@ -0,0 +1,126 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// PreparedQuery is a statement prepared once, and executed on any connection of the pool.
+//
+// Under the hood, the statement is prepared on each connection it is executed on, at most once,
+// using the statement cache of the connection.
+// When the execution fails because the statement became invalid (ORA-04061, ORA-04065, ORA-04068, ORA-08103),
+// the statement is prepared again, and the execution is retried once.
+//
+// It is safe for concurrent use.
+type PreparedQuery struct {
+	db    *sql.DB
+	stmt  *sql.Stmt
+	query string
+	mu    sync.RWMutex
+}
+
+// NewPreparedQuery prepares the query for executing on the connections of db.
+func NewPreparedQuery(ctx context.Context, db *sql.DB, qry string) (*PreparedQuery, error) {
+	stmt, err := db.PrepareContext(ctx, qry)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	return &PreparedQuery{db: db, query: qry, stmt: stmt}, nil
+}
+
+// Query returns the text of the statement.
+func (pq *PreparedQuery) Query() string { return pq.query }
+
+// ExecContext executes the statement.
+func (pq *PreparedQuery) ExecContext(ctx context.Context, args ...interface{}) (sql.Result, error) {
+	stmt := pq.current()
+	if stmt == nil {
+		return nil, errPreparedQueryClosed
+	}
+	res, err := stmt.ExecContext(ctx, args...)
+	if !isStaleStmtErr(err) {
+		return res, err
+	}
+	if stmt, err = pq.reprepare(ctx, stmt); err != nil {
+		return nil, err
+	}
+	return stmt.ExecContext(ctx, append(args[:len(args):len(args)], DeleteFromCache())...)
+}
+
+// QueryContext executes the query.
+//
+// Only the execution is retried, errors while fetching the rows are not.
+func (pq *PreparedQuery) QueryContext(ctx context.Context, args ...interface{}) (*sql.Rows, error) {
+	stmt := pq.current()
+	if stmt == nil {
+		return nil, errPreparedQueryClosed
+	}
+	rows, err := stmt.QueryContext(ctx, args...)
+	if !isStaleStmtErr(err) {
+		return rows, err
+	}
+	if stmt, err = pq.reprepare(ctx, stmt); err != nil {
+		return nil, err
+	}
+	return stmt.QueryContext(ctx, append(args[:len(args):len(args)], DeleteFromCache())...)
+}
+
+// Close closes the statement on all connections.
+func (pq *PreparedQuery) Close() error {
+	pq.mu.Lock()
+	stmt := pq.stmt
+	pq.stmt = nil
+	pq.mu.Unlock()
+	if stmt == nil {
+		return nil
+	}
+	return stmt.Close()
+}
+
+var errPreparedQueryClosed = errors.New("PreparedQuery is closed")
+
+func (pq *PreparedQuery) current() *sql.Stmt {
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+	return pq.stmt
+}
+
+// reprepare replaces the stale statement with a newly prepared one
+// (unless another goroutine has already done so), and returns it.
+func (pq *PreparedQuery) reprepare(ctx context.Context, stale *sql.Stmt) (*sql.Stmt, error) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if pq.stmt == nil {
+		return nil, errPreparedQueryClosed
+	}
+	if pq.stmt != stale {
+		return pq.stmt, nil
+	}
+	stmt, err := pq.db.PrepareContext(ctx, pq.query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pq.query, err)
+	}
+	pq.stmt = stmt
+	// Close waits for the rows of the old statement to be closed
+	go stale.Close()
+	return stmt, nil
+}
+
+// isStaleStmtErr reports whether the error means that the statement should be prepared again.
+func isStaleStmtErr(err error) bool {
+	if isInvalidErr(err) {
+		return true
+	}
+	var cdr interface{ Code() int }
+	// ORA-08103: object no longer exists
+	return errors.As(err, &cdr) && cdr.Code() == 8103
+}
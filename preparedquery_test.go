@@ -0,0 +1,38 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestIsStaleStmtErr(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{codeErr(1), false},
+		{codeErr(4068), true},
+		{fmt.Errorf("wrapped: %w", codeErr(8103)), true},
+	} {
+		if got := isStaleStmtErr(tc.err); got != tc.want {
+			t.Errorf("%v: got %t, wanted %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestPreparedQueryClosed(t *testing.T) {
+	var pq PreparedQuery
+	if _, err := pq.ExecContext(context.Background()); err != errPreparedQueryClosed {
+		t.Errorf("got %v, wanted %v", err, errPreparedQueryClosed)
+	}
+	if err := pq.Close(); err != nil {
+		t.Error(err)
+	}
+}
@@ -533,6 +533,7 @@ func (M *Message) fromOra(c *conn, props *C.dpiMsgProps, objType *ObjectType) er
 				return objType.drv.getError()
 			}
 			M.Object = &Object{dpiObject: obj, ObjectType: objType}
+			resourceCounters.objects.Add(1)
 		}
 	}
 	return nil
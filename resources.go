@@ -0,0 +1,54 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"encoding/json"
+	"runtime"
+	"sync/atomic"
+)
+
+// ResourceStats are lightweight counters of the live resources of all the drivers.
+type ResourceStats struct {
+	// Conns is the number of open connections (including the ones idle in the database/sql pool).
+	Conns int64 `json:"conns"`
+	// Stmts is the number of prepared, not closed statements.
+	Stmts int64 `json:"stmts"`
+	// Objects is the number of Objects not closed.
+	Objects int64 `json:"objects"`
+	// LOBs is the number of LOBs (the readers of the fetched and OUT LOBs, Data.GetLob, and the temporary LOBs) not closed.
+	LOBs int64 `json:"lobs"`
+	// CgoCalls is the number of cgo calls of the process (runtime.NumCgoCall).
+	CgoCalls int64 `json:"cgo_calls"`
+}
+
+var resourceCounters struct {
+	conns, stmts, objects, lobs atomic.Int64
+}
+
+// GetResourceStats returns the counters of the live resources.
+func GetResourceStats() ResourceStats {
+	return ResourceStats{
+		Conns:    resourceCounters.conns.Load(),
+		Stmts:    resourceCounters.stmts.Load(),
+		Objects:  resourceCounters.objects.Load(),
+		LOBs:     resourceCounters.lobs.Load(),
+		CgoCalls: runtime.NumCgoCall(),
+	}
+}
+
+// ResourceStatsVar is an expvar.Var of the current ResourceStats:
+//
+//	expvar.Publish("godror", godror.ResourceStatsVar{})
+//
+// godror does not import expvar, as that registers the /debug/vars handler on http.DefaultServeMux.
+type ResourceStatsVar struct{}
+
+// String returns the current ResourceStats as JSON.
+func (ResourceStatsVar) String() string {
+	b, _ := json.Marshal(GetResourceStats())
+	return string(b)
+}
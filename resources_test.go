@@ -0,0 +1,25 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestResourceStatsVar(t *testing.T) {
+	resourceCounters.lobs.Add(2)
+	defer resourceCounters.lobs.Add(-2)
+	s := ResourceStatsVar{}.String()
+	t.Log(s)
+	var rs ResourceStats
+	if err := json.Unmarshal([]byte(s), &rs); err != nil {
+		t.Fatal(err)
+	}
+	if rs.LOBs < 2 || rs.CgoCalls <= 0 {
+		t.Errorf("got %+v", rs)
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClientResultCacheConfig configures the OCI client result cache.
+//
+// The OCI client result cache keeps the result sets of the cache-eligible queries
+// in the client memory, and serves the repeated executions from there, without a round trip.
+//
+// It is enabled by the CLIENT_RESULT_CACHE_SIZE database parameter
+// (and can be limited per client with the sqlnet.ora lines of SqlnetOra),
+// and needs the statement cache (see StmtCacheSize).
+//
+// Queries are eligible for caching if annotated with ResultCacheHint,
+// their tables are annotated with RESULT_CACHE (MODE FORCE) (see SetTableResultCache),
+// or the session has RESULT_CACHE_MODE=FORCE - set it for a whole pool with
+// alterSession="result_cache_mode=FORCE" in the connection string.
+type ClientResultCacheConfig struct {
+	// MaxSize is the maximum size of the cache in bytes, overriding CLIENT_RESULT_CACHE_SIZE.
+	MaxSize int
+	// MaxRSetSize is the maximum size of a cached result set in bytes.
+	MaxRSetSize int
+	// MaxRSetRows is the maximum number of rows of a cached result set.
+	MaxRSetRows int
+}
+
+// SqlnetOra returns the sqlnet.ora lines of the configuration.
+// Put them into the sqlnet.ora of the configDir (TNS_ADMIN) of the pool.
+func (cfg ClientResultCacheConfig) SqlnetOra() string {
+	var buf strings.Builder
+	for _, kv := range []struct {
+		k string
+		v int
+	}{
+		{"OCI_RESULT_CACHE_MAX_SIZE", cfg.MaxSize},
+		{"OCI_RESULT_CACHE_MAX_RSET_SIZE", cfg.MaxRSetSize},
+		{"OCI_RESULT_CACHE_MAX_RSET_ROWS", cfg.MaxRSetRows},
+	} {
+		if kv.v > 0 {
+			fmt.Fprintf(&buf, "%s = %d\n", kv.k, kv.v)
+		}
+	}
+	return buf.String()
+}
+
+// ResultCacheHint returns the query annotated with the RESULT_CACHE hint,
+// making it eligible for the (client and server) result cache.
+//
+// Only queries starting with SELECT are annotated, the others are returned as is.
+// The hint is merged into the existing hint comment, as only the first one is used.
+func ResultCacheHint(qry string) string {
+	trimmed := strings.TrimLeft(qry, " \t\r\n")
+	if len(trimmed) < 6 || !strings.EqualFold(trimmed[:6], "SELECT") ||
+		len(trimmed) > 6 && !strings.ContainsRune(" \t\r\n/", rune(trimmed[6])) {
+		return qry
+	}
+	start := len(qry) - len(trimmed) + 6
+	rest := strings.TrimLeft(qry[start:], " \t\r\n")
+	if !strings.HasPrefix(rest, "/*+") {
+		return qry[:start] + " /*+ RESULT_CACHE */" + qry[start:]
+	}
+	if end := strings.Index(rest, "*/"); end >= 0 && strings.Contains(strings.ToUpper(rest[:end]), "RESULT_CACHE") {
+		return qry
+	}
+	hint := len(qry) - len(rest) + 3
+	return qry[:hint] + " RESULT_CACHE" + qry[hint:]
+}
+
+// ClientResultCacheStats are the statistics of the client result cache of the connection's client,
+// as in CLIENT_RESULT_CACHE_STATS$.
+type ClientResultCacheStats struct {
+	// All holds all the statistics by name.
+	All                                    map[string]int64
+	BlockSize, BlockCountMax, BlockCount   int64
+	CreateCountSuccess, CreateCountFailure int64
+	FindCount, InvalidationCount           int64
+	DeleteCountInvalid, DeleteCountValid   int64
+}
+
+// GetClientResultCacheStats returns the statistics of the client result cache
+// of the client of the connection.
+//
+// This needs SELECT privilege on CLIENT_RESULT_CACHE_STATS$ and V$SESSION_CONNECT_INFO.
+func GetClientResultCacheStats(ctx context.Context, q Querier) (ClientResultCacheStats, error) {
+	const qry = `SELECT s.name, s.value
+  FROM client_result_cache_stats$ s
+  WHERE s.cache_id IN (SELECT i.client_regid FROM v$session_connect_info i
+                         WHERE i.sid = SYS_CONTEXT('USERENV', 'SID'))`
+	stats := ClientResultCacheStats{All: make(map[string]int64)}
+	rows, err := q.QueryContext(ctx, qry)
+	if err != nil {
+		return stats, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var value int64
+		if err = rows.Scan(&name, &value); err != nil {
+			return stats, err
+		}
+		stats.All[name] = value
+		switch name {
+		case "Block Size":
+			stats.BlockSize = value
+		case "Block Count Max":
+			stats.BlockCountMax = value
+		case "Block Count Current":
+			stats.BlockCount = value
+		case "Create Count Success":
+			stats.CreateCountSuccess = value
+		case "Create Count Failure":
+			stats.CreateCountFailure = value
+		case "Find Count":
+			stats.FindCount = value
+		case "Invalidation Count":
+			stats.InvalidationCount = value
+		case "Delete Count Invalid":
+			stats.DeleteCountInvalid = value
+		case "Delete Count Valid":
+			stats.DeleteCountValid = value
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return stats, err
+	}
+	return stats, rows.Close()
+}
+
+// ClientResultCacheSettings are the database parameters of the client result cache.
+type ClientResultCacheSettings struct {
+	// Size is CLIENT_RESULT_CACHE_SIZE, the client result cache is disabled if it is 0.
+	Size int64
+	// Lag is CLIENT_RESULT_CACHE_LAG, the maximum time since the last round trip,
+	// before the client checks the database for invalidations.
+	Lag time.Duration
+}
+
+// GetClientResultCacheSettings returns the database parameters of the client result cache.
+func GetClientResultCacheSettings(ctx context.Context, q Querier) (ClientResultCacheSettings, error) {
+	const qry = `SELECT name, value FROM v$parameter
+  WHERE name IN ('client_result_cache_size', 'client_result_cache_lag')`
+	var settings ClientResultCacheSettings
+	rows, err := q.QueryContext(ctx, qry)
+	if err != nil {
+		return settings, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, value string
+		if err = rows.Scan(&name, &value); err != nil {
+			return settings, err
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return settings, fmt.Errorf("%s=%q: %w", name, value, err)
+		}
+		switch name {
+		case "client_result_cache_size":
+			settings.Size = n
+		case "client_result_cache_lag":
+			settings.Lag = time.Duration(n) * time.Millisecond
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return settings, err
+	}
+	return settings, rows.Close()
+}
+
+// ResultCacheOptions are the options of NewResultCacheInterceptor.
+type ResultCacheOptions struct {
+	// OnInvalidate is called with the allow-listed tables modified by a successful DML execution,
+	// for invalidating the application level caches.
+	// Note that this is called right after the execution, before the commit of the transaction.
+	OnInvalidate func(ctx context.Context, tables []string)
+	// Tables is the allow-list of the cacheable tables.
+	// An unqualified name matches the table of any schema, a schema.name only that one.
+	Tables []string
+}
+
+// NewResultCacheInterceptor returns an Interceptor that adds the RESULT_CACHE hint (see ResultCacheHint)
+// to the queries selecting only from the allow-listed tables, and reports the DML to those tables.
+//
+// The same hint is used by the server result cache and the client result cache
+// (enabled with the CLIENT_RESULT_CACHE_SIZE database parameter).
+// Register it with RegisterInterceptor.
+func NewResultCacheInterceptor(opts ResultCacheOptions) (Interceptor, error) {
+	allow := make(map[string]struct{}, len(opts.Tables))
+	for _, t := range opts.Tables {
+		on, err := ParseObjectName(t)
+		if err != nil {
+			return nil, err
+		}
+		allow[on.String()] = struct{}{}
+	}
+	allowed := func(table string) bool {
+		if _, ok := allow[table]; ok {
+			return true
+		}
+		on, err := ParseObjectName(table)
+		if err != nil || on.Schema == "" {
+			return false
+		}
+		_, ok := allow[ObjectName{Name: on.Name, DBLink: on.DBLink}.String()]
+		return ok
+	}
+	return func(ctx context.Context, op *Op, next func(context.Context, *Op) error) error {
+		switch op.Kind {
+		case OpPrepare:
+			if tables := queryTables(op.Query); len(tables) != 0 {
+				cacheable := true
+				for _, t := range tables {
+					if cacheable = allowed(t); !cacheable {
+						break
+					}
+				}
+				if cacheable {
+					op.Query = ResultCacheHint(op.Query)
+				}
+			}
+		case OpExec:
+			if opts.OnInvalidate == nil {
+				break
+			}
+			if err := next(ctx, op); err != nil {
+				return err
+			}
+			var tables []string
+			for _, t := range dmlTables(op.Query) {
+				if allowed(t) {
+					tables = append(tables, t)
+				}
+			}
+			if len(tables) != 0 {
+				opts.OnInvalidate(ctx, tables)
+			}
+			return nil
+		}
+		return next(ctx, op)
+	}, nil
+}
+
+// SetTableResultCache annotates the table for result caching, with
+// ALTER TABLE name RESULT_CACHE (MODE FORCE) - or MODE DEFAULT, which needs the hint.
+func SetTableResultCache(ctx context.Context, ex Execer, table string, force bool) error {
+	on, err := ParseObjectName(table)
+	if err != nil {
+		return err
+	}
+	mode := "DEFAULT"
+	if force {
+		mode = "FORCE"
+	}
+	qry := "ALTER TABLE " + on.String() + " RESULT_CACHE (MODE " + mode + ")"
+	if _, err = ex.ExecContext(ctx, qry); err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return nil
+}
+
+// queryTables returns the names of the tables and views the SELECT query selects from
+// (after FROM and JOIN, also in the subqueries), normalized as ParseObjectName does.
+//
+// This is a best effort lexer, as dmlTables: nil is returned for the not recognized statements.
+func queryTables(qry string) []string {
+	toks := sqlTokens(qry)
+	if len(toks) == 0 || !(strings.EqualFold(toks[0], "SELECT") || strings.EqualFold(toks[0], "WITH")) {
+		return nil
+	}
+	var tables []string
+	var inFrom, expect bool
+	var outer []bool // the inFrom states outside the parentheses
+	for _, t := range toks {
+		switch strings.ToUpper(t) {
+		case "FROM", "JOIN":
+			inFrom, expect = true, true
+			continue
+		case ",":
+			expect = inFrom
+			continue
+		case "(":
+			// a subquery after FROM, whose tables will be found inside
+			outer = append(outer, inFrom)
+			inFrom, expect = false, false
+			continue
+		case ")":
+			if n := len(outer); n != 0 {
+				inFrom, outer = outer[n-1], outer[:n-1]
+			}
+			expect = false
+			continue
+		case "WHERE", "GROUP", "ORDER", "HAVING", "CONNECT", "START", "UNION", "INTERSECT", "MINUS",
+			"ON", "USING", "FETCH", "OFFSET", "FOR":
+			inFrom, expect = false, false
+			continue
+		}
+		if !expect {
+			continue
+		}
+		expect = false
+		name := t
+		if on, err := ParseObjectName(name); err == nil {
+			name = on.String()
+		}
+		tables = appendNewStrings(tables, name)
+	}
+	return tables
+}
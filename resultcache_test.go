@@ -0,0 +1,96 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestResultCacheHint(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"SELECT * FROM dual", "SELECT /*+ RESULT_CACHE */ * FROM dual"},
+		{"\n  select a FROM b", "\n  select /*+ RESULT_CACHE */ a FROM b"},
+		{"SELECT /*+ INDEX(b) */ a FROM b", "SELECT /*+ RESULT_CACHE INDEX(b) */ a FROM b"},
+		{"SELECT /*+ result_cache */ a FROM b", "SELECT /*+ result_cache */ a FROM b"},
+		{"SELECTED", "SELECTED"},
+		{"UPDATE b SET a = 1", "UPDATE b SET a = 1"},
+	} {
+		if got := ResultCacheHint(tc.in); got != tc.want {
+			t.Errorf("%q: got %q, wanted %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestClientResultCacheConfig(t *testing.T) {
+	got := ClientResultCacheConfig{MaxSize: 1 << 20, MaxRSetRows: 100}.SqlnetOra()
+	const want = "OCI_RESULT_CACHE_MAX_SIZE = 1048576\nOCI_RESULT_CACHE_MAX_RSET_ROWS = 100\n"
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestQueryTables(t *testing.T) {
+	for _, tc := range []struct {
+		qry  string
+		want []string
+	}{
+		{"SELECT * FROM emp", []string{"EMP"}},
+		{"SELECT e.name, d.name FROM hr.emp e, dept d WHERE e.dept_id = d.id", []string{"HR.EMP", "DEPT"}},
+		{"SELECT * FROM emp e JOIN dept d ON d.id = e.dept_id LEFT JOIN loc l USING (loc_id)", []string{"EMP", "DEPT", "LOC"}},
+		{"SELECT * FROM (SELECT id FROM emp) a, dept WHERE 'FROM x' = a.id", []string{"EMP", "DEPT"}},
+		{"WITH a AS (SELECT * FROM emp) SELECT * FROM a", []string{"EMP", "A"}},
+		{"SELECT EXTRACT(YEAR FROM hired) FROM emp", []string{"HIRED", "EMP"}},
+		{"UPDATE emp SET x = 1", nil},
+	} {
+		if got := queryTables(tc.qry); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: got %q, wanted %q", tc.qry, got, tc.want)
+		}
+	}
+}
+
+func TestResultCacheInterceptor(t *testing.T) {
+	var invalidated [][]string
+	ic, err := NewResultCacheInterceptor(ResultCacheOptions{
+		Tables:       []string{"emp", "hr.dept"},
+		OnInvalidate: func(_ context.Context, tables []string) { invalidated = append(invalidated, tables) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := func(context.Context, *Op) error { return nil }
+	for qry, want := range map[string]string{
+		"SELECT * FROM scott.emp e, hr.dept d": "SELECT /*+ RESULT_CACHE */ * FROM scott.emp e, hr.dept d",
+		"SELECT * FROM emp, dept":              "SELECT * FROM emp, dept",
+		"SELECT * FROM emp, loc":               "SELECT * FROM emp, loc",
+	} {
+		op := &Op{Kind: OpPrepare, Query: qry}
+		if err = ic(context.Background(), op, next); err != nil {
+			t.Fatal(err)
+		}
+		if op.Query != want {
+			t.Errorf("%q: got %q, wanted %q", qry, op.Query, want)
+		}
+	}
+
+	for _, qry := range []string{"UPDATE scott.emp SET x = 1", "DELETE FROM loc", "INSERT INTO hr.dept VALUES (1)"} {
+		op := &Op{Kind: OpExec, Query: qry, Result: driver.ResultNoRows}
+		if err = ic(context.Background(), op, next); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := [][]string{{"SCOTT.EMP"}, {"HR.DEPT"}}; !reflect.DeepEqual(invalidated, want) {
+		t.Errorf("got %q, wanted %q", invalidated, want)
+	}
+
+	if _, err = NewResultCacheInterceptor(ResultCacheOptions{Tables: []string{"a b"}}); err == nil {
+		t.Error("wanted error for invalid table name")
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// IsRetryable reports whether err is a transient error,
+// after which re-executing an idempotent statement may succeed:
+// bad connections (see IsBadConn), unavailable services and listeners,
+// discarded package states, objects changed under a running query,
+// deadlocks and resource waits (also of distributed transactions, see IsDistributedTxError).
+//
+// Context cancelation and deadline are never retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if IsBadConn(err) {
+		return true
+	}
+	var cd interface{ Code() int }
+	if !errors.As(err, &cd) {
+		return false
+	}
+	switch cd.Code() {
+	case 18, // maximum number of sessions exceeded
+		20,    // maximum number of processes exceeded
+		60,    // deadlock detected while waiting for resource
+		1555,  // snapshot too old
+		2049,  // timeout: distributed transaction waiting for lock
+		2068,  // following severe error from database link
+		4061,  // existing state of package has been invalidated
+		4065,  // package not executed, altered or dropped
+		4068,  // existing state of packages has been discarded
+		8103,  // object no longer exists
+		8176,  // consistent read failure; rollback data not available
+		12514, // TNS:listener does not currently know of service requested
+		12516, // TNS:listener could not find available handler
+		12519, // TNS:no appropriate service handler found
+		12520, // TNS:listener could not find available handler for requested type of server
+		12541, // TNS:no listener
+		12757, // instance does not currently know of requested service
+		24459, // OCISessionGet() timed out waiting for pool to create new connections
+		25402, // transaction must roll back
+		25408, // cannot safely replay call
+		30006: // resource busy; acquire with WAIT timeout expired
+		return true
+	}
+	return false
+}
+
+// RetryPolicy configures Retry.
+type RetryPolicy struct {
+	// IsRetryable classifies the errors, IsRetryable by default.
+	IsRetryable func(error) bool
+	// MaxAttempts is the maximum number of executions, 3 by default.
+	MaxAttempts int
+	// Backoff is the wait before the first retry, 100ms by default;
+	// it is doubled for each subsequent retry, up to MaxBackoff (5s by default).
+	// The waits are randomized between half and the full duration.
+	Backoff, MaxBackoff time.Duration
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.IsRetryable == nil {
+		p.IsRetryable = IsRetryable
+	}
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+	if p.Backoff <= 0 {
+		p.Backoff = 100 * time.Millisecond
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = 5 * time.Second
+	}
+	return p
+}
+
+// Retry calls f till it succeeds, returns a non-retryable error,
+// or the number of attempts or the context is exhausted.
+//
+// f must be idempotent!
+func Retry(ctx context.Context, policy RetryPolicy, f func(context.Context) error) error {
+	p := policy.withDefaults()
+	backoff := p.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = f(ctx); err == nil || attempt >= p.MaxAttempts || !p.IsRetryable(err) {
+			return err
+		}
+		wait := backoff/2 + rand.N(backoff/2+1)
+		if logger := getLogger(ctx); logger != nil {
+			logger.Warn("retry", "attempt", attempt, "wait", wait, "error", err)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		if backoff *= 2; backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+}
+
+// RetryDB wraps an *sql.DB (or *sql.Conn, *sql.Tx), re-executing the statements on
+// retryable errors, as RetryPolicy says.
+//
+// Use it only for idempotent statements!
+//
+// With an *sql.DB, each retry gets a connection from the pool (bad connections are discarded by the driver).
+// With an *sql.Conn or *sql.Tx, bad connection errors are not retried, as it's the same connection.
+type RetryDB struct {
+	DB interface {
+		Execer
+		Querier
+	}
+	Policy RetryPolicy
+}
+
+// ExecContext executes the statement, retrying it on retryable errors.
+func (r RetryDB) ExecContext(ctx context.Context, qry string, args ...interface{}) (sql.Result, error) {
+	var res sql.Result
+	err := Retry(ctx, r.policy(), func(ctx context.Context) error {
+		var err error
+		res, err = r.DB.ExecContext(ctx, qry, args...)
+		return err
+	})
+	return res, err
+}
+
+// QueryContext executes the query, retrying it on retryable errors.
+//
+// Only the execution is retried, errors while fetching the rows are not.
+func (r RetryDB) QueryContext(ctx context.Context, qry string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := Retry(ctx, r.policy(), func(ctx context.Context) error {
+		var err error
+		rows, err = r.DB.QueryContext(ctx, qry, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (r RetryDB) policy() RetryPolicy {
+	p := r.Policy.withDefaults()
+	if _, isDB := r.DB.(*sql.DB); isDB {
+		return p
+	}
+	isRetryable := p.IsRetryable
+	p.IsRetryable = func(err error) bool { return !IsBadConn(err) && isRetryable(err) }
+	return p
+}
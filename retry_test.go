@@ -0,0 +1,70 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type codeErr int
+
+func (c codeErr) Error() string { return fmt.Sprintf("ORA-%05d", int(c)) }
+func (c codeErr) Code() int     { return int(c) }
+
+func TestIsRetryable(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("x"), false},
+		{codeErr(1), false},
+		{codeErr(3113), true},
+		{fmt.Errorf("wrapped: %w", codeErr(12514)), true},
+		{codeErr(4068), true},
+		{codeErr(8103), true},
+		{codeErr(2049), true},
+		{codeErr(2091), false},
+		{context.Canceled, false},
+		{fmt.Errorf("%w: %w", context.DeadlineExceeded, codeErr(3135)), false},
+	} {
+		if got := IsRetryable(tc.err); got != tc.want {
+			t.Errorf("%v: got %t, wanted %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	var n int
+	err := Retry(ctx, policy, func(context.Context) error {
+		if n++; n < 3 {
+			return codeErr(3113)
+		}
+		return nil
+	})
+	if err != nil || n != 3 {
+		t.Errorf("got %v after %d attempts, wanted success after 3", err, n)
+	}
+
+	n = 0
+	err = Retry(ctx, policy, func(context.Context) error { n++; return codeErr(3113) })
+	if !errors.Is(err, codeErr(3113)) || n != 3 {
+		t.Errorf("got %v after %d attempts, wanted ORA-03113 after 3", err, n)
+	}
+
+	n = 0
+	err = Retry(ctx, policy, func(context.Context) error { n++; return codeErr(1) })
+	if n != 1 {
+		t.Errorf("non-retryable %v retried %d times", err, n)
+	}
+}
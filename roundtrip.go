@@ -0,0 +1,90 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import "sync/atomic"
+
+// RoundTrips is the number of network round trips, counted on the client side
+// (OCI does not report them), so they're estimations:
+// a fetch is counted only if it could not be served from the fetch buffer or the prefetched rows.
+type RoundTrips struct {
+	// Execute is the number of statement executions (the prepare is done with the execution).
+	Execute uint64
+	// Fetch is the number of fetches reaching the database.
+	Fetch uint64
+	// LOB is the number of LOB size queries and reads of the LOBs fetched by the query.
+	LOB uint64
+	// Transaction is the number of commits and rollbacks, only in the driver statistics.
+	Transaction uint64
+}
+
+// Total returns the total number of round trips.
+func (rt RoundTrips) Total() uint64 { return rt.Execute + rt.Fetch + rt.LOB + rt.Transaction }
+
+// CountRoundTrips returns an option to count the round trips of the statement execution into dest:
+// set after Exec, and for queries, updated with each fetch and LOB read till the rows are closed.
+//
+// Use it "naked", without sql.Named!
+func CountRoundTrips(dest *RoundTrips) Option {
+	return func(o *stmtOptions) {
+		if dest != nil {
+			*dest = RoundTrips{}
+		}
+		o.roundTrips = dest
+	}
+}
+
+type roundTripStats struct {
+	execute, fetch, lob, transaction atomic.Uint64
+}
+
+func (s *roundTripStats) get() RoundTrips {
+	return RoundTrips{
+		Execute: s.execute.Load(), Fetch: s.fetch.Load(),
+		LOB: s.lob.Load(), Transaction: s.transaction.Load(),
+	}
+}
+
+// RoundTripStats returns the number of round trips of the driver.
+func (d *drv) RoundTripStats() RoundTrips {
+	if d == nil {
+		return RoundTrips{}
+	}
+	return d.roundTrips.get()
+}
+
+// GetRoundTripStats returns the number of round trips of the default driver.
+func GetRoundTripStats() RoundTrips { return defaultDrv.RoundTripStats() }
+
+// countExecute counts an execution of the statement.
+func (st *statement) countExecute() {
+	if st.roundTrips != nil {
+		st.roundTrips.Execute++
+	}
+	if st.conn != nil && st.drv != nil {
+		st.drv.roundTrips.execute.Add(1)
+	}
+}
+
+// countFetch counts a fetch round trip of the statement.
+func (st *statement) countFetch() {
+	if st.roundTrips != nil {
+		st.roundTrips.Fetch++
+	}
+	if st.conn != nil && st.drv != nil {
+		st.drv.roundTrips.fetch.Add(1)
+	}
+}
+
+// countLOB counts a LOB round trip of the reader.
+func (dlr *dpiLobReader) countLOB() {
+	if dlr.roundTrips != nil {
+		dlr.roundTrips.LOB++
+	}
+	if dlr.drv != nil {
+		dlr.drv.roundTrips.lob.Add(1)
+	}
+}
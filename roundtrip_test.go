@@ -0,0 +1,41 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import "testing"
+
+func TestCountRoundTrips(t *testing.T) {
+	rt := RoundTrips{Execute: 1, Fetch: 2, LOB: 3}
+	if got := rt.Total(); got != 6 {
+		t.Errorf("Total: got %d, wanted 6", got)
+	}
+	var o stmtOptions
+	CountRoundTrips(&rt)(&o)
+	if rt != (RoundTrips{}) {
+		t.Errorf("not reset: %+v", rt)
+	}
+	if o.roundTrips != &rt {
+		t.Fatal("roundTrips not set")
+	}
+
+	d := &drv{}
+	st := &statement{conn: &conn{drv: d}, stmtOptions: o}
+	st.countExecute()
+	st.countFetch()
+	st.countFetch()
+	(&dpiLobReader{drv: d, roundTrips: &rt}).countLOB()
+	if want := (RoundTrips{Execute: 1, Fetch: 2, LOB: 1}); rt != want {
+		t.Errorf("got %+v, wanted %+v", rt, want)
+	}
+	if got := d.RoundTripStats(); got != rt {
+		t.Errorf("driver stats: got %+v, wanted %+v", got, rt)
+	}
+	// without the option, only the driver stats are counted
+	(&statement{conn: &conn{drv: d}}).countExecute()
+	if got := d.RoundTripStats(); got.Execute != 2 || rt.Execute != 1 {
+		t.Errorf("got %+v (driver), %+v (statement)", got, rt)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RowID is the address of a row, as returned for ROWID and UROWID columns.
+//
+// It can be bound as is, for example in "WHERE ROWID = :1".
+// The zero RowID is bound as NULL.
+type RowID string
+
+// RowIDParts are the components of an extended (physical) ROWID.
+type RowIDParts struct {
+	// Object is the data object number of the segment.
+	Object uint32
+	// File is the relative file number of the tablespace.
+	File uint32
+	// Block is the number of the data block in the file.
+	Block uint32
+	// Row is the number of the row in the block.
+	Row uint32
+}
+
+// rowIDAlphabet is the base64 alphabet of the extended ROWID format.
+const rowIDAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// The extended ROWID format is OOOOOOFFFBBBBBBRRR.
+const (
+	rowIDObjectLen = 6
+	rowIDFileLen   = 3
+	rowIDBlockLen  = 6
+	rowIDRowLen    = 3
+	rowIDLen       = rowIDObjectLen + rowIDFileLen + rowIDBlockLen + rowIDRowLen
+)
+
+var errNotExtendedRowID = errors.New("not an extended ROWID")
+
+func (r RowID) String() string { return string(r) }
+
+// IsLogical reports whether this is a logical ROWID (of an index-organized table),
+// which has no Parts.
+func (r RowID) IsLogical() bool { return strings.HasPrefix(string(r), "*") }
+
+// Parts returns the components of the extended ROWID, for diagnostics.
+//
+// Logical and foreign (non-Oracle) ROWIDs cannot be parsed.
+func (r RowID) Parts() (RowIDParts, error) {
+	var p RowIDParts
+	s := string(r)
+	if len(s) != rowIDLen {
+		return p, fmt.Errorf("%q: %w", s, errNotExtendedRowID)
+	}
+	for _, f := range []struct {
+		Dest *uint32
+		Len  int
+	}{
+		{&p.Object, rowIDObjectLen}, {&p.File, rowIDFileLen},
+		{&p.Block, rowIDBlockLen}, {&p.Row, rowIDRowLen},
+	} {
+		var n uint64
+		for _, c := range []byte(s[:f.Len]) {
+			i := strings.IndexByte(rowIDAlphabet, c)
+			if i < 0 {
+				return RowIDParts{}, fmt.Errorf("%q: %w", string(r), errNotExtendedRowID)
+			}
+			n = n<<6 | uint64(i)
+		}
+		if n > 1<<32-1 {
+			return RowIDParts{}, fmt.Errorf("%q: %w", string(r), errNotExtendedRowID)
+		}
+		*f.Dest = uint32(n)
+		s = s[f.Len:]
+	}
+	return p, nil
+}
+
+// RowID returns the extended ROWID of the parts (as DBMS_ROWID.ROWID_CREATE would).
+func (p RowIDParts) RowID() RowID {
+	var buf [rowIDLen]byte
+	b := buf[:]
+	for _, f := range []struct {
+		N   uint32
+		Len int
+	}{
+		{p.Object, rowIDObjectLen}, {p.File, rowIDFileLen},
+		{p.Block, rowIDBlockLen}, {p.Row, rowIDRowLen},
+	} {
+		n := f.N
+		for i := f.Len - 1; i >= 0; i-- {
+			b[i] = rowIDAlphabet[n&63]
+			n >>= 6
+		}
+		b = b[f.Len:]
+	}
+	return RowID(buf[:])
+}
+
+// Scan the ROWID (string) into the RowID. NULL is scanned as the zero RowID.
+func (r *RowID) Scan(src interface{}) error {
+	switch x := src.(type) {
+	case nil:
+		*r = ""
+	case string:
+		*r = RowID(x)
+	case []byte:
+		*r = RowID(x)
+	case RowID:
+		*r = x
+	default:
+		return fmt.Errorf("cannot scan %T into RowID", src)
+	}
+	return nil
+}
+
+// Value returns the RowID as string, or nil for the zero RowID.
+func (r RowID) Value() (driver.Value, error) {
+	if r == "" {
+		return nil, nil
+	}
+	return string(r), nil
+}
+
+var (
+	_ sql.Scanner   = (*RowID)(nil)
+	_ driver.Valuer = RowID("")
+)
@@ -0,0 +1,36 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import "testing"
+
+func TestRowIDParts(t *testing.T) {
+	for r, want := range map[RowID]RowIDParts{
+		"AAAR3sAAEAAAACXAAA": {Object: 73196, File: 4, Block: 151},
+		"AAAAAAAAAAAAAAAAAA": {},
+		"AAASdqAAHAAAAFkAAB": {Object: 75626, File: 7, Block: 356, Row: 1},
+	} {
+		got, err := r.Parts()
+		if err != nil {
+			t.Errorf("%q: %+v", r, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: got %+v, wanted %+v", r, got, want)
+		}
+		if back := got.RowID(); back != r {
+			t.Errorf("%+v: got %q, wanted %q", got, back, r)
+		}
+	}
+	for _, r := range []RowID{"", "*BAMAAJMCwQL+", "AAAR3sAAEAAAACXAA", "AAAR3sAAEAAAACXAA.", "//////AAEAAAACXAAA"} {
+		if got, err := r.Parts(); err == nil {
+			t.Errorf("%q: got %+v, wanted error", r, got)
+		}
+	}
+	if r := RowID("*BAMAAJMCwQL+"); !r.IsLogical() {
+		t.Errorf("%q is logical", r)
+	}
+}
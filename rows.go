@@ -8,6 +8,14 @@ package godror
 /*
 #include "dpiImpl.h"
 
+// the Oracle NUMBER in its internal format, as fetched into the variable
+static unsigned char *godror_varNumber(dpiVar *v, uint32_t pos) {
+	return v->buffer.data.asNumber[pos].value;
+}
+
+// defined in drv.go
+int godror_dpiVar_getTimeZoneName(dpiVar *var, uint32_t pos, char *buf, uint32_t *bufLen);
+
 //int dpiData_getRowidStringValue(dpiData *data, const char **value, uint32_t *valueLength) {
 //	return dpiRowid_getStringValue(data->value.asRowid, value, valueLength);
 //}
@@ -20,6 +28,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"github.com/godror/godror/slog"
 	"io"
@@ -28,6 +37,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unsafe"
 )
@@ -49,9 +59,80 @@ type rows struct {
 	data           [][]C.dpiData
 	columns        []Column
 	vars           []*C.dpiVar
+	cursors        []*rows // nested cursors of the current row
+	restoreTZ      func() error
+	converters     []typeConverter
+	bg             *bgFetch
+	slow           *slowTimer
+	interned       *interner
+	lent           [][]byte // the ZeroCopyBytes values, in debug mode
+	arena          *fetchArena
+	defineInfos    []varInfo
+	fetchArraySize int
 	bufferRowIndex C.uint32_t
 	fetched        C.uint32_t
 	fromData       bool
+	convertersSet  bool
+}
+
+// maxInterned is the maximum number of distinct values interned for a result set.
+const maxInterned = 1 << 14
+
+// interner returns the same value for the same bytes, for the InternStrings option.
+//
+// The values are stored boxed, so returning them as driver.Value does not allocate.
+type interner struct {
+	strs, byts map[string]driver.Value
+}
+
+func (in *interner) string(b []byte) driver.Value {
+	if v, ok := in.strs[string(b)]; ok {
+		return v
+	}
+	s := string(b)
+	if in.strs == nil {
+		in.strs = make(map[string]driver.Value)
+	}
+	if len(in.strs) >= maxInterned {
+		return s
+	}
+	var v driver.Value = s
+	in.strs[s] = v
+	return v
+}
+
+func (in *interner) bytes(b []byte) driver.Value {
+	if v, ok := in.byts[string(b)]; ok {
+		return v
+	}
+	p := append([]byte(nil), b...)
+	if in.byts == nil {
+		in.byts = make(map[string]driver.Value)
+	}
+	if len(in.byts) >= maxInterned {
+		return p
+	}
+	var v driver.Value = p
+	in.byts[string(b)] = v
+	return v
+}
+
+// closeIfForeground closes the rows at the end of the fetch,
+// except when fetching in the background: then Close must wait for the fetcher.
+func (r *rows) closeIfForeground() {
+	if r.bg == nil {
+		_ = r.Close()
+	}
+}
+
+// closeCursors closes the nested cursors of the current row,
+// as they are valid only till the parent advances.
+func (r *rows) closeCursors() {
+	cursors := r.cursors
+	r.cursors = r.cursors[:0]
+	for _, c := range cursors {
+		c.Close()
+	}
 }
 
 // Columns returns the names of the columns. The number of
@@ -71,10 +152,36 @@ func (r *rows) Close() error {
 	if r == nil {
 		return nil
 	}
-	vars, st, nextRs := r.vars, r.statement, r.nextRs
-	r.columns, r.vars, r.data, r.statement, r.nextRs = nil, nil, nil, nil, nil
+	if bg := r.bg; bg != nil {
+		bg.close()
+	}
+	if slow := r.slow; slow != nil && r.statement != nil {
+		r.slow = nil
+		slow.report(r.statement.conn)
+	}
+	r.closeCursors()
+	r.revokeZeroCopy()
+	if a := r.arena; a != nil && r.statement != nil && r.statement.fetchArenaDest != nil {
+		*r.statement.fetchArenaDest = a.counters.stats()
+	}
+	r.arena = nil
+	if restoreTZ := r.restoreTZ; restoreTZ != nil {
+		r.restoreTZ = nil
+		if err := restoreTZ(); err != nil {
+			ctx := context.TODO()
+			if logger := getLogger(ctx); logger != nil {
+				logger.Error("restore session time zone", "error", err)
+			}
+		}
+	}
+	vars, data, infos, st, nextRs := r.vars, r.data, r.defineInfos, r.statement, r.nextRs
+	r.columns, r.vars, r.data, r.defineInfos, r.statement, r.nextRs = nil, nil, nil, nil, nil, nil
 	fromData := r.fromData
 	r.fromData = false
+	if st != nil && !fromData && nextRs == nil && st.dpiStmt != nil && st.dpiStmt.refCount >= 2 {
+		// the statement lives on, keep the define variables for its next execution
+		vars = st.keepDefines(vars, data, infos)
+	}
 	for _, v := range vars[:cap(vars)] {
 		if v != nil {
 			C.dpiVar_release(v)
@@ -132,9 +239,31 @@ func (r *rows) ColumnTypeLength(index int) (length int64, ok bool) {
 
 // ColumnTypeDatabaseTypeName returns the database system type name without the length.
 // Type names should be uppercase.
+// For user-defined types, this is the full (schema.name) name of the type.
 // Examples of returned types: "VARCHAR", "NVARCHAR", "VARCHAR2", "CHAR", "TEXT", "DECIMAL", "SMALLINT", "INT", "BIGINT", "BOOL", "[]BIGINT", "JSONB", "XML", "TIMESTAMP".
 func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
-	switch r.columns[index].OrigOracleType {
+	if r.columns[index].OrigOracleType == C.DPI_ORACLE_TYPE_OBJECT {
+		if ot := r.columns[index].ObjectType; ot != nil && r.statement != nil {
+			if name, err := r.statement.objectTypeName(ot); err == nil {
+				return name
+			}
+		}
+		return "OBJECT"
+	}
+	return oracleTypeName(r.columns[index].OrigOracleType)
+}
+
+// errNumberOverflow is returned by NumberByPrecision for values not fitting into int64.
+var errNumberOverflow = errors.New("NUMBER does not fit into int64")
+
+// isIntegerColumn reports whether the column is NUMBER(p,0) with p <= 18.
+func isIntegerColumn(col Column) bool {
+	return col.OrigOracleType == C.DPI_ORACLE_TYPE_NUMBER && col.Scale == 0 && col.Precision > 0 && col.Precision <= 18
+}
+
+// oracleTypeName returns the database type name of the (non-object) Oracle type.
+func oracleTypeName(typ C.dpiOracleTypeNum) string {
+	switch typ {
 	case C.DPI_ORACLE_TYPE_VARCHAR:
 		return "VARCHAR2"
 	case C.DPI_ORACLE_TYPE_NVARCHAR:
@@ -194,7 +323,7 @@ func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
 	case C.DPI_ORACLE_TYPE_VECTOR:
 		return "VECTOR"
 	default:
-		return fmt.Sprintf("OTHER[%d]", r.columns[index].OracleType)
+		return fmt.Sprintf("OTHER[%d]", typ)
 	}
 }
 
@@ -234,6 +363,9 @@ func (r *rows) ColumnTypeScanType(index int) reflect.Type {
 		C.DPI_ORACLE_TYPE_LONG_RAW:
 		return reflect.TypeOf([]byte(nil))
 	case C.DPI_ORACLE_TYPE_NUMBER:
+		if r.statement != nil && r.statement.NumberAsDecimal128() {
+			return reflect.TypeOf(Decimal128{})
+		}
 		switch col.NativeType {
 		case C.DPI_NATIVE_TYPE_INT64:
 			return reflect.TypeOf(int64(0))
@@ -257,6 +389,9 @@ func (r *rows) ColumnTypeScanType(index int) reflect.Type {
 	case C.DPI_ORACLE_TYPE_TIMESTAMP, C.DPI_NATIVE_TYPE_TIMESTAMP,
 		C.DPI_ORACLE_TYPE_TIMESTAMP_TZ, C.DPI_ORACLE_TYPE_TIMESTAMP_LTZ,
 		C.DPI_ORACLE_TYPE_DATE:
+		if col.OracleType == C.DPI_ORACLE_TYPE_TIMESTAMP_TZ && r.statement != nil && r.statement.fetchTimestampTZ {
+			return reflect.TypeOf(TimestampTZ{})
+		}
 		return reflect.TypeOf(NullTime{})
 	case C.DPI_ORACLE_TYPE_INTERVAL_DS, C.DPI_NATIVE_TYPE_INTERVAL_DS:
 		return reflect.TypeOf(time.Duration(0))
@@ -291,12 +426,29 @@ const debugRowsNext = false
 //
 // As with all Objects, you MUST call Close on the returned Object instances when they're not needed anymore!
 func (r *rows) Next(dest []driver.Value) error {
+	if r.bg != nil {
+		return r.nextBackground(dest)
+	}
+	if r.err == nil && r.statement != nil && r.statement.BackgroundFetch() &&
+		r.fetched == 0 && r.canFetchInBackground() {
+		if len(dest) != len(r.columns) {
+			return fmt.Errorf("column count mismatch: we have %d columns, but given %d destination", len(r.columns), len(dest))
+		}
+		r.startBackgroundFetch()
+		return r.nextBackground(dest)
+	}
+	return r.next(dest)
+}
+
+func (r *rows) next(dest []driver.Value) error {
 	if r.err != nil {
 		return r.err
 	}
 	if len(dest) != len(r.columns) {
 		return fmt.Errorf("column count mismatch: we have %d columns, but given %d destination", len(r.columns), len(dest))
 	}
+	r.closeCursors()
+	r.revokeZeroCopy()
 	ctx := context.Background()
 	logger := getLogger(ctx)
 
@@ -310,7 +462,7 @@ func (r *rows) Next(dest []driver.Value) error {
 			if r.err = ctx.Err(); r.err != nil {
 				return r.err
 			}
-			if _, hasDeadline := r.statement.ctx.Deadline(); hasDeadline {
+			if _, hasDeadline := r.statement.ctx.Deadline(); hasDeadline || r.statement.callTimeout != 0 {
 				// handle deadline for dpiStmt_fetchRows. context reused from stmt
 				cleanup, err := r.statement.handleDeadline(ctx)
 				if err != nil {
@@ -323,14 +475,66 @@ func (r *rows) Next(dest []driver.Value) error {
 		var moreRows C.int
 		var start time.Time
 		maxRows := C.uint32_t(r.statement.FetchArraySize())
+		if r.fetchArraySize > 0 {
+			maxRows = C.uint32_t(r.fetchArraySize)
+		}
 		r.statement.Lock()
 		if debugRowsNext {
 			fmt.Printf("fetching max=%d\n", maxRows)
 			start = time.Now()
 		}
-		err := r.statement.checkExecNoLOT(func() C.int {
-			return C.dpiStmt_fetchRows(r.dpiStmt, maxRows, &r.bufferRowIndex, &r.fetched, &moreRows)
-		})
+		stmtCtx := r.statement.ctx
+		var span Span
+		if r.statement.traceFetch && stmtCtx != nil {
+			_, span = r.statement.tracer.Start(stmtCtx, "fetch", TraceAttr{Key: "oracle.fetch_array_size", Value: int(maxRows)})
+		}
+		var fetchStart time.Time
+		if r.slow != nil {
+			fetchStart = time.Now()
+		}
+		fetch := func() error {
+			stop := r.statement.conn.watchCancel(stmtCtx, "dpiStmt_fetchRows")
+			defer stop()
+			return r.statement.checkExecNoLOT(func() C.int {
+				return C.dpiStmt_fetchRows(r.dpiStmt, maxRows, &r.bufferRowIndex, &r.fetched, &moreRows)
+			})
+		}
+		// dpiStmt_fetchRows serves the rows from its buffer while it can
+		reaches := r.dpiStmt.bufferRowIndex >= r.dpiStmt.bufferRowCount && r.dpiStmt.hasRowsToFetch != 0
+		firstFetch := r.dpiStmt.bufferRowCount == 0
+		var err error
+		if r.statement.conn.intercepted() {
+			op := &Op{Kind: OpFetch, Query: r.statement.query}
+			err = r.statement.conn.intercept(stmtCtx, op, func(ctx context.Context, op *Op) error {
+				err := fetch()
+				op.Fetched = int(r.fetched)
+				return err
+			})
+		} else {
+			err = fetch()
+		}
+		// the first fetch is served from the rows prefetched with the execution if the result is shorter
+		if reaches && !(firstFetch && err == nil && int(r.fetched) < r.statement.PrefetchCount()) {
+			r.statement.countFetch()
+		}
+		if r.slow != nil {
+			r.slow.sq.Fetch += time.Since(fetchStart)
+			r.slow.sq.Rows += int64(r.fetched)
+			if err != nil {
+				r.slow.sq.Err = err
+			}
+		}
+		if span != nil {
+			span.SetAttributes(TraceAttr{Key: "db.response.returned_rows", Value: int(r.fetched)})
+			span.End(err)
+		}
+		if c := r.statement.conn; c != nil && c.drv != nil && c.drv.metrics.enabled.Load() {
+			if err != nil {
+				c.drv.metrics.addError(err)
+			} else {
+				c.drv.metrics.rowsFetched.Add(uint64(r.fetched))
+			}
+		}
 		failed := err != nil
 		if debugRowsNext {
 			fmt.Printf("failed=%t bri=%d fetched=%d more=%d data=%d cols=%d dur=%s\n", failed, r.bufferRowIndex, r.fetched, moreRows, len(r.data), len(r.columns), time.Since(start))
@@ -340,9 +544,11 @@ func (r *rows) Next(dest []driver.Value) error {
 			if logger != nil {
 				logger.Error("fetch", "error", err)
 			}
-			_ = r.Close()
+			r.closeIfForeground()
 			if strings.Contains(err.Error(), "DPI-1039: statement was already closed") {
 				r.err = io.EOF
+			} else if stmtCtx != nil && stmtCtx.Err() != nil {
+				r.err = stmtCtx.Err()
 			} else {
 				r.err = fmt.Errorf("Next: %w", err)
 			}
@@ -352,7 +558,7 @@ func (r *rows) Next(dest []driver.Value) error {
 			logger.Debug("fetched", "bri", r.bufferRowIndex, "fetched", r.fetched, "moreRows", moreRows, "len(data)", len(r.data), "cols", len(r.columns))
 		}
 		if r.fetched == 0 {
-			_ = r.Close()
+			r.closeIfForeground()
 			r.err = io.EOF
 			return r.err
 		}
@@ -377,6 +583,25 @@ func (r *rows) Next(dest []driver.Value) error {
 	nullDate := r.statement.NullDate()
 	nass := r.statement.NumberAsString()
 	naf := !nass && r.statement.NumberAsFloat64()
+	nad := r.statement.NumberAsDecimal128()
+	nai := r.statement.NumberAsInt64()
+	nbp := r.statement.NumberByPrecision()
+	nfm := r.statement.nonFiniteFetch
+	ftz := r.statement.fetchTimestampTZ
+	scc := r.statement.strictCharset
+	tcs := r.statement.trimChar
+	sentinels := r.statement.dateSentinels
+	zc := r.statement.zeroCopyBytes
+	var arena *fetchArena
+	if r.statement.fetchArena {
+		arena = r.fetchArena()
+	}
+	if r.interned == nil && r.statement.InternStrings() {
+		r.interned = new(interner)
+	}
+	if !r.convertersSet {
+		r.converters, r.convertersSet = r.columnConverters(), true
+	}
 
 	//fmt.Printf("bri=%d fetched=%d\n", r.bufferRowIndex, r.fetched)
 	//fmt.Printf("data=%#v\n", r.data[0][r.bufferRowIndex])
@@ -386,6 +611,15 @@ func (r *rows) Next(dest []driver.Value) error {
 		d := &r.data[i][r.bufferRowIndex]
 		isNull := d.isNull == 1
 
+		if r.converters != nil && r.converters[i].toGo != nil {
+			v, err := r.convertColumn(r.converters[i].toGo, col, d)
+			if err != nil {
+				return fmt.Errorf("convert %s: %w", col.Name, err)
+			}
+			dest[i] = v
+			continue
+		}
+
 		switch typ {
 		case C.DPI_ORACLE_TYPE_VARCHAR, C.DPI_ORACLE_TYPE_NVARCHAR,
 			C.DPI_ORACLE_TYPE_CHAR, C.DPI_ORACLE_TYPE_NCHAR,
@@ -402,7 +636,27 @@ func (r *rows) Next(dest []driver.Value) error {
 				dest[i] = ""
 				continue
 			}
-			if b.length < 10 {
+			if scc {
+				if err := checkCharsetConversion(unsafe.Slice((*byte)(unsafe.Pointer(b.ptr)), b.length)); err != nil {
+					return fmt.Errorf("column %s: %w", col.Name, err)
+				}
+			}
+			if tcs && (typ == C.DPI_ORACLE_TYPE_CHAR || typ == C.DPI_ORACLE_TYPE_NCHAR) {
+				bb := trimBlanks(unsafe.Slice((*byte)(unsafe.Pointer(b.ptr)), b.length))
+				if zc {
+					dest[i] = r.zeroCopyBytes(bb)
+				} else if r.interned != nil {
+					dest[i] = r.interned.string(bb)
+				} else {
+					dest[i] = string(bb)
+				}
+				continue
+			}
+			if zc {
+				dest[i] = r.zeroCopyBytes(unsafe.Slice((*byte)(unsafe.Pointer(b.ptr)), b.length))
+			} else if r.interned != nil {
+				dest[i] = r.interned.string(unsafe.Slice((*byte)(unsafe.Pointer(b.ptr)), b.length))
+			} else if b.length < 10 {
 				//bb := ((*[1 << 30]byte)((unsafe.Pointer(b.ptr))))[:int(b.length):int(b.length)]
 				bb := unsafe.Slice((*byte)(unsafe.Pointer(b.ptr)), b.length)
 				dest[i] = string(bb)
@@ -415,6 +669,31 @@ func (r *rows) Next(dest []driver.Value) error {
 				dest[i] = nil
 				continue
 			}
+			if nbp && col.NativeType == C.DPI_NATIVE_TYPE_INT64 && isIntegerColumn(col) &&
+				r.vars[i] != nil && r.vars[i]._type.oracleTypeNum == C.DPI_ORACLE_TYPE_NUMBER {
+				on := ociNumber(unsafe.Slice((*byte)(unsafe.Pointer(
+					C.godror_varNumber(r.vars[i], C.uint32_t(r.bufferRowIndex)))), C.DPI_OCI_NUMBER_SIZE))
+				v, ok := on.integer()
+				if _, isInt := v.(int64); !ok || !isInt {
+					return fmt.Errorf("column %s NUMBER(%d): value %s: %w", col.Name, col.Precision,
+						decimal128FromOCINumber(on), errNumberOverflow)
+				}
+				dest[i] = v
+				continue
+			}
+			if (nad || nai && col.NativeType == C.DPI_NATIVE_TYPE_BYTES) &&
+				r.vars[i] != nil && r.vars[i]._type.oracleTypeNum == C.DPI_ORACLE_TYPE_NUMBER {
+				on := ociNumber(unsafe.Slice((*byte)(unsafe.Pointer(
+					C.godror_varNumber(r.vars[i], C.uint32_t(r.bufferRowIndex)))), C.DPI_OCI_NUMBER_SIZE))
+				if nad {
+					dest[i] = decimal128FromOCINumber(on)
+					continue
+				}
+				if v, ok := on.integer(); ok {
+					dest[i] = v
+					continue
+				}
+			}
 			switch col.NativeType {
 			case C.DPI_NATIVE_TYPE_INT64:
 				//dest[i] = int64(C.dpiData_getInt64(d))
@@ -451,7 +730,7 @@ func (r *rows) Next(dest []driver.Value) error {
 					dest[i] = printFloat(f64)
 				}
 			case C.DPI_NATIVE_TYPE_BOOLEAN:
-				dest[i] = d.isNull != 0 && C.dpiData_getBool(d) != 0
+				dest[i] = dpiData_getBool(d)
 			default:
 				//b := C.dpiData_getBytes(d)
 				b := (*C.dpiBytes)(unsafe.Pointer(&d.value))
@@ -459,13 +738,18 @@ func (r *rows) Next(dest []driver.Value) error {
 				//bb := ((*[1 << 30]byte)((unsafe.Pointer(b.ptr))))[:int(b.length):int(b.length)]
 				bb := unsafe.Slice((*byte)(unsafe.Pointer(b.ptr)), b.length)
 
-				if nass {
+				if nass && arena != nil {
+					dest[i] = arena.number(bb, true)
+				} else if nass {
 					dest[i] = string(bb)
 				} else if naf {
-					var err error
-					if dest[i], err = strconv.ParseFloat(string(bb), 64); err != nil {
+					f, err := strconv.ParseFloat(string(bb), 64)
+					if err != nil {
 						return fmt.Errorf("parse %q as float64: %w", string(bb), err)
 					}
+					dest[i] = f
+				} else if arena != nil {
+					dest[i] = arena.number(bb, false)
 				} else {
 					dest[i] = Number(bb)
 				}
@@ -499,6 +783,14 @@ func (r *rows) Next(dest []driver.Value) error {
 				dest[i] = []byte{}
 				continue
 			}
+			if zc {
+				dest[i] = r.zeroCopyBytes(unsafe.Slice((*byte)(unsafe.Pointer(b.ptr)), b.length))
+				continue
+			}
+			if r.interned != nil {
+				dest[i] = r.interned.bytes(unsafe.Slice((*byte)(unsafe.Pointer(b.ptr)), b.length))
+				continue
+			}
 			dest[i] = C.GoBytes(unsafe.Pointer(b.ptr), C.int(b.length))
 		case C.DPI_ORACLE_TYPE_NATIVE_FLOAT, C.DPI_NATIVE_TYPE_FLOAT:
 			if isNull {
@@ -506,14 +798,30 @@ func (r *rows) Next(dest []driver.Value) error {
 				continue
 			}
 			//dest[i] = float32(C.dpiData_getFloat(d))
-			dest[i] = *((*float32)(unsafe.Pointer(&d.value)))
+			f32 := *((*float32)(unsafe.Pointer(&d.value)))
+			if nfm != NonFiniteKeep && isNonFinite(float64(f32)) {
+				if nfm == NonFiniteError {
+					return fmt.Errorf("column %s: %v: %w", col.Name, f32, ErrNonFinite)
+				}
+				dest[i] = nil
+				continue
+			}
+			dest[i] = f32
 		case C.DPI_ORACLE_TYPE_NATIVE_DOUBLE, C.DPI_NATIVE_TYPE_DOUBLE:
 			if isNull {
 				dest[i] = nil
 				continue
 			}
 			//dest[i] = float64(C.dpiData_getDouble(d))
-			dest[i] = *((*float64)(unsafe.Pointer(&d.value)))
+			f64 := *((*float64)(unsafe.Pointer(&d.value)))
+			if nfm != NonFiniteKeep && isNonFinite(f64) {
+				if nfm == NonFiniteError {
+					return fmt.Errorf("column %s: %v: %w", col.Name, f64, ErrNonFinite)
+				}
+				dest[i] = nil
+				continue
+			}
+			dest[i] = f64
 		case C.DPI_ORACLE_TYPE_NATIVE_INT, C.DPI_NATIVE_TYPE_INT64:
 			if isNull {
 				dest[i] = nil
@@ -538,6 +846,10 @@ func (r *rows) Next(dest []driver.Value) error {
 			}
 			//ts := C.dpiData_getTimestamp(d)
 			ts := *((*C.dpiTimestamp)(unsafe.Pointer(&d.value)))
+			if sentinels != nil && isDateSentinel(ts, sentinels) {
+				dest[i] = nullDate
+				continue
+			}
 			tz := r.conn.Timezone()
 			if col.OracleType == C.DPI_ORACLE_TYPE_TIMESTAMP_TZ || col.OracleType == C.DPI_ORACLE_TYPE_TIMESTAMP_LTZ {
 				tz = timeZoneFor(ts.tzHourOffset, ts.tzMinuteOffset,
@@ -550,11 +862,23 @@ func (r *rows) Next(dest []driver.Value) error {
 				}
 				tz = time.Local
 			}
-			dest[i] = time.Date(
+			t := time.Date(
 				int(ts.year), time.Month(ts.month), int(ts.day),
 				int(ts.hour), int(ts.minute), int(ts.second), int(ts.fsecond),
 				tz,
 			)
+			if col.OracleType == C.DPI_ORACLE_TYPE_TIMESTAMP_LTZ && r.ltzLocation != nil {
+				t = t.In(r.ltzLocation)
+			}
+			dest[i] = t
+			if ftz && col.OracleType == C.DPI_ORACLE_TYPE_TIMESTAMP_TZ && r.vars[i] != nil {
+				var buf [64]C.char
+				n := C.uint32_t(len(buf))
+				if C.godror_dpiVar_getTimeZoneName(r.vars[i], C.uint32_t(r.bufferRowIndex), &buf[0], &n) == C.DPI_FAILURE {
+					return fmt.Errorf("column %s: get time zone name: %w", col.Name, r.conn.getError())
+				}
+				dest[i] = newTimestampTZ(t, C.GoStringN(&buf[0], C.int(n)))
+			}
 			if logger != nil && logger.Enabled(context.Background(), slog.LevelDebug) {
 				logger.Debug("DATE", "i", i, "oraTyp", col.OracleType, "tz", fmt.Sprintf("%+v", tz), "ts", fmt.Sprintf("%+v", ts), "dest", dest[i])
 			}
@@ -563,17 +887,20 @@ func (r *rows) Next(dest []driver.Value) error {
 				dest[i] = nil
 				continue
 			}
-			var t time.Duration
-			dataGetIntervalDS(ctx, &t, d)
-			dest[i] = t
+			// as time.Duration if it fits, as IntervalDS otherwise - IntervalDS.Scan accepts both
+			ds := dpiData_getIntervalDS(d)
+			if t, err := ds.Duration(); err == nil {
+				dest[i] = t
+			} else {
+				dest[i] = ds
+			}
 		case C.DPI_ORACLE_TYPE_INTERVAL_YM, C.DPI_NATIVE_TYPE_INTERVAL_YM:
 			if isNull {
 				dest[i] = nil
 				continue
 			}
-			//ym := C.dpiData_getIntervalYM(d)
-			ym := *((*C.dpiIntervalYM)(unsafe.Pointer(&d.value)))
-			dest[i] = strconv.Itoa(int(ym.years)) + "-" + strconv.Itoa(int(ym.months))
+			// as string, to be scannable into string, too - IntervalYM.Scan parses it
+			dest[i] = dpiData_getIntervalYM(d).String()
 
 		case C.DPI_ORACLE_TYPE_CLOB, C.DPI_ORACLE_TYPE_NCLOB,
 			C.DPI_ORACLE_TYPE_BLOB,
@@ -590,7 +917,7 @@ func (r *rows) Next(dest []driver.Value) error {
 			}
 			rdr := &dpiLobReader{
 				drv: r.drv, dpiLob: C.dpiData_getLOB(d),
-				IsClob: isClob,
+				IsClob: isClob, roundTrips: r.roundTrips,
 			}
 			if isClob && (r.ClobAsString() || !r.LobAsReader()) {
 				sb := stringBuilders.Get()
@@ -604,6 +931,7 @@ func (r *rows) Next(dest []driver.Value) error {
 				stringBuilders.Put(sb)
 				continue
 			}
+			resourceCounters.lobs.Add(1)
 			dest[i] = &Lob{Reader: rdr, IsClob: rdr.IsClob}
 
 		case C.DPI_ORACLE_TYPE_STMT, C.DPI_NATIVE_TYPE_STMT:
@@ -614,6 +942,12 @@ func (r *rows) Next(dest []driver.Value) error {
 			st := &statement{conn: r.conn, dpiStmt: C.dpiData_getStmt(d),
 				stmtOptions: r.statement.stmtOptions, // inherit parent statement's options
 			}
+			resourceCounters.stmts.Add(1)
+			// The define variable holds its own reference, which is released on the next fetch;
+			// this one is released by st.Close.
+			if err := r.statement.checkExecNoLOT(func() C.int { return C.dpiStmt_addRef(st.dpiStmt) }); err != nil {
+				return fmt.Errorf("addRef: %w", err)
+			}
 			var colCount C.uint32_t
 			if err := r.statement.checkExecNoLOT(func() C.int {
 				return C.dpiStmt_getNumQueryColumns(st.dpiStmt, &colCount)
@@ -636,6 +970,7 @@ func (r *rows) Next(dest []driver.Value) error {
 			}
 			r2.fromData = true
 			stmtSetFinalizer(ctx, st, "Next")
+			r.cursors = append(r.cursors, r2)
 			dest[i] = r2
 
 		case C.DPI_ORACLE_TYPE_BOOLEAN, C.DPI_NATIVE_TYPE_BOOLEAN:
@@ -643,8 +978,7 @@ func (r *rows) Next(dest []driver.Value) error {
 				dest[i] = nil
 				continue
 			}
-			//dest[i] = C.dpiData_getBool(d) == 1
-			dest[i] = *((*C.int)(unsafe.Pointer(&d.value))) == 1
+			dest[i] = dpiData_getBool(d)
 
 		case C.DPI_ORACLE_TYPE_OBJECT: //Default type used for named type columns in the database. Data is transferred to/from Oracle in Oracle's internal format.
 			if isNull {
@@ -720,6 +1054,110 @@ func (r *rows) Next(dest []driver.Value) error {
 	return nil
 }
 
+// bgBatch is a fetched array of rows, converted to Go values.
+type bgBatch struct {
+	err   error
+	arena *fetchArena
+	rows  [][]driver.Value
+	vals  []driver.Value // the slab the rows are carved from
+}
+
+// bgFetch is the state of fetching the rows in the background (see BackgroundFetch).
+type bgFetch struct {
+	err     error
+	batches chan *bgBatch
+	stop    chan struct{}
+	done    chan struct{}
+	arena   *fetchArena
+	last    *bgBatch // the batch being consumed, to be reused
+	current [][]driver.Value
+	once    sync.Once
+}
+
+// canFetchInBackground reports whether all the columns are converted to self-contained Go values,
+// not referencing the fetch buffers.
+func (r *rows) canFetchInBackground() bool {
+	if r.statement != nil && r.statement.zeroCopyBytes {
+		// the values alias the fetch buffers
+		return false
+	}
+	for _, col := range r.columns {
+		switch col.OracleType {
+		case C.DPI_ORACLE_TYPE_STMT, C.DPI_ORACLE_TYPE_OBJECT, C.DPI_ORACLE_TYPE_JSON,
+			C.DPI_ORACLE_TYPE_BLOB, C.DPI_ORACLE_TYPE_BFILE:
+			return false
+		case C.DPI_ORACLE_TYPE_CLOB, C.DPI_ORACLE_TYPE_NCLOB:
+			// without LobAsReader, they're read into strings
+			// (CLOB and BLOB are even defined as LONG and LONG RAW)
+			if r.LobAsReader() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// startBackgroundFetch starts a goroutine which converts the fetched array of rows,
+// and fetches the next array while the previous is consumed.
+func (r *rows) startBackgroundFetch() {
+	bg := &bgFetch{
+		batches: make(chan *bgBatch, 1), stop: make(chan struct{}), done: make(chan struct{}),
+		arena: r.fetchArena(),
+	}
+	r.bg = bg
+	go func() {
+		defer close(bg.done)
+		defer close(bg.batches)
+		for {
+			batch := bg.arena.batch()
+			for {
+				dest := batch.nextRow(len(r.columns))
+				if batch.err = r.next(dest); batch.err != nil {
+					break
+				}
+				batch.addRow(dest)
+				if r.fetched == 0 { // the fetched array is consumed
+					break
+				}
+			}
+			select {
+			case bg.batches <- batch:
+			case <-bg.stop:
+				return
+			}
+			if batch.err != nil {
+				return
+			}
+		}
+	}()
+}
+
+// nextBackground returns the next row fetched by the background goroutine.
+func (r *rows) nextBackground(dest []driver.Value) error {
+	bg := r.bg
+	for len(bg.current) == 0 {
+		if bg.err != nil {
+			return bg.err
+		}
+		bg.arena.put(bg.last)
+		batch, ok := <-bg.batches
+		if bg.last = batch; !ok {
+			bg.err = io.EOF
+			return bg.err
+		}
+		bg.current, bg.err = batch.rows, batch.err
+	}
+	copy(dest, bg.current[0])
+	bg.current = bg.current[1:]
+	return nil
+}
+
+// close stops the background goroutine and waits for it to finish.
+func (bg *bgFetch) close() {
+	bg.once.Do(func() { close(bg.stop) })
+	<-bg.done
+}
+
 var _ = driver.Rows((*directRow)(nil))
 
 type directRow struct {
@@ -808,6 +1246,7 @@ func (r *rows) NextResultSet() error {
 		return fmt.Errorf("getImplicitResult: %w", io.EOF)
 	}
 	st := &statement{conn: r.conn, dpiStmt: r.nextRs}
+	resourceCounters.stmts.Add(1)
 
 	var n C.uint32_t
 	logger := getLogger(context.TODO())
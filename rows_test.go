@@ -0,0 +1,124 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestInterner(t *testing.T) {
+	var in interner
+	a, b := []byte("CODE"), []byte("CODE")
+	if sa, sb := in.string(a), in.string(b); sa != sb || sa != "CODE" {
+		t.Errorf("got %q and %q", sa, sb)
+	}
+	if _, ok := in.bytes(a).([]byte); !ok {
+		t.Errorf("bytes returned %T", in.bytes(a))
+	}
+	a[0] = 'X' // the interned values must not change
+	if got := in.string(b); got != "CODE" {
+		t.Errorf("got %q, wanted CODE", got)
+	}
+	if got := string(in.bytes(b).([]byte)); got != "CODE" {
+		t.Errorf("got %q, wanted CODE", got)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = in.string(b) }); allocs != 0 {
+		t.Errorf("interned string allocates %.1f times", allocs)
+	}
+
+	for i := 0; i < maxInterned+10; i++ {
+		in.string([]byte(fmt.Sprintf("%d", i)))
+	}
+	if len(in.strs) != maxInterned {
+		t.Errorf("interned %d strings, wanted at most %d", len(in.strs), maxInterned)
+	}
+}
+
+func TestZeroCopyBytes(t *testing.T) {
+	var r rows
+	buf := []byte("fetch buffer")
+	if got := r.zeroCopyBytes(buf); &got[0] != &buf[0] {
+		t.Error("not aliased")
+	}
+
+	SetZeroCopyDebug(true)
+	defer SetZeroCopyDebug(false)
+	got := r.zeroCopyBytes(buf)
+	if &got[0] == &buf[0] || string(got) != "fetch buffer" {
+		t.Errorf("got %q, wanted a copy", got)
+	}
+	r.revokeZeroCopy()
+	if !bytes.Equal(got, bytes.Repeat([]byte{zeroCopyPoison}, len(buf))) {
+		t.Errorf("got %q, wanted poisoned", got)
+	}
+	if string(buf) != "fetch buffer" || len(r.lent) != 0 {
+		t.Errorf("buf=%q lent=%d", buf, len(r.lent))
+	}
+}
+
+func TestFetchArena(t *testing.T) {
+	var r rows
+	a := r.fetchArena()
+	if r.fetchArena() != a {
+		t.Fatal("arena is not reused")
+	}
+	before := GetFetchArenaStats()
+	b := a.batch()
+	for i := 0; i < 100; i++ {
+		row := b.nextRow(2)
+		row[0], row[1] = i, fmt.Sprint(i)
+		b.addRow(row)
+	}
+	for i, row := range b.rows {
+		if row[0] != i || row[1] != fmt.Sprint(i) {
+			t.Fatalf("%d. got %v", i, row)
+		}
+	}
+	a.put(b)
+	if len(b.rows) != 0 || len(b.vals) != 0 || b.vals[:1][0] != nil || b.rows[:1][0] != nil {
+		t.Errorf("put batch is not reset: %d rows, %d values", len(b.rows), len(b.vals))
+	}
+	if got := a.batch(); got != b {
+		t.Error("batch is not reused")
+	}
+	want := FetchArenaStats{Arenas: 1, Batches: 1, BatchReuses: 1, Slabs: 2}
+	if got := a.counters.stats(); got != want {
+		t.Errorf("got %+v, wanted %+v", got, want)
+	}
+	if after := GetFetchArenaStats(); after.BatchReuses-before.BatchReuses != 1 || after.Slabs-before.Slabs != 2 {
+		t.Errorf("got %+v, wanted 1 reuse and 2 slabs since %+v", after, before)
+	}
+}
+
+func TestFetchArenaValues(t *testing.T) {
+	var a fetchArena
+	var vals []driver.Value
+	const n = arenaTextLen / 2
+	for i := 0; i < n; i++ {
+		vals = append(vals, a.number([]byte(strconv.Itoa(i)), false), a.number([]byte("-"+strconv.Itoa(i)), true))
+	}
+	for i := 0; i < n; i++ {
+		want := []driver.Value{Number(strconv.Itoa(i)), "-" + strconv.Itoa(i)}
+		if got := vals[2*i : 2*i+2]; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d. got %#v, wanted %#v", i, got, want)
+		}
+	}
+	if got := a.counters.stats(); got.Values != int64(len(vals)) || got.Slabs < 2 {
+		t.Errorf("got %+v", got)
+	}
+
+	if n := testing.AllocsPerRun(1000, func() {
+		_ = a.number([]byte("3.14"), false)
+	}); n > 1 {
+		t.Errorf("got %.2f allocations per value, wanted only the boxing", n)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// JobType is the type of the action of a scheduler job.
+type JobType string
+
+// The job types of DBMS_SCHEDULER.CREATE_JOB.
+const (
+	JobPLSQLBlock      = JobType("PLSQL_BLOCK")
+	JobStoredProcedure = JobType("STORED_PROCEDURE")
+	JobExecutable      = JobType("EXECUTABLE")
+	JobExternalScript  = JobType("EXTERNAL_SCRIPT")
+	JobSQLScript       = JobType("SQL_SCRIPT")
+)
+
+// SchedulerJob is the definition of a DBMS_SCHEDULER job.
+type SchedulerJob struct {
+	// StartDate is the first run, the zero value means right after enabling.
+	// EndDate is after the last run, the zero value means no end.
+	StartDate, EndDate time.Time
+	// Name is the [schema.]name of the job.
+	Name string
+	Type JobType
+	// Action is the PL/SQL block, the name of the procedure or the path of the executable.
+	Action string
+	// RepeatInterval is the calendaring expression (such as "FREQ=DAILY;BYHOUR=2"),
+	// empty for a job run once.
+	RepeatInterval string
+	Comments       string
+	// NumberOfArguments of the procedure or executable, to be set with SetJobArgument.
+	NumberOfArguments int
+	// Enabled enables the job on creation - do not set it when the job has arguments yet to be set.
+	Enabled bool
+	// AutoDrop drops the job after its last run.
+	AutoDrop bool
+}
+
+// CreateJob creates the scheduler job, with DBMS_SCHEDULER.CREATE_JOB.
+func CreateJob(ctx context.Context, ex Execer, job SchedulerJob) error {
+	name, err := jobName(job.Name)
+	if err != nil {
+		return err
+	}
+	typ := job.Type
+	if typ == "" {
+		typ = JobPLSQLBlock
+	}
+	const qry = `BEGIN
+  DBMS_SCHEDULER.create_job(job_name=>:1, job_type=>:2, job_action=>:3,
+    number_of_arguments=>:4, start_date=>:5, repeat_interval=>:6, end_date=>:7,
+    enabled=>:8 = 1, auto_drop=>:9 = 1, comments=>:10);
+END;`
+	if _, err = ex.ExecContext(ctx, qry,
+		name, string(typ), job.Action, job.NumberOfArguments,
+		optionalTime(job.StartDate), job.RepeatInterval, optionalTime(job.EndDate),
+		boolToInt(job.Enabled), boolToInt(job.AutoDrop), job.Comments,
+	); err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return nil
+}
+
+// SetJobArgument sets the value of the argument (from 1) of the job.
+func SetJobArgument(ctx context.Context, ex Execer, jobName string, position int, value string) error {
+	return execJob(ctx, ex,
+		"BEGIN DBMS_SCHEDULER.set_job_argument_value(job_name=>:1, argument_position=>:2, argument_value=>:3); END;",
+		jobName, position, value)
+}
+
+// EnableJob enables the job.
+func EnableJob(ctx context.Context, ex Execer, jobName string) error {
+	return execJob(ctx, ex, "BEGIN DBMS_SCHEDULER.enable(name=>:1); END;", jobName)
+}
+
+// DisableJob disables the job. With force, a running job is let to finish, without force it is an error.
+func DisableJob(ctx context.Context, ex Execer, jobName string, force bool) error {
+	return execJob(ctx, ex, "BEGIN DBMS_SCHEDULER.disable(name=>:1, force=>:2 = 1); END;", jobName, boolToInt(force))
+}
+
+// RunJob runs the job now. With useCurrentSession, the job runs in the session of ex,
+// and RunJob returns when it finished; otherwise in the background.
+func RunJob(ctx context.Context, ex Execer, jobName string, useCurrentSession bool) error {
+	return execJob(ctx, ex,
+		"BEGIN DBMS_SCHEDULER.run_job(job_name=>:1, use_current_session=>:2 = 1); END;",
+		jobName, boolToInt(useCurrentSession))
+}
+
+// StopJob stops the running job. With force, the job slave is terminated, which needs the MANAGE SCHEDULER privilege.
+func StopJob(ctx context.Context, ex Execer, jobName string, force bool) error {
+	return execJob(ctx, ex, "BEGIN DBMS_SCHEDULER.stop_job(job_name=>:1, force=>:2 = 1); END;", jobName, boolToInt(force))
+}
+
+// DropJob drops the job. With force, a running job is stopped first.
+func DropJob(ctx context.Context, ex Execer, jobName string, force bool) error {
+	return execJob(ctx, ex, "BEGIN DBMS_SCHEDULER.drop_job(job_name=>:1, force=>:2 = 1); END;", jobName, boolToInt(force))
+}
+
+func execJob(ctx context.Context, ex Execer, qry, job string, args ...interface{}) error {
+	name, err := jobName(job)
+	if err != nil {
+		return err
+	}
+	if _, err = ex.ExecContext(ctx, qry, append([]interface{}{name}, args...)...); err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return nil
+}
+
+// JobStatus is the status of a scheduler job, as in ALL_SCHEDULER_JOBS.
+type JobStatus struct {
+	LastStartDate, NextRunDate time.Time
+	Owner, Name                string
+	// State is such as SCHEDULED, RUNNING, DISABLED, SUCCEEDED, FAILED, BROKEN or COMPLETED.
+	State                  string
+	LastRunDuration        time.Duration
+	RunCount, FailureCount int64
+	Enabled                bool
+}
+
+// GetJob returns the status of the job.
+func GetJob(ctx context.Context, q Querier, jobName string) (JobStatus, error) {
+	var js JobStatus
+	on, err := ParseObjectName(jobName)
+	if err != nil {
+		return js, err
+	}
+	const qry = `SELECT owner, job_name, state, enabled, NVL(run_count, 0), NVL(failure_count, 0),
+       last_start_date, NVL(last_run_duration, INTERVAL '0' SECOND), next_run_date
+  FROM all_scheduler_jobs
+  WHERE owner = NVL(:1, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')) AND job_name = :2`
+	rows, err := q.QueryContext(ctx, qry, on.Schema, on.Name)
+	if err != nil {
+		return js, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err == nil {
+			err = sql.ErrNoRows
+		}
+		return js, fmt.Errorf("%s: %w", qry, err)
+	}
+	var state, enabled sql.NullString
+	var lastStart, nextRun sql.NullTime
+	if err = rows.Scan(&js.Owner, &js.Name, &state, &enabled, &js.RunCount, &js.FailureCount,
+		&lastStart, &js.LastRunDuration, &nextRun,
+	); err != nil {
+		return js, fmt.Errorf("%s: %w", qry, err)
+	}
+	js.State, js.Enabled = state.String, enabled.String == "TRUE"
+	js.LastStartDate, js.NextRunDate = lastStart.Time, nextRun.Time
+	return js, rows.Close()
+}
+
+// JobRunDetail is the log of a run of a scheduler job, as in ALL_SCHEDULER_JOB_RUN_DETAILS.
+type JobRunDetail struct {
+	LogDate, ActualStartDate time.Time
+	// Status is such as SUCCEEDED, FAILED or STOPPED.
+	Status, AdditionalInfo, SessionID string
+	LogID                             int64
+	RunDuration                       time.Duration
+	// ErrorCode is the ORA- error number of the failed run.
+	ErrorCode int
+}
+
+// GetJobRunDetails returns the logged runs of the job since the given time (all for the zero time), the latest first.
+func GetJobRunDetails(ctx context.Context, q Querier, jobName string, since time.Time) ([]JobRunDetail, error) {
+	on, err := ParseObjectName(jobName)
+	if err != nil {
+		return nil, err
+	}
+	const qry = `SELECT log_id, log_date, status, NVL(error#, 0), actual_start_date,
+       NVL(run_duration, INTERVAL '0' SECOND), session_id, additional_info
+  FROM all_scheduler_job_run_details
+  WHERE owner = NVL(:1, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')) AND job_name = :2 AND
+        log_date >= NVL(:3, log_date)
+  ORDER BY log_date DESC, log_id DESC`
+	rows, err := q.QueryContext(ctx, qry, on.Schema, on.Name, optionalTime(since))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	var details []JobRunDetail
+	for rows.Next() {
+		var d JobRunDetail
+		var status, sessionID, info sql.NullString
+		var logDate, start sql.NullTime
+		if err = rows.Scan(&d.LogID, &logDate, &status, &d.ErrorCode, &start,
+			&d.RunDuration, &sessionID, &info,
+		); err != nil {
+			return details, fmt.Errorf("%s: %w", qry, err)
+		}
+		d.LogDate, d.ActualStartDate = logDate.Time, start.Time
+		d.Status, d.SessionID, d.AdditionalInfo = status.String, sessionID.String, info.String
+		details = append(details, d)
+	}
+	if err = rows.Err(); err != nil {
+		return details, err
+	}
+	return details, rows.Close()
+}
+
+// jobName returns the normalized [schema.]name of the job.
+func jobName(name string) (string, error) {
+	on, err := ParseObjectName(name)
+	if err != nil {
+		return "", fmt.Errorf("job name: %w", err)
+	}
+	if on.Package != "" || on.DBLink != "" {
+		return "", fmt.Errorf("job name %q: %w", name, ErrInvalidIdentifier)
+	}
+	return on.String(), nil
+}
+
+// optionalTime returns the zero t as NULL.
+func optionalTime(t time.Time) sql.NullTime { return sql.NullTime{Time: t, Valid: !t.IsZero()} }
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
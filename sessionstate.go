@@ -0,0 +1,229 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SessionStateFunc establishes (part of) the state of a session, such as
+// NLS settings, private temporary tables or application context values.
+type SessionStateFunc func(ctx context.Context, ex Execer) error
+
+// ErrSessionReplaced is returned (wrapping the original error) by StatefulConn
+// when its session has failed, and has been replaced by a new one, with the state restored.
+//
+// The failed statement has NOT been retried, and the transaction of the failed session is lost.
+var ErrSessionReplaced = errors.New("session replaced")
+
+// StatefulConn is a connection (session) of a pool, which records the state of the session
+// set with SetState, and replays it on a new session when the session fails,
+// so the code relying on the session state does not silently misbehave after a failover.
+type StatefulConn struct {
+	db         *sql.DB
+	conn       *sql.Conn
+	onReplaced func(ctx context.Context, err error)
+	states     []namedSessionState
+	mu         sync.Mutex
+}
+
+type namedSessionState struct {
+	f    SessionStateFunc
+	name string
+}
+
+// NewStatefulConn returns a StatefulConn with a session from the pool.
+//
+// The onReplaced hook (if not nil) is called after replacing the failed session
+// and restoring the state on the new one, with the error of the failed session.
+func NewStatefulConn(ctx context.Context, db *sql.DB, onReplaced func(ctx context.Context, err error)) (*StatefulConn, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &StatefulConn{db: db, conn: conn, onReplaced: onReplaced}, nil
+}
+
+// SetState executes f on the session, and records it for restoring the state on a new session.
+// A state with the same name replaces the previous one (but keeps its place in the replay order).
+func (sc *StatefulConn) SetState(ctx context.Context, name string, f SessionStateFunc) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.conn == nil {
+		return sql.ErrConnDone
+	}
+	if err := f(ctx, sc.conn); err != nil {
+		return sc.handleErr(ctx, err)
+	}
+	sc.record(name, f)
+	return nil
+}
+
+func (sc *StatefulConn) record(name string, f SessionStateFunc) {
+	for i, st := range sc.states {
+		if st.name == name {
+			sc.states[i].f = f
+			return
+		}
+	}
+	sc.states = append(sc.states, namedSessionState{name: name, f: f})
+}
+
+// ForgetState removes the named state from the states to be restored.
+func (sc *StatefulConn) ForgetState(name string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	for i, st := range sc.states {
+		if st.name == name {
+			sc.states = append(sc.states[:i], sc.states[i+1:]...)
+			return
+		}
+	}
+}
+
+// CaptureNLS records the current NLS settings of the session (as in NLS_SESSION_PARAMETERS)
+// as the "nls" state, restored with ALTER SESSION on a new session.
+func (sc *StatefulConn) CaptureNLS(ctx context.Context) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.conn == nil {
+		return sql.ErrConnDone
+	}
+	f, err := captureNLS(ctx, sc.conn)
+	if err != nil {
+		return sc.handleErr(ctx, err)
+	}
+	sc.record("nls", f)
+	return nil
+}
+
+// captureNLS returns a SessionStateFunc restoring the current NLS settings of the session.
+func captureNLS(ctx context.Context, q Querier) (SessionStateFunc, error) {
+	// NLS_LANGUAGE and NLS_TERRITORY reset the derived parameters, so they go first
+	const qry = `SELECT parameter, value FROM nls_session_parameters
+  ORDER BY DECODE(parameter, 'NLS_LANGUAGE', 0, 'NLS_TERRITORY', 1, 2), parameter`
+	rows, err := q.QueryContext(ctx, qry)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	var stmts []string
+	for rows.Next() {
+		var param string
+		var value sql.NullString
+		if err = rows.Scan(&param, &value); err != nil {
+			return nil, fmt.Errorf("%s: %w", qry, err)
+		}
+		if !value.Valid {
+			continue
+		}
+		stmts = append(stmts, "ALTER SESSION SET "+param+" = '"+strings.ReplaceAll(value.String, "'", "''")+"'")
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, ex Execer) error {
+		for _, qry := range stmts {
+			if _, err := ex.ExecContext(ctx, qry); err != nil {
+				return fmt.Errorf("%s: %w", qry, err)
+			}
+		}
+		return nil
+	}, rows.Close()
+}
+
+// ExecContext executes the statement on the session.
+func (sc *StatefulConn) ExecContext(ctx context.Context, qry string, args ...interface{}) (sql.Result, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.conn == nil {
+		return nil, sql.ErrConnDone
+	}
+	res, err := sc.conn.ExecContext(ctx, qry, args...)
+	return res, sc.handleErr(ctx, err)
+}
+
+// QueryContext executes the query on the session.
+//
+// Only the errors of the execution are handled, not the ones while fetching the rows.
+func (sc *StatefulConn) QueryContext(ctx context.Context, qry string, args ...interface{}) (*sql.Rows, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.conn == nil {
+		return nil, sql.ErrConnDone
+	}
+	rows, err := sc.conn.QueryContext(ctx, qry, args...)
+	return rows, sc.handleErr(ctx, err)
+}
+
+// BeginTx starts a transaction on the session.
+func (sc *StatefulConn) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.conn == nil {
+		return nil, sql.ErrConnDone
+	}
+	tx, err := sc.conn.BeginTx(ctx, opts)
+	return tx, sc.handleErr(ctx, err)
+}
+
+// Raw executes f with the underlying driver connection (see Raw).
+func (sc *StatefulConn) Raw(f func(driverConn interface{}) error) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.conn == nil {
+		return sql.ErrConnDone
+	}
+	return sc.handleErr(context.Background(), sc.conn.Raw(f))
+}
+
+// Close returns the session to the pool.
+func (sc *StatefulConn) Close() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	conn := sc.conn
+	sc.conn, sc.states = nil, nil
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
+}
+
+// handleErr replaces the session if err means it has failed, restores the state on the new one,
+// and returns the error wrapped with ErrSessionReplaced.
+//
+// Must be called with sc.mu held.
+func (sc *StatefulConn) handleErr(ctx context.Context, err error) error {
+	if err == nil || !(IsBadConn(err) || errors.Is(err, sql.ErrConnDone)) {
+		return err
+	}
+	_ = sc.conn.Close()
+	conn, connErr := sc.db.Conn(ctx)
+	if connErr != nil {
+		sc.conn = nil
+		return fmt.Errorf("%w (replacing the session: %w)", err, connErr)
+	}
+	for _, st := range sc.states {
+		if stErr := st.f(ctx, conn); stErr != nil {
+			// discard the half-restored session
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+			_ = conn.Close()
+			sc.conn = nil
+			return fmt.Errorf("%w (restoring %q on the new session: %w)", err, st.name, stErr)
+		}
+	}
+	sc.conn = conn
+	if sc.onReplaced != nil {
+		sc.onReplaced(ctx, err)
+	}
+	return fmt.Errorf("%w: %w", ErrSessionReplaced, err)
+}
@@ -0,0 +1,215 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// SlowQuery is the report of a statement execution exceeding the threshold of SetSlowQueryHook.
+type SlowQuery struct {
+	// Err is the error of the execution.
+	Err error
+	// Query is the SQL text.
+	Query string
+	// Binds are the bind variables: the name, the type and the length of strings, byte slices and slices,
+	// followed by "=" and the value (or the mask of the RedactPolicy),
+	// such as `:1 string(3)="abc"`, ":id int64=***" or ":2 []int64(100)=***".
+	Binds []string
+	// Session is the session of the connection, empty if it could not be determined.
+	Session SessionID
+	// Prepare, Exec and Fetch are the durations spent preparing, executing and
+	// fetching the rows (for queries, till the rows are closed, without the time spent between the fetches).
+	Prepare, Exec, Fetch time.Duration
+	// Rows is the number of rows fetched or affected.
+	Rows int64
+}
+
+// Total returns the total duration spent in the database calls.
+func (sq SlowQuery) Total() time.Duration { return sq.Prepare + sq.Exec + sq.Fetch }
+
+type slowQueryHook struct {
+	hook      func(context.Context, SlowQuery)
+	policy    RedactPolicy
+	threshold time.Duration
+}
+
+// SetSlowQueryHook sets the hook called with the report of each statement execution whose
+// Prepare+Exec+Fetch time exceeds the threshold.
+// A nil hook switches it off.
+//
+// The bind values of the report are masked as the policy says
+// (RedactPolicy{All: true} masks all of them).
+//
+// Measuring costs only a few time.Now() calls, but determining the SID of the session
+// costs a round trip, once for each connection with a slow statement.
+func (d *drv) SetSlowQueryHook(threshold time.Duration, hook func(context.Context, SlowQuery), policy RedactPolicy) {
+	if hook == nil {
+		d.slowQuery.Store(nil)
+		return
+	}
+	d.slowQuery.Store(&slowQueryHook{threshold: threshold, hook: hook, policy: policy})
+}
+
+// SetSlowQueryHook sets the slow query hook of the default driver.
+func SetSlowQueryHook(threshold time.Duration, hook func(context.Context, SlowQuery), policy RedactPolicy) {
+	defaultDrv.SetSlowQueryHook(threshold, hook, policy)
+}
+
+// slowTimer measures the execution of a statement.
+type slowTimer struct {
+	ctx      context.Context
+	hook     *slowQueryHook
+	plan     *planCapture
+	args     []driver.NamedValue
+	start    time.Time
+	sqlID    string
+	sq       SlowQuery
+	reported atomic.Bool
+}
+
+// startSlowTimer starts measuring the execution of the statement,
+// if there's a slow query hook or a plan to capture.
+func (st *statement) startSlowTimer(ctx context.Context, args []driver.NamedValue) *slowTimer {
+	if st.conn == nil || st.drv == nil || ctx.Value(noSlowQueryCtxKey{}) != nil {
+		return nil
+	}
+	hook := st.drv.slowQuery.Load()
+	if hook == nil && st.planCapture == nil {
+		return nil
+	}
+	// the prepare time is accounted to the first execution only
+	prepare := st.prepareDur
+	st.prepareDur = 0
+	return &slowTimer{
+		ctx: ctx, hook: hook, plan: st.planCapture, args: args, start: time.Now(),
+		sq: SlowQuery{Query: st.query, Prepare: prepare},
+	}
+}
+
+// execDone records the end of the execution.
+func (t *slowTimer) execDone(st *statement, err error) {
+	if t == nil {
+		return
+	}
+	t.sq.Exec, t.sq.Err = time.Since(t.start), err
+	if t.plan != nil && err == nil {
+		// the cursor may be closed by the time of the report
+		t.sqlID, _ = st.sqlID()
+	}
+}
+
+// report calls the hook and captures the plan if the execution was slow.
+func (t *slowTimer) report(c *conn) {
+	if t == nil {
+		return
+	}
+	total := t.sq.Total()
+	slowHook := t.hook != nil && total >= t.hook.threshold
+	slowPlan := t.plan != nil && total >= t.plan.threshold && t.sqlID != ""
+	if !(slowHook || slowPlan) || t.reported.Swap(true) {
+		return
+	}
+	if slowHook {
+		t.sq.Binds = redactBinds(t.args, t.hook.policy)
+		if c != nil {
+			t.sq.Session, _ = c.sessionID(t.ctx)
+		}
+		t.hook.hook(t.ctx, t.sq)
+	}
+	if slowPlan {
+		ep := ExecutionPlan{Query: t.sq.Query, SQLID: t.sqlID, Elapsed: total, Err: driver.ErrBadConn}
+		if c != nil {
+			ep.Plan, ep.Err = c.displayCursor(t.ctx, t.sqlID)
+		}
+		t.plan.f(t.ctx, ep)
+	}
+}
+
+// sessionID returns the (cached) SID and SERIAL# of the session.
+func (c *conn) sessionID(ctx context.Context) (SessionID, error) {
+	c.mu.RLock()
+	sid := c.sid
+	c.mu.RUnlock()
+	if sid.SID != 0 {
+		return sid, nil
+	}
+	if ctx == nil || ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	db := sql.OpenDB(borrowedConnector{c: c})
+	defer db.Close()
+	// the query of the session ID must not be reported as slow, again
+	sid, err := CurrentSessionID(context.WithValue(ctx, noSlowQueryCtxKey{}, true), db)
+	if err != nil {
+		return sid, err
+	}
+	c.mu.Lock()
+	c.sid = sid
+	c.mu.Unlock()
+	return sid, nil
+}
+
+type noSlowQueryCtxKey struct{}
+
+// borrowedConnector connects to the already open connection,
+// for the helpers querying through database/sql.
+type borrowedConnector struct{ c *conn }
+
+func (bc borrowedConnector) Connect(context.Context) (driver.Conn, error) {
+	return borrowedConn{bc.c}, nil
+}
+func (bc borrowedConnector) Driver() driver.Driver { return bc.c.drv }
+
+// borrowedConn is the connection lent to database/sql, which must not close, reset or release it.
+type borrowedConn struct{ *conn }
+
+func (borrowedConn) Close() error                       { return nil }
+func (borrowedConn) ResetSession(context.Context) error { return nil }
+func (borrowedConn) IsValid() bool                      { return true }
+
+// redactBinds returns the name, type and length of the bind variables,
+// and their values masked as the policy says.
+func redactBinds(args []driver.NamedValue, policy RedactPolicy) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	binds := make([]string, len(args))
+	for i, a := range args {
+		name := a.Name
+		if name == "" {
+			name = strconv.Itoa(a.Ordinal)
+		}
+		binds[i] = ":" + name + " " + redactValue(a.Value) + "="
+		if policy.redacted(BindValue{Value: a.Value, Name: a.Name, Position: a.Ordinal}) {
+			binds[i] += fmt.Sprint(policy.mask())
+		} else if s, ok := a.Value.(string); ok {
+			binds[i] += strconv.Quote(s)
+		} else {
+			binds[i] += fmt.Sprint(a.Value)
+		}
+	}
+	return binds
+}
+
+func redactValue(v interface{}) string {
+	if v == nil {
+		return "nil"
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("%T(%d)", v, rv.Len())
+	}
+	return fmt.Sprintf("%T", v)
+}
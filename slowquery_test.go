@@ -0,0 +1,76 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRedactBinds(t *testing.T) {
+	got := redactBinds([]driver.NamedValue{
+		{Ordinal: 1, Value: "secret"},
+		{Name: "id", Ordinal: 2, Value: int64(42)},
+		{Ordinal: 3, Value: []int64{1, 2, 3}},
+		{Ordinal: 4, Value: nil},
+		{Ordinal: 5, Value: time.Time{}},
+	}, RedactPolicy{All: true})
+	want := []string{":1 string(6)=***", ":id int64=***", ":3 []int64(3)=***", ":4 nil=***", ":5 time.Time=***"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+
+	got = redactBinds([]driver.NamedValue{
+		{Ordinal: 1, Value: "secret"},
+		{Name: "id", Ordinal: 2, Value: int64(42)},
+	}, RedactPolicy{Names: []string{"id"}, Mask: "?"})
+	if want := []string{`:1 string(6)="secret"`, ":id int64=?"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestSlowTimer(t *testing.T) {
+	d := &drv{}
+	var reports []SlowQuery
+	d.SetSlowQueryHook(time.Second, func(_ context.Context, sq SlowQuery) { reports = append(reports, sq) }, RedactPolicy{All: true})
+	st := &statement{conn: &conn{drv: d}, query: "SELECT 1 FROM DUAL"}
+	st.prepareDur = 2 * time.Second
+
+	slow := st.startSlowTimer(context.Background(), []driver.NamedValue{{Ordinal: 1, Value: "x"}})
+	if slow == nil {
+		t.Fatal("no timer")
+	}
+	if st.prepareDur != 0 {
+		t.Errorf("prepare is kept: %s", st.prepareDur)
+	}
+	slow.execDone(st, nil)
+	slow.sq.Session = SessionID{SID: 1} // do not query it
+	slow.report(nil)
+	slow.report(nil)
+	if len(reports) != 1 {
+		t.Fatalf("got %d reports, wanted 1", len(reports))
+	}
+	if sq := reports[0]; sq.Query != st.query || sq.Prepare != 2*time.Second || sq.Total() < sq.Prepare ||
+		len(sq.Binds) != 1 || sq.Binds[0] != ":1 string(1)=***" {
+		t.Errorf("got %+v", sq)
+	}
+
+	// fast
+	slow = st.startSlowTimer(context.Background(), nil)
+	slow.execDone(st, nil)
+	slow.report(nil)
+	if len(reports) != 1 {
+		t.Errorf("got %d reports, wanted 1", len(reports))
+	}
+
+	d.SetSlowQueryHook(0, nil, RedactPolicy{})
+	if slow = st.startSlowTimer(context.Background(), nil); slow != nil {
+		t.Error("timer without hook")
+	}
+}
@@ -0,0 +1,650 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include <stdlib.h>
+#include "dpiImpl.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// ErrSodaCollectionNotFound is returned by OpenCollection for a non-existing collection.
+var ErrSodaCollectionNotFound = errors.New("SODA collection not found")
+
+// SodaDB is the SODA (Simple Oracle Document Access) database of a connection,
+// for managing the document collections.
+//
+// The modifications are committed by each call, unless the connection is in a transaction.
+type SodaDB struct {
+	conn        *conn
+	dpiSodaDb   *C.dpiSodaDb
+	connIsOwned bool
+}
+
+// NewSodaDB returns the SODA database of the connection of execer.
+// For a connection pool (*sql.DB), a connection is acquired, which is released by Close.
+func NewSodaDB(ctx context.Context, execer Execer) (*SodaDB, error) {
+	cx, err := DriverConn(ctx, execer)
+	if err != nil {
+		return nil, err
+	}
+	// Check whether this is a pool or a single connection.
+	cx2, err := DriverConn(ctx, execer)
+	if err != nil {
+		cx.Close()
+		return nil, err
+	}
+	c := cx.(*conn)
+	owned := c.dpiConn != cx2.(*conn).dpiConn
+	if owned {
+		cx2.Close()
+	}
+	db := SodaDB{conn: c, connIsOwned: owned}
+	if err = c.checkExec(func() C.int { return C.dpiConn_getSodaDb(c.dpiConn, &db.dpiSodaDb) }); err != nil {
+		if owned {
+			cx.Close()
+		}
+		return nil, fmt.Errorf("getSodaDb: %w", err)
+	}
+	return &db, nil
+}
+
+// Close releases the SODA database, and the connection if it was acquired by NewSodaDB.
+func (db *SodaDB) Close() error {
+	if db == nil {
+		return nil
+	}
+	c, sdb := db.conn, db.dpiSodaDb
+	db.conn, db.dpiSodaDb = nil, nil
+	if sdb == nil {
+		return nil
+	}
+	err := c.checkExec(func() C.int { return C.dpiSodaDb_release(sdb) })
+	if c != nil && db.connIsOwned {
+		c.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("release: %w", err)
+	}
+	return nil
+}
+
+// checkExec calls f within the deadline of ctx.
+func (db *SodaDB) checkExec(ctx context.Context, f func() C.int) error {
+	if db == nil || db.dpiSodaDb == nil {
+		return errors.New("SODA database is closed")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	cleanup, err := db.conn.handleDeadline(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return db.conn.checkExec(f)
+}
+
+// flags returns the flags of the modifying operations: commit, unless in a transaction.
+func (db *SodaDB) flags() C.uint32_t {
+	db.conn.mu.RLock()
+	inTx := db.conn.inTransaction
+	db.conn.mu.RUnlock()
+	if inTx {
+		return C.DPI_SODA_FLAGS_DEFAULT
+	}
+	return C.DPI_SODA_FLAGS_ATOMIC_COMMIT
+}
+
+// CreateCollection creates the collection (or opens it, if it exists with the same metadata).
+// The metadata (JSON, may be empty for the default) describes the storage of the collection.
+func (db *SodaDB) CreateCollection(ctx context.Context, name, metadata string) (*SodaCollection, error) {
+	cName, cMeta := C.CString(name), C.CString(metadata)
+	defer func() { C.free(unsafe.Pointer(cName)); C.free(unsafe.Pointer(cMeta)) }()
+	coll := SodaCollection{db: db, Name: name}
+	if err := db.checkExec(ctx, func() C.int {
+		return C.dpiSodaDb_createCollection(db.dpiSodaDb, cName, C.uint32_t(len(name)),
+			cMeta, C.uint32_t(len(metadata)), db.flags(), &coll.dpiSodaColl)
+	}); err != nil {
+		return nil, fmt.Errorf("createCollection(%q): %w", name, err)
+	}
+	return &coll, nil
+}
+
+// OpenCollection opens the existing collection, returns ErrSodaCollectionNotFound if it does not exist.
+func (db *SodaDB) OpenCollection(ctx context.Context, name string) (*SodaCollection, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	coll := SodaCollection{db: db, Name: name}
+	if err := db.checkExec(ctx, func() C.int {
+		return C.dpiSodaDb_openCollection(db.dpiSodaDb, cName, C.uint32_t(len(name)), C.DPI_SODA_FLAGS_DEFAULT, &coll.dpiSodaColl)
+	}); err != nil {
+		return nil, fmt.Errorf("openCollection(%q): %w", name, err)
+	}
+	if coll.dpiSodaColl == nil {
+		return nil, fmt.Errorf("%q: %w", name, ErrSodaCollectionNotFound)
+	}
+	return &coll, nil
+}
+
+// CollectionNames returns the names of the collections, starting with startName (all if empty),
+// at most limit (all if not positive).
+func (db *SodaDB) CollectionNames(ctx context.Context, startName string, limit int) ([]string, error) {
+	cStart := C.CString(startName)
+	defer C.free(unsafe.Pointer(cStart))
+	var list C.dpiSodaCollNames
+	if err := db.checkExec(ctx, func() C.int {
+		return C.dpiSodaDb_getCollectionNames(db.dpiSodaDb, cStart, C.uint32_t(len(startName)),
+			C.uint32_t(max(limit, 0)), C.DPI_SODA_FLAGS_DEFAULT, &list)
+	}); err != nil {
+		return nil, fmt.Errorf("getCollectionNames: %w", err)
+	}
+	names := stringList((*C.dpiStringList)(unsafe.Pointer(&list)))
+	_ = C.dpiSodaDb_freeCollectionNames(db.dpiSodaDb, &list)
+	return names, nil
+}
+
+// stringList copies the strings of the list.
+func stringList(list *C.dpiStringList) []string {
+	n := int(*(*C.uint32_t)(unsafe.Pointer(list)))
+	if n == 0 {
+		return nil
+	}
+	// the fields are unions, see dpiStringList
+	strs := unsafe.Slice(*(***C.char)(unsafe.Pointer(uintptr(unsafe.Pointer(list)) + unsafe.Offsetof(list.anon1))), n)
+	lengths := unsafe.Slice(*(**C.uint32_t)(unsafe.Pointer(uintptr(unsafe.Pointer(list)) + unsafe.Offsetof(list.anon2))), n)
+	ss := make([]string, n)
+	for i := range ss {
+		ss[i] = C.GoStringN(strs[i], C.int(lengths[i]))
+	}
+	return ss
+}
+
+// SodaCollection is a SODA document collection.
+type SodaCollection struct {
+	db          *SodaDB
+	dpiSodaColl *C.dpiSodaColl
+	Name        string
+}
+
+// Close releases the collection.
+func (coll *SodaCollection) Close() error {
+	if coll == nil || coll.dpiSodaColl == nil {
+		return nil
+	}
+	c := coll.dpiSodaColl
+	coll.dpiSodaColl = nil
+	if err := coll.db.conn.checkExec(func() C.int { return C.dpiSodaColl_release(c) }); err != nil {
+		return fmt.Errorf("release: %w", err)
+	}
+	return nil
+}
+
+// Drop drops the collection, reporting whether it existed.
+func (coll *SodaCollection) Drop(ctx context.Context) (bool, error) {
+	var isDropped C.int
+	if err := coll.db.checkExec(ctx, func() C.int {
+		return C.dpiSodaColl_drop(coll.dpiSodaColl, coll.db.flags(), &isDropped)
+	}); err != nil {
+		return false, fmt.Errorf("drop %q: %w", coll.Name, err)
+	}
+	return isDropped == 1, nil
+}
+
+// SodaDocument is a document of a SODA collection.
+type SodaDocument struct {
+	// Key is the unique key of the document, assigned by the database on insert by default.
+	Key string
+	// Version changes with each modification of the document.
+	Version   string
+	MediaType string
+	// CreatedOn and LastModified are ISO 8601 timestamps.
+	CreatedOn, LastModified string
+	// Content is the (JSON) content of the document.
+	Content []byte
+}
+
+// SodaQuery selects the documents of a collection.
+// The zero value selects all the documents.
+type SodaQuery struct {
+	// Filter is the query-by-example (QBE) filter, such as
+	//
+	//	map[string]interface{}{"age": map[string]interface{}{"$gt": 18}, "$orderby": map[string]interface{}{"name": 1}}
+	//
+	// given as a map (or anything encoding/json marshals to a JSON object),
+	// or as raw JSON in a string, []byte or json.RawMessage.
+	Filter interface{}
+	// Key or Keys select the documents by their keys.
+	Key  string
+	Keys []string
+	// Version is the version of the document selected by Key, for optimistic locking.
+	Version string
+	// Hint is the SQL hint of the query (needs Oracle Client 19.11 or 21.3).
+	Hint string
+	// Skip and Limit are for pagination: the first Skip documents are skipped,
+	// and at most Limit documents are returned (all if zero).
+	// Use an "$orderby" in the Filter for stable pages.
+	Skip, Limit int
+	// FetchArraySize is the number of documents fetched in one round trip (needs Oracle Client 19.5).
+	FetchArraySize int
+	// Lock locks the documents (as SELECT FOR UPDATE), for modifying them in a transaction.
+	Lock bool
+}
+
+// filterJSON returns the Filter as JSON.
+func (q SodaQuery) filterJSON() ([]byte, error) {
+	switch f := q.Filter.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(f), nil
+	case []byte:
+		return f, nil
+	case json.RawMessage:
+		return f, nil
+	}
+	b, err := json.Marshal(q.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("marshal filter: %w", err)
+	}
+	return b, nil
+}
+
+// operOptions returns the query as dpiSodaOperOptions, allocated in C, to be freed by the returned function.
+func (q SodaQuery) operOptions() (*C.dpiSodaOperOptions, func(), error) {
+	filter, err := q.filterJSON()
+	if err != nil {
+		return nil, func() {}, err
+	}
+	if q.Skip < 0 || q.Limit < 0 || q.FetchArraySize < 0 {
+		return nil, func() {}, fmt.Errorf("negative skip (%d), limit (%d) or fetch array size (%d)", q.Skip, q.Limit, q.FetchArraySize)
+	}
+	opts := (*C.dpiSodaOperOptions)(C.calloc(1, C.sizeof_dpiSodaOperOptions))
+	var ptrs []unsafe.Pointer
+	cString := func(s string) (*C.char, C.uint32_t) {
+		if s == "" {
+			return nil, 0
+		}
+		p := C.CString(s)
+		ptrs = append(ptrs, unsafe.Pointer(p))
+		return p, C.uint32_t(len(s))
+	}
+	free := func() {
+		for _, p := range ptrs {
+			C.free(p)
+		}
+		C.free(unsafe.Pointer(opts))
+	}
+	opts.key, opts.keyLength = cString(q.Key)
+	opts.version, opts.versionLength = cString(q.Version)
+	opts.filter, opts.filterLength = cString(string(filter))
+	opts.hint, opts.hintLength = cString(q.Hint)
+	opts.skip, opts.limit, opts.fetchArraySize = C.uint32_t(q.Skip), C.uint32_t(q.Limit), C.uint32_t(q.FetchArraySize)
+	opts.lock = C.int(b2i(q.Lock))
+	if n := len(q.Keys); n != 0 {
+		keys := (**C.char)(C.calloc(C.size_t(n), C.size_t(unsafe.Sizeof((*C.char)(nil)))))
+		lengths := (*C.uint32_t)(C.calloc(C.size_t(n), C.sizeof_uint32_t))
+		ptrs = append(ptrs, unsafe.Pointer(keys), unsafe.Pointer(lengths))
+		ks, ls := unsafe.Slice(keys, n), unsafe.Slice(lengths, n)
+		for i, k := range q.Keys {
+			ks[i], ls[i] = cString(k)
+		}
+		opts.numKeys, opts.keys, opts.keyLengths = C.uint32_t(n), keys, lengths
+	}
+	return opts, free, nil
+}
+
+// Find returns the documents selected by the query.
+func (coll *SodaCollection) Find(ctx context.Context, q SodaQuery) ([]SodaDocument, error) {
+	var docs []SodaDocument
+	err := coll.FindEach(ctx, q, func(doc SodaDocument) error {
+		docs = append(docs, doc)
+		return nil
+	})
+	return docs, err
+}
+
+// FindEach calls f with each document selected by the query, stopping at the first error.
+func (coll *SodaCollection) FindEach(ctx context.Context, q SodaQuery, f func(SodaDocument) error) error {
+	opts, free, err := q.operOptions()
+	defer free()
+	if err != nil {
+		return err
+	}
+	var cursor *C.dpiSodaDocCursor
+	if err = coll.db.checkExec(ctx, func() C.int {
+		return C.dpiSodaColl_find(coll.dpiSodaColl, opts, C.DPI_SODA_FLAGS_DEFAULT, &cursor)
+	}); err != nil {
+		return fmt.Errorf("find in %q: %w", coll.Name, err)
+	}
+	defer C.dpiSodaDocCursor_release(cursor)
+	for {
+		var doc *C.dpiSodaDoc
+		if err = coll.db.checkExec(ctx, func() C.int {
+			return C.dpiSodaDocCursor_getNext(cursor, C.DPI_SODA_FLAGS_DEFAULT, &doc)
+		}); err != nil {
+			return fmt.Errorf("getNext of %q: %w", coll.Name, err)
+		}
+		if doc == nil {
+			return nil
+		}
+		d, err := coll.db.readDocument(doc)
+		C.dpiSodaDoc_release(doc)
+		if err != nil {
+			return err
+		}
+		if err = f(d); err != nil {
+			return err
+		}
+	}
+}
+
+// Count returns the number of the documents selected by the query (Skip and Limit are not allowed).
+func (coll *SodaCollection) Count(ctx context.Context, q SodaQuery) (int64, error) {
+	opts, free, err := q.operOptions()
+	defer free()
+	if err != nil {
+		return 0, err
+	}
+	var n C.uint64_t
+	if err = coll.db.checkExec(ctx, func() C.int {
+		return C.dpiSodaColl_getDocCount(coll.dpiSodaColl, opts, C.DPI_SODA_FLAGS_DEFAULT, &n)
+	}); err != nil {
+		return 0, fmt.Errorf("getDocCount of %q: %w", coll.Name, err)
+	}
+	return int64(n), nil
+}
+
+// Remove removes the documents selected by the query, returns their number.
+func (coll *SodaCollection) Remove(ctx context.Context, q SodaQuery) (int64, error) {
+	opts, free, err := q.operOptions()
+	defer free()
+	if err != nil {
+		return 0, err
+	}
+	var n C.uint64_t
+	if err = coll.db.checkExec(ctx, func() C.int {
+		return C.dpiSodaColl_remove(coll.dpiSodaColl, opts, coll.db.flags(), &n)
+	}); err != nil {
+		return 0, fmt.Errorf("remove from %q: %w", coll.Name, err)
+	}
+	return int64(n), nil
+}
+
+// SodaInsertError is the error of a document of InsertMany.
+type SodaInsertError struct {
+	Err error
+	// Index is the index of the document in the arguments of InsertMany.
+	Index int
+}
+
+func (e SodaInsertError) Error() string { return strconv.Itoa(e.Index) + ". " + e.Err.Error() }
+func (e SodaInsertError) Unwrap() error { return e.Err }
+
+// SodaInsertErrors is returned by InsertMany when some of the documents could not be inserted.
+type SodaInsertErrors struct {
+	Errs []SodaInsertError
+}
+
+func (e *SodaInsertErrors) Error() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%d documents failed:", len(e.Errs))
+	for _, err := range e.Errs {
+		buf.WriteString("\n")
+		buf.WriteString(err.Error())
+	}
+	return buf.String()
+}
+func (e *SodaInsertErrors) Unwrap() []error {
+	errs := make([]error, len(e.Errs))
+	for i, err := range e.Errs {
+		errs[i] = err
+	}
+	return errs
+}
+
+// InsertMany inserts the JSON documents in bulk (needs Oracle Client 18.5),
+// returning the inserted documents (without Content) - the zero SodaDocument for the failed ones.
+//
+// If the bulk insert fails, it is rolled back, and the documents are inserted one by one,
+// returning the errors of the failed ones in *SodaInsertErrors.
+func (coll *SodaCollection) InsertMany(ctx context.Context, contents ...[]byte) ([]SodaDocument, error) {
+	if len(contents) == 0 {
+		return nil, nil
+	}
+	db := coll.db
+	docs := make([]*C.dpiSodaDoc, len(contents))
+	defer func() {
+		for _, doc := range docs {
+			if doc != nil {
+				C.dpiSodaDoc_release(doc)
+			}
+		}
+	}()
+	for i, content := range contents {
+		var err error
+		if docs[i], err = db.newDocument(ctx, content); err != nil {
+			return nil, fmt.Errorf("%d. %w", i, err)
+		}
+	}
+	commit := db.flags() == C.DPI_SODA_FLAGS_ATOMIC_COMMIT
+	const savepoint = "godror_soda_insert"
+	if err := db.conn.execDirect("SAVEPOINT " + savepoint); err != nil {
+		return nil, err
+	}
+	n := C.size_t(len(docs))
+	cDocs := (**C.dpiSodaDoc)(C.calloc(n, C.size_t(unsafe.Sizeof((*C.dpiSodaDoc)(nil)))))
+	inserted := (**C.dpiSodaDoc)(C.calloc(n, C.size_t(unsafe.Sizeof((*C.dpiSodaDoc)(nil)))))
+	defer func() { C.free(unsafe.Pointer(cDocs)); C.free(unsafe.Pointer(inserted)) }()
+	copy(unsafe.Slice(cDocs, len(docs)), docs)
+	inserteds := unsafe.Slice(inserted, len(docs))
+	result := make([]SodaDocument, len(docs))
+	readInserted := func(i int) error {
+		doc := inserteds[i]
+		if doc == nil {
+			return nil
+		}
+		inserteds[i] = nil
+		var err error
+		result[i], err = db.readDocument(doc)
+		C.dpiSodaDoc_release(doc)
+		return err
+	}
+	err := db.checkExec(ctx, func() C.int {
+		return C.dpiSodaColl_insertMany(coll.dpiSodaColl, C.uint32_t(len(docs)), cDocs, C.DPI_SODA_FLAGS_DEFAULT, inserted)
+	})
+	if err == nil {
+		for i := range inserteds {
+			if err = readInserted(i); err != nil {
+				break
+			}
+		}
+	} else {
+		for i := range inserteds {
+			if doc := inserteds[i]; doc != nil {
+				inserteds[i] = nil
+				C.dpiSodaDoc_release(doc)
+			}
+		}
+		if rbErr := db.conn.execDirect("ROLLBACK TO SAVEPOINT " + savepoint); rbErr != nil {
+			return nil, fmt.Errorf("insertMany into %q: %w (rollback: %w)", coll.Name, err, rbErr)
+		}
+		var errs SodaInsertErrors
+		for i, doc := range docs {
+			if err = db.checkExec(ctx, func() C.int {
+				return C.dpiSodaColl_insertOne(coll.dpiSodaColl, doc, C.DPI_SODA_FLAGS_DEFAULT, &inserteds[i])
+			}); err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				errs.Errs = append(errs.Errs, SodaInsertError{Index: i, Err: err})
+				continue
+			}
+			if err = readInserted(i); err != nil {
+				break
+			}
+		}
+		if err == nil && len(errs.Errs) != 0 {
+			err = &errs
+		}
+	}
+	var insErrs *SodaInsertErrors
+	if err != nil && !errors.As(err, &insErrs) {
+		// do not leave the partial insert behind
+		if commit {
+			_ = db.conn.endTran(false)
+		} else {
+			_ = db.conn.execDirect("ROLLBACK TO SAVEPOINT " + savepoint)
+		}
+		return nil, err
+	}
+	if commit {
+		if cErr := db.conn.endTran(true); cErr != nil {
+			return result, cErr
+		}
+	}
+	return result, err
+}
+
+// newDocument creates a document of the JSON content.
+func (db *SodaDB) newDocument(ctx context.Context, content []byte) (*C.dpiSodaDoc, error) {
+	const mediaType = "application/json"
+	cMediaType := C.CString(mediaType)
+	defer C.free(unsafe.Pointer(cMediaType))
+	var cContent *C.char
+	if len(content) != 0 {
+		cContent = (*C.char)(C.CBytes(content))
+		defer C.free(unsafe.Pointer(cContent))
+	}
+	var doc *C.dpiSodaDoc
+	if err := db.checkExec(ctx, func() C.int {
+		return C.dpiSodaDb_createDocument(db.dpiSodaDb, nil, 0, cContent, C.uint32_t(len(content)),
+			cMediaType, C.uint32_t(len(mediaType)), C.DPI_SODA_FLAGS_DEFAULT, &doc)
+	}); err != nil {
+		return nil, fmt.Errorf("createDocument: %w", err)
+	}
+	return doc, nil
+}
+
+// readDocument copies the attributes and the content of the document.
+func (db *SodaDB) readDocument(doc *C.dpiSodaDoc) (SodaDocument, error) {
+	var d SodaDocument
+	for _, a := range []struct {
+		dest *string
+		get  func(**C.char, *C.uint32_t) C.int
+		name string
+	}{
+		{name: "key", dest: &d.Key, get: func(v **C.char, n *C.uint32_t) C.int { return C.dpiSodaDoc_getKey(doc, v, n) }},
+		{name: "version", dest: &d.Version, get: func(v **C.char, n *C.uint32_t) C.int { return C.dpiSodaDoc_getVersion(doc, v, n) }},
+		{name: "mediaType", dest: &d.MediaType, get: func(v **C.char, n *C.uint32_t) C.int { return C.dpiSodaDoc_getMediaType(doc, v, n) }},
+		{name: "createdOn", dest: &d.CreatedOn, get: func(v **C.char, n *C.uint32_t) C.int { return C.dpiSodaDoc_getCreatedOn(doc, v, n) }},
+		{name: "lastModified", dest: &d.LastModified, get: func(v **C.char, n *C.uint32_t) C.int { return C.dpiSodaDoc_getLastModified(doc, v, n) }},
+	} {
+		var v *C.char
+		var n C.uint32_t
+		if err := db.conn.checkExec(func() C.int { return a.get(&v, &n) }); err != nil {
+			return d, fmt.Errorf("get %s: %w", a.name, err)
+		}
+		*a.dest = C.GoStringN(v, C.int(n))
+	}
+	var isJSON C.int
+	if err := db.conn.checkExec(func() C.int { return C.dpiSodaDoc_getIsJson(doc, &isJSON) }); err != nil {
+		return d, fmt.Errorf("getIsJson: %w", err)
+	}
+	if isJSON == 1 {
+		// JSON type collection (Oracle 23)
+		var j JSON
+		if err := db.conn.checkExec(func() C.int { return C.dpiSodaDoc_getJsonContent(doc, &j.dpiJson) }); err != nil {
+			return d, fmt.Errorf("getJsonContent: %w", err)
+		}
+		v, err := j.GetValue(JSONOptDefault)
+		if err != nil {
+			return d, fmt.Errorf("get JSON value: %w", err)
+		}
+		d.Content, err = json.Marshal(v)
+		return d, err
+	}
+	var v, encoding *C.char
+	var n C.uint32_t
+	if err := db.conn.checkExec(func() C.int { return C.dpiSodaDoc_getContent(doc, &v, &n, &encoding) }); err != nil {
+		return d, fmt.Errorf("getContent: %w", err)
+	}
+	if v != nil {
+		d.Content = C.GoBytes(unsafe.Pointer(v), C.int(n))
+	}
+	return d, nil
+}
+
+// CreateIndex creates an index on the collection, described by the index specification
+// (a map, or raw JSON in a string, []byte or json.RawMessage), such as
+//
+//	{"name": "emp_name_idx", "fields": [{"path": "name", "datatype": "string"}]}
+//
+// for a B-tree index, or
+//
+//	{"name": "emp_search_idx", "search_on": "text_value"}
+//
+// for a JSON search index.
+func (coll *SodaCollection) CreateIndex(ctx context.Context, spec interface{}) error {
+	b, err := SodaQuery{Filter: spec}.filterJSON()
+	if err != nil {
+		return err
+	}
+	cSpec := C.CString(string(b))
+	defer C.free(unsafe.Pointer(cSpec))
+	if err = coll.db.checkExec(ctx, func() C.int {
+		return C.dpiSodaColl_createIndex(coll.dpiSodaColl, cSpec, C.uint32_t(len(b)), coll.db.flags())
+	}); err != nil {
+		return fmt.Errorf("createIndex(%s) on %q: %w", b, coll.Name, err)
+	}
+	return nil
+}
+
+// DropIndex drops the named index, reporting whether it existed.
+// Force is needed for dropping a JSON search index with pending changes.
+func (coll *SodaCollection) DropIndex(ctx context.Context, name string, force bool) (bool, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	flags := coll.db.flags()
+	if force {
+		flags |= C.DPI_SODA_FLAGS_INDEX_DROP_FORCE
+	}
+	var isDropped C.int
+	if err := coll.db.checkExec(ctx, func() C.int {
+		return C.dpiSodaColl_dropIndex(coll.dpiSodaColl, cName, C.uint32_t(len(name)), flags, &isDropped)
+	}); err != nil {
+		return false, fmt.Errorf("dropIndex(%q) on %q: %w", name, coll.Name, err)
+	}
+	return isDropped == 1, nil
+}
+
+// Indexes returns the specifications (JSON) of the indexes of the collection
+// (needs Oracle Client 19.13 or 21.3).
+func (coll *SodaCollection) Indexes(ctx context.Context) ([]json.RawMessage, error) {
+	var list C.dpiStringList
+	if err := coll.db.checkExec(ctx, func() C.int {
+		return C.dpiSodaColl_listIndexes(coll.dpiSodaColl, C.DPI_SODA_FLAGS_DEFAULT, &list)
+	}); err != nil {
+		return nil, fmt.Errorf("listIndexes of %q: %w", coll.Name, err)
+	}
+	ss := stringList(&list)
+	_ = C.dpiContext_freeStringList(coll.db.conn.drv.dpiContext, &list)
+	specs := make([]json.RawMessage, len(ss))
+	for i, s := range ss {
+		specs[i] = json.RawMessage(s)
+	}
+	return specs, nil
+}
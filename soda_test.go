@@ -0,0 +1,66 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestSodaQueryFilter(t *testing.T) {
+	for name, tC := range map[string]struct {
+		Filter interface{}
+		Want   string
+	}{
+		"nil":    {},
+		"string": {Filter: `{"n":1}`, Want: `{"n":1}`},
+		"bytes":  {Filter: []byte(`{"n":1}`), Want: `{"n":1}`},
+		"raw":    {Filter: json.RawMessage(`{"n":1}`), Want: `{"n":1}`},
+		"map": {
+			Filter: map[string]interface{}{"n": map[string]interface{}{"$gt": 1}, "$orderby": map[string]int{"n": -1}},
+			Want:   `{"$orderby":{"n":-1},"n":{"$gt":1}}`,
+		},
+	} {
+		b, err := SodaQuery{Filter: tC.Filter}.filterJSON()
+		if err != nil {
+			t.Fatalf("%s: %+v", name, err)
+		}
+		if got := string(b); got != tC.Want {
+			t.Errorf("%s: got %q, wanted %q", name, got, tC.Want)
+		}
+	}
+	if _, err := (SodaQuery{Filter: func() {}}).filterJSON(); err == nil {
+		t.Error("wanted error for unmarshalable filter")
+	}
+
+	opts, free, err := SodaQuery{Filter: `{"n":1}`, Keys: []string{"a", "b"}, Skip: 1, Limit: 2}.operOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts == nil {
+		t.Error("got nil options")
+	}
+	free()
+	_, free, err = SodaQuery{Skip: -1}.operOptions()
+	free()
+	if err == nil {
+		t.Error("wanted error for negative skip")
+	}
+}
+
+func TestSodaInsertErrors(t *testing.T) {
+	err := error(&SodaInsertErrors{Errs: []SodaInsertError{{Index: 2, Err: io.ErrUnexpectedEOF}}})
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("%v does not wrap %v", err, io.ErrUnexpectedEOF)
+	}
+	var insErr SodaInsertError
+	if !errors.As(err, &insErr) || insErr.Index != 2 {
+		t.Errorf("got %+v", insErr)
+	}
+	t.Log(err)
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SQLTraceOptions are the options of EnableSQLTrace.
+type SQLTraceOptions struct {
+	// Binds includes the bind variables in the trace.
+	Binds bool
+	// Waits includes the wait events in the trace.
+	Waits bool
+}
+
+// EnableSQLTrace enables the SQL trace (event 10046) of the session, with DBMS_SESSION.session_trace_enable:
+// level 1 by default, level 4 with binds, 8 with waits, 12 with both.
+//
+// Warning! EnableSQLTrace, the traced code and DisableSQLTrace must all execute on the same session
+// - for example by using the same *sql.Tx, or *sql.Conn. A *sql.DB connection pool won't work!
+func EnableSQLTrace(ctx context.Context, ex Execer, opts SQLTraceOptions) error {
+	const qry = "BEGIN DBMS_SESSION.session_trace_enable(waits=>:1 = 1, binds=>:2 = 1); END;"
+	var waits, binds int
+	if opts.Waits {
+		waits = 1
+	}
+	if opts.Binds {
+		binds = 1
+	}
+	if _, err := ex.ExecContext(ctx, qry, waits, binds); err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return nil
+}
+
+// DisableSQLTrace disables the SQL trace of the session.
+func DisableSQLTrace(ctx context.Context, ex Execer) error {
+	const qry = "BEGIN DBMS_SESSION.session_trace_disable; END;"
+	if _, err := ex.ExecContext(ctx, qry); err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return nil
+}
+
+// SetTracefileIdentifier sets the TRACEFILE_IDENTIFIER of the session, which is appended to the name of
+// the trace files opened after this (so an already opened trace file is not renamed).
+// The id must consist of only ASCII letters, digits and underscores.
+func SetTracefileIdentifier(ctx context.Context, ex Execer, id string) error {
+	if err := checkTracefileIdentifier(id); err != nil {
+		return err
+	}
+	// ALTER SESSION does not accept bind variables
+	qry := "ALTER SESSION SET tracefile_identifier = '" + id + "'"
+	if _, err := ex.ExecContext(ctx, qry); err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return nil
+}
+
+func checkTracefileIdentifier(id string) error {
+	if id == "" || len(id) > 255 {
+		return fmt.Errorf("tracefile identifier %q: %w", id, ErrInvalidIdentifier)
+	}
+	for _, r := range id {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_') {
+			return fmt.Errorf("tracefile identifier %q: %w", id, ErrInvalidIdentifier)
+		}
+	}
+	return nil
+}
+
+// TraceFileName returns the path (on the database server) of the trace file of the session.
+func TraceFileName(ctx context.Context, q Querier) (string, error) {
+	const qry = "SELECT value FROM v$diag_info WHERE name = 'Default Trace File'"
+	rows, err := q.QueryContext(ctx, qry)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err == nil {
+			err = sql.ErrNoRows
+		}
+		return "", fmt.Errorf("%s: %w", qry, err)
+	}
+	var path string
+	if err = rows.Scan(&path); err != nil {
+		return "", fmt.Errorf("%s: %w", qry, err)
+	}
+	return path, rows.Close()
+}
+
+// ReadTraceFile copies the contents of the named trace file (the path returned by TraceFileName,
+// or just the file name) into w, from V$DIAG_TRACE_FILE_CONTENTS (available since Oracle 12.2).
+//
+// This needs SELECT privilege on V$DIAG_TRACE_FILE_CONTENTS.
+func ReadTraceFile(ctx context.Context, w io.Writer, q Querier, name string) error {
+	// the view has only the file name
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	const qry = "SELECT payload FROM v$diag_trace_file_contents WHERE trace_filename = :1 ORDER BY line_number"
+	rows, err := q.QueryContext(ctx, qry, name, FetchArraySize(1024))
+	if err != nil {
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	bw := bufio.NewWriter(w)
+	var payload sql.NullString
+	for rows.Next() {
+		if err = rows.Scan(&payload); err != nil {
+			_ = bw.Flush()
+			return fmt.Errorf("%s: %w", qry, err)
+		}
+		// the payload lines contain their line endings
+		if _, err = bw.WriteString(payload.String); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		_ = bw.Flush()
+		return fmt.Errorf("%s: %w", qry, err)
+	}
+	return bw.Flush()
+}
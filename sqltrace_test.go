@@ -0,0 +1,34 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckTracefileIdentifier(t *testing.T) {
+	for _, tc := range []struct {
+		id string
+		ok bool
+	}{
+		{"godror_test_1", true},
+		{"ABC", true},
+		{"", false},
+		{"a'b", false},
+		{"with space", false},
+		{"árvíz", false},
+		{strings.Repeat("x", 256), false},
+	} {
+		err := checkTracefileIdentifier(tc.id)
+		if tc.ok && err != nil {
+			t.Errorf("%q: %+v", tc.id, err)
+		} else if !tc.ok && !errors.Is(err, ErrInvalidIdentifier) {
+			t.Errorf("%q: got %v, wanted ErrInvalidIdentifier", tc.id, err)
+		}
+	}
+}
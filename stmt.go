@@ -20,6 +20,33 @@ void godror_setFromString(dpiVar *dv, uint32_t pos, const _GoString_ value) {
 	dpiVar_setFromBytes(dv, pos, _GoStringPtr(value), length);
 }
 
+// OCI_ATTR_PREFETCH_MEMORY is not exposed by ODPI-C.
+int godror_setPrefetchMemory(dpiStmt *stmt, uint32_t size) {
+	dpiError error;
+	int status;
+	if (dpiGen__startPublicFn(stmt, DPI_HTYPE_STMT, __func__, &error) < 0)
+		return dpiGen__endPublicFn(stmt, DPI_FAILURE, &error);
+	status = dpiOci__attrSet(stmt->handle, DPI_OCI_HTYPE_STMT, &size, 0,
+		13, "set prefetch memory", &error); // OCI_ATTR_PREFETCH_MEMORY
+	return dpiGen__endPublicFn(stmt, status, &error);
+}
+
+// OCI_ATTR_CHAR_USED is not exposed by ODPI-C.
+int godror_getCharUsed(dpiStmt *stmt, uint32_t pos, uint8_t *charUsed) {
+	dpiError error;
+	void *param;
+	int status;
+	if (dpiGen__startPublicFn(stmt, DPI_HTYPE_STMT, __func__, &error) < 0)
+		return dpiGen__endPublicFn(stmt, DPI_FAILURE, &error);
+	if (dpiOci__paramGet(stmt->handle, DPI_OCI_HTYPE_STMT, &param, pos,
+			"get parameter", &error) < 0)
+		return dpiGen__endPublicFn(stmt, DPI_FAILURE, &error);
+	status = dpiOci__attrGet(param, DPI_OCI_HTYPE_DESCRIBE, charUsed, 0,
+		285, "get char used", &error); // OCI_ATTR_CHAR_USED
+	dpiOci__descriptorFree(param, DPI_OCI_DTYPE_PARAM);
+	return dpiGen__endPublicFn(stmt, status, &error);
+}
+
 dpiAnnotation godror_getAnnotation(dpiAnnotation *annotations, int32_t idx) {
 	return annotations[idx];
 }
@@ -55,21 +82,54 @@ type NullTime = sql.NullTime
 var nullTime interface{} = nil
 
 type stmtOptions struct {
-	boolString         boolString
-	fetchArraySize     int // zero means DefaultFetchArraySize
-	prefetchCount      int // zero means DefaultPrefetchCount, -1 is zero.
-	arraySize          int
-	callTimeout        time.Duration
-	execMode           C.dpiExecMode
-	plSQLArrays        bool
-	lobAsReader        bool
-	nullDateAsZeroTime bool
-	deleteFromCache    bool
-	numberAsString     bool
-	numberAsFloat64    bool
-	partialBatch       bool
-	warningAsError     bool
-	noRetry            bool
+	boolString          boolString
+	fetchArraySize      int // zero means DefaultFetchArraySize
+	prefetchCount       int // zero means DefaultPrefetchCount, -1 is zero.
+	prefetchMemory      int // zero means unlimited
+	arraySize           int
+	callTimeout         time.Duration
+	execMode            C.dpiExecMode
+	plSQLArrays         bool
+	plSQLTimestamps     bool
+	lobAsReader         bool
+	nullDateAsZeroTime  bool
+	deleteFromCache     bool
+	numberAsString      bool
+	numberAsFloat64     bool
+	numberAsDecimal128  bool
+	numberAsInt64       bool
+	numberByPrecision   bool
+	nonFiniteFetch      NonFiniteMode
+	nonFiniteBind       NonFiniteMode
+	partialBatch        bool
+	arrayDMLRowCounts   bool
+	rowCountsDest       *[]int64
+	warningAsError      bool
+	noRetry             bool
+	fetchAllAsString    bool
+	backgroundFetch     bool
+	internStrings       bool
+	zeroCopyBytes       bool
+	columnInfoDest      *[]ColumnInfo
+	fetchArena          bool
+	fetchArenaDest      *FetchArenaStats
+	noLobPromotion      bool
+	fetchMemory         int
+	fetchAsString       []string
+	sessionTimezone     string
+	ltzLocation         *time.Location
+	durationMode        DurationMode
+	fetchTimestampTZ    bool
+	strictCharset       bool
+	trimChar            bool
+	bindStringAsChar    bool
+	dateSentinels       []dateSentinel
+	bindDateSentinel    time.Time
+	bindDateSentinelSet bool
+	roundTrips          *RoundTrips
+	planCapture         *planCapture
+	bindsPolicy         RedactPolicy
+	bindsOnError        bool
 }
 
 type boolString struct {
@@ -138,10 +198,35 @@ func (o stmtOptions) NullDate() interface{} {
 	}
 	return nullTime
 }
-func (o stmtOptions) DeleteFromCache() bool { return o.deleteFromCache }
-func (o stmtOptions) NumberAsString() bool  { return o.numberAsString }
-func (o stmtOptions) NumberAsFloat64() bool { return o.numberAsFloat64 }
+func (o stmtOptions) DeleteFromCache() bool   { return o.deleteFromCache }
+func (o stmtOptions) NumberAsString() bool    { return o.numberAsString && !o.numberByPrecision }
+func (o stmtOptions) NumberAsFloat64() bool   { return o.numberAsFloat64 && !o.numberByPrecision }
+func (o stmtOptions) NumberByPrecision() bool { return o.numberByPrecision }
+func (o stmtOptions) NumberAsDecimal128() bool {
+	return o.numberAsDecimal128 && !o.numberAsString && !o.numberAsFloat64 && !o.numberByPrecision
+}
+func (o stmtOptions) NumberAsInt64() bool {
+	return o.numberAsInt64 && !o.numberAsString && !o.numberAsFloat64 && !o.numberByPrecision
+}
 func (o stmtOptions) PartialBatch() bool    { return o.partialBatch }
+func (o stmtOptions) BackgroundFetch() bool { return o.backgroundFetch }
+func (o stmtOptions) InternStrings() bool   { return o.internStrings }
+func (o stmtOptions) LobPromotion() bool    { return !o.noLobPromotion }
+func (o stmtOptions) FetchMemory() int      { return o.fetchMemory }
+func (o stmtOptions) FetchAsString(column string) bool {
+	if o.fetchAllAsString {
+		return true
+	}
+	for _, c := range o.fetchAsString {
+		if strings.EqualFold(c, column) {
+			return true
+		}
+	}
+	return false
+}
+func (o stmtOptions) ArrayDMLRowCounts() bool {
+	return o.arrayDMLRowCounts || o.rowCountsDest != nil
+}
 
 // Option holds statement options.
 //
@@ -150,7 +235,7 @@ type Option func(*stmtOptions)
 
 // BoolToString is an option that governs convertsion from bool to string in the database.
 // This is for converting from bool to string, from outside of the database
-// (which does not have a BOOL(EAN) column (SQL) type, only a BOOLEAN PL/SQL type).
+// (which does not have a BOOL(EAN) column (SQL) type before 23ai, only a BOOLEAN PL/SQL type).
 //
 // This will be used only with DML statements and when the PlSQLArrays Option is not used.
 //
@@ -178,6 +263,14 @@ func BoolToString(trueVal, falseVal string) Option {
 // Use it "naked", without sql.Named!
 var PlSQLArrays Option = func(o *stmtOptions) { o.plSQLArrays = true }
 
+// PlSQLTimestampArrays is PlSQLArrays, binding the slices of times as arrays of TIMESTAMP
+// (for TABLE OF TIMESTAMP parameters, keeping the fractional seconds), not of DATE.
+//
+// The element type of the array must match the declared PL/SQL table type (PLS-00418).
+//
+// Use it "naked", without sql.Named!
+var PlSQLTimestampArrays Option = func(o *stmtOptions) { o.plSQLArrays, o.plSQLTimestamps = true, true }
+
 // FetchRowCount is DEPRECATED, use FetchArraySize.
 //
 // It returns an option to set the rows to be fetched, overriding DefaultFetchRowCount.
@@ -217,6 +310,139 @@ func PrefetchCount(rowCount int) Option {
 	}
 }
 
+// StreamRows returns an option for consuming the rows of long-running producers
+// (such as pipelined table functions) incrementally:
+// the execution does not wait for prefetched rows,
+// and each fetch round-trip returns as soon as batchSize rows (DefaultFetchArraySize if batchSize <= 0)
+// are produced, making them available to Next.
+//
+// The next batch is requested only when Next is called after consuming the previous one,
+// so a slow consumer holds back the producer (PIPE ROW blocks on the server).
+//
+// Use it "naked", without sql.Named!
+func StreamRows(batchSize int) Option {
+	return func(o *stmtOptions) {
+		o.prefetchCount = -1
+		if batchSize > 0 {
+			o.fetchArraySize = batchSize
+		} else {
+			o.fetchArraySize = 0
+		}
+	}
+}
+
+// BackgroundFetch is an option to fetch the next array of rows (see FetchArraySize)
+// on a background goroutine while the current one is consumed,
+// overlapping the network round-trips and the processing of large extracts.
+//
+// The rows are converted to Go values in the background, so queries returning
+// LOBs as readers (LobAsReader), objects, JSON or nested cursors are fetched as usual.
+//
+// Use it "naked", without sql.Named!
+func BackgroundFetch() Option { return func(o *stmtOptions) { o.backgroundFetch = true } }
+
+// InternStrings is an option to return the same string (and []byte) value for
+// the repeated values of the result set, instead of allocating a new one for each row.
+// This helps a lot with low-cardinality (code) columns of wide extracts.
+//
+// The []byte values are shared, so they must not be modified
+// (database/sql copies them on Scan, this matters only when using the driver directly).
+//
+// Use it "naked", without sql.Named!
+func InternStrings() Option { return func(o *stmtOptions) { o.internStrings = true } }
+
+// ZeroCopyBytes is an option to return the VARCHAR2, CHAR (also N-) and RAW values
+// as []byte aliasing the fetch buffers of the driver, instead of copying each value.
+// This helps with large extracts of long values.
+//
+// The values are valid only till the next Next (or Close) call!
+// With database/sql, Scan them into sql.RawBytes, which has the same lifetime
+// (scanning into string, []byte or interface{} copies them, as usual).
+// See SetZeroCopyDebug for catching the misuse.
+//
+// InternStrings does not apply to these columns, and BackgroundFetch is switched off.
+//
+// Use it "naked", without sql.Named!
+func ZeroCopyBytes() Option { return func(o *stmtOptions) { o.zeroCopyBytes = true } }
+
+// FetchArena is an option to carve the text of the fetched NUMBER values from slabs
+// of a per-result-set arena, instead of allocating each one, to cut the GC pressure of
+// fetching millions of rows. The counters of the arena are written to stats
+// (if not nil) when the rows are closed.
+//
+// A retained value keeps its slab (4KiB of NUMBER text) alive.
+//
+// Use it "naked", without sql.Named!
+func FetchArena(stats *FetchArenaStats) Option {
+	return func(o *stmtOptions) { o.fetchArena, o.fetchArenaDest = true, stats }
+}
+
+// NoLobPromotion is an option to bind the string and []byte values longer than 32767 bytes
+// as VARCHAR2/RAW, as is, not as temporary CLOB/BLOB.
+//
+// Use it "naked", without sql.Named!
+func NoLobPromotion() Option { return func(o *stmtOptions) { o.noLobPromotion = true } }
+
+// FetchColumnInfo is an option to get the detailed metadata of the result columns
+// (the sql.ColumnType of database/sql cannot hold them) into dest, when the query is executed.
+//
+// Use it "naked", without sql.Named!
+func FetchColumnInfo(dest *[]ColumnInfo) Option {
+	return func(o *stmtOptions) { o.columnInfoDest = dest }
+}
+
+// PrefetchMemory returns an option to limit the memory used for the prefetched rows (OCI_ATTR_PREFETCH_MEMORY).
+// When both PrefetchCount and PrefetchMemory are set, the one resulting in fewer rows wins.
+//
+// Use it "naked", without sql.Named!
+func PrefetchMemory(bytes int) Option {
+	return func(o *stmtOptions) {
+		if bytes > 0 {
+			o.prefetchMemory = bytes
+		} else {
+			o.prefetchMemory = 0
+		}
+	}
+}
+
+// FetchMemory returns an option to limit the memory used for fetching the rows of a query:
+// the array size (see FetchArraySize) is shrunk to fit the define buffers into the limit,
+// and the prefetch memory (see PrefetchMemory) is limited to it, too.
+//
+// This keeps a SELECT of many wide (VARCHAR2(32767), CLOB as string) columns from allocating
+// hundreds of megabytes for the default array size.
+//
+// Use it "naked", without sql.Named!
+func FetchMemory(bytes int) Option {
+	return func(o *stmtOptions) { o.fetchMemory = max(0, bytes) }
+}
+
+type stmtOptionsCtxKey struct{}
+
+// ContextWithOptions returns a context which applies the given Options to the
+// statements prepared with it, before the Options given as arguments.
+//
+// This allows setting for example the PrefetchCount, FetchArraySize and PrefetchMemory
+// per query on the same pool: small for point lookups, big for bulk extracts.
+//
+// Note that a *sql.Stmt gets the Options of the context of its Prepare call.
+func ContextWithOptions(ctx context.Context, options ...Option) context.Context {
+	if prev, _ := ctx.Value(stmtOptionsCtxKey{}).([]Option); len(prev) != 0 {
+		options = append(append(make([]Option, 0, len(prev)+len(options)), prev...), options...)
+	}
+	return context.WithValue(ctx, stmtOptionsCtxKey{}, options)
+}
+
+// applyContextOptions applies the Options of ContextWithOptions.
+func (o *stmtOptions) applyContextOptions(ctx context.Context) {
+	options, _ := ctx.Value(stmtOptionsCtxKey{}).([]Option)
+	for _, apply := range options {
+		if apply != nil {
+			apply(o)
+		}
+	}
+}
+
 // ArraySize returns an option to set the array size to be used, overriding DefaultArraySize.
 //
 // Use it "naked", without sql.Named!
@@ -270,7 +496,9 @@ func ClobAsString() Option { return func(o *stmtOptions) { o.lobAsReader = false
 // Use it "naked", without sql.Named!
 func LobAsReader() Option { return func(o *stmtOptions) { o.lobAsReader = true } }
 
-// CallTimeout sets the round-trip timeout (OCI_ATTR_CALL_TIMEOUT).
+// CallTimeout sets the round-trip timeout (OCI_ATTR_CALL_TIMEOUT):
+// each call to the database (execute, fetch...) of the statement fails with an error
+// which IsCallTimeout recognizes, if it takes longer than d - even with a long-lived context.
 //
 // See https://docs.oracle.com/en/database/oracle/oracle-database/18/lnoci/handle-and-descriptor-attributes.html#GUID-D8EE68EB-7E38-4068-B06E-DF5686379E5E
 func CallTimeout(d time.Duration) Option {
@@ -281,7 +509,36 @@ func CallTimeout(d time.Duration) Option {
 // If you must Scan into time.Time (cannot use sql.NullTime), this may help.
 func NullDateAsZeroTime() Option { return func(o *stmtOptions) { o.nullDateAsZeroTime = true } }
 
-// DeleteFromCache is an option to delete the statement from the statement cache.
+// SessionTimezone returns an option to set the session time zone (ALTER SESSION SET TIME_ZONE)
+// for the execution of the statement and the fetching of its rows,
+// such as "+02:00", "Europe/Budapest", "DBTIMEZONE" or "LOCAL".
+//
+// The database uses the session time zone for converting TIMESTAMP WITH LOCAL TIME ZONE values
+// to and from TIMESTAMP and DATE, and for CURRENT_TIMESTAMP and CURRENT_DATE.
+//
+// The previous time zone of the session is restored after the execution, or when the rows are closed.
+// This costs three round-trips.
+//
+// Use it "naked", without sql.Named!
+func SessionTimezone(tz string) Option { return func(o *stmtOptions) { o.sessionTimezone = tz } }
+
+// LTZLocation returns an option to return the TIMESTAMP WITH LOCAL TIME ZONE values in loc,
+// instead of the time zone of the session - which may differ between the clients.
+//
+// Use it "naked", without sql.Named!
+func LTZLocation(loc *time.Location) Option { return func(o *stmtOptions) { o.ltzLocation = loc } }
+
+// BindDurationAs returns an option to bind the time.Duration parameters (and OUT destinations)
+// as INTERVAL DAY TO SECOND (the default), or as a NUMBER of seconds or days;
+// or to return ErrAmbiguousDuration for them (DurationAsError).
+//
+// Use it "naked", without sql.Named!
+func BindDurationAs(mode DurationMode) Option { return func(o *stmtOptions) { o.durationMode = mode } }
+
+// DeleteFromCache is an option to delete the statement from the statement cache,
+// for example to not let a one-off statement push out the often used ones.
+//
+// DDL statements are never kept in the statement cache.
 func DeleteFromCache() Option { return func(o *stmtOptions) { o.deleteFromCache = true } }
 
 // NumberAsString is an option to return numbers as string, not Number.
@@ -290,13 +547,88 @@ func NumberAsString() Option { return func(o *stmtOptions) { o.numberAsString =
 // NumberAsFloat64 is an option to return numbers as float64, not Number (which is a string).
 func NumberAsFloat64() Option { return func(o *stmtOptions) { o.numberAsFloat64 = true } }
 
+// NumberAsDecimal128 is an option to return NUMBERs as Decimal128 (IEEE 754 decimal128),
+// converted from the internal format of the NUMBER, without going through text.
+//
+// NumberAsString and NumberAsFloat64 take precedence.
+func NumberAsDecimal128() Option { return func(o *stmtOptions) { o.numberAsDecimal128 = true } }
+
+// NumberAsInt64 is an option to decode the integral NUMBERs straight from their internal format
+// to int64 (or uint64, if only that fits), without the intermediate text and its allocations.
+//
+// NUMBER(p,0) columns with p <= 18 are always fetched as int64.
+// This option is for the other NUMBER columns (such as NUMBER(19), plain NUMBER or COUNT(*)):
+// their values which are not integral or do not fit are still returned as Number.
+//
+// NumberAsString, NumberAsFloat64 and NumberAsDecimal128 take precedence.
+func NumberAsInt64() Option { return func(o *stmtOptions) { o.numberAsInt64 = true } }
+
+// NumberByPrecision is an option to return the NUMBERs according to the column metadata:
+// NUMBER(p,0) with p <= 18 as int64, returning an error for values not fitting into int64;
+// all the other NUMBERs as Number.
+//
+// It overrides NumberAsString, NumberAsFloat64, NumberAsDecimal128 and NumberAsInt64.
+func NumberByPrecision() Option { return func(o *stmtOptions) { o.numberByPrecision = true } }
+
+// FetchAsString is an option to fetch the named columns (all columns if none is given)
+// as string, converted by the database (using the session's NLS settings),
+// regardless of their type, to preserve the exact textual representation of
+// NUMBER, DATE, TIMESTAMP, INTERVAL and RAW (as hex) values.
+//
+// Column names are matched case-insensitively;
+// columns of other types (LOB, object, cursor, ...) are fetched as usual.
+func FetchAsString(columns ...string) Option {
+	return func(o *stmtOptions) {
+		o.fetchAllAsString = len(columns) == 0
+		o.fetchAsString = append(o.fetchAsString[:0:0], columns...)
+	}
+}
+
 // PartialBatch is an option to allow batch executing like FORALL SAVE EXCEPTIONS.
 //
 // WARNING: this means the INSERT/UPDATE/DELETE statement may be executed partially.
 // In such case the returned error is a BatchErrors containing the failed offsets.
 func PartialBatch() Option { return func(o *stmtOptions) { o.partialBatch = true } }
 
+// ArrayDMLRowCounts is an option to get the number of rows affected by each
+// element of an array DML (e.g. MERGE or UPDATE batch) into *counts.
+//
+// The driver.Result returned by the statement also implements RowCountsResult,
+// but database/sql hides that, so use this option with *sql.DB.
+func ArrayDMLRowCounts(counts *[]int64) Option {
+	return func(o *stmtOptions) { o.rowCountsDest = counts }
+}
+
+// RowCountsResult is the driver.Result of array DML executed with
+// the ArrayDMLRowCounts or ContinueOnError option.
+type RowCountsResult interface {
+	driver.Result
+	// RowCounts returns the number of rows affected by each element of the batch.
+	RowCounts() []int64
+}
+
+type rowCountsResult struct {
+	driver.Result
+	rowCounts []int64
+}
+
+func (r rowCountsResult) RowCounts() []int64 { return r.rowCounts }
+
+var _ RowCountsResult = rowCountsResult{}
+
+// ContinueOnError is an option to execute array DML in batch errors mode,
+// like PartialBatch, but the returned BatchErrors contains the per-row
+// affected counts (RowCounts), too.
+//
+// WARNING: this means the INSERT/UPDATE/DELETE statement may be executed partially.
+func ContinueOnError() Option {
+	return func(o *stmtOptions) { o.partialBatch, o.arrayDMLRowCounts = true, true }
+}
+
 // Return ORA-24344 warning as an error
+//
+// For the CREATE and ALTER ... COMPILE statements of PL/SQL objects,
+// the error is a *CompilationError, holding the compilation errors.
 func WarningAsError() Option { return func(o *stmtOptions) { o.warningAsError = true } }
 
 // Do not re-execute statement if ORA-04061, ORA-04065 or ORA-04068 occurs
@@ -304,6 +636,11 @@ func NoRetry() Option { return func(o *stmtOptions) { o.noRetry = true } }
 
 const minChunkSize = 1 << 16
 
+// handleDeadline sets the call timeout to the lesser of the context deadline and the CallTimeout option.
+func (st *statement) handleDeadline(ctx context.Context) (cleanup func(), err error) {
+	return st.conn.handleCallTimeout(ctx, st.callTimeout)
+}
+
 var _ driver.Stmt = (*statement)(nil)
 var _ driver.StmtQueryContext = (*statement)(nil)
 var _ driver.StmtExecContext = (*statement)(nil)
@@ -321,10 +658,13 @@ type statement struct {
 	data     [][]C.dpiData
 	vars     []*C.dpiVar
 	varInfos []varInfo
+	defines  []cachedDefine // define variables kept for the next execution
 	stmtOptions
 	arrLen      int
 	dpiStmtInfo C.dpiStmtInfo
 	sync.Mutex
+	prepareDur time.Duration // for the slow query hook
+	traceFetch bool          // create spans for the fetches
 }
 type dataGetter func(ctx context.Context, v interface{}, data []C.dpiData) error
 
@@ -373,12 +713,15 @@ func (st *statement) closeNotLocking(ctx context.Context) error {
 			C.dpiVar_release(v)
 		}
 	}
+	st.releaseDefines()
 	if dpiStmt.refCount > 0 {
 		C.dpiStmt_release(dpiStmt)
 	}
+	resourceCounters.stmts.Add(-1)
 	if c == nil {
 		return driver.ErrBadConn
 	}
+	c.cursors.closed(st)
 	return nil
 }
 
@@ -423,6 +766,57 @@ func (st *statement) ExecContext(ctx context.Context, args []driver.NamedValue)
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	if st.dpiStmt == nil {
+		return st.execContext(ctx, args)
+	}
+	if !st.conn.intercepted() {
+		return st.execInstrumented(ctx, args)
+	}
+	op := &Op{Kind: OpExec, Query: st.query, Args: args}
+	err := st.conn.intercept(ctx, op, func(ctx context.Context, op *Op) (err error) {
+		op.Result, err = st.execInstrumented(ctx, op.Args)
+		return err
+	})
+	if err == nil && op.Result == nil {
+		err = fmt.Errorf("%s: %w", OpExec, ErrInterceptedNoResult)
+	}
+	return op.Result, err
+}
+
+// execInstrumented executes the statement with the metrics, slow query hook and tracing.
+func (st *statement) execInstrumented(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	done := st.observe("exec")
+	slow := st.startSlowTimer(ctx, args)
+	var span Span
+	if st.conn.traceSampled() {
+		ctx, span = st.startSpan(ctx, "exec", TraceAttr{Key: "db.query.text", Value: st.query})
+	}
+	res, err := st.execContext(ctx, args)
+	st.countExecute()
+	done(err)
+	slow.execDone(st, err)
+	var affected int64
+	if err == nil {
+		affected, _ = res.RowsAffected()
+	}
+	if span != nil {
+		if err == nil {
+			span.SetAttributes(TraceAttr{Key: "db.response.affected_rows", Value: affected})
+		}
+		st.traceSQLID(span)
+		span.End(err)
+	}
+	if slow != nil {
+		slow.sq.Rows = affected
+		slow.report(st.conn)
+	}
+	if err == nil {
+		st.audit(ctx, affected)
+	}
+	return res, st.snapshotBinds(err, args)
+}
+
+func (st *statement) execContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
 	logger := st.conn.getLogger(ctx)
 	if logger != nil && logger.Enabled(ctx, slog.LevelDebug) {
 		logger.Debug("ExecContext", "stmt", fmt.Sprintf("%p", st), "args", fmt.Sprintf("%#v", args))
@@ -439,15 +833,13 @@ func (st *statement) ExecContext(ctx context.Context, args []driver.NamedValue)
 		*(args[0].Value.(sql.Out).Dest.(*interface{})) = st.conn
 		return driver.ResultNoRows, nil
 	}
+	if hasStringIndexedTable(args) {
+		return st.execStringIndexedTables(ctx, args)
+	}
 
 	st.conn.mu.RLock()
 	defer st.conn.mu.RUnlock()
 
-	if st.callTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, st.callTimeout)
-		defer cancel()
-	}
 	// HandleDeadline for all ODPI calls called below
 	cleanup, err := st.handleDeadline(ctx)
 	if err != nil {
@@ -469,6 +861,15 @@ func (st *statement) ExecContext(ctx context.Context, args []driver.NamedValue)
 	if err = st.bindVars(ctx, args, logger); err != nil {
 		return nil, closeIfBadConn(err)
 	}
+	restoreTZ, err := st.conn.setSessionTimezone(st.sessionTimezone)
+	if err != nil {
+		return nil, closeIfBadConn(err)
+	}
+	defer func() {
+		if err := restoreTZ(); err != nil && logger != nil {
+			logger.Error("restore session time zone", "error", err)
+		}
+	}()
 
 	mode := st.ExecMode()
 	//fmt.Printf("%p.%p: inTran? %t\n%s\n", st.conn, st, st.inTransaction, st.query)
@@ -477,6 +878,7 @@ func (st *statement) ExecContext(ctx context.Context, args []driver.NamedValue)
 	}
 	if st.DeleteFromCache() {
 		C.dpiStmt_deleteFromCache(st.dpiStmt)
+		st.conn.stmtCache.remove(st.query)
 	}
 	// execute
 	var f func() C.int
@@ -485,6 +887,9 @@ func (st *statement) ExecContext(ctx context.Context, args []driver.NamedValue)
 		if st.PartialBatch() {
 			mode |= C.DPI_MODE_EXEC_BATCH_ERRORS
 		}
+		if st.ArrayDMLRowCounts() {
+			mode |= C.DPI_MODE_EXEC_ARRAY_DML_ROWCOUNTS
+		}
 		f = func() C.int { return C.dpiStmt_executeMany(st.dpiStmt, mode, C.uint32_t(st.arrLen)) }
 	} else {
 		f = func() C.int { return C.dpiStmt_execute(st.dpiStmt, mode, nil) }
@@ -493,26 +898,9 @@ func (st *statement) ExecContext(ctx context.Context, args []driver.NamedValue)
 		if logger != nil && logger.Enabled(ctx, slog.LevelDebug) {
 			logger.Debug("dpiStmt_execute", "st", fmt.Sprintf("%p", st.dpiStmt), "many", many, "mode", mode, "len", st.arrLen)
 		}
-		done := make(chan struct{})
-		if !st.conn.params.NoBreakOnContextCancel {
-			// Forcefully BREAK execution on context cancelation
-			go func() {
-				select {
-				case <-done:
-				case <-ctx.Done():
-					select {
-					case <-done:
-					default:
-						if logger != nil {
-							logger.Warn("BREAK dpiStmt_execute")
-						}
-						st.conn.Break()
-					}
-				}
-			}()
-		}
 		if err = func() error {
-			defer close(done)
+			// Forcefully BREAK execution on context cancelation
+			defer st.conn.watchCancel(ctx, "dpiStmt_execute")()
 			if st.warningAsError {
 				return st.checkExecWithWarning(f)
 			} else {
@@ -522,14 +910,18 @@ func (st *statement) ExecContext(ctx context.Context, args []driver.NamedValue)
 			break
 		}
 		if ctxErr := ctx.Err(); ctxErr != nil {
+			cleanup()
 			return nil, ctxErr
 		}
 		if st.noRetry || !isInvalidErr(err) {
 			break
 		}
 	}
+	if err != nil && st.warningAsError && !many {
+		err = st.compilationError(ctx, err)
+	}
 	if err != nil && (!many || !st.PartialBatch() || closeIfBadConn(err) == driver.ErrBadConn) {
-		return nil, err
+		return nil, withSQL(err, st.query)
 	}
 
 	var batchErrors error
@@ -568,6 +960,12 @@ func (st *statement) ExecContext(ctx context.Context, args []driver.NamedValue)
 					be.Affected = append(be.Affected, i)
 				}
 			}
+			if st.ArrayDMLRowCounts() {
+				var err error
+				if be.RowCounts, err = st.getRowCounts(); err != nil && logger != nil {
+					logger.Error("getRowCounts", "error", err)
+				}
+			}
 			return &be
 		}(); batchErrors != nil {
 			if logger != nil && logger.Enabled(ctx, slog.LevelWarn) {
@@ -586,21 +984,20 @@ func (st *statement) ExecContext(ctx context.Context, args []driver.NamedValue)
 			continue
 		}
 		i := i
+		data := st.data[i]
 		if st.dpiStmtInfo.isReturning == 1 {
-			var n C.uint32_t
-			data := &st.data[i][0]
-			if err := st.checkExec(func() C.int { return C.dpiVar_getReturnedData(st.vars[i], 0, &n, &data) }); err != nil {
-				return nil, closeIfBadConn(fmt.Errorf("%d.getReturnedData: %w", i, err))
+			arrLen := 1
+			if many {
+				arrLen = st.arrLen
 			}
-			if n == 0 {
-				st.data[i] = st.data[i][:0]
-			} else {
-				st.data[i] = unsafe.Slice(data, n)
+			var rErr error
+			if data, rErr = st.returnedData(i, arrLen); rErr != nil {
+				return nil, closeIfBadConn(rErr)
 			}
 		}
 		dest := st.dests[i]
 		if !st.isSlice[i] {
-			if err := get(ctx, dest, st.data[i]); err != nil {
+			if err := get(ctx, dest, data); err != nil {
 				if logger != nil {
 					logger.Error("get", "i", i, "error", err)
 				}
@@ -611,6 +1008,16 @@ func (st *statement) ExecContext(ctx context.Context, args []driver.NamedValue)
 			}
 			continue
 		}
+		if st.dpiStmtInfo.isReturning == 1 {
+			// all the returned rows, of all the executions
+			if err := get(ctx, dest, data); err != nil {
+				if logger != nil {
+					logger.Error("get", "i", i, "n", len(data), "error", err)
+				}
+				return nil, closeIfBadConn(fmt.Errorf("%d. get: %w", i, err))
+			}
+			continue
+		}
 		var n C.uint32_t = 1
 		if err := st.checkExec(func() C.int { return C.dpiVar_getNumElementsInArray(st.vars[i], &n) }); err != nil {
 			if logger != nil {
@@ -633,7 +1040,70 @@ func (st *statement) ExecContext(ctx context.Context, args []driver.NamedValue)
 		}
 		return nil, batchErrors
 	}
-	return driver.RowsAffected(count), batchErrors
+	if !many || !st.ArrayDMLRowCounts() {
+		return driver.RowsAffected(count), batchErrors
+	}
+	var rowCounts []int64
+	var be *BatchErrors
+	if errors.As(batchErrors, &be) {
+		rowCounts = be.RowCounts
+	} else if rowCounts, err = st.getRowCounts(); err != nil {
+		return nil, closeIfBadConn(err)
+	}
+	if st.rowCountsDest != nil {
+		*st.rowCountsDest = rowCounts
+	}
+	return rowCountsResult{Result: driver.RowsAffected(count), rowCounts: rowCounts}, batchErrors
+}
+
+// returnedData returns the rows returned by a DML returning statement into the i-th variable,
+// concatenated for each of the arrLen executions of an array DML.
+func (st *statement) returnedData(i, arrLen int) ([]C.dpiData, error) {
+	var all []C.dpiData
+	for pos := 0; pos < arrLen; pos++ {
+		var n C.uint32_t
+		var data *C.dpiData
+		if err := st.checkExec(func() C.int { return C.dpiVar_getReturnedData(st.vars[i], C.uint32_t(pos), &n, &data) }); err != nil {
+			return nil, fmt.Errorf("%d.getReturnedData[%d]: %w", i, pos, err)
+		}
+		if n == 0 {
+			continue
+		}
+		if arrLen == 1 {
+			return unsafe.Slice(data, n), nil
+		}
+		all = append(all, unsafe.Slice(data, n)...)
+	}
+	return all, nil
+}
+
+// nullIfNoData returns a NULL data for the empty data,
+// as returned by a DML returning statement that affected no rows.
+func nullIfNoData(data []C.dpiData) []C.dpiData {
+	if len(data) != 0 {
+		return data
+	}
+	null := make([]C.dpiData, 1)
+	null[0].isNull = 1
+	return null
+}
+
+// getRowCounts returns the per-row affected counts of the last
+// array DML execution with DPI_MODE_EXEC_ARRAY_DML_ROWCOUNTS.
+func (st *statement) getRowCounts() ([]int64, error) {
+	var n C.uint32_t
+	var counts *C.uint64_t
+	if err := st.checkExec(func() C.int { return C.dpiStmt_getRowCounts(st.dpiStmt, &n, &counts) }); err != nil {
+		return nil, fmt.Errorf("getRowCounts: %w", err)
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	rowCounts := make([]int64, n)
+	for i, c := range unsafe.Slice(counts, n) {
+		rowCounts[i] = int64(c)
+	}
+	return rowCounts, nil
 }
 
 // QueryContext executes a query that may return rows, such as a SELECT.
@@ -646,6 +1116,55 @@ func (st *statement) QueryContext(ctx context.Context, args []driver.NamedValue)
 		return nil, err
 	}
 
+	if st.dpiStmt == nil {
+		st.traceFetch = false
+		return st.queryContext(ctx, args)
+	}
+	if !st.conn.intercepted() {
+		return st.queryInstrumented(ctx, args)
+	}
+	op := &Op{Kind: OpQuery, Query: st.query, Args: args}
+	err := st.conn.intercept(ctx, op, func(ctx context.Context, op *Op) (err error) {
+		op.Rows, err = st.queryInstrumented(ctx, op.Args)
+		return err
+	})
+	if err == nil && op.Rows == nil {
+		err = fmt.Errorf("%s: %w", OpQuery, ErrInterceptedNoResult)
+	}
+	return op.Rows, err
+}
+
+// queryInstrumented executes the query with the metrics, slow query hook and tracing.
+func (st *statement) queryInstrumented(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	done := st.observe("query")
+	slow := st.startSlowTimer(ctx, args)
+	var span Span
+	st.traceFetch = false
+	if st.conn.traceSampled() {
+		ctx, span = st.startSpan(ctx, "query", TraceAttr{Key: "db.query.text", Value: st.query})
+		st.traceFetch = !st.traceOpts.NoFetchSpans
+	}
+	dr, err := st.queryContext(ctx, args)
+	st.countExecute()
+	done(err)
+	slow.execDone(st, err)
+	if span != nil {
+		if err == nil {
+			st.traceSQLID(span)
+		}
+		span.End(err)
+	}
+	if slow != nil {
+		if r, ok := dr.(*rows); ok && err == nil {
+			r.slow = slow // reported on Close, with the fetches
+		} else {
+			slow.report(st.conn)
+		}
+	}
+	return dr, st.snapshotBinds(err, args)
+}
+
+func (st *statement) queryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
 	st.Lock()
 	defer st.Unlock()
 	if st.conn == nil {
@@ -705,34 +1224,35 @@ func (st *statement) queryContextNotLocked(ctx context.Context, args []driver.Na
 	if !st.inTransaction {
 		mode |= C.DPI_MODE_EXEC_COMMIT_ON_SUCCESS
 	}
+	if st.DeleteFromCache() {
+		C.dpiStmt_deleteFromCache(st.dpiStmt)
+		st.conn.stmtCache.remove(st.query)
+	}
 	// set Prefetch Parameters before execute
 	C.dpiStmt_setFetchArraySize(st.dpiStmt, C.uint32_t(st.FetchArraySize()))
 	C.dpiStmt_setPrefetchRows(st.dpiStmt, C.uint32_t(st.PrefetchCount()))
+	prefetchMemory := st.prefetchMemory
+	if fm := st.FetchMemory(); fm > 0 && (prefetchMemory == 0 || prefetchMemory > fm) {
+		prefetchMemory = fm
+	}
+	if prefetchMemory > 0 {
+		if err = st.checkExec(func() C.int { return C.godror_setPrefetchMemory(st.dpiStmt, C.uint32_t(prefetchMemory)) }); err != nil {
+			return nil, closeIfBadConn(fmt.Errorf("setPrefetchMemory: %w", err))
+		}
+	}
+
+	restoreTZ, err := st.conn.setSessionTimezone(st.sessionTimezone)
+	if err != nil {
+		return nil, closeIfBadConn(err)
+	}
 
 	// execute
 	var colCount C.uint32_t
 	f := func() C.int { return C.dpiStmt_execute(st.dpiStmt, mode, &colCount) }
 	for i := 0; i < 3; i++ {
-		done := make(chan struct{})
-		if !st.conn.params.NoBreakOnContextCancel {
-			// Forcefully BREAK execution on context cancelation
-			go func() {
-				select {
-				case <-done:
-				case <-ctx.Done():
-					select {
-					case <-done:
-					default:
-						if logger != nil {
-							logger.Warn("BREAK dpiStmt_execute")
-						}
-						st.conn.Break()
-					}
-				}
-			}()
-		}
 		if err = func() error {
-			defer close(done)
+			// Forcefully BREAK execution on context cancelation
+			defer st.conn.watchCancel(ctx, "dpiStmt_execute")()
 			if st.warningAsError {
 				return st.checkExecWithWarning(f)
 			} else {
@@ -742,6 +1262,8 @@ func (st *statement) queryContextNotLocked(ctx context.Context, args []driver.Na
 			break
 		}
 		if ctxErr := ctx.Err(); ctxErr != nil {
+			_ = restoreTZ()
+			cleanup()
 			return nil, ctxErr
 		}
 		if st.noRetry || !isInvalidErr(err) {
@@ -749,11 +1271,21 @@ func (st *statement) queryContextNotLocked(ctx context.Context, args []driver.Na
 		}
 	}
 	if err != nil {
-		return nil, closeIfBadConn(fmt.Errorf("dpiStmt_execute: %w", err))
+		_ = restoreTZ()
+		return nil, closeIfBadConn(fmt.Errorf("dpiStmt_execute: %w", withSQL(err, st.query)))
 	}
 
 	rows, err := st.openRows(ctx, int(colCount))
-	return rows, closeIfBadConn(err)
+	if err != nil {
+		_ = restoreTZ()
+		return rows, closeIfBadConn(err)
+	}
+	// the rows are fetched in the session time zone, too
+	rows.restoreTZ = restoreTZ
+	if st.columnInfoDest != nil {
+		*st.columnInfoDest = rows.columnInfos()
+	}
+	return rows, closeIfBadConn(nil)
 }
 
 // NumInput returns the number of placeholder parameters.
@@ -801,9 +1333,19 @@ func (st *statement) NumInput() int {
 		return int(cnt)
 	}
 
+	if !mayHaveBindName(st.query) {
+		return int(cnt)
+	}
+	// A struct may be given as the sole argument for the named binds (see BindStructTag),
+	// or OutStruct for several of them, which database/sql would count as one argument,
+	// so the count of the distinct bind names is checked by bindVars, after expanding them.
+	return -1
+}
+
+// mayHaveBindName reports whether the query may have a named (:name) bind variable.
+func mayHaveBindName(qry string) bool {
 	var prevColon bool
-	var mayHaveBoundName bool
-	for _, r := range st.query {
+	for _, r := range qry {
 		if r == ':' {
 			prevColon = true
 			continue
@@ -811,32 +1353,29 @@ func (st *statement) NumInput() int {
 		if prevColon {
 			prevColon = false
 			if 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' {
-				mayHaveBoundName = true
-				break
+				return true
 			}
 		}
 	}
-	if !mayHaveBoundName {
-		return int(cnt)
-	}
+	return false
+}
 
-	names := make([]*C.char, int(cnt))
-	lengths := make([]C.uint32_t, int(cnt))
-	if err := st.checkExec(func() C.int { return C.dpiStmt_getBindNames(st.dpiStmt, &cnt, &names[0], &lengths[0]) }); err != nil {
-		if logger != nil {
-			logger.Error("getBindNames", "error", err)
-		}
-		if st.conn == nil {
-			panic(driver.ErrBadConn)
-		}
-		panic(err)
+// checkNumInput checks the number of the (expanded) arguments against the number of the
+// distinct bind names, as database/sql does for the statements NumInput returns the count for.
+//
+// Describe-only executions (Describe, DescribeQuery) bind nothing.
+func (st *statement) checkNumInput(args []driver.NamedValue) error {
+	if st.dpiStmt == nil || st.ExecMode() == C.DPI_MODE_EXEC_DESCRIBE_ONLY || !mayHaveBindName(st.query) {
+		return nil
 	}
-	if logger != nil && logger.Enabled(ctx, slog.LevelDebug) {
-		logger.Debug("NumInput", "count", cnt, "stmt", fmt.Sprintf("%p", st))
+	names, err := st.bindNames()
+	if err != nil {
+		return fmt.Errorf("bindNames: %w", err)
 	}
-
-	// return the number of *unique* arguments
-	return int(cnt)
+	if len(args) != len(names) {
+		return fmt.Errorf("expected %d arguments, got %d", len(names), len(args))
+	}
+	return nil
 }
 
 type argInfo struct {
@@ -853,6 +1392,27 @@ func (st *statement) bindVars(ctx context.Context, args []driver.NamedValue, log
 	if logger != nil && logger.Enabled(ctx, slog.LevelDebug) {
 		logger.Debug("enter bindVars", "st", fmt.Sprintf("%p", st), "args", fmt.Sprintf("%#v", args))
 	}
+	var err error
+	if args, err = expandOutStructs(args, st.bindNames); err != nil {
+		return err
+	}
+	if len(args) == 1 && args[0].Name == "" {
+		expanded, ok, err := namedArgsFromStruct(args[0].Value, st.bindNames)
+		if err != nil {
+			return fmt.Errorf("bindNames: %w", err)
+		}
+		if ok {
+			args = expanded
+		}
+	}
+	if err = st.checkNumInput(args); err != nil {
+		return err
+	}
+	args, closeCollections, err := st.conn.structCollections(ctx, args)
+	if err != nil {
+		return err
+	}
+	defer closeCollections()
 	var named bool
 	if cap(st.vars) < len(args) {
 		st.vars = make([]*C.dpiVar, len(args))
@@ -928,7 +1488,8 @@ func (st *statement) bindVars(ctx context.Context, args []driver.NamedValue, log
 		}
 		if _, isByteSlice := value.([]byte); !isByteSlice {
 			st.isSlice[i] = rArgs[i].Kind() == reflect.Slice
-			if !st.PlSQLArrays() && st.isSlice[i] {
+			// OUT-only slices are filled by RETURNING INTO, they don't drive the array DML
+			if !st.PlSQLArrays() && st.isSlice[i] && info.isIn {
 				n := rArgs[i].Len()
 				if minArrLen == -1 || n < minArrLen {
 					minArrLen = n
@@ -964,6 +1525,11 @@ func (st *statement) bindVars(ctx context.Context, args []driver.NamedValue, log
 	if logger != nil && logger.Enabled(ctx, slog.LevelDebug) {
 		logger.Debug("bindVars", "doManyCount", doManyCount, "arrLen", st.arrLen, "doExecMany", doExecMany, "minArrLen", "maxArrLen")
 	}
+	var binds []BindValue
+	bh := getBindHook(ctx)
+	if bh != nil {
+		binds = make([]BindValue, len(args))
+	}
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 	for i := range args {
@@ -974,6 +1540,12 @@ func (st *statement) bindVars(ctx context.Context, args []driver.NamedValue, log
 		if value, err = st.bindVarTypeSwitch(ctx, info, &(st.gets[i]), value); err != nil {
 			return fmt.Errorf("%d. arg: %w", i+1, err)
 		}
+		if bh != nil {
+			binds[i] = BindValue{Name: args[i].Name, Position: i + 1, IsOut: info.isOut}
+			if info.isIn {
+				binds[i].Value = value
+			}
+		}
 
 		var rv reflect.Value
 		if st.isSlice[i] {
@@ -1052,6 +1624,10 @@ func (st *statement) bindVars(ctx context.Context, args []driver.NamedValue, log
 		}
 	}
 
+	if bh != nil {
+		bh.call(ctx, st.query, binds)
+	}
+
 	if !named {
 		for i, v := range st.vars {
 			i, v := i, v
@@ -1103,6 +1679,37 @@ func (st *statement) bindVarTypeSwitch(ctx context.Context, info *argInfo, get *
 		}
 	}
 
+	// Too long strings and []bytes would result in ORA-01460/ORA-01704, so bind them as temporary LOBs.
+	// The temporary LOB is freed when the variable is released.
+	if info.isIn && !info.isOut && st.LobPromotion() {
+		switch v := value.(type) {
+		case string:
+			if len(v) > maxBindBytes {
+				value = Lob{Reader: strings.NewReader(v), IsClob: true}
+			}
+		case []byte:
+			if len(v) > maxBindBytes {
+				value = Lob{Reader: bytes.NewReader(v)}
+			}
+		}
+	}
+
+	if mode := st.nonFiniteBind; mode != NonFiniteKeep && info.isIn && (mode == NonFiniteError || !info.isOut) {
+		conv, err := nonFiniteBind(value, mode)
+		if err != nil {
+			return value, fmt.Errorf("bindVarTypeSwitch(%T): %w", value, err)
+		}
+		if conv != nil {
+			return st.bindVarTypeSwitch(ctx, info, get, conv)
+		}
+	}
+
+	if st.bindDateSentinelSet && info.isIn && !info.isOut && !nilPtr {
+		if conv := bindDateSentinel(value, st.bindDateSentinel); conv != nil {
+			return st.bindVarTypeSwitch(ctx, info, get, conv)
+		}
+	}
+
 	switch v := value.(type) {
 	case Lob, []Lob:
 		info.typ, info.natTyp = C.DPI_ORACLE_TYPE_BLOB, C.DPI_NATIVE_TYPE_LOB
@@ -1228,6 +1835,12 @@ func (st *statement) bindVarTypeSwitch(ctx context.Context, info *argInfo, get *
 		if info.isOut {
 			*get = dataGetNumber
 		}
+	case sql.NullBool, []sql.NullBool:
+		info.typ, info.natTyp = C.DPI_ORACLE_TYPE_BOOLEAN, C.DPI_NATIVE_TYPE_BOOLEAN
+		info.set = dataSetBool
+		if info.isOut {
+			*get = dataGetBool
+		}
 	case bool, []bool:
 		if st.dpiStmtInfo.isPLSQL == 1 || st.stmtOptions.boolString.IsZero() || st.PlSQLArrays() {
 			info.typ, info.natTyp = C.DPI_ORACLE_TYPE_BOOLEAN, C.DPI_NATIVE_TYPE_BOOLEAN
@@ -1299,6 +1912,33 @@ func (st *statement) bindVarTypeSwitch(ctx context.Context, info *argInfo, get *
 					}
 				}
 			}
+			if st.bindStringAsChar && v != nil && info.bufSize <= 4*maxCharBind {
+				info.typ = C.DPI_ORACLE_TYPE_CHAR
+			}
+		}
+
+	case NString, []NString:
+		info.typ, info.natTyp = C.DPI_ORACLE_TYPE_NVARCHAR, C.DPI_NATIVE_TYPE_BYTES
+		info.set = dataSetBytes
+		if info.isOut {
+			info.bufSize = 32767
+			*get = dataGetBytes
+		} else {
+			switch v := v.(type) {
+			case NString:
+				info.bufSize = 4 * len(v)
+			case []NString:
+				for _, s := range v {
+					if n := 4 * len(s); n > info.bufSize {
+						info.bufSize = n
+					}
+				}
+			}
+			// the maximum size of an NVARCHAR2 bind
+			info.bufSize = min(info.bufSize, 32767)
+			if st.bindStringAsChar && info.bufSize <= 4*maxCharBind {
+				info.typ = C.DPI_ORACLE_TYPE_NCHAR
+			}
 		}
 
 	case time.Time, NullTime, *timestamppb.Timestamp:
@@ -1311,7 +1951,11 @@ func (st *statement) bindVarTypeSwitch(ctx context.Context, info *argInfo, get *
 	case []time.Time, []NullTime, []*timestamppb.Timestamp:
 		info.typ, info.natTyp = C.DPI_ORACLE_TYPE_TIMESTAMP_TZ, C.DPI_NATIVE_TYPE_TIMESTAMP
 		if st.plSQLArrays {
+			// the type must match the declared element type, so it cannot depend on the values
 			info.typ = C.DPI_ORACLE_TYPE_DATE
+			if st.plSQLTimestamps {
+				info.typ = C.DPI_ORACLE_TYPE_TIMESTAMP
+			}
 		}
 		info.set = st.conn.dataSetTime
 		if info.isOut {
@@ -1319,13 +1963,36 @@ func (st *statement) bindVarTypeSwitch(ctx context.Context, info *argInfo, get *
 		}
 
 	case time.Duration, []time.Duration:
+		switch mode := st.durationMode; mode {
+		case DurationAsError:
+			return value, fmt.Errorf("bindVarTypeSwitch(%T): %w", value, ErrAmbiguousDuration)
+		case DurationAsSeconds, DurationAsDays:
+			return st.bindDurationAsNumber(ctx, info, get, v, mode)
+		}
 		info.typ, info.natTyp = C.DPI_ORACLE_TYPE_INTERVAL_DS, C.DPI_NATIVE_TYPE_INTERVAL_DS
 		info.set = st.conn.dataSetIntervalDS
 		if info.isOut {
 			*get = st.conn.dataGetIntervalDS
 		}
 
+	case IntervalDS, []IntervalDS:
+		info.typ, info.natTyp = C.DPI_ORACLE_TYPE_INTERVAL_DS, C.DPI_NATIVE_TYPE_INTERVAL_DS
+		info.set = dataSetIntervalDSExact
+		if info.isOut {
+			*get = dataGetIntervalDSExact
+		}
+
+	case IntervalYM, []IntervalYM:
+		info.typ, info.natTyp = C.DPI_ORACLE_TYPE_INTERVAL_YM, C.DPI_NATIVE_TYPE_INTERVAL_YM
+		info.set = dataSetIntervalYM
+		if info.isOut {
+			*get = dataGetIntervalYM
+		}
+
 	case Object:
+		if v.ObjectType == nil {
+			return value, fmt.Errorf("%T without ObjectType: %w", value, errUnknownType)
+		}
 		info.objType = v.ObjectType.dpiObjectType
 		info.typ, info.natTyp = C.DPI_ORACLE_TYPE_OBJECT, C.DPI_NATIVE_TYPE_OBJECT
 		info.set = st.dataSetObject
@@ -1348,6 +2015,9 @@ func (st *statement) bindVarTypeSwitch(ctx context.Context, info *argInfo, get *
 		}
 
 	case userType:
+		if o := v.ObjectRef(); o == nil || o.ObjectType == nil {
+			return value, fmt.Errorf("%T without ObjectType: %w", value, errUnknownType)
+		}
 		info.objType = v.ObjectRef().ObjectType.dpiObjectType
 		info.typ, info.natTyp = C.DPI_ORACLE_TYPE_OBJECT, C.DPI_NATIVE_TYPE_OBJECT
 		info.set = st.dataSetObject
@@ -1383,6 +2053,15 @@ func (st *statement) bindVarTypeSwitch(ctx context.Context, info *argInfo, get *
 		if logger != nil && logger.Enabled(ctx, slog.LevelDebug) {
 			logger.Debug("bindVarTypeSwitch default", "value", fmt.Sprintf("%T", value))
 		}
+		if rt := reflect.TypeOf(value); !isValuer && info.isIn && !info.isOut &&
+			rt.Kind() == reflect.Slice && rt.Elem().Implements(valuerType) {
+			// such as []sql.Null[T]
+			conv, err := valuerSlice(value)
+			if err != nil {
+				return value, fmt.Errorf("bindVarTypeSwitch(%T): %w", value, err)
+			}
+			return st.bindVarTypeSwitch(ctx, info, get, conv)
+		}
 		if !isValuer {
 			rt := reflect.TypeOf(value)
 			kind := rt.Kind()
@@ -1427,6 +2106,15 @@ func (st *statement) bindVarTypeSwitch(ctx context.Context, info *argInfo, get *
 				return value, nil
 			}
 
+			if mt := rt; mt.Kind() == reflect.Map || mt.Kind() == reflect.Pointer && mt.Elem().Kind() == reflect.Map {
+				if mt.Kind() == reflect.Pointer {
+					mt = mt.Elem()
+				}
+				if mt.Key().Kind() == reflect.String {
+					return value, fmt.Errorf("bindVarTypeSwitch(%T): %w", value, ErrStringIndexedTable)
+				}
+			}
+
 			if ot, err := st.conn.getStructObjectType(ctx, value, ""); err != nil {
 				if logger != nil {
 					logger.Error("getStructObjectType", "value", fmt.Sprintf("%T", value), "error", err)
@@ -1454,15 +2142,144 @@ func (st *statement) bindVarTypeSwitch(ctx context.Context, info *argInfo, get *
 		if value, err = vlr.Value(); err != nil {
 			return value, fmt.Errorf("arg.Value(): %w", err)
 		}
+		isNull := value == nil
+		if isNull {
+			// the NULL of sql.Null[T] is bound with the type of T
+			value = nullValueOf(oval)
+		}
 		if logger != nil && logger.Enabled(ctx, slog.LevelDebug) {
 			logger.Debug("valuer", "old", fmt.Sprintf("[%T]%#v.Value()", oval, oval), "new", fmt.Sprintf("[%T]%#v", value, value))
 		}
-		return st.bindVarTypeSwitch(ctx, info, get, value)
+		if value, err = st.bindVarTypeSwitch(ctx, info, get, value); err != nil {
+			return value, err
+		}
+		if isNull && value != nil {
+			// T only gives the type, the value is NULL
+			info.set = dataSetNull
+		}
+		if !info.isOut || *get == nil {
+			return value, nil
+		}
+		// an OUT sql.Scanner gets the value of its Value's type
+		inner, rt := *get, reflect.TypeOf(value)
+		if rt == nil {
+			rt = reflect.TypeOf("")
+		}
+		*get = func(ctx context.Context, v interface{}, data []C.dpiData) error {
+			sc, ok := v.(sql.Scanner)
+			if !ok {
+				return inner(ctx, v, data)
+			}
+			if len(data) == 0 || data[0].isNull == 1 {
+				return sc.Scan(nil)
+			}
+			tmp := reflect.New(rt)
+			if err := inner(ctx, tmp.Interface(), data); err != nil {
+				return err
+			}
+			return sc.Scan(tmp.Elem().Interface())
+		}
+		return value, nil
 	}
 
 	return value, nil
 }
 
+// nullValueOf returns the zero value of the V field of sql.Null[T] (and look-alike generic option types),
+// or nil if v does not have such a field.
+func nullValueOf(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	if f, ok := rv.Type().FieldByName("V"); ok && f.IsExported() {
+		if _, ok := rv.Type().FieldByName("Valid"); ok {
+			return reflect.Zero(f.Type).Interface()
+		}
+	}
+	return nil
+}
+
+// valuerSlice converts the slice of driver.Valuers (such as []sql.Null[T])
+// to a slice of a type with NULLs that can be bound:
+// []sql.NullInt64, []sql.NullFloat64, []sql.NullBool, []NullTime, []string or [][]byte.
+func valuerSlice(v interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	vals := make([]driver.Value, rv.Len())
+	var typ driver.Value
+	for i := range vals {
+		var err error
+		if vals[i], err = driver.DefaultParameterConverter.ConvertValue(rv.Index(i).Interface()); err != nil {
+			return nil, fmt.Errorf("%d.: %w", i, err)
+		}
+		if typ == nil {
+			typ = vals[i]
+		}
+	}
+	if typ == nil {
+		// all NULL: take the type from the V of sql.Null[T]
+		if z := nullValueOf(reflect.Zero(rv.Type().Elem()).Interface()); z != nil {
+			var err error
+			if typ, err = driver.DefaultParameterConverter.ConvertValue(z); err != nil {
+				typ = nil
+			}
+		}
+	}
+	for i, v := range vals {
+		if v != nil && reflect.TypeOf(v) != reflect.TypeOf(typ) {
+			return nil, fmt.Errorf("%d.: mixed types (%T and %T): %w", i, typ, v, errUnknownType)
+		}
+	}
+	switch typ.(type) {
+	case int64:
+		a := make([]sql.NullInt64, len(vals))
+		for i, v := range vals {
+			a[i].Int64, a[i].Valid = v.(int64)
+		}
+		return a, nil
+	case float64:
+		a := make([]sql.NullFloat64, len(vals))
+		for i, v := range vals {
+			a[i].Float64, a[i].Valid = v.(float64)
+		}
+		return a, nil
+	case bool:
+		a := make([]sql.NullBool, len(vals))
+		for i, v := range vals {
+			a[i].Bool, a[i].Valid = v.(bool)
+		}
+		return a, nil
+	case time.Time:
+		a := make([]NullTime, len(vals))
+		for i, v := range vals {
+			a[i].Time, a[i].Valid = v.(time.Time)
+		}
+		return a, nil
+	case []byte:
+		a := make([][]byte, len(vals))
+		for i, v := range vals {
+			a[i], _ = v.([]byte)
+		}
+		return a, nil
+	case string, nil:
+		a := make([]string, len(vals))
+		for i, v := range vals {
+			a[i], _ = v.(string)
+		}
+		return a, nil
+	}
+	return nil, fmt.Errorf("slice of %T: %w", typ, errUnknownType)
+}
+
+// maxBindBytes is the maximum size of a VARCHAR2/RAW bind (with MAX_STRING_SIZE=EXTENDED and in PL/SQL).
+const maxBindBytes = 32767
+
 type dataSetter func(ctx context.Context, dv *C.dpiVar, data []C.dpiData, vv interface{}) error
 
 func dataSetNull(ctx context.Context, dv *C.dpiVar, data []C.dpiData, vv interface{}) error {
@@ -1472,54 +2289,79 @@ func dataSetNull(ctx context.Context, dv *C.dpiVar, data []C.dpiData, vv interfa
 	return nil
 }
 func dataGetBool(ctx context.Context, v interface{}, data []C.dpiData) error {
-	if b, ok := v.(*bool); ok {
+	switch x := v.(type) {
+	case *bool:
+		*x = len(data) != 0 && data[0].isNull == 0 && dpiData_getBool(&data[0])
+	case *sql.NullBool:
 		if len(data) == 0 || data[0].isNull == 1 {
-			*b = false
-			return nil
+			*x = sql.NullBool{}
+		} else {
+			*x = sql.NullBool{Valid: true, Bool: dpiData_getBool(&data[0])}
 		}
-		//*b = C.dpiData_getBool(&data[0]) == 1
-		*b = *((*C.int)(unsafe.Pointer(&data[0].value))) == 1
-		return nil
-	}
-	slice := v.(*[]bool)
-	if cap(*slice) >= len(data) {
-		*slice = (*slice)[:len(data)]
-	} else {
-		*slice = make([]bool, len(data))
-	}
-	for i := range data {
-		if data[i].isNull == 1 {
-			(*slice)[i] = false
-			continue
+	case *[]bool:
+		if cap(*x) >= len(data) {
+			*x = (*x)[:len(data)]
+		} else {
+			*x = make([]bool, len(data))
+		}
+		for i := range data {
+			(*x)[i] = data[i].isNull == 0 && dpiData_getBool(&data[i])
 		}
-		//(*slice)[i] = C.dpiData_getBool(&data[i]) == 1
-		(*slice)[i] = *((*C.int)(unsafe.Pointer(&data[i].value))) == 1
+	case *[]sql.NullBool:
+		if cap(*x) >= len(data) {
+			*x = (*x)[:len(data)]
+		} else {
+			*x = make([]sql.NullBool, len(data))
+		}
+		for i := range data {
+			(*x)[i] = sql.NullBool{Valid: data[i].isNull == 0}
+			if (*x)[i].Valid {
+				(*x)[i].Bool = dpiData_getBool(&data[i])
+			}
+		}
+	default:
+		return fmt.Errorf("dataGetBool: %T: %w", v, errUnknownType)
 	}
 	return nil
 }
+
+// dpiData_getBool returns the boolean value of the data.
+func dpiData_getBool(data *C.dpiData) bool {
+	//return C.dpiData_getBool(data) == 1
+	return *((*C.int)(unsafe.Pointer(&data.value))) == 1
+}
+
 func dataSetBool(ctx context.Context, dv *C.dpiVar, data []C.dpiData, vv interface{}) error {
-	if vv == nil {
-		return dataSetNull(ctx, dv, data, nil)
-	}
-	b := C.int(0)
-	if v, ok := vv.(bool); ok {
-		if v {
-			b = 1
+	setBool := func(d *C.dpiData, b bool) {
+		var i C.int
+		if b {
+			i = 1
 		}
-		C.dpiData_setBool(&data[0], b)
-		return nil
+		C.dpiData_setBool(d, i)
 	}
-	if bb, ok := vv.([]bool); ok {
-		for i, v := range bb {
-			if v {
-				b = 1
+	switch x := vv.(type) {
+	case bool:
+		setBool(&data[0], x)
+	case sql.NullBool:
+		if !x.Valid {
+			data[0].isNull = 1
+		} else {
+			setBool(&data[0], x.Bool)
+		}
+	case []bool:
+		for i, b := range x {
+			setBool(&data[i], b)
+		}
+	case []sql.NullBool:
+		for i, b := range x {
+			if !b.Valid {
+				data[i].isNull = 1
+			} else {
+				setBool(&data[i], b.Bool)
 			}
-			C.dpiData_setBool(&data[i], b)
 		}
-		return nil
-	}
-	for i := range data {
-		data[i].isNull = 1
+	default:
+		return dataSetNull(ctx, dv, data, nil)
 	}
 	return nil
 }
@@ -1678,6 +2520,57 @@ func (c *conn) dataSetTime(ctx context.Context, dv *C.dpiVar, data []C.dpiData,
 	return nil
 }
 
+// bindDurationAsNumber binds the time.Duration (or []time.Duration) as Number of seconds or days.
+func (st *statement) bindDurationAsNumber(ctx context.Context, info *argInfo, get *dataGetter, value interface{}, mode DurationMode) (interface{}, error) {
+	var conv interface{}
+	switch x := value.(type) {
+	case time.Duration:
+		conv = durationToNumber(x, mode)
+	case []time.Duration:
+		ns := make([]Number, len(x), cap(x))
+		for i, d := range x {
+			ns[i] = durationToNumber(d, mode)
+		}
+		conv = ns
+	}
+	conv, err := st.bindVarTypeSwitch(ctx, info, get, conv)
+	if err != nil || !info.isOut {
+		return conv, err
+	}
+	inner := *get
+	*get = func(ctx context.Context, v interface{}, data []C.dpiData) error {
+		var err error
+		switch x := v.(type) {
+		case *time.Duration:
+			var n Number
+			if err = inner(ctx, &n, data); err != nil || n == "" {
+				*x = 0
+				return err
+			}
+			*x, err = numberToDuration(n, mode)
+		case *[]time.Duration:
+			var ns []Number
+			if err = inner(ctx, &ns, data); err != nil {
+				return err
+			}
+			*x = (*x)[:0]
+			for _, n := range ns {
+				var d time.Duration
+				if n != "" {
+					if d, err = numberToDuration(n, mode); err != nil {
+						return err
+					}
+				}
+				*x = append(*x, d)
+			}
+		default:
+			return inner(ctx, v, data)
+		}
+		return err
+	}
+	return conv, nil
+}
+
 func (c *conn) dataGetIntervalDS(ctx context.Context, v interface{}, data []C.dpiData) error {
 	logger := getLogger(ctx)
 	if logger != nil && logger.Enabled(ctx, slog.LevelDebug) {
@@ -1706,18 +2599,76 @@ func (c *conn) dataGetIntervalDS(ctx context.Context, v interface{}, data []C.dp
 }
 
 func dataGetIntervalDS(ctx context.Context, t *time.Duration, d *C.dpiData) {
-	//ds := C.dpiData_getIntervalDS(d)
-	ds := *((*C.dpiIntervalDS)(unsafe.Pointer(&d.value)))
-	*t = time.Duration(ds.days)*24*time.Hour +
-		time.Duration(ds.hours)*time.Hour +
-		time.Duration(ds.minutes)*time.Minute +
-		time.Duration(ds.seconds)*time.Second +
-		time.Duration(ds.fseconds)
+	ds := dpiData_getIntervalDS(d)
+	*t = time.Duration(ds.Days)*24*time.Hour +
+		time.Duration(ds.Hours)*time.Hour +
+		time.Duration(ds.Minutes)*time.Minute +
+		time.Duration(ds.Seconds)*time.Second +
+		time.Duration(ds.Nanoseconds)
 	if logger := getLogger(ctx); logger != nil && logger.Enabled(ctx, slog.LevelDebug) {
 		logger.Debug("dataGetIntervalDS", "d", *d, "t", *t)
 	}
 }
 
+func dpiData_getIntervalDS(d *C.dpiData) IntervalDS {
+	//ds := C.dpiData_getIntervalDS(d)
+	ds := *((*C.dpiIntervalDS)(unsafe.Pointer(&d.value)))
+	return IntervalDS{
+		Days: int(ds.days), Hours: int(ds.hours), Minutes: int(ds.minutes),
+		Seconds: int(ds.seconds), Nanoseconds: int(ds.fseconds),
+	}
+}
+
+func dataGetIntervalDSExact(ctx context.Context, v interface{}, data []C.dpiData) error {
+	switch x := v.(type) {
+	case *IntervalDS:
+		if len(data) == 0 || data[0].isNull == 1 {
+			*x = IntervalDS{}
+			return nil
+		}
+		*x = dpiData_getIntervalDS(&data[0])
+
+	case *[]IntervalDS:
+		n := len(data)
+		if cap(*x) >= n {
+			*x = (*x)[:n]
+		} else {
+			*x = make([]IntervalDS, n)
+		}
+		for i := range data {
+			if data[i].isNull == 1 {
+				(*x)[i] = IntervalDS{}
+			} else {
+				(*x)[i] = dpiData_getIntervalDS(&data[i])
+			}
+		}
+	}
+	return nil
+}
+
+func dataSetIntervalDSExact(ctx context.Context, dv *C.dpiVar, data []C.dpiData, vv interface{}) error {
+	if vv == nil {
+		return dataSetNull(ctx, dv, data, nil)
+	}
+	switch x := vv.(type) {
+	case IntervalDS:
+		data[0].isNull = 0
+		C.dpiData_setIntervalDS(&data[0], C.int32_t(x.Days), C.int32_t(x.Hours), C.int32_t(x.Minutes), C.int32_t(x.Seconds), C.int32_t(x.Nanoseconds))
+
+	case []IntervalDS:
+		for i, ds := range x {
+			data[i].isNull = 0
+			C.dpiData_setIntervalDS(&data[i], C.int32_t(ds.Days), C.int32_t(ds.Hours), C.int32_t(ds.Minutes), C.int32_t(ds.Seconds), C.int32_t(ds.Nanoseconds))
+		}
+
+	default:
+		for i := range data {
+			data[i].isNull = 1
+		}
+	}
+	return nil
+}
+
 func (c *conn) dataSetIntervalDS(ctx context.Context, dv *C.dpiVar, data []C.dpiData, vv interface{}) error {
 	if vv == nil {
 		return dataSetNull(ctx, dv, data, nil)
@@ -1769,6 +2720,62 @@ func (c *conn) dataSetIntervalDS(ctx context.Context, dv *C.dpiVar, data []C.dpi
 	return nil
 }
 
+func dataGetIntervalYM(ctx context.Context, v interface{}, data []C.dpiData) error {
+	switch x := v.(type) {
+	case *IntervalYM:
+		if len(data) == 0 || data[0].isNull == 1 {
+			*x = IntervalYM{}
+			return nil
+		}
+		*x = dpiData_getIntervalYM(&data[0])
+
+	case *[]IntervalYM:
+		n := len(data)
+		if cap(*x) >= n {
+			*x = (*x)[:n]
+		} else {
+			*x = make([]IntervalYM, n)
+		}
+		for i := range data {
+			if data[i].isNull == 1 {
+				(*x)[i] = IntervalYM{}
+			} else {
+				(*x)[i] = dpiData_getIntervalYM(&data[i])
+			}
+		}
+	}
+	return nil
+}
+
+func dpiData_getIntervalYM(d *C.dpiData) IntervalYM {
+	//ym := C.dpiData_getIntervalYM(d)
+	ym := *((*C.dpiIntervalYM)(unsafe.Pointer(&d.value)))
+	return IntervalYM{Years: int(ym.years), Months: int(ym.months)}
+}
+
+func dataSetIntervalYM(ctx context.Context, dv *C.dpiVar, data []C.dpiData, vv interface{}) error {
+	if vv == nil {
+		return dataSetNull(ctx, dv, data, nil)
+	}
+	switch x := vv.(type) {
+	case IntervalYM:
+		data[0].isNull = 0
+		C.dpiData_setIntervalYM(&data[0], C.int32_t(x.Years), C.int32_t(x.Months))
+
+	case []IntervalYM:
+		for i, ym := range x {
+			data[i].isNull = 0
+			C.dpiData_setIntervalYM(&data[i], C.int32_t(ym.Years), C.int32_t(ym.Months))
+		}
+
+	default:
+		for i := range data {
+			data[i].isNull = 1
+		}
+	}
+	return nil
+}
+
 func dataGetNumber(ctx context.Context, v interface{}, data []C.dpiData) error {
 	switch x := v.(type) {
 	case *int:
@@ -2032,7 +3039,7 @@ func dataGetNumber(ctx context.Context, v interface{}, data []C.dpiData) error {
 			}
 		}
 
-	case *Number, *[]Number, decimalCompose, *[]decimalCompose:
+	case *Number, *[]Number, DecimalComposer, *[]DecimalComposer:
 		return dataGetBytes(ctx, x, data)
 
 	default:
@@ -2205,7 +3212,7 @@ func dataSetNumber(ctx context.Context, dv *C.dpiVar, data []C.dpiData, vv inter
 			C.dpiData_setDouble(&data[i], C.double(x))
 		}
 
-	case Number, []Number, decimalDecompose, []decimalDecompose, string, []string:
+	case Number, []Number, DecimalDecomposer, []DecimalDecomposer, string, []string:
 		return dataSetBytes(ctx, dv, data, vv)
 
 	default:
@@ -2275,13 +3282,13 @@ func dataGetBytes(ctx context.Context, v interface{}, data []C.dpiData) error {
 			}
 			*x = append(*x, Number(dpiData_getBytes(&data[i])))
 		}
-	case decimalCompose:
+	case DecimalComposer:
 		if len(data) == 0 || data[0].isNull == 1 {
 			x = nil
 			return nil
 		}
 		return x.Compose(Number(dpiData_getBytes(&data[0])).Decompose(nil))
-	case *[]decimalCompose:
+	case *[]DecimalComposer:
 		*x = (*x)[:0]
 		et := reflect.TypeOf(*x).Elem()
 		var a [22]byte
@@ -2290,7 +3297,7 @@ func dataGetBytes(ctx context.Context, v interface{}, data []C.dpiData) error {
 				*x = append(*x, nil)
 				continue
 			}
-			z := reflect.Zero(et).Interface().(decimalCompose)
+			z := reflect.Zero(et).Interface().(DecimalComposer)
 			if err := z.Compose(Number(dpiData_getBytes(&data[i])).Decompose(a[:0])); err != nil {
 				return err
 			}
@@ -2313,6 +3320,22 @@ func dataGetBytes(ctx context.Context, v interface{}, data []C.dpiData) error {
 			*x = append(*x, string(dpiData_getBytes(&data[i])))
 		}
 
+	case *NString:
+		if len(data) == 0 || data[0].isNull == 1 {
+			*x = ""
+			return nil
+		}
+		*x = NString(dpiData_getBytes(&data[0]))
+	case *[]NString:
+		*x = (*x)[:0]
+		for i := range data {
+			if data[i].isNull == 1 {
+				*x = append(*x, "")
+				continue
+			}
+			*x = append(*x, NString(dpiData_getBytes(&data[i])))
+		}
+
 	case *sql.NullInt32:
 		if len(data) == 0 || data[0].isNull == 1 {
 			x.Int32, x.Valid = 0, false
@@ -2467,7 +3490,7 @@ func dataSetBytes(ctx context.Context, dv *C.dpiVar, data []C.dpiData, vv interf
 			dpiSetFromString(dv, C.uint32_t(i), string(x))
 		}
 
-	case decimalDecompose:
+	case DecimalDecomposer:
 		i, x := 0, slice
 		if x == nil {
 			data[i].isNull = 1
@@ -2479,7 +3502,7 @@ func dataSetBytes(ctx context.Context, dv *C.dpiVar, data []C.dpiData, vv interf
 		}
 		data[i].isNull = 0
 		dpiSetFromString(dv, C.uint32_t(i), string(n))
-	case []decimalDecompose:
+	case []DecimalDecomposer:
 		var n Number
 		var a [22]byte
 		for i, x := range slice {
@@ -2512,6 +3535,24 @@ func dataSetBytes(ctx context.Context, dv *C.dpiVar, data []C.dpiData, vv interf
 			dpiSetFromString(dv, C.uint32_t(i), x)
 		}
 
+	case NString:
+		i, x := 0, slice
+		if len(x) == 0 {
+			data[i].isNull = 1
+			return nil
+		}
+		data[i].isNull = 0
+		dpiSetFromString(dv, C.uint32_t(i), string(x))
+	case []NString:
+		for i, x := range slice {
+			if len(x) == 0 {
+				data[i].isNull = 1
+				continue
+			}
+			data[i].isNull = 0
+			dpiSetFromString(dv, C.uint32_t(i), string(x))
+		}
+
 	default:
 		return fmt.Errorf("awaited [][]byte/[]string/[]Number, got %T (%#v)", vv, vv)
 	}
@@ -2617,6 +3658,7 @@ func (st *statement) dataGetStmtC(ctx context.Context, row *driver.Rows, data *C
 	st2 := &statement{conn: st.conn, dpiStmt: C.dpiData_getStmt(data),
 		stmtOptions: st.stmtOptions, // inherit parent statement's options
 	}
+	resourceCounters.stmts.Add(1)
 
 	logger := getLogger(ctx)
 	var n C.uint32_t
@@ -2672,6 +3714,7 @@ func (c *conn) dataGetLOBC(ctx context.Context, L *Lob, data *C.dpiData) {
 	if lob == nil {
 		return
 	}
+	resourceCounters.lobs.Add(1) // released by the Close of the reader
 	L.Reader = &dpiLobReader{drv: c.drv, dpiLob: lob, IsClob: L.IsClob}
 }
 
@@ -2739,6 +3782,7 @@ func (c *conn) dataSetLOBOne(ctx context.Context, dv *C.dpiVar, data []C.dpiData
 	if err := c.checkExec(func() C.int { return C.dpiConn_newTempLob(c.dpiConn, typ, &lob) }); err != nil {
 		return fmt.Errorf("newTempLob(typ=%d): %w", typ, err)
 	}
+	resourceCounters.lobs.Add(1)
 	var chunkSize C.uint32_t
 	_ = C.dpiLob_getChunkSize(lob, &chunkSize)
 	if chunkSize == 0 {
@@ -3029,6 +4073,7 @@ func (c *conn) dataSetObjectStruct(ctx context.Context, ot *ObjectType, dv *C.dp
 }
 func (c *conn) dataGetObject(ctx context.Context, v interface{}, data []C.dpiData) error {
 	logger := getLogger(ctx)
+	data = nullIfNoData(data)
 	switch out := v.(type) {
 	case *ObjectCollection:
 		d := Data{
@@ -3314,6 +4359,7 @@ func (c *conn) dataGetObjectStruct(ctx context.Context, ot *ObjectType, v interf
 	if kind := rv.Type().Kind(); kind != reflect.Struct && kind != reflect.Slice {
 		return fmt.Errorf("dataGetObjectStruct: not a struct or slice: %T: %w", v, errUnknownType)
 	}
+	data = nullIfNoData(data)
 	d := Data{
 		ObjectType: ot,
 		dpiData:    data[0],
@@ -3657,6 +4703,12 @@ func (c *conn) dataSetVectorValue(ctx context.Context, dv *C.dpiVar, data []C.dp
 var (
 	// ErrNotImplemented is returned when the functionality is not implemented
 	ErrNotImplemented = errors.New("not implemented")
+	// ErrStringIndexedTable is returned when a map is bound as is, e.g. for a
+	// TABLE OF x INDEX BY VARCHAR2(n) parameter.
+	//
+	// OCI (and thus ODPI-C) can only bind integer-indexed PL/SQL tables,
+	// so wrap the map in a StringIndexedTable.
+	ErrStringIndexedTable = errors.New("string-indexed PL/SQL tables cannot be bound")
 	// ErrBadDate is returned when the date is not assignable to Oracle DATE type
 	ErrBadDate = errors.New("date out of range (year must be between -4713 and 9999, and must not be 0)")
 )
@@ -3716,10 +4768,11 @@ func (st *statement) openRows(ctx context.Context, colCount int) (*rows, error)
 	sliceLen := st.FetchArraySize()
 
 	r := rows{
-		statement: st,
-		columns:   make([]Column, colCount),
-		vars:      make([]*C.dpiVar, colCount),
-		data:      make([][]C.dpiData, colCount),
+		statement:   st,
+		columns:     make([]Column, colCount),
+		vars:        make([]*C.dpiVar, colCount),
+		data:        make([][]C.dpiData, colCount),
+		defineInfos: make([]varInfo, colCount),
 	}
 
 	var info C.dpiQueryInfo
@@ -3737,6 +4790,7 @@ func (st *statement) openRows(ctx context.Context, colCount int) (*rows, error)
 			logger.Debug("openRows", "col", i, "info", ti)
 		}
 		//if logger != nil {Log("dNTN", int(ti.defaultNativeTypeNum), "number", C.DPI_ORACLE_TYPE_NUMBER) }
+		name := C.GoStringN(info.name, C.int(info.nameLength))
 		effTypeNum := ti.oracleTypeNum
 		switch effTypeNum {
 		case C.DPI_ORACLE_TYPE_NUMBER:
@@ -3760,15 +4814,23 @@ func (st *statement) openRows(ctx context.Context, colCount int) (*rows, error)
 				ti.defaultNativeTypeNum = C.DPI_NATIVE_TYPE_BYTES
 			}
 		}
+		if st.FetchAsString(name) {
+			if n := stringFetchSize(ti); n > 0 {
+				effTypeNum, ti.defaultNativeTypeNum, bufSize = C.DPI_ORACLE_TYPE_VARCHAR, C.DPI_NATIVE_TYPE_BYTES, n
+			}
+		}
 		col := Column{
-			Name:             C.GoStringN(info.name, C.int(info.nameLength)),
+			Name:             name,
 			OracleType:       effTypeNum,
 			OrigOracleType:   ti.oracleTypeNum,
 			NativeType:       ti.defaultNativeTypeNum,
 			Size:             ti.clientSizeInBytes,
 			Precision:        ti.precision,
 			Scale:            ti.scale,
+			FsPrecision:      ti.fsPrecision,
 			Nullable:         info.nullOk == 1,
+			IsJSON:           ti.isJson != 0,
+			IsOSON:           ti.isOson != 0,
 			ObjectType:       ti.objectType,
 			SizeInChars:      ti.sizeInChars,
 			DBSize:           ti.dbSizeInBytes,
@@ -3777,19 +4839,60 @@ func (st *statement) openRows(ctx context.Context, colCount int) (*rows, error)
 			VectorFlags:      ti.vectorFlags,
 		}
 		col.DomainAnnotation.init(ti)
+		switch ti.oracleTypeNum {
+		case C.DPI_ORACLE_TYPE_VARCHAR, C.DPI_ORACLE_TYPE_CHAR:
+			var charUsed C.uint8_t
+			if err := st.checkExecNoLOT(func() C.int {
+				return C.godror_getCharUsed(st.dpiStmt, C.uint32_t(i+1), &charUsed)
+			}); err != nil {
+				return nil, fmt.Errorf("getCharUsed[%d]: %w", i, err)
+			}
+			col.CharSemantics = charUsed != 0
+		case C.DPI_ORACLE_TYPE_NVARCHAR, C.DPI_ORACLE_TYPE_NCHAR:
+			col.CharSemantics = true
+		}
 		r.columns[i] = col
 
-		var err error
 		//fmt.Printf("%d. %+v\n", i, r.columns[i])
-		vi := varInfo{
+		r.defineInfos[i] = varInfo{
 			Typ:        effTypeNum,
 			NatTyp:     ti.defaultNativeTypeNum,
 			ObjectType: ti.objectType,
 			BufSize:    bufSize,
-			SliceLen:   sliceLen,
 		}
-		if r.vars[i], r.data[i], err = st.newVar(vi); err != nil {
-			return nil, err
+	}
+
+	if limit := st.FetchMemory(); limit > 0 {
+		var rowSize int
+		for _, vi := range r.defineInfos {
+			rowSize += defineRowSize(vi)
+		}
+		if n := limit / max(1, rowSize); n < sliceLen {
+			sliceLen = max(1, n)
+			if err := st.checkExecNoLOT(func() C.int {
+				return C.dpiStmt_setFetchArraySize(st.dpiStmt, C.uint32_t(sliceLen))
+			}); err != nil {
+				return nil, fmt.Errorf("setFetchArraySize(%d): %w", sliceLen, err)
+			}
+			if logger != nil && logger.Enabled(ctx, slog.LevelDebug) {
+				logger.Debug("openRows", "fetchMemory", limit, "rowSize", rowSize, "fetchArraySize", sliceLen)
+			}
+		}
+	}
+	r.fetchArraySize = sliceLen
+
+	for i := range r.defineInfos {
+		r.defineInfos[i].SliceLen = sliceLen
+		vi := r.defineInfos[i]
+		var err error
+		var reused bool
+		if r.vars[i], r.data[i], reused = st.takeDefine(i, vi); reused {
+			st.drv.defineStats.reused.Add(1)
+		} else {
+			if r.vars[i], r.data[i], err = st.newVar(vi); err != nil {
+				return nil, err
+			}
+			st.drv.defineStats.allocated.Add(1)
 		}
 
 		if err = st.checkExecNoLOT(func() C.int {
@@ -3807,6 +4910,42 @@ func (st *statement) openRows(ctx context.Context, colCount int) (*rows, error)
 	return &r, nil
 }
 
+// defineRowSize returns the estimated memory needed by a row of the define variable.
+func defineRowSize(vi varInfo) int {
+	size := int(unsafe.Sizeof(C.dpiData{}))
+	switch vi.Typ {
+	case C.DPI_ORACLE_TYPE_LONG_VARCHAR, C.DPI_ORACLE_TYPE_LONG_NVARCHAR, C.DPI_ORACLE_TYPE_LONG_RAW:
+		// dynamically allocated in chunks
+		return size + C.DPI_DYNAMIC_BYTES_CHUNK_SIZE
+	}
+	switch vi.NatTyp {
+	case C.DPI_NATIVE_TYPE_BYTES:
+		return size + vi.BufSize
+	case C.DPI_NATIVE_TYPE_LOB, C.DPI_NATIVE_TYPE_OBJECT, C.DPI_NATIVE_TYPE_STMT, C.DPI_NATIVE_TYPE_ROWID:
+		// handle and descriptor
+		return size + 128
+	}
+	return size + 16
+}
+
+// stringFetchSize returns the buffer size needed to fetch a column of the given type
+// as VARCHAR, or 0 if it should not be fetched as string.
+func stringFetchSize(ti C.dpiDataTypeInfo) int {
+	switch ti.oracleTypeNum {
+	case C.DPI_ORACLE_TYPE_NUMBER, C.DPI_ORACLE_TYPE_NATIVE_INT, C.DPI_ORACLE_TYPE_NATIVE_UINT,
+		C.DPI_ORACLE_TYPE_NATIVE_FLOAT, C.DPI_ORACLE_TYPE_NATIVE_DOUBLE,
+		C.DPI_ORACLE_TYPE_INTERVAL_DS, C.DPI_ORACLE_TYPE_INTERVAL_YM:
+		return 64
+	case C.DPI_ORACLE_TYPE_DATE,
+		C.DPI_ORACLE_TYPE_TIMESTAMP, C.DPI_ORACLE_TYPE_TIMESTAMP_TZ, C.DPI_ORACLE_TYPE_TIMESTAMP_LTZ:
+		// NLS formats may contain month names and time zone regions
+		return 128
+	case C.DPI_ORACLE_TYPE_RAW:
+		return 2 * int(ti.dbSizeInBytes)
+	}
+	return 0
+}
+
 // Column holds the info from a column.
 type Column struct {
 	ObjectType                 *C.dpiObjectType
@@ -3816,7 +4955,11 @@ type Column struct {
 	Size, SizeInChars, DBSize  C.uint32_t
 	Precision                  C.int16_t
 	Scale                      C.int8_t
+	FsPrecision                C.uint8_t
 	Nullable                   bool
+	IsJSON, IsOSON             bool
+	// CharSemantics is true for character columns with the length in characters (CHAR length semantics).
+	CharSemantics bool
 	DomainAnnotation
 	VectorDimensions C.uint32_t
 	VectorFormat     C.uint8_t
@@ -3824,12 +4967,15 @@ type Column struct {
 }
 
 type DomainAnnotation struct {
-	DomainName  string
-	Annotations []Annotation
+	DomainSchema, DomainName string
+	Annotations              []Annotation
 }
 type Annotation struct{ Key, Value string }
 
 func (da *DomainAnnotation) init(ti C.dpiDataTypeInfo) {
+	if ti.domainSchemaLength != 0 {
+		da.DomainSchema = C.GoStringN(ti.domainSchema, C.int(ti.domainSchemaLength))
+	}
 	if ti.domainNameLength != 0 {
 		da.DomainName = C.GoStringN(ti.domainName, C.int(ti.domainNameLength))
 	}
@@ -3878,6 +5024,9 @@ func isInvalidErr(err error) bool {
 var maxStackSize uint32 = 2048
 
 func stmtSetFinalizer(ctx context.Context, st *statement, tag string) {
+	if st.conn != nil {
+		st.conn.cursors.opened(ctx, st.conn, st, tag)
+	}
 	if !guardWithFinalizers.Load() {
 		return
 	}
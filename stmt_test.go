@@ -0,0 +1,104 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestContextWithOptions(t *testing.T) {
+	ctx := ContextWithOptions(context.Background(), PrefetchCount(10), FetchArraySize(100))
+	ctx = ContextWithOptions(ctx, PrefetchCount(1), PrefetchMemory(1<<20))
+	var o stmtOptions
+	o.applyContextOptions(ctx)
+	if got := o.PrefetchCount(); got != 1 {
+		t.Errorf("PrefetchCount: got %d, wanted 1", got)
+	}
+	if got := o.FetchArraySize(); got != 100 {
+		t.Errorf("FetchArraySize: got %d, wanted 100", got)
+	}
+	if o.prefetchMemory != 1<<20 {
+		t.Errorf("PrefetchMemory: got %d, wanted %d", o.prefetchMemory, 1<<20)
+	}
+
+	o = stmtOptions{}
+	o.applyContextOptions(context.Background())
+	if o.PrefetchCount() != DefaultPrefetchCount || o.FetchArraySize() != DefaultFetchArraySize {
+		t.Errorf("no options, but got %+v", o)
+	}
+}
+
+func TestStreamRows(t *testing.T) {
+	var o stmtOptions
+	StreamRows(10)(&o)
+	if o.PrefetchCount() != 0 || o.FetchArraySize() != 10 {
+		t.Errorf("got prefetch=%d fetch=%d, wanted 0 and 10", o.PrefetchCount(), o.FetchArraySize())
+	}
+	StreamRows(0)(&o)
+	if o.PrefetchCount() != 0 || o.FetchArraySize() != DefaultFetchArraySize {
+		t.Errorf("got prefetch=%d fetch=%d, wanted 0 and %d", o.PrefetchCount(), o.FetchArraySize(), DefaultFetchArraySize)
+	}
+}
+
+func TestValuerSlice(t *testing.T) {
+	now := time.Now()
+	for i, tc := range []struct {
+		In, Want interface{}
+	}{
+		{In: []sql.Null[int32]{{V: 1, Valid: true}, {}},
+			Want: []sql.NullInt64{{Int64: 1, Valid: true}, {}}},
+		{In: []sql.Null[float64]{{}, {V: 0.5, Valid: true}},
+			Want: []sql.NullFloat64{{}, {Float64: 0.5, Valid: true}}},
+		{In: []sql.Null[string]{{V: "a", Valid: true}, {}},
+			Want: []string{"a", ""}},
+		{In: []sql.Null[time.Time]{{V: now, Valid: true}, {}},
+			Want: []NullTime{{Time: now, Valid: true}, {}}},
+		{In: []sql.Null[int64]{{}, {}},
+			Want: []sql.NullInt64{{}, {}}},
+		{In: []sql.NullString{{String: "b", Valid: true}},
+			Want: []string{"b"}},
+	} {
+		got, err := valuerSlice(tc.In)
+		if err != nil {
+			t.Errorf("%d. %T: %+v", i, tc.In, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.Want) {
+			t.Errorf("%d. got %#v, wanted %#v", i, got, tc.Want)
+		}
+	}
+
+	if _, err := valuerSlice([]driver.Valuer{sql.NullInt64{Int64: 1, Valid: true}, sql.NullString{String: "a", Valid: true}}); err == nil {
+		t.Error("mixed types: wanted error")
+	}
+
+	if got := nullValueOf(sql.Null[int32]{}); got != int32(0) {
+		t.Errorf("nullValueOf: got %#v, wanted int32(0)", got)
+	}
+	if got := nullValueOf(sql.NullString{}); got != nil {
+		t.Errorf("nullValueOf: got %#v, wanted nil", got)
+	}
+}
+
+func TestMayHaveBindName(t *testing.T) {
+	for qry, want := range map[string]bool{
+		"SELECT :1 FROM DUAL":          false,
+		"SELECT :id FROM DUAL":         true,
+		"BEGIN :1 := 2; END;":          false,
+		"BEGIN x := :Val; END;":        true,
+		"SELECT 1 FROM DUAL":           false,
+		"INSERT INTO t VALUES (:1,:b)": true,
+	} {
+		if got := mayHaveBindName(qry); got != want {
+			t.Errorf("%q: got %t, wanted %t", qry, got, want)
+		}
+	}
+}
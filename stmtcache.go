@@ -0,0 +1,195 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include "dpiImpl.h"
+*/
+import "C"
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// StmtCacheStats is the statement cache statistics of a connection.
+//
+// OCI does not report statement cache hits and misses,
+// so these are estimated by following the LRU order of the statement texts
+// prepared on the connection, since the session has been acquired.
+type StmtCacheStats struct {
+	// Hits is the number of prepares which found the statement in the cache.
+	Hits uint64
+	// Misses is the number of prepares which had to parse the statement.
+	Misses uint64
+	// Size is the statement cache size, Len is the number of cached statements.
+	Size, Len int
+}
+
+// stmtCacheMirror follows the LRU statement cache of the session.
+type stmtCacheMirror struct {
+	elems        map[string]*list.Element
+	lru          list.List
+	hits, misses uint64
+	size         int
+	sizeKnown    bool
+	mu           sync.Mutex
+}
+
+// prepared registers the preparation of query, and returns whether it was a cache hit.
+func (m *stmtCacheMirror) prepared(query string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.elems[query]; ok {
+		m.lru.MoveToFront(e)
+		m.hits++
+		return true
+	}
+	m.misses++
+	if m.size <= 0 {
+		return false
+	}
+	if m.elems == nil {
+		m.elems = make(map[string]*list.Element, m.size)
+	}
+	m.elems[query] = m.lru.PushFront(query)
+	m.evictNotLocked()
+	return false
+}
+
+// remove the query from the cache, as DeleteFromCache does.
+func (m *stmtCacheMirror) remove(query string) {
+	m.mu.Lock()
+	if e, ok := m.elems[query]; ok {
+		m.lru.Remove(e)
+		delete(m.elems, query)
+	}
+	m.mu.Unlock()
+}
+
+func (m *stmtCacheMirror) setSize(size int) {
+	m.mu.Lock()
+	m.size, m.sizeKnown = size, true
+	m.evictNotLocked()
+	m.mu.Unlock()
+}
+
+func (m *stmtCacheMirror) evictNotLocked() {
+	for m.lru.Len() > m.size && m.lru.Len() != 0 {
+		delete(m.elems, m.lru.Remove(m.lru.Back()).(string))
+	}
+}
+
+// reset forgets everything, for a new session.
+func (m *stmtCacheMirror) reset() {
+	m.mu.Lock()
+	m.elems, m.hits, m.misses, m.size, m.sizeKnown = nil, 0, 0, 0, false
+	m.lru.Init()
+	m.mu.Unlock()
+}
+
+func (m *stmtCacheMirror) stats() StmtCacheStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return StmtCacheStats{Hits: m.hits, Misses: m.misses, Size: m.size, Len: m.lru.Len()}
+}
+
+// stmtCachePrepared registers the preparation of query in the statement cache mirror.
+func (c *conn) stmtCachePrepared(query string) {
+	c.stmtCache.mu.Lock()
+	known := c.stmtCache.sizeKnown
+	c.stmtCache.mu.Unlock()
+	if !known {
+		if size, err := c.stmtCacheSizeNotLocked(); err == nil {
+			c.stmtCache.setSize(size)
+		}
+	}
+	hit := c.stmtCache.prepared(query)
+	if c.drv != nil && c.drv.metrics.enabled.Load() {
+		if hit {
+			c.drv.metrics.cacheHits.Add(1)
+		} else {
+			c.drv.metrics.cacheMiss.Add(1)
+		}
+	}
+}
+
+// StmtCacheConn is the optional interface of a Conn for managing its statement cache.
+type StmtCacheConn interface {
+	StmtCacheSize() (int, error)
+	SetStmtCacheSize(int) error
+	PurgeStmtCache() error
+	StmtCacheStats() StmtCacheStats
+}
+
+var _ StmtCacheConn = (*conn)(nil)
+
+// StmtCacheSize returns the statement cache size of the connection.
+func (c *conn) StmtCacheSize() (int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.stmtCacheSizeNotLocked()
+}
+
+func (c *conn) stmtCacheSizeNotLocked() (int, error) {
+	var size C.uint32_t
+	if err := c.checkExec(func() C.int { return C.dpiConn_getStmtCacheSize(c.dpiConn, &size) }); err != nil {
+		return 0, err
+	}
+	return int(size), nil
+}
+
+// SetStmtCacheSize sets the statement cache size of the connection.
+//
+// A zero size disables the statement cache.
+func (c *conn) SetStmtCacheSize(size int) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.setStmtCacheSizeNotLocked(size)
+}
+
+func (c *conn) setStmtCacheSizeNotLocked(size int) error {
+	if size < 0 {
+		size = 0
+	}
+	if err := c.checkExec(func() C.int { return C.dpiConn_setStmtCacheSize(c.dpiConn, C.uint32_t(size)) }); err != nil {
+		return err
+	}
+	c.stmtCache.setSize(size)
+	return nil
+}
+
+// PurgeStmtCache removes all the statements from the statement cache of the connection.
+func (c *conn) PurgeStmtCache() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	size, err := c.stmtCacheSizeNotLocked()
+	if err != nil || size == 0 {
+		return err
+	}
+	if err = c.setStmtCacheSizeNotLocked(0); err != nil {
+		return err
+	}
+	return c.setStmtCacheSizeNotLocked(size)
+}
+
+// StmtCacheStats returns the (estimated) statement cache statistics of the connection.
+func (c *conn) StmtCacheStats() StmtCacheStats { return c.stmtCache.stats() }
+
+// PurgeStatementCache removes all the statements from the statement cache of the connection of ex.
+//
+// For an *sql.DB, this is just one connection of the pool!
+func PurgeStatementCache(ctx context.Context, ex Execer) error {
+	return Raw(ctx, ex, func(c Conn) error {
+		sc, ok := c.(StmtCacheConn)
+		if !ok {
+			return fmt.Errorf("%T has no statement cache: %w", c, ErrNotSupported)
+		}
+		return sc.PurgeStmtCache()
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import "testing"
+
+func TestStmtCacheMirror(t *testing.T) {
+	var m stmtCacheMirror
+	m.setSize(2)
+	for _, q := range []string{"a", "b", "a", "c", "b", "a"} {
+		m.prepared(q)
+	}
+	// a miss, b miss, a hit, c miss (evicts b), b miss (evicts a), a miss (evicts c)
+	if got, want := m.stats(), (StmtCacheStats{Hits: 1, Misses: 5, Size: 2, Len: 2}); got != want {
+		t.Errorf("got %+v, wanted %+v", got, want)
+	}
+
+	m.remove("a")
+	if m.prepared("a") {
+		t.Error("removed a, but got a hit")
+	}
+	if !m.prepared("b") {
+		t.Error("wanted a hit for b")
+	}
+
+	m.setSize(0)
+	if m.prepared("b") {
+		t.Error("cache disabled, but got a hit")
+	}
+	if got := m.stats(); got.Len != 0 {
+		t.Errorf("cache disabled, but has %d elements", got.Len)
+	}
+
+	m.reset()
+	if got := m.stats(); got != (StmtCacheStats{}) {
+		t.Errorf("reset, but got %+v", got)
+	}
+}
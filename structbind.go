@@ -0,0 +1,302 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include "dpiImpl.h"
+*/
+import "C"
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BindStructTag is the struct tag naming the bind variable of a struct field,
+// when a struct is given as the sole argument of Exec or Query:
+//
+//	type params struct {
+//		ID   int    `db:"id"`
+//		Name string `db:"name"`
+//		Skip bool   `db:"-"`
+//	}
+//	db.ExecContext(ctx, "UPDATE tbl SET name = :name WHERE id = :id", params{ID: 1, Name: "A"})
+//
+// A slice of such structs is expanded into array binds, one slice per field, for array DML.
+//
+// Untagged exported fields are bound by their name, fields of embedded structs are included.
+// Only the fields named as bind variables in the statement are bound.
+// Bind names are case-insensitive.
+const BindStructTag = "db"
+
+// bindField is a struct field to be bound.
+type bindField struct {
+	name  string
+	index []int
+	inOut bool
+}
+
+// structBindFields returns the fields of the struct type to be bound,
+// and whether it has any field tagged with BindStructTag.
+func structBindFields(rt reflect.Type) ([]bindField, bool) {
+	var fields []bindField
+	var tagged bool
+	var walk func(rt reflect.Type, index []int)
+	walk = func(rt reflect.Type, index []int) {
+		for i := 0; i < rt.NumField(); i++ {
+			f := rt.Field(i)
+			tag, hasTag := f.Tag.Lookup(BindStructTag)
+			opts := strings.Split(tag, ",")
+			if tag = strings.TrimSpace(opts[0]); tag == "-" {
+				tagged = true
+				continue
+			}
+			idx := append(index[:len(index):len(index)], i)
+			if f.Anonymous && !hasTag {
+				ft := f.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft, idx)
+					continue
+				}
+			}
+			if !f.IsExported() {
+				continue
+			}
+			tagged = tagged || hasTag
+			if tag == "" {
+				tag = f.Name
+			}
+			bf := bindField{name: tag, index: idx}
+			for _, o := range opts[1:] {
+				bf.inOut = bf.inOut || strings.TrimSpace(o) == "inout"
+			}
+			fields = append(fields, bf)
+		}
+	}
+	walk(rt, nil)
+	return fields, tagged
+}
+
+// namedArgsFromStruct expands the struct (or pointer to struct) having BindStructTag tagged fields
+// into named args, restricted to the bind names of the statement.
+func namedArgsFromStruct(value interface{}, getBindNames func() ([]string, error)) ([]driver.NamedValue, bool, error) {
+	switch value.(type) {
+	case nil, driver.Valuer, ObjectWriter:
+		return nil, false, nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, false, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Slice {
+		return namedArgsFromStructSlice(rv, getBindNames)
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, false, nil
+	}
+	fields, tagged := structBindFields(rv.Type())
+	if !tagged {
+		return nil, false, nil
+	}
+	bindNames, err := getBindNames()
+	if err != nil {
+		return nil, false, err
+	}
+	args := make([]driver.NamedValue, 0, len(bindNames))
+	for _, bn := range bindNames {
+		for _, f := range fields {
+			if !strings.EqualFold(f.name, bn) {
+				continue
+			}
+			var v interface{}
+			if fv, ok := fieldByIndex(rv, f.index); ok {
+				if fv.Kind() == reflect.Ptr {
+					if _, isValuer := fv.Interface().(driver.Valuer); !isValuer {
+						if fv.IsNil() {
+							fv = reflect.Value{}
+						} else {
+							fv = fv.Elem()
+						}
+					}
+				}
+				if fv.IsValid() {
+					v = fv.Interface()
+				}
+			}
+			args = append(args, driver.NamedValue{Name: bn, Ordinal: len(args) + 1, Value: v})
+			break
+		}
+	}
+	return args, true, nil
+}
+
+// namedArgsFromStructSlice expands the slice of structs having BindStructTag tagged fields
+// into named array args (one slice per field), for array DML.
+func namedArgsFromStructSlice(rv reflect.Value, getBindNames func() ([]string, error)) ([]driver.NamedValue, bool, error) {
+	et := rv.Type().Elem()
+	isPtr := et.Kind() == reflect.Ptr
+	if isPtr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct || et.Implements(valuerType) || reflect.PointerTo(et).Implements(valuerType) {
+		return nil, false, nil
+	}
+	fields, tagged := structBindFields(et)
+	if !tagged {
+		return nil, false, nil
+	}
+	bindNames, err := getBindNames()
+	if err != nil {
+		return nil, false, err
+	}
+	args := make([]driver.NamedValue, 0, len(bindNames))
+	for _, bn := range bindNames {
+		for _, f := range fields {
+			if !strings.EqualFold(f.name, bn) {
+				continue
+			}
+			ft := et.FieldByIndex(f.index).Type
+			col := reflect.MakeSlice(reflect.SliceOf(ft), rv.Len(), rv.Len())
+			for i := 0; i < rv.Len(); i++ {
+				ev := rv.Index(i)
+				if isPtr {
+					if ev.IsNil() {
+						return nil, false, fmt.Errorf("%d. element is nil", i)
+					}
+					ev = ev.Elem()
+				}
+				if fv, ok := fieldByIndex(ev, f.index); ok {
+					col.Index(i).Set(fv)
+				}
+			}
+			args = append(args, driver.NamedValue{Name: bn, Ordinal: len(args) + 1, Value: col.Interface()})
+			break
+		}
+	}
+	return args, true, nil
+}
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// OutStructArg is the argument returned by OutStruct.
+type OutStructArg struct {
+	Dest interface{}
+}
+
+// OutStruct returns an argument which binds the fields of the struct pointed to by dest
+// as OUT parameters (as with sql.Out), named by BindStructTag tags (or the field names).
+//
+// A field tagged with the "inout" option (`db:"name,inout"`) is an IN OUT parameter.
+// Only the fields named as bind variables in the statement and not given as other
+// arguments are bound, so this can be mixed with sql.Named arguments:
+//
+//	var res struct {
+//		Status int         `db:"status"`
+//		Item   ItemObject  `db:"item"` // with a godror.ObjectTypeName field
+//		Rows   driver.Rows `db:"rows"`
+//	}
+//	db.ExecContext(ctx, "BEGIN pkg.get(:id, :status, :item, :rows); END;",
+//		sql.Named("id", 1), godror.OutStruct(&res))
+func OutStruct(dest interface{}) OutStructArg { return OutStructArg{Dest: dest} }
+
+// expandOutStructs replaces the OutStructArg arguments with their fields as sql.Out arguments.
+func expandOutStructs(args []driver.NamedValue, getBindNames func() ([]string, error)) ([]driver.NamedValue, error) {
+	var has bool
+	for _, a := range args {
+		if _, has = a.Value.(OutStructArg); has {
+			break
+		}
+	}
+	if !has {
+		return args, nil
+	}
+	bindNames, err := getBindNames()
+	if err != nil {
+		return nil, err
+	}
+	given := make(map[string]struct{}, len(args))
+	for _, a := range args {
+		if _, ok := a.Value.(OutStructArg); !ok && a.Name != "" {
+			given[strings.ToUpper(a.Name)] = struct{}{}
+		}
+	}
+	expanded := make([]driver.NamedValue, 0, len(bindNames))
+	for _, a := range args {
+		os, ok := a.Value.(OutStructArg)
+		if !ok {
+			expanded = append(expanded, a)
+			continue
+		}
+		rv := reflect.ValueOf(os.Dest)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+			return nil, fmt.Errorf("OutStruct needs a non-nil pointer to a struct, got %T", os.Dest)
+		}
+		rv = rv.Elem()
+		fields, _ := structBindFields(rv.Type())
+		for _, bn := range bindNames {
+			if _, ok := given[strings.ToUpper(bn)]; ok {
+				continue
+			}
+			for _, f := range fields {
+				if !strings.EqualFold(f.name, bn) {
+					continue
+				}
+				if fv, ok := fieldByIndex(rv, f.index); ok {
+					expanded = append(expanded, driver.NamedValue{
+						Name: bn, Value: sql.Out{Dest: fv.Addr().Interface(), In: f.inOut},
+					})
+					given[strings.ToUpper(bn)] = struct{}{}
+				}
+				break
+			}
+		}
+	}
+	for i := range expanded {
+		expanded[i].Ordinal = i + 1
+	}
+	return expanded, nil
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex, returning false for nil embedded pointers.
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return rv, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// bindNames returns the (unique) bind variable names of the statement.
+func (st *statement) bindNames() ([]string, error) {
+	var cnt C.uint32_t
+	if err := st.checkExec(func() C.int { return C.dpiStmt_getBindCount(st.dpiStmt, &cnt) }); err != nil || cnt == 0 {
+		return nil, err
+	}
+	names := make([]*C.char, int(cnt))
+	lengths := make([]C.uint32_t, int(cnt))
+	if err := st.checkExec(func() C.int { return C.dpiStmt_getBindNames(st.dpiStmt, &cnt, &names[0], &lengths[0]) }); err != nil {
+		return nil, err
+	}
+	bindNames := make([]string, int(cnt))
+	for i := range bindNames {
+		bindNames[i] = C.GoStringN(names[i], C.int(lengths[i]))
+	}
+	return bindNames, nil
+}
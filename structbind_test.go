@@ -0,0 +1,111 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNamedArgsFromStruct(t *testing.T) {
+	type Audit struct {
+		Modified time.Time `db:"modified"`
+	}
+	type params struct {
+		Audit
+		ID       int     `db:"id"`
+		Name     *string `db:"name"`
+		Comment  *string `db:"comment"`
+		Skip     bool    `db:"-"`
+		Untagged string
+		private  int
+	}
+	name := "A"
+	now := time.Now()
+	bindNames := func() ([]string, error) { return []string{"ID", "NAME", "COMMENT", "MODIFIED", "UNTAGGED"}, nil }
+	args, ok, err := namedArgsFromStruct(&params{Audit: Audit{Modified: now}, ID: 1, Name: &name, Untagged: "u", private: 2}, bindNames)
+	if err != nil || !ok {
+		t.Fatalf("ok=%t err=%+v", ok, err)
+	}
+	want := []driver.NamedValue{
+		{Name: "ID", Ordinal: 1, Value: 1},
+		{Name: "NAME", Ordinal: 2, Value: "A"},
+		{Name: "COMMENT", Ordinal: 3, Value: nil},
+		{Name: "MODIFIED", Ordinal: 4, Value: now},
+		{Name: "UNTAGGED", Ordinal: 5, Value: "u"},
+	}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("got %+v, wanted %+v", args, want)
+	}
+
+	for _, v := range []interface{}{nil, 1, "a", now, struct{ A int }{1}, (*params)(nil)} {
+		if _, ok, _ := namedArgsFromStruct(v, bindNames); ok {
+			t.Errorf("%T: wanted no expansion", v)
+		}
+	}
+}
+
+func TestNamedArgsFromStructSlice(t *testing.T) {
+	type row struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+		Note string `db:"note"`
+	}
+	bindNames := func() ([]string, error) { return []string{"ID", "NAME"}, nil }
+	for _, v := range []interface{}{
+		[]row{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}},
+		[]*row{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}},
+	} {
+		args, ok, err := namedArgsFromStruct(v, bindNames)
+		if err != nil || !ok {
+			t.Fatalf("%T: ok=%t err=%+v", v, ok, err)
+		}
+		want := []driver.NamedValue{
+			{Name: "ID", Ordinal: 1, Value: []int{1, 2}},
+			{Name: "NAME", Ordinal: 2, Value: []string{"a", "b"}},
+		}
+		if !reflect.DeepEqual(args, want) {
+			t.Errorf("%T: got %+v, wanted %+v", v, args, want)
+		}
+	}
+	for _, v := range []interface{}{[]int{1}, []struct{ A int }{{1}}, []time.Time{time.Now()}} {
+		if _, ok, _ := namedArgsFromStruct(v, bindNames); ok {
+			t.Errorf("%T: wanted no expansion", v)
+		}
+	}
+}
+
+func TestExpandOutStructs(t *testing.T) {
+	var res struct {
+		ID     int    `db:"id"`
+		Status int    `db:"status"`
+		Msg    string `db:"msg,inout"`
+		Other  string `db:"other"`
+	}
+	bindNames := func() ([]string, error) { return []string{"ID", "STATUS", "MSG"}, nil }
+	args, err := expandOutStructs([]driver.NamedValue{
+		{Name: "id", Ordinal: 1, Value: 3},
+		{Ordinal: 2, Value: OutStruct(&res)},
+	}, bindNames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []driver.NamedValue{
+		{Name: "id", Ordinal: 1, Value: 3},
+		{Name: "STATUS", Ordinal: 2, Value: sql.Out{Dest: &res.Status}},
+		{Name: "MSG", Ordinal: 3, Value: sql.Out{Dest: &res.Msg, In: true}},
+	}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("got %+v, wanted %+v", args, want)
+	}
+
+	if _, err = expandOutStructs([]driver.NamedValue{{Value: OutStruct(res)}}, bindNames); err == nil {
+		t.Error("wanted error for non-pointer")
+	}
+}
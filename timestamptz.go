@@ -0,0 +1,132 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TimestampTZ is a TIMESTAMP WITH TIME ZONE with its time zone region name (such as Europe/Vienna),
+// which a time.Time cannot carry faithfully: the database keeps the region, so DST shifts
+// in date arithmetic follow the region, not the offset.
+//
+// TIMESTAMP WITH TIME ZONE columns are fetched as TimestampTZ with the FetchTimestampTZ option.
+//
+// A TimestampTZ with an empty Region is bound as its Time.
+// With a Region, it is bound as text in TimestampTZFormat, to be converted in the statement
+// with TO_TIMESTAMP_TZ(:1, 'YYYY-MM-DD HH24:MI:SS.FF9 TZR'), as the bound timestamps carry only the offset.
+type TimestampTZ struct {
+	// Time is in the Region's location if it is known to the time package, in a fixed offset zone otherwise.
+	Time time.Time
+	// Region is the time zone region name, empty if the time zone is an offset only.
+	Region string
+}
+
+// TimestampTZFormat is the time.Format layout of the text a TimestampTZ with a Region is bound as
+// (without the region) - it is 'YYYY-MM-DD HH24:MI:SS.FF9 TZR' in Oracle's notation.
+const TimestampTZFormat = "2006-01-02 15:04:05.000000000"
+
+// FetchTimestampTZ returns an option to fetch the TIMESTAMP WITH TIME ZONE columns as TimestampTZ,
+// keeping their time zone region names.
+//
+// Use it "naked", without sql.Named!
+func FetchTimestampTZ() Option { return func(o *stmtOptions) { o.fetchTimestampTZ = true } }
+
+// String returns the time with the region name (or the offset).
+func (t TimestampTZ) String() string {
+	if t.Region == "" {
+		return t.Time.Format(TimestampTZFormat + " -07:00")
+	}
+	tm := t.Time
+	if loc, err := loadRegion(t.Region); err == nil {
+		tm = tm.In(loc)
+	}
+	return tm.Format(TimestampTZFormat) + " " + t.Region
+}
+
+// Value returns the Time for an empty Region, the TimestampTZFormat text with the Region otherwise.
+// The zero TimestampTZ is NULL.
+func (t TimestampTZ) Value() (driver.Value, error) {
+	if t.Time.IsZero() {
+		return nil, nil
+	}
+	if t.Region == "" {
+		return t.Time, nil
+	}
+	return t.String(), nil
+}
+
+// Scan a TimestampTZ or a time.Time into the TimestampTZ. NULL is scanned as the zero TimestampTZ.
+//
+// The Region of a time.Time is the name of its Location, unless that is a fixed offset zone.
+func (t *TimestampTZ) Scan(src interface{}) error {
+	switch x := src.(type) {
+	case nil:
+		*t = TimestampTZ{}
+	case TimestampTZ:
+		*t = x
+	case time.Time:
+		*t = TimestampTZ{Time: x}
+		if name := x.Location().String(); name != "Local" && !isOffsetZoneName(name) {
+			if _, err := loadRegion(name); err == nil {
+				t.Region = name
+			}
+		}
+	default:
+		return fmt.Errorf("cannot scan %T into TimestampTZ", src)
+	}
+	return nil
+}
+
+// isOffsetZoneName reports whether the time zone name is an offset, such as +02:00, and not a region.
+func isOffsetZoneName(name string) bool {
+	return len(name) > 0 && (name[0] == '+' || name[0] == '-')
+}
+
+var regionLocations sync.Map // region name -> *time.Location or error
+
+// loadRegion returns the (cached) location of the time zone region name.
+func loadRegion(name string) (*time.Location, error) {
+	if v, ok := regionLocations.Load(name); ok {
+		if err, isErr := v.(error); isErr {
+			return nil, err
+		}
+		return v.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		regionLocations.Store(name, err)
+		return nil, err
+	}
+	v, _ := regionLocations.LoadOrStore(name, loc)
+	return v.(*time.Location), nil
+}
+
+// newTimestampTZ returns the TimestampTZ of the time in the offset zone and the database's time zone name.
+// The time is moved to the region's location only if that agrees with the offset.
+func newTimestampTZ(t time.Time, zoneName string) TimestampTZ {
+	if zoneName == "" || isOffsetZoneName(zoneName) {
+		return TimestampTZ{Time: t}
+	}
+	tz := TimestampTZ{Time: t, Region: zoneName}
+	if loc, err := loadRegion(zoneName); err == nil {
+		lt := t.In(loc)
+		_, off := t.Zone()
+		if _, locOff := lt.Zone(); locOff == off {
+			tz.Time = lt
+		}
+	}
+	return tz
+}
+
+var (
+	_ sql.Scanner   = (*TimestampTZ)(nil)
+	_ driver.Valuer = TimestampTZ{}
+)
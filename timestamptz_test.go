@@ -0,0 +1,68 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimestampTZ(t *testing.T) {
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	if err != nil {
+		t.Skip(err)
+	}
+	// the offset zone as fetched
+	fixed := time.Date(2025, 7, 1, 12, 30, 0, 5, time.FixedZone("+02:00", 2*3600))
+
+	tz := newTimestampTZ(fixed, "Europe/Vienna")
+	if tz.Region != "Europe/Vienna" || tz.Time.Location().String() != "Europe/Vienna" || !tz.Time.Equal(fixed) {
+		t.Errorf("got %#v", tz)
+	}
+	if got, want := tz.String(), "2025-07-01 12:30:00.000000005 Europe/Vienna"; got != want {
+		t.Errorf("String: got %q, wanted %q", got, want)
+	}
+	if v, err := tz.Value(); err != nil || v != tz.String() {
+		t.Errorf("Value: got %#v, %+v", v, err)
+	}
+
+	if tz = newTimestampTZ(fixed, "+02:00"); tz.Region != "" || tz.Time != fixed {
+		t.Errorf("offset: got %#v", tz)
+	}
+	if v, err := tz.Value(); err != nil || v != fixed {
+		t.Errorf("offset Value: got %#v, %+v", v, err)
+	}
+	if got, want := tz.String(), "2025-07-01 12:30:00.000000005 +02:00"; got != want {
+		t.Errorf("offset String: got %q, wanted %q", got, want)
+	}
+	// the offset disagrees with the region: keep the time as fetched
+	if tz = newTimestampTZ(fixed, "Asia/Tokyo"); tz.Region != "Asia/Tokyo" || tz.Time != fixed {
+		t.Errorf("Tokyo: got %#v", tz)
+	}
+	if tz = newTimestampTZ(fixed, "Nowhere/Land"); tz.Region != "Nowhere/Land" || tz.Time != fixed {
+		t.Errorf("unknown: got %#v", tz)
+	}
+
+	if err = tz.Scan(fixed.In(vienna)); err != nil {
+		t.Fatal(err)
+	} else if tz.Region != "Europe/Vienna" {
+		t.Errorf("Scan: got %#v", tz)
+	}
+	if err = tz.Scan(fixed); err != nil {
+		t.Fatal(err)
+	} else if tz.Region != "" {
+		t.Errorf("Scan offset: got %#v", tz)
+	}
+	if err = tz.Scan(nil); err != nil || tz != (TimestampTZ{}) {
+		t.Errorf("Scan nil: got %#v, %+v", tz, err)
+	}
+	if v, err := tz.Value(); err != nil || v != nil {
+		t.Errorf("zero Value: got %#v, %+v", v, err)
+	}
+	if err = tz.Scan(1); err == nil {
+		t.Error("Scan int: wanted error")
+	}
+}
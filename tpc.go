@@ -0,0 +1,348 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include <stdlib.h>
+#include "dpiImpl.h"
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Xid is the identifier of a global (distributed, two-phase commit) transaction, as in the XA standard.
+type Xid struct {
+	// GlobalTransactionID is the global transaction identifier, at most 64 bytes.
+	GlobalTransactionID []byte
+	// BranchQualifier is the branch qualifier, at most 64 bytes.
+	BranchQualifier []byte
+	// FormatID identifies the format of the Xid, chosen by the transaction manager.
+	FormatID int64
+}
+
+// MaxXidPartLen is the maximum length of the GlobalTransactionID and the BranchQualifier of an Xid.
+const MaxXidPartLen = 64
+
+func (x Xid) String() string {
+	return fmt.Sprintf("%d:%x:%x", x.FormatID, x.GlobalTransactionID, x.BranchQualifier)
+}
+
+// IsZero reports whether the Xid is empty, meaning the current transaction of the connection.
+func (x Xid) IsZero() bool { return len(x.GlobalTransactionID) == 0 && len(x.BranchQualifier) == 0 }
+
+// TPCBeginFlags are the flags of TPCBegin.
+type TPCBeginFlags uint32
+
+const (
+	// TPCBeginNew starts a new transaction.
+	TPCBeginNew = TPCBeginFlags(C.DPI_TPC_BEGIN_NEW)
+	// TPCBeginJoin joins an existing transaction (as a tightly coupled branch).
+	TPCBeginJoin = TPCBeginFlags(C.DPI_TPC_BEGIN_JOIN)
+	// TPCBeginResume resumes a transaction suspended by TPCEnd with TPCEndSuspend.
+	TPCBeginResume = TPCBeginFlags(C.DPI_TPC_BEGIN_RESUME)
+	// TPCBeginPromote promotes the local transaction to a global one.
+	TPCBeginPromote = TPCBeginFlags(C.DPI_TPC_BEGIN_PROMOTE)
+)
+
+// TPCEndFlags are the flags of TPCEnd.
+type TPCEndFlags uint32
+
+const (
+	// TPCEndNormal ends (detaches from) the transaction.
+	TPCEndNormal = TPCEndFlags(C.DPI_TPC_END_NORMAL)
+	// TPCEndSuspend suspends the transaction, to be resumed later with TPCBeginResume.
+	TPCEndSuspend = TPCEndFlags(C.DPI_TPC_END_SUSPEND)
+)
+
+// withDpiXid calls f with the C representation of the Xid - NULL for the zero Xid.
+func withDpiXid(xid Xid, f func(*C.dpiXid) C.int) func() C.int {
+	return func() C.int {
+		if xid.IsZero() {
+			return f(nil)
+		}
+		var dx C.dpiXid
+		dx.formatId = C.long(xid.FormatID)
+		if n := len(xid.GlobalTransactionID); n != 0 {
+			gtrid := C.CBytes(xid.GlobalTransactionID)
+			defer C.free(gtrid)
+			dx.globalTransactionId, dx.globalTransactionIdLength = (*C.char)(gtrid), C.uint32_t(n)
+		}
+		if n := len(xid.BranchQualifier); n != 0 {
+			bqual := C.CBytes(xid.BranchQualifier)
+			defer C.free(bqual)
+			dx.branchQualifier, dx.branchQualifierLength = (*C.char)(bqual), C.uint32_t(n)
+		}
+		return f(&dx)
+	}
+}
+
+func (x Xid) check() error {
+	if x.IsZero() {
+		return errors.New("empty Xid")
+	}
+	if len(x.GlobalTransactionID) > MaxXidPartLen || len(x.BranchQualifier) > MaxXidPartLen {
+		return fmt.Errorf("Xid %s: parts must be at most %d bytes", x, MaxXidPartLen)
+	}
+	return nil
+}
+
+// TPCConn is the optional interface of a Conn for two-phase commit.
+type TPCConn interface {
+	TPCBegin(Xid, time.Duration, TPCBeginFlags) error
+	TPCEnd(Xid, TPCEndFlags) error
+	TPCPrepare(Xid) (bool, error)
+	TPCCommit(Xid, bool) error
+	TPCRollback(Xid) error
+	TPCForget(Xid) error
+}
+
+var _ TPCConn = (*conn)(nil)
+
+// rawTPC executes f on the connection of ex, as a TPCConn.
+func rawTPC(ctx context.Context, ex Execer, f func(TPCConn) error) error {
+	return Raw(ctx, ex, func(c Conn) error {
+		tc, ok := c.(TPCConn)
+		if !ok {
+			return fmt.Errorf("%T has no two-phase commit: %w", c, ErrNotSupported)
+		}
+		return f(tc)
+	})
+}
+
+// TPCBegin begins (or joins, resumes) the global transaction identified by the Xid.
+//
+// The timeout (rounded down to seconds) is the time the transaction can be inactive before
+// it is automatically rolled back, for TPCBeginNew.
+//
+// The statements executed on the connection are part of the global transaction,
+// and not committed automatically (as outside of a transaction), till the
+// TPCCommit, TPCRollback or TPCEnd.
+func (c *conn) TPCBegin(xid Xid, timeout time.Duration, flags TPCBeginFlags) error {
+	if err := xid.check(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.inTransaction {
+		return errors.New("already in transaction")
+	}
+	if err := c.checkExec(withDpiXid(xid, func(dx *C.dpiXid) C.int {
+		return C.dpiConn_tpcBegin(c.dpiConn, dx, C.uint32_t(timeout/time.Second), C.uint32_t(flags))
+	})); err != nil {
+		return maybeBadConn(fmt.Errorf("tpcBegin(%s): %w", xid, err), c)
+	}
+	c.inTransaction = true
+	return nil
+}
+
+// TPCEnd ends (detaches from) or suspends the global transaction.
+// The zero Xid means the current transaction.
+func (c *conn) TPCEnd(xid Xid, flags TPCEndFlags) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkExec(withDpiXid(xid, func(dx *C.dpiXid) C.int {
+		return C.dpiConn_tpcEnd(c.dpiConn, dx, C.uint32_t(flags))
+	})); err != nil {
+		return maybeBadConn(fmt.Errorf("tpcEnd(%s): %w", xid, err), c)
+	}
+	c.inTransaction = false
+	return nil
+}
+
+// TPCPrepare prepares the global transaction for commit - the first phase of the two-phase commit.
+// The zero Xid means the current transaction.
+//
+// It returns false if there is nothing to commit (the transaction was read-only),
+// and then TPCCommit must not be called.
+func (c *conn) TPCPrepare(xid Xid) (commitNeeded bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var needed C.int
+	if err := c.checkExec(withDpiXid(xid, func(dx *C.dpiXid) C.int {
+		return C.dpiConn_tpcPrepare(c.dpiConn, dx, &needed)
+	})); err != nil {
+		return false, maybeBadConn(fmt.Errorf("tpcPrepare(%s): %w", xid, err), c)
+	}
+	return needed != 0, nil
+}
+
+// TPCCommit commits the global transaction - the second phase of the two-phase commit,
+// or both phases in one step if onePhase is true.
+// The zero Xid means the current transaction.
+//
+// A transaction prepared on another connection (or process) can be committed by its Xid.
+func (c *conn) TPCCommit(xid Xid, onePhase bool) error {
+	var one C.int
+	if onePhase {
+		one = 1
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkExec(withDpiXid(xid, func(dx *C.dpiXid) C.int {
+		return C.dpiConn_tpcCommit(c.dpiConn, dx, one)
+	})); err != nil {
+		return maybeBadConn(fmt.Errorf("tpcCommit(%s): %w", xid, err), c)
+	}
+	c.inTransaction = false
+	return nil
+}
+
+// TPCRollback rolls back the global transaction.
+// The zero Xid means the current transaction.
+//
+// A transaction prepared on another connection (or process) can be rolled back by its Xid.
+func (c *conn) TPCRollback(xid Xid) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkExec(withDpiXid(xid, func(dx *C.dpiXid) C.int {
+		return C.dpiConn_tpcRollback(c.dpiConn, dx)
+	})); err != nil {
+		return maybeBadConn(fmt.Errorf("tpcRollback(%s): %w", xid, err), c)
+	}
+	c.inTransaction = false
+	return nil
+}
+
+// TPCForget makes the database forget the heuristically completed global transaction.
+func (c *conn) TPCForget(xid Xid) error {
+	if err := xid.check(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkExec(withDpiXid(xid, func(dx *C.dpiXid) C.int {
+		return C.dpiConn_tpcForget(c.dpiConn, dx)
+	})); err != nil {
+		return maybeBadConn(fmt.Errorf("tpcForget(%s): %w", xid, err), c)
+	}
+	return nil
+}
+
+// PendingXids returns the Xids of the prepared global transactions waiting for
+// the second phase (commit or rollback), such as the ones left behind by a crashed
+// transaction coordinator, as in DBA_PENDING_TRANSACTIONS.
+//
+// This needs SELECT privilege on DBA_PENDING_TRANSACTIONS.
+func PendingXids(ctx context.Context, q Querier) ([]Xid, error) {
+	const qry = `SELECT formatid, globalid, branchid FROM dba_pending_transactions ORDER BY formatid, globalid, branchid`
+	rows, err := q.QueryContext(ctx, qry)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	defer rows.Close()
+	var xids []Xid
+	for rows.Next() {
+		var xid Xid
+		if err = rows.Scan(&xid.FormatID, &xid.GlobalTransactionID, &xid.BranchQualifier); err != nil {
+			return xids, fmt.Errorf("%s: %w", qry, err)
+		}
+		xids = append(xids, xid)
+	}
+	if err = rows.Err(); err != nil {
+		return xids, err
+	}
+	return xids, rows.Close()
+}
+
+// CommitPrepared commits the prepared global transaction identified by the Xid,
+// on a connection of ex (not participating in the transaction).
+//
+// This needs the FORCE TRANSACTION (or FORCE ANY TRANSACTION for the transactions of other users) privilege.
+func CommitPrepared(ctx context.Context, ex Execer, xid Xid) error {
+	if err := xid.check(); err != nil {
+		return err
+	}
+	return rawTPC(ctx, ex, func(c TPCConn) error { return c.TPCCommit(xid, false) })
+}
+
+// RollbackPrepared rolls back the prepared global transaction identified by the Xid,
+// on a connection of ex (not participating in the transaction).
+//
+// This needs the FORCE TRANSACTION (or FORCE ANY TRANSACTION for the transactions of other users) privilege.
+func RollbackPrepared(ctx context.Context, ex Execer, xid Xid) error {
+	if err := xid.check(); err != nil {
+		return err
+	}
+	return rawTPC(ctx, ex, func(c TPCConn) error { return c.TPCRollback(xid) })
+}
+
+// XAResource drives the branches of global transactions on a connection,
+// with the XA verbs of the transaction managers, while the statements
+// are executed with the database/sql APIs of the embedded *sql.Conn.
+//
+// The statements executed between Start and End belong to the branch of the Xid,
+// the others (e.g. while the branch is suspended) are committed automatically as usual.
+type XAResource struct {
+	*sql.Conn
+}
+
+// NewXAResource returns an XAResource for the connection.
+func NewXAResource(conn *sql.Conn) *XAResource { return &XAResource{Conn: conn} }
+
+func (r *XAResource) raw(ctx context.Context, f func(TPCConn) error) error {
+	return rawTPC(ctx, r.Conn, f)
+}
+
+// Start associates the connection with the branch of the Xid:
+// starts a new one (TPCBeginNew), joins (TPCBeginJoin) or resumes (TPCBeginResume) it.
+//
+// The timeout is the time the branch can be inactive before it is rolled back, for TPCBeginNew.
+func (r *XAResource) Start(ctx context.Context, xid Xid, timeout time.Duration, flags TPCBeginFlags) error {
+	return r.raw(ctx, func(c TPCConn) error { return c.TPCBegin(xid, timeout, flags) })
+}
+
+// End dissociates the connection from the branch of the Xid,
+// or suspends it (TPCEndSuspend), to be resumed by Start with TPCBeginResume.
+func (r *XAResource) End(ctx context.Context, xid Xid, flags TPCEndFlags) error {
+	return r.raw(ctx, func(c TPCConn) error { return c.TPCEnd(xid, flags) })
+}
+
+// Suspend suspends the branch of the Xid (End with TPCEndSuspend).
+func (r *XAResource) Suspend(ctx context.Context, xid Xid) error {
+	return r.End(ctx, xid, TPCEndSuspend)
+}
+
+// Resume resumes the suspended branch of the Xid (Start with TPCBeginResume).
+func (r *XAResource) Resume(ctx context.Context, xid Xid) error {
+	return r.Start(ctx, xid, 0, TPCBeginResume)
+}
+
+// Prepare prepares the branch of the Xid for commit.
+// It returns readOnly=true if there is nothing to commit - then Commit must not be called.
+func (r *XAResource) Prepare(ctx context.Context, xid Xid) (readOnly bool, err error) {
+	err = r.raw(ctx, func(c TPCConn) error {
+		commitNeeded, err := c.TPCPrepare(xid)
+		readOnly = !commitNeeded
+		return err
+	})
+	return readOnly, err
+}
+
+// Commit commits the (prepared, or with onePhase, the ended) branch of the Xid.
+func (r *XAResource) Commit(ctx context.Context, xid Xid, onePhase bool) error {
+	return r.raw(ctx, func(c TPCConn) error { return c.TPCCommit(xid, onePhase) })
+}
+
+// Rollback rolls back the branch of the Xid.
+func (r *XAResource) Rollback(ctx context.Context, xid Xid) error {
+	return r.raw(ctx, func(c TPCConn) error { return c.TPCRollback(xid) })
+}
+
+// Forget forgets the heuristically completed branch of the Xid.
+func (r *XAResource) Forget(ctx context.Context, xid Xid) error {
+	return r.raw(ctx, func(c TPCConn) error { return c.TPCForget(xid) })
+}
+
+// Recover returns the Xids of the prepared branches (see PendingXids).
+func (r *XAResource) Recover(ctx context.Context) ([]Xid, error) {
+	return PendingXids(ctx, r.Conn)
+}
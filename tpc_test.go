@@ -0,0 +1,27 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestXid(t *testing.T) {
+	xid := Xid{FormatID: 1, GlobalTransactionID: []byte("gt"), BranchQualifier: []byte{1}}
+	if got, want := xid.String(), "1:6774:01"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	if err := xid.check(); err != nil {
+		t.Error(err)
+	}
+	if err := (Xid{}).check(); err == nil {
+		t.Error("empty Xid: wanted error")
+	}
+	if err := (Xid{GlobalTransactionID: bytes.Repeat([]byte{'x'}, MaxXidPartLen+1)}).check(); err == nil {
+		t.Error("long Xid: wanted error")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+/*
+#include <stdlib.h>
+#include "dpiImpl.h"
+
+// OCI_ATTR_SQL_ID is not exposed by ODPI-C.
+int godror_getSqlID(dpiStmt *stmt, const char **value, uint32_t *valueLength) {
+	dpiError error;
+	int status;
+	if (dpiGen__startPublicFn(stmt, DPI_HTYPE_STMT, __func__, &error) < 0)
+		return dpiGen__endPublicFn(stmt, DPI_FAILURE, &error);
+	status = dpiOci__attrGet(stmt->handle, DPI_OCI_HTYPE_STMT, (void*) value, valueLength,
+		504, "get sql id", &error); // OCI_ATTR_SQL_ID
+	return dpiGen__endPublicFn(stmt, status, &error);
+}
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"unsafe"
+)
+
+// Tracer starts the spans of the database calls.
+//
+// godror does not depend on OpenTelemetry, but an adapter is a few lines:
+//
+//	type otelTracer struct{ trace.Tracer }
+//	func (t otelTracer) Start(ctx context.Context, name string, attrs ...godror.TraceAttr) (context.Context, godror.Span) {
+//		kv := make([]attribute.KeyValue, len(attrs))
+//		for i, a := range attrs {
+//			kv[i] = attribute.String(a.Key, fmt.Sprint(a.Value))
+//		}
+//		ctx, span := t.Tracer.Start(ctx, "oracle."+name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(kv...))
+//		return ctx, otelSpan{span}
+//	}
+//	type otelSpan struct{ trace.Span }
+//	func (s otelSpan) SetAttributes(attrs ...godror.TraceAttr) { ... }
+//	func (s otelSpan) End(err error) {
+//		if err != nil {
+//			s.Span.RecordError(err)
+//			s.Span.SetStatus(codes.Error, err.Error())
+//		}
+//		s.Span.End()
+//	}
+//	func (s otelSpan) TraceID() string { return s.Span.SpanContext().TraceID().String() }
+type Tracer interface {
+	// Start starts a span of the named (connect, prepare, exec, query, fetch, commit or rollback) call,
+	// returning the context carrying the span.
+	Start(ctx context.Context, name string, attrs ...TraceAttr) (context.Context, Span)
+}
+
+// Span is a started span of a Tracer.
+type Span interface {
+	SetAttributes(attrs ...TraceAttr)
+	// End ends the span, recording the error if not nil.
+	End(err error)
+	// TraceID returns the ID of the trace, to be set as the execution context ID (ECID) of the session
+	// - so the database-side traces can be correlated. Empty to leave it alone.
+	TraceID() string
+}
+
+// TraceAttr is an attribute of a Span.
+type TraceAttr struct {
+	Value interface{}
+	Key   string
+}
+
+// TraceOptions are the options of WithTracer.
+type TraceOptions struct {
+	// SampleRatio is the ratio of the traced statements (exec and query, with its fetches)
+	// and transaction ends, between 0 and 1. 0 means to trace all.
+	SampleRatio float64
+	// NoFetchSpans disables the spans of the fetch round trips of queries.
+	NoFetchSpans bool
+	// NoECID disables setting the trace ID as the execution context ID (ECID) of the session.
+	NoECID bool
+}
+
+// WithTracer returns a driver.Connector which traces the connections of the given godror connector
+// (returned by NewConnector) with the tracer: spans are created for connect, prepare, exec, query,
+// each fetch round trip, commit and rollback.
+//
+// The spans have the following attributes, following the OpenTelemetry semantic conventions where applicable:
+// db.system.name, db.namespace (service name), db.query.text,
+// db.response.affected_rows (exec), db.response.returned_rows (fetch), oracle.sql_id (exec and query)
+// and oracle.fetch_array_size (fetch).
+// The number of round trips of a query is its number of fetch spans plus one.
+func WithTracer(connector driver.Connector, tracer Tracer, opts TraceOptions) driver.Connector {
+	return tracingConnector{Connector: connector, tracer: tracer, opts: opts}
+}
+
+type tracingConnector struct {
+	driver.Connector
+	tracer Tracer
+	opts   TraceOptions
+}
+
+// Connect returns a traced connection.
+func (tc tracingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	ctx, span := tc.tracer.Start(ctx, "connect", TraceAttr{Key: "db.system.name", Value: "oracle"})
+	dc, err := tc.Connector.Connect(ctx)
+	if err == nil {
+		if c, ok := dc.(*conn); ok {
+			c.tracer, c.traceOpts = tc.tracer, tc.opts
+			span.SetAttributes(c.traceAttrs()...)
+		}
+	}
+	span.End(err)
+	return dc, err
+}
+
+// Close closes the underlying connector.
+func (tc tracingConnector) Close() error {
+	if c, ok := tc.Connector.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// traceSampled reports whether the next statement is to be traced.
+func (c *conn) traceSampled() bool {
+	if c == nil || c.tracer == nil {
+		return false
+	}
+	r := c.traceOpts.SampleRatio
+	return r <= 0 || r >= 1 || rand.Float64() < r
+}
+
+func (c *conn) traceAttrs(attrs ...TraceAttr) []TraceAttr {
+	return append(attrs,
+		TraceAttr{Key: "db.system.name", Value: "oracle"},
+		TraceAttr{Key: "db.namespace", Value: c.ServiceName},
+	)
+}
+
+// startSpan starts a span, and sets the trace ID as the ECID of the session.
+func (c *conn) startSpan(ctx context.Context, name string, attrs ...TraceAttr) (context.Context, Span) {
+	ctx, span := c.tracer.Start(ctx, name, c.traceAttrs(attrs...)...)
+	if !c.traceOpts.NoECID {
+		c.setECID(span.TraceID())
+	}
+	return ctx, span
+}
+
+// setECID sets the execution context ID of the session, if changed.
+func (c *conn) setECID(id string) {
+	if id == "" || id == c.ecid || c.dpiConn == nil {
+		return
+	}
+	cs := C.CString(id)
+	// sent with the next round trip
+	if c.checkExec(func() C.int { return C.dpiConn_setEcontextId(c.dpiConn, cs, C.uint32_t(len(id))) }) == nil {
+		c.ecid = id
+	}
+	C.free(unsafe.Pointer(cs))
+}
+
+// sqlID returns the SQL_ID of the executed statement.
+func (st *statement) sqlID() (string, error) {
+	st.Lock()
+	defer st.Unlock()
+	if st.dpiStmt == nil {
+		return "", nil
+	}
+	var value *C.char
+	var length C.uint32_t
+	if err := st.checkExec(func() C.int { return C.godror_getSqlID(st.dpiStmt, &value, &length) }); err != nil {
+		return "", fmt.Errorf("getSqlID: %w", err)
+	}
+	return C.GoStringN(value, C.int(length)), nil
+}
+
+// traceSQLID adds the SQL_ID to the span.
+func (st *statement) traceSQLID(span Span) {
+	if id, _ := st.sqlID(); id != "" {
+		span.SetAttributes(TraceAttr{Key: "oracle.sql_id", Value: id})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+type testSpan struct {
+	err   error
+	name  string
+	attrs []TraceAttr
+	ended bool
+}
+
+func (s *testSpan) SetAttributes(attrs ...TraceAttr) { s.attrs = append(s.attrs, attrs...) }
+func (s *testSpan) End(err error)                    { s.err, s.ended = err, true }
+func (s *testSpan) TraceID() string                  { return "" }
+
+type testTracer struct{ spans []*testSpan }
+
+func (t *testTracer) Start(ctx context.Context, name string, attrs ...TraceAttr) (context.Context, Span) {
+	s := &testSpan{name: name, attrs: attrs}
+	t.spans = append(t.spans, s)
+	return ctx, s
+}
+
+type failingConnector struct{ err error }
+
+func (c failingConnector) Connect(context.Context) (driver.Conn, error) { return nil, c.err }
+func (c failingConnector) Driver() driver.Driver                        { return nil }
+
+func TestTracingConnector(t *testing.T) {
+	var tr testTracer
+	wantErr := errors.New("no listener")
+	tc := WithTracer(failingConnector{err: wantErr}, &tr, TraceOptions{})
+	if _, err := tc.Connect(context.Background()); !errors.Is(err, wantErr) {
+		t.Errorf("got %+v, wanted %v", err, wantErr)
+	}
+	if len(tr.spans) != 1 {
+		t.Fatalf("got %d spans, wanted 1", len(tr.spans))
+	}
+	if s := tr.spans[0]; s.name != "connect" || !s.ended || !errors.Is(s.err, wantErr) {
+		t.Errorf("got %+v", s)
+	}
+	if err := tc.(interface{ Close() error }).Close(); err != nil {
+		t.Error(err)
+	}
+
+	c := &conn{tracer: &tr}
+	for _, tc := range []struct {
+		Ratio    float64
+		Min, Max int
+	}{{0, 1000, 1000}, {1, 1000, 1000}, {0.5, 300, 700}, {0.001, 0, 50}} {
+		c.traceOpts.SampleRatio = tc.Ratio
+		var n int
+		for i := 0; i < 1000; i++ {
+			if c.traceSampled() {
+				n++
+			}
+		}
+		if n < tc.Min || n > tc.Max {
+			t.Errorf("%f: got %d sampled, wanted between %d and %d", tc.Ratio, n, tc.Min, tc.Max)
+		}
+	}
+	if (*conn)(nil).traceSampled() || (&conn{}).traceSampled() {
+		t.Error("sampled without tracer")
+	}
+}
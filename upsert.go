@@ -0,0 +1,180 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UpsertMode selects the statement Upsert generates.
+type UpsertMode uint8
+
+const (
+	// UpsertMerge updates the existing rows, then MERGEs the rest (inserting them).
+	UpsertMerge UpsertMode = iota
+	// UpsertInsertIgnore inserts the new rows with the IGNORE_ROW_ON_DUPKEY_INDEX hint,
+	// leaving the existing ones untouched. This needs a unique index on the key columns.
+	UpsertInsertIgnore
+)
+
+// UpsertStatus is the outcome of Upsert for a row.
+type UpsertStatus uint8
+
+const (
+	// UpsertUnchanged means the row has not been written, as it already exists.
+	UpsertUnchanged UpsertStatus = iota
+	// UpsertInserted means the row has been inserted.
+	UpsertInserted
+	// UpsertUpdated means the existing row has been updated.
+	UpsertUpdated
+)
+
+func (s UpsertStatus) String() string {
+	switch s {
+	case UpsertUnchanged:
+		return "unchanged"
+	case UpsertInserted:
+		return "inserted"
+	case UpsertUpdated:
+		return "updated"
+	}
+	return fmt.Sprintf("UpsertStatus(%d)", uint8(s))
+}
+
+// Upsert inserts the rows into the table, or updates the existing ones, matching on the keyCols,
+// and returns the status of each row.
+//
+// The rows must be a slice of structs (or pointers to structs) with BindStructTag tagged fields
+// (see BindStructTag), which name the columns, and must include the keyCols.
+//
+// With UpsertMerge (the default), the existing rows are updated with an array UPDATE,
+// and the rest is inserted with an array MERGE, so a row inserted concurrently
+// between the two is updated, but reported as UpsertInserted.
+// This needs one round trip, if all the rows exist, two otherwise.
+//
+// With UpsertInsertIgnore, the new rows are inserted in one round trip,
+// and the existing rows are left as is, reported as UpsertUnchanged.
+func Upsert(ctx context.Context, ex Execer, table string, keyCols []string, rows interface{}, mode ...UpsertMode) ([]UpsertStatus, error) {
+	if len(keyCols) == 0 {
+		return nil, errors.New("Upsert: no key columns")
+	}
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("Upsert: rows is %T, not a slice", rows)
+	}
+	if rv.Len() == 0 {
+		return nil, nil
+	}
+	et := rv.Type().Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Upsert: rows is %T, not a slice of structs", rows)
+	}
+	fields, tagged := structBindFields(et)
+	if !tagged {
+		return nil, fmt.Errorf("Upsert: %v has no %q tagged fields", et, BindStructTag)
+	}
+	columns := make([]string, 0, len(fields))
+	isKey := make(map[string]bool, len(keyCols))
+	for _, k := range keyCols {
+		isKey[strings.ToUpper(k)] = false
+	}
+	var setCols []string
+	for _, f := range fields {
+		columns = append(columns, f.name)
+		if _, ok := isKey[strings.ToUpper(f.name)]; ok {
+			isKey[strings.ToUpper(f.name)] = true
+		} else {
+			setCols = append(setCols, f.name)
+		}
+	}
+	for _, k := range keyCols {
+		if !isKey[strings.ToUpper(k)] {
+			return nil, fmt.Errorf("Upsert: key column %q is not a field of %v", k, et)
+		}
+	}
+
+	statuses := make([]UpsertStatus, rv.Len())
+	if len(mode) != 0 && mode[0] == UpsertInsertIgnore {
+		name := table[strings.LastIndexByte(table, '.')+1:]
+		qry := InsertStatement(table, columns...)
+		qry = "INSERT /*+ IGNORE_ROW_ON_DUPKEY_INDEX(" + name + " (" + strings.Join(keyCols, ", ") + ")) */" +
+			strings.TrimPrefix(qry, "INSERT")
+		var counts []int64
+		if _, err := ex.ExecContext(ctx, qry, rows, ArrayDMLRowCounts(&counts)); err != nil {
+			return nil, fmt.Errorf("%s: %w", qry, err)
+		}
+		for i, n := range counts {
+			if n != 0 {
+				statuses[i] = UpsertInserted
+			}
+		}
+		return statuses, nil
+	}
+
+	// The rows to be merged, and their indexes in rows.
+	rest, restIdx := rv, make([]int, rv.Len())
+	for i := range restIdx {
+		restIdx[i] = i
+	}
+	if len(setCols) != 0 {
+		qry := updateStatement(table, keyCols, setCols)
+		var counts []int64
+		if _, err := ex.ExecContext(ctx, qry, rows, ArrayDMLRowCounts(&counts)); err != nil {
+			return nil, fmt.Errorf("%s: %w", qry, err)
+		}
+		rest, restIdx = reflect.MakeSlice(rv.Type(), 0, rv.Len()), restIdx[:0]
+		for i, n := range counts {
+			if n != 0 {
+				statuses[i] = UpsertUpdated
+			} else {
+				rest = reflect.Append(rest, rv.Index(i))
+				restIdx = append(restIdx, i)
+			}
+		}
+		if len(restIdx) == 0 {
+			return statuses, nil
+		}
+	}
+	qry := MergeStatement(table, keyCols, columns...)
+	var counts []int64
+	if _, err := ex.ExecContext(ctx, qry, rest.Interface(), ArrayDMLRowCounts(&counts)); err != nil {
+		return nil, fmt.Errorf("%s: %w", qry, err)
+	}
+	for i, n := range counts {
+		if n != 0 {
+			statuses[restIdx[i]] = UpsertInserted
+		}
+	}
+	return statuses, nil
+}
+
+// updateStatement returns an UPDATE statement setting the columns of the rows matching the keys,
+// with the columns' names as bind variable names.
+func updateStatement(table string, keys, columns []string) string {
+	var buf strings.Builder
+	buf.WriteString("UPDATE " + table + " SET ")
+	for i, c := range columns {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(c + " = :" + c)
+	}
+	buf.WriteString(" WHERE ")
+	for i, k := range keys {
+		if i != 0 {
+			buf.WriteString(" AND ")
+		}
+		buf.WriteString(k + " = :" + k)
+	}
+	return buf.String()
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUpdateStatement(t *testing.T) {
+	const want = "UPDATE tbl SET name = :name, qty = :qty WHERE id = :id AND ver = :ver"
+	if got := updateStatement("tbl", []string{"id", "ver"}, []string{"name", "qty"}); got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestUpsertArgs(t *testing.T) {
+	type row struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	ctx := context.Background()
+	for name, tc := range map[string]struct {
+		Rows interface{}
+		Keys []string
+	}{
+		"noKeys":     {Rows: []row{{1, "a"}}},
+		"notSlice":   {Rows: row{1, "a"}, Keys: []string{"id"}},
+		"notStructs": {Rows: []int{1}, Keys: []string{"id"}},
+		"missingKey": {Rows: []row{{1, "a"}}, Keys: []string{"code"}},
+	} {
+		if _, err := Upsert(ctx, nil, "tbl", tc.Keys, tc.Rows); err == nil {
+			t.Errorf("%s: wanted error", name)
+		} else {
+			t.Logf("%s: %v", name, err)
+		}
+	}
+	if statuses, err := Upsert(ctx, nil, "tbl", []string{"id"}, []row{}); err != nil || statuses != nil {
+		t.Errorf("empty: got %v, %+v", statuses, err)
+	}
+}
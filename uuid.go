@@ -0,0 +1,176 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// UUID is a UUID stored in a RAW(16) column, in RFC 4122 (big endian) byte order.
+//
+// It is a [16]byte, so it is convertible to/from github.com/google/uuid.UUID.
+// For UUIDs stored in another byte order, use UUIDIn.
+type UUID [16]byte
+
+// UUIDByteOrder is the byte order of a UUID stored in RAW(16).
+type UUIDByteOrder uint8
+
+const (
+	// UUIDBigEndian is the RFC 4122 byte order, the same as the canonical string form.
+	UUIDBigEndian = UUIDByteOrder(iota)
+	// UUIDMixedEndian is the byte order of Microsoft GUIDs (.NET's Guid.ToByteArray):
+	// the first three groups are little endian.
+	UUIDMixedEndian
+)
+
+var errInvalidUUID = errors.New("invalid UUID")
+
+// ParseUUID parses the canonical (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx) or the
+// plain hex (as RAWTOHEX returns) form of the UUID, optionally enclosed in {}.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	orig := s
+	if len(s) == 38 && s[0] == '{' && s[37] == '}' {
+		s = s[1:37]
+	}
+	switch len(s) {
+	case 32:
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, fmt.Errorf("%q: %w", orig, errInvalidUUID)
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	default:
+		return u, fmt.Errorf("%q: %w", orig, errInvalidUUID)
+	}
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return u, fmt.Errorf("%q: %w: %w", orig, errInvalidUUID, err)
+	}
+	return u, nil
+}
+
+// UUIDFromBytes returns the UUID from the stored bytes in the given byte order.
+func UUIDFromBytes(b []byte, order UUIDByteOrder) (UUID, error) {
+	var u UUID
+	if len(b) != len(u) {
+		return u, fmt.Errorf("%d bytes: %w", len(b), errInvalidUUID)
+	}
+	copy(u[:], b)
+	if order == UUIDMixedEndian {
+		u.swap()
+	}
+	return u, nil
+}
+
+// Bytes returns the UUID in the given byte order, as stored in the RAW(16).
+func (u UUID) Bytes(order UUIDByteOrder) []byte {
+	if order == UUIDMixedEndian {
+		u.swap()
+	}
+	return u[:]
+}
+
+// swap converts between the big and mixed endian byte orders.
+func (u *UUID) swap() {
+	u[0], u[1], u[2], u[3] = u[3], u[2], u[1], u[0]
+	u[4], u[5] = u[5], u[4]
+	u[6], u[7] = u[7], u[6]
+}
+
+// String returns the canonical form of the UUID.
+func (u UUID) String() string {
+	b, _ := u.MarshalText()
+	return string(b)
+}
+
+// MarshalText returns the canonical form of the UUID.
+func (u UUID) MarshalText() ([]byte, error) {
+	b := make([]byte, 36)
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return b, nil
+}
+
+// UnmarshalText parses the UUID as ParseUUID.
+func (u *UUID) UnmarshalText(b []byte) error {
+	v, err := ParseUUID(string(b))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// Value returns the UUID as RAW, in big endian byte order.
+func (u UUID) Value() (driver.Value, error) { return u.Bytes(UUIDBigEndian), nil }
+
+// Scan the RAW(16) (or its string form) into the UUID, in big endian byte order.
+// NULL is scanned as the zero UUID.
+func (u *UUID) Scan(src interface{}) error { return u.scan(src, UUIDBigEndian) }
+
+func (u *UUID) scan(src interface{}, order UUIDByteOrder) error {
+	var err error
+	switch x := src.(type) {
+	case nil:
+		*u = UUID{}
+	case []byte:
+		*u, err = UUIDFromBytes(x, order)
+	case string:
+		*u, err = ParseUUID(x)
+	case UUID:
+		*u = x
+	default:
+		// [16]byte, github.com/google/uuid.UUID
+		if rv := reflect.ValueOf(src); rv.Kind() == reflect.Array && rv.Len() == len(u) && rv.Type().Elem().Kind() == reflect.Uint8 {
+			reflect.Copy(reflect.ValueOf(u[:]), rv)
+			return nil
+		}
+		return fmt.Errorf("cannot scan %T into UUID", src)
+	}
+	return err
+}
+
+// OrderedUUID binds and scans a UUID stored in the given byte order, see UUIDIn.
+type OrderedUUID struct {
+	UUID  *UUID
+	Order UUIDByteOrder
+}
+
+// UUIDIn returns an OrderedUUID for binding or scanning u stored in the byte order,
+// for example
+//
+//	rows.Scan(godror.UUIDIn(&u, godror.UUIDMixedEndian))
+func UUIDIn(u *UUID, order UUIDByteOrder) OrderedUUID { return OrderedUUID{UUID: u, Order: order} }
+
+// Value returns the UUID as RAW, in the byte order. A nil UUID is bound as NULL.
+func (o OrderedUUID) Value() (driver.Value, error) {
+	if o.UUID == nil {
+		return nil, nil
+	}
+	return o.UUID.Bytes(o.Order), nil
+}
+
+// Scan the RAW(16) into the UUID, in the byte order.
+func (o OrderedUUID) Scan(src interface{}) error { return o.UUID.scan(src, o.Order) }
+
+var (
+	_ sql.Scanner   = (*UUID)(nil)
+	_ driver.Valuer = UUID{}
+	_ sql.Scanner   = OrderedUUID{}
+	_ driver.Valuer = OrderedUUID{}
+)
@@ -0,0 +1,66 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUUID(t *testing.T) {
+	const s = "00112233-4455-6677-8899-aabbccddeeff"
+	u, err := ParseUUID(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.String(); got != s {
+		t.Errorf("got %q, wanted %q", got, s)
+	}
+	for _, in := range []string{"00112233445566778899AABBCCDDEEFF", "{" + s + "}"} {
+		if got, err := ParseUUID(in); err != nil {
+			t.Errorf("%q: %+v", in, err)
+		} else if got != u {
+			t.Errorf("%q: got %s, wanted %s", in, got, u)
+		}
+	}
+	for _, in := range []string{"", "00112233-4455-6677-8899-aabbccddeef", "0011223344-55-6677-8899-aabbccddeeff", "00112233-4455-6677-8899-aabbccddeegg"} {
+		if got, err := ParseUUID(in); err == nil {
+			t.Errorf("%q: got %s, wanted error", in, got)
+		}
+	}
+
+	for order, want := range map[UUIDByteOrder][]byte{
+		UUIDBigEndian:   {0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+		UUIDMixedEndian: {0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+	} {
+		b := u.Bytes(order)
+		if !bytes.Equal(b, want) {
+			t.Errorf("%d: got %x, wanted %x", order, b, want)
+		}
+		var got UUID
+		if err := UUIDIn(&got, order).Scan(b); err != nil {
+			t.Errorf("%d: %+v", order, err)
+		} else if got != u {
+			t.Errorf("%d: scanned %s, wanted %s", order, got, u)
+		}
+		if v, err := UUIDIn(&u, order).Value(); err != nil {
+			t.Errorf("%d: %+v", order, err)
+		} else if !bytes.Equal(v.([]byte), want) {
+			t.Errorf("%d: got value %x, wanted %x", order, v, want)
+		}
+	}
+
+	var got UUID
+	if err := got.Scan([16]byte(u)); err != nil || got != u {
+		t.Errorf("scan [16]byte: got %s (%+v), wanted %s", got, err, u)
+	}
+	if err := got.Scan(nil); err != nil || got != (UUID{}) {
+		t.Errorf("scan nil: got %s (%+v)", got, err)
+	}
+	if err := got.Scan([]byte{1, 2, 3}); err == nil {
+		t.Errorf("scan 3 bytes: got %s, wanted error", got)
+	}
+}
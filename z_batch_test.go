@@ -9,6 +9,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -616,3 +617,104 @@ func TestBatchFlushError(t *testing.T) {
 
 	t.Logf("Got expected error: %v", err)
 }
+
+func TestBatchStructMerge(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("BatchStructMerge"), time.Minute)
+	defer cancel()
+
+	const tbl = "test_batch_merge"
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER(9) PRIMARY KEY, name VARCHAR2(5) NOT NULL)"); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
+
+	type row struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	for _, qry := range []string{
+		godror.InsertStatement(tbl, "id", "name"),
+		godror.MergeStatement(tbl, []string{"id"}, "id", "name"),
+	} {
+		stmt, err := testDb.PrepareContext(ctx, qry)
+		if err != nil {
+			t.Fatalf("%s: %+v", qry, err)
+		}
+		defer stmt.Close()
+		b := godror.Batch{Stmt: stmt, Limit: 2, ContinueOnError: true}
+		for _, r := range []row{{1, "a"}, {2, "too long"}, {3, "c"}} {
+			if err = b.Add(ctx, r); err != nil {
+				t.Fatalf("%s: %+v", qry, err)
+			}
+		}
+		if err = b.Flush(ctx); err != nil {
+			t.Fatalf("%s: %+v", qry, err)
+		}
+		t.Log(qry, b.RowErrors())
+		if got := b.RowsAffected(); got != 2 {
+			t.Errorf("%s: got %d rows affected, wanted 2", qry, got)
+		}
+		if errs := b.RowErrors(); len(errs) != 1 || errs[0].Row != 1 {
+			t.Errorf("%s: got row errors %+v, wanted one for row 1", qry, errs)
+		}
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("Upsert"), time.Minute)
+	defer cancel()
+
+	const tbl = "test_upsert"
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER(9) PRIMARY KEY, name VARCHAR2(10) NOT NULL)"); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
+
+	type row struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	statuses, err := godror.Upsert(ctx, testDb, tbl, []string{"id"}, []row{{1, "a"}, {2, "b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []godror.UpsertStatus{godror.UpsertInserted, godror.UpsertInserted}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("got %v, wanted %v", statuses, want)
+	}
+	if statuses, err = godror.Upsert(ctx, testDb, tbl, []string{"id"}, []*row{{1, "aa"}, {3, "c"}, {2, "bb"}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []godror.UpsertStatus{godror.UpsertUpdated, godror.UpsertInserted, godror.UpsertUpdated}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("got %v, wanted %v", statuses, want)
+	}
+	if statuses, err = godror.Upsert(ctx, testDb, tbl, []string{"id"}, []row{{3, "cc"}, {4, "d"}}, godror.UpsertInsertIgnore); err != nil {
+		t.Fatal(err)
+	}
+	if want := []godror.UpsertStatus{godror.UpsertUnchanged, godror.UpsertInserted}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("got %v, wanted %v", statuses, want)
+	}
+
+	var got []string
+	rows, err := testDb.QueryContext(ctx, "SELECT id||'='||name FROM "+tbl+" ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var s string
+		if err = rows.Scan(&s); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, s)
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1=aa", "2=bb", "3=c", "4=d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
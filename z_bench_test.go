@@ -411,6 +411,37 @@ func TestSelectOrder(t *testing.T) {
 	}
 }
 
+// go test -c && ./godror.v2.test -test.run=^$ -test.bench=SelectNumber -test.benchmem
+func BenchmarkSelectNumber(b *testing.B) {
+	const qry = "SELECT LEVEL * 1000 FROM DUAL CONNECT BY LEVEL <= 1000"
+	for _, tc := range []struct {
+		Name    string
+		Options []interface{}
+	}{
+		{Name: "Number"},
+		{Name: "NumberAsInt64", Options: []interface{}{godror.NumberAsInt64()}},
+	} {
+		b.Run(tc.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; {
+				rows, err := testDb.Query(qry, tc.Options...)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for rows.Next() && i < b.N {
+					var n int64
+					if err = rows.Scan(&n); err != nil {
+						rows.Close()
+						b.Fatal(err)
+					}
+					i++
+				}
+				rows.Close()
+			}
+		})
+	}
+}
+
 // go test -c && ./godror.v2.test -test.run=^$ -test.bench=Date -test.cpuprofile=/tmp/cpu.prof && go tool pprof godror.v2.test /tmp/cpu.prof
 func BenchmarkSelectDate(b *testing.B) {
 	b.ResetTimer()
@@ -657,3 +657,25 @@ func TestCLOBSurrogate(t *testing.T) {
 	})
 
 }
+
+func TestLOBPromotion(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("LOBPromotion"), 30*time.Second)
+	defer cancel()
+
+	long := strings.Repeat("árvíztűrő tükörfúrógép ", 4096)
+	const qry = "SELECT DBMS_LOB.getlength(:1) FROM DUAL"
+	var n int
+	if err := testDb.QueryRowContext(ctx, qry, long).Scan(&n); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	if want := utf8.RuneCountInString(long); n != want {
+		t.Errorf("CLOB: got %d, wanted %d", n, want)
+	}
+	if err := testDb.QueryRowContext(ctx, qry, bytes.Repeat([]byte{1, 2, 3}, 20000)).Scan(&n); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	if n != 60000 {
+		t.Errorf("BLOB: got %d, wanted 60000", n)
+	}
+}
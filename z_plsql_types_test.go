@@ -1120,6 +1120,71 @@ END;
 	runtime.GC()
 }
 
+// BenchmarkObjWide measures AsMap and FromMap of a 50-attribute object,
+// whose attribute values are transferred with one cgo call.
+func BenchmarkObjWide(b *testing.B) {
+	const typName = "test_wide50_ot"
+	cleanup := func() { testDb.Exec("DROP TYPE " + typName) }
+	cleanup()
+	var buf strings.Builder
+	buf.WriteString("CREATE OR REPLACE TYPE " + typName + " AS OBJECT (")
+	for i := 0; i < 50; i++ {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		if i%2 == 0 {
+			fmt.Fprintf(&buf, "n%02d NUMBER", i)
+		} else {
+			fmt.Fprintf(&buf, "s%02d VARCHAR2(100)", i)
+		}
+	}
+	buf.WriteString(")")
+	qry := buf.String()
+	if _, err := testDb.Exec(qry); err != nil {
+		b.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(testContext("BenchmarkObjWide"))
+	defer cancel()
+	typ, err := godror.GetObjectType(ctx, testDb, strings.ToUpper(typName))
+	if err != nil {
+		b.Fatal(err)
+	}
+	obj, err := typ.NewObject()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer obj.Close()
+	m := make(map[string]interface{}, 50)
+	for i, nm := range typ.AttributeNames() {
+		if i%2 == 0 {
+			m[nm] = i
+		} else {
+			m[nm] = fmt.Sprintf("value-%02d", i)
+		}
+	}
+
+	b.Run("FromMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := obj.FromMap(false, m); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("AsMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			got, err := obj.AsMap(false)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(got) != 50 {
+				b.Fatalf("got %d attributes, wanted 50", len(got))
+			}
+		}
+	})
+}
+
 func BenchmarkObjArray(b *testing.B) {
 	cleanup := func() { testDb.Exec("DROP FUNCTION test_objarr"); testDb.Exec("DROP TYPE test_vc2000_arr") }
 	cleanup()
@@ -2484,6 +2549,141 @@ END;`,
 	}
 }
 
+type collItem struct {
+	godror.ObjectTypeName `godror:"test_coll_item_ot,collection=test_coll_item_tt"`
+	ID                    int    `godror:"ID"`
+	Name                  string `godror:"NAME"`
+}
+
+func TestStructSliceCollection(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("StructSliceCollection"), 10*time.Second)
+	defer cancel()
+
+	cleanup := func() {
+		for _, qry := range []string{"DROP TYPE test_coll_item_tt", "DROP TYPE test_coll_item_ot"} {
+			testDb.ExecContext(context.Background(), qry)
+		}
+	}
+	cleanup()
+	defer cleanup()
+	for _, qry := range []string{
+		"CREATE OR REPLACE TYPE test_coll_item_ot AS OBJECT (id NUMBER(3), name VARCHAR2(128))",
+		"CREATE OR REPLACE TYPE test_coll_item_tt AS TABLE OF test_coll_item_ot",
+	} {
+		if _, err := testDb.ExecContext(ctx, qry); err != nil {
+			t.Fatalf("%s: %+v", qry, err)
+		}
+	}
+
+	const qry = `DECLARE
+  v_items test_coll_item_tt := :items;
+BEGIN
+  :cnt := 0; :sum := 0;
+  IF v_items IS NOT NULL THEN
+    :cnt := v_items.COUNT;
+    FOR i IN 1..v_items.COUNT LOOP
+      :sum := :sum + v_items(i).id;
+    END LOOP;
+  END IF;
+END;`
+	for _, tc := range []struct {
+		items    interface{}
+		cnt, sum int
+	}{
+		{items: []collItem{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, cnt: 2, sum: 3},
+		{items: []*collItem{{ID: 3, Name: "c"}}, cnt: 1, sum: 3},
+		{items: []collItem{}, cnt: 0, sum: 0},
+		{items: []collItem(nil), cnt: 0, sum: 0},
+	} {
+		var cnt, sum int
+		if _, err := testDb.ExecContext(ctx, qry,
+			sql.Named("items", tc.items),
+			sql.Named("cnt", sql.Out{Dest: &cnt}), sql.Named("sum", sql.Out{Dest: &sum}),
+		); err != nil {
+			t.Fatalf("%#v: %+v", tc.items, err)
+		}
+		if cnt != tc.cnt || sum != tc.sum {
+			t.Errorf("%#v: got cnt=%d sum=%d, wanted %d, %d", tc.items, cnt, sum, tc.cnt, tc.sum)
+		}
+	}
+}
+
+func TestReturningObject(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("ReturningObject"), 30*time.Second)
+	defer cancel()
+
+	tbl := "test_ret_obj" + tblSuffix
+	cleanup := func() {
+		for _, qry := range []string{"DROP TABLE " + tbl, "DROP TYPE test_ret_lt", "DROP TYPE test_ret_ot"} {
+			testDb.ExecContext(context.Background(), qry)
+		}
+	}
+	cleanup()
+	defer cleanup()
+	for _, qry := range []string{
+		"CREATE OR REPLACE TYPE test_ret_ot AS OBJECT (id NUMBER(3), name VARCHAR2(128))",
+		"CREATE OR REPLACE TYPE test_ret_lt AS TABLE OF VARCHAR2(30)",
+		"CREATE TABLE " + tbl + " (id NUMBER(3), obj test_ret_ot, lst test_ret_lt) NESTED TABLE lst STORE AS " + tbl + "_lst",
+		"CREATE OR REPLACE TRIGGER " + tbl + "_trg BEFORE INSERT ON " + tbl + ` FOR EACH ROW
+BEGIN
+  :NEW.obj.name := UPPER(:NEW.obj.name);
+END;`,
+	} {
+		if _, err := testDb.ExecContext(ctx, qry); err != nil {
+			t.Fatalf("%s: %+v", qry, err)
+		}
+	}
+
+	conn, err := testDb.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ot, err := godror.GetObjectType(ctx, conn, "test_ret_ot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ot.Close()
+	lt, err := godror.GetObjectType(ctx, conn, "test_ret_lt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lt.Close()
+
+	obj := godror.Object{ObjectType: ot}
+	lst, err := lt.NewCollection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lst.Close()
+	qry := "INSERT INTO " + tbl + " (id, obj, lst) VALUES (1, test_ret_ot(1, 'abc'), test_ret_lt('x', 'y')) RETURNING obj, lst INTO :obj, :lst"
+	if _, err = conn.ExecContext(ctx, qry, sql.Out{Dest: &obj}, sql.Out{Dest: &lst}); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	defer obj.Close()
+	if name, err := obj.Get("NAME"); err != nil {
+		t.Error(err)
+	} else if name != "ABC" {
+		t.Errorf("got name %q, wanted the trigger-set ABC", name)
+	}
+	if n, err := lst.Len(); err != nil {
+		t.Error(err)
+	} else if n != 2 {
+		t.Errorf("got %d elements, wanted 2", n)
+	}
+
+	// no rows returned
+	var empty godror.Object
+	qry = "UPDATE " + tbl + " SET id = id WHERE 1 = 0 RETURNING obj INTO :obj"
+	if _, err = conn.ExecContext(ctx, qry, sql.Out{Dest: &empty}); err == nil {
+		t.Errorf("%s: wanted error for Object without ObjectType", qry)
+	}
+	empty.ObjectType = ot
+	if _, err = conn.ExecContext(ctx, qry, sql.Out{Dest: &empty}); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+}
+
 func TestObjLobClose(t *testing.T) {
 	const dropQry = `DROP TYPE test_clob_ot`
 	cleanup := func() { testDb.ExecContext(context.Background(), dropQry) }
@@ -2690,3 +2890,59 @@ func TestIssue323(t *testing.T) {
 		t.Errorf("pa[0]=%#v\n==\n%#v=pa[1]", pa[0], pa[1])
 	}
 }
+
+func TestPlSQLStringIndexedTable(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("PlSQLStringIndexedTable"), 30*time.Second)
+	defer cancel()
+
+	name := "test_plsql_strindexed" + tblSuffix
+	dropQry := `DROP PACKAGE ` + name
+	_, _ = testDb.ExecContext(ctx, dropQry)
+	defer func() { _, _ = testDb.ExecContext(context.Background(), dropQry) }()
+
+	for _, creQry := range []string{
+		`CREATE OR REPLACE PACKAGE ` + name + ` IS
+TYPE num_tab_typ IS TABLE OF NUMBER INDEX BY VARCHAR2(30);
+TYPE str_tab_typ IS TABLE OF VARCHAR2(100) INDEX BY VARCHAR2(30);
+PROCEDURE double(p_in IN num_tab_typ, p_out OUT str_tab_typ, p_inout IN OUT num_tab_typ);
+END;`,
+		`CREATE OR REPLACE PACKAGE BODY ` + name + ` IS
+PROCEDURE double(p_in IN num_tab_typ, p_out OUT str_tab_typ, p_inout IN OUT num_tab_typ) IS
+  v_key VARCHAR2(30) := p_in.FIRST;
+BEGIN
+  WHILE v_key IS NOT NULL LOOP
+    p_out(v_key) := TO_CHAR(2 * p_in(v_key));
+    p_inout(v_key) := NVL(p_inout(v_key), 0) + p_in(v_key);
+    v_key := p_in.NEXT(v_key);
+  END LOOP;
+END;
+END;`,
+	} {
+		if _, err := testDb.ExecContext(ctx, creQry); err != nil {
+			t.Fatalf("%s: %+v", creQry, err)
+		}
+	}
+
+	in := map[string]int64{"a": 1, "b": 2}
+	var out map[string]string
+	inout := map[string]float64{"a": 0.5, "c": 3}
+	if _, err := testDb.ExecContext(ctx, "BEGIN "+name+".double(:1, :2, :3); END;",
+		godror.StringIndexedTable{TypeName: name + ".num_tab_typ", Map: in},
+		godror.StringIndexedTable{TypeName: name + ".str_tab_typ", Map: &out},
+		godror.StringIndexedTable{TypeName: name + ".num_tab_typ", Map: &inout},
+	); err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(map[string]string{"a": "2", "b": "4"}, out); d != "" {
+		t.Error(d)
+	}
+	if d := cmp.Diff(map[string]float64{"a": 1.5, "b": 2, "c": 3}, inout); d != "" {
+		t.Error(d)
+	}
+
+	if _, err := testDb.ExecContext(ctx, "BEGIN "+name+".double(:1, :2, :3); END;",
+		in, sql.Out{Dest: &out}, sql.Out{Dest: &inout, In: true},
+	); !errors.Is(err, godror.ErrStringIndexedTable) {
+		t.Errorf("map bind: got %+v, wanted %v", err, godror.ErrStringIndexedTable)
+	}
+}
@@ -17,11 +17,13 @@ import (
 	"io"
 	"math"
 	"math/rand"
+	"net/http/httptest"
 	"os"
 	"os/user"
 	"reflect"
 	"runtime"
 	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -37,6 +39,7 @@ import (
 
 	godror "github.com/godror/godror"
 	"github.com/godror/godror/dsn"
+	"github.com/godror/godror/metadata"
 )
 
 var (
@@ -389,3291 +392,6375 @@ func TestDescribeQuery(t *testing.T) {
 		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
 	t.Log(cols)
+
+	const bindQry = "SELECT table_name, column_id FROM user_tab_cols WHERE table_name = :tbl AND column_id > :id"
+	if cols, err = godror.DescribeQuery(ctx, testDb, bindQry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", bindQry, err))
+	}
+	if len(cols) != 2 {
+		t.Errorf("got %d columns, wanted 2", len(cols))
+	}
 }
 
-func TestParseOnly(t *testing.T) {
+func TestDescribe(t *testing.T) {
 	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("ParseOnly"), 10*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("Describe"), 10*time.Second)
 	defer cancel()
 
-	tbl := "test_not_exist" + tblSuffix
-	cnt := func() int {
-		var cnt int64
-		if err := testDb.QueryRowContext(ctx,
-			"SELECT COUNT(0) FROM user_tables WHERE table_name = UPPER('"+tbl+"')").Scan(&cnt); //nolint:gas
-		err != nil {
-			t.Fatal(err)
-		}
-		return int(cnt)
+	const qry = "SELECT table_name, column_id, data_default FROM user_tab_cols WHERE table_name = :tbl AND column_id > :id"
+	desc, err := godror.Describe(ctx, testDb, qry)
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-
-	if cnt() != 0 {
-		if _, err := testDb.ExecContext(ctx, "DROP TABLE "+tbl); err != nil {
-			t.Error(err)
+	t.Logf("%+v", desc)
+	if !desc.IsQuery {
+		t.Error("not a query")
+	}
+	if len(desc.Params) != 2 || desc.Params[0].Name != "TBL" || desc.Params[1].Name != "ID" {
+		t.Errorf("got params %+v, wanted TBL, ID", desc.Params)
+	}
+	if len(desc.Columns) != 3 {
+		t.Fatalf("got %d columns, wanted 3", len(desc.Columns))
+	}
+	for i, want := range []string{"VARCHAR2", "NUMBER", "LONG"} {
+		if got := desc.Columns[i].TypeName; got != want {
+			t.Errorf("%d. got %q, wanted %q", i, got, want)
 		}
 	}
-	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+"(t VARCHAR2(1))", godror.ParseOnly()); err != nil {
-		t.Fatal(err)
+
+	const plsql = "BEGIN DBMS_SESSION.set_identifier(:id); END;"
+	if desc, err = godror.Describe(ctx, testDb, plsql); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", plsql, err))
 	}
-	if got := cnt(); got != 1 {
-		t.Errorf("got %d, wanted 0", got)
+	if !desc.IsPLSQL || len(desc.Columns) != 0 || len(desc.Params) != 1 {
+		t.Errorf("got %+v", desc)
 	}
 }
 
-func TestInputArray(t *testing.T) {
+func TestFetchColumnInfo(t *testing.T) {
 	t.Parallel()
-	defer tl.enableLogging(t)()
-	ctx, cancel := context.WithTimeout(testContext("InputArray"), 10*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("FetchColumnInfo"), 10*time.Second)
 	defer cancel()
 
-	pkg := strings.ToUpper("test_in_pkg" + tblSuffix)
-	qry := `CREATE OR REPLACE PACKAGE ` + pkg + ` AS
-TYPE int_tab_typ IS TABLE OF BINARY_INTEGER INDEX BY PLS_INTEGER;
-TYPE num_tab_typ IS TABLE OF NUMBER INDEX BY PLS_INTEGER;
-TYPE vc_tab_typ IS TABLE OF VARCHAR2(100) INDEX BY PLS_INTEGER;
-TYPE dt_tab_typ IS TABLE OF DATE INDEX BY PLS_INTEGER;
-TYPE ids_tab_typ IS TABLE OF INTERVAL DAY TO SECOND INDEX BY PLS_INTEGER;
---TYPE lob_tab_typ IS TABLE OF CLOB INDEX BY PLS_INTEGER;
-
-FUNCTION in_int(p_int IN int_tab_typ) RETURN VARCHAR2;
-FUNCTION in_num(p_num IN num_tab_typ) RETURN VARCHAR2;
-FUNCTION in_vc(p_vc IN vc_tab_typ) RETURN VARCHAR2;
-FUNCTION in_dt(p_dt IN dt_tab_typ) RETURN VARCHAR2;
-FUNCTION in_ids(p_dur IN ids_tab_typ) RETURN VARCHAR2;
-END;
-`
-	testDb.Exec("DROP PACKAGE " + pkg)
-	t.Log("package", pkg)
-	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		t.Fatal(err, qry)
+	const qry = "SELECT CAST('a' AS NVARCHAR2(10)) AS nv, SYS.ODCIVARCHAR2LIST('b') AS lst, 1 AS n FROM DUAL"
+	var infos []godror.ColumnInfo
+	rows, err := testDb.QueryContext(ctx, qry, godror.FetchColumnInfo(&infos))
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-	defer testDb.Exec("DROP PACKAGE " + pkg)
-
-	qry = `CREATE OR REPLACE PACKAGE BODY ` + pkg + ` AS
-FUNCTION in_int(p_int IN int_tab_typ) RETURN VARCHAR2 IS
-  v_idx PLS_INTEGER;
-  v_res VARCHAR2(32767);
-BEGIN
-  v_idx := p_int.FIRST;
-  WHILE v_idx IS NOT NULL LOOP
-    v_res := v_res||v_idx||':'||p_int(v_idx)||CHR(10);
-    v_idx := p_int.NEXT(v_idx);
-  END LOOP;
-  RETURN(v_res);
-END;
+	rows.Close()
+	t.Logf("%+v", infos)
+	if len(infos) != 3 {
+		t.Fatalf("got %d infos, wanted 3", len(infos))
+	}
+	if !infos[0].National {
+		t.Errorf("NV: not national: %+v", infos[0])
+	}
+	if got, want := infos[1].ObjectTypeName, "SYS.ODCIVARCHAR2LIST"; got != want {
+		t.Errorf("LST: got %q, wanted %q", got, want)
+	}
+	if got := infos[1].TypeName; got != infos[1].ObjectTypeName {
+		t.Errorf("LST: got type name %q, wanted %q", got, infos[1].ObjectTypeName)
+	}
+}
 
-FUNCTION in_num(p_num IN num_tab_typ) RETURN VARCHAR2 IS
-  v_idx PLS_INTEGER;
-  v_res VARCHAR2(32767);
-BEGIN
-  v_idx := p_num.FIRST;
-  WHILE v_idx IS NOT NULL LOOP
-    v_res := v_res||v_idx||':'||p_num(v_idx)||CHR(10);
-    v_idx := p_num.NEXT(v_idx);
-  END LOOP;
-  RETURN(v_res);
-END;
+func TestParallelQuery(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("ParallelQuery"), 30*time.Second)
+	defer cancel()
 
-FUNCTION in_vc(p_vc IN vc_tab_typ) RETURN VARCHAR2 IS
-  v_idx PLS_INTEGER;
-  v_res VARCHAR2(32767);
-BEGIN
-  v_idx := p_vc.FIRST;
-  WHILE v_idx IS NOT NULL LOOP
-    v_res := v_res||v_idx||':'||p_vc(v_idx)||CHR(10);
-    v_idx := p_vc.NEXT(v_idx);
-  END LOOP;
-  RETURN(v_res);
-END;
-FUNCTION in_dt(p_dt IN dt_tab_typ) RETURN VARCHAR2 IS
-  v_idx PLS_INTEGER;
-  v_res VARCHAR2(32767);
-BEGIN
-  v_idx := p_dt.FIRST;
-  WHILE v_idx IS NOT NULL LOOP
-    v_res := v_res||v_idx||':'||TO_CHAR(p_dt(v_idx), 'YYYY-MM-DD"T"HH24:MI:SS')||CHR(10);
-    v_idx := p_dt.NEXT(v_idx);
-  END LOOP;
-  RETURN(v_res);
-END;
-FUNCTION in_ids(p_dur IN ids_tab_typ) RETURN VARCHAR2 IS
-  V_idx PLS_INTEGER;
-  v_res VARCHAR2(32767);
-BEGIN
-  v_idx := p_dur.FIRST;
-  WHILE v_idx IS NOT NULL LOOP
-    v_res := v_res||v_idx||':'||TO_CHAR(p_dur(v_idx), 'YYYY-MM-DD"T"HH24:MI:SS')||CHR(10);
-    v_idx := p_dur.NEXT(v_idx);
-  END LOOP;
-  RETURN(v_res);
-END;
-END;
-`
-	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		t.Fatal(err, qry)
-	}
-	compileErrors, err := godror.GetCompileErrors(ctx, testDb, false)
-	if err != nil {
-		t.Fatal(err)
+	const qry = "SELECT :1 * 100 + LEVEL FROM DUAL CONNECT BY LEVEL <= 10"
+	splits := make([]godror.QuerySplit, 4)
+	for i := range splits {
+		splits[i] = godror.QuerySplit{Query: qry, Args: []interface{}{i}}
 	}
-	if len(compileErrors) != 0 {
-		t.Logf("compile errors: %v", compileErrors)
-		for _, ce := range compileErrors {
-			if strings.Contains(ce.Error(), pkg) {
-				t.Fatal(ce)
-			}
+	seen := make(map[int]int)
+	if err := godror.ParallelQuery(ctx, testDb, splits, 2, func(split int, scan func(...interface{}) error) error {
+		var n int
+		if err := scan(&n); err != nil {
+			return err
 		}
-	}
-	serverTZ := time.Local
-	if err = godror.Raw(ctx, testDb, func(conn godror.Conn) error {
-		serverTZ = conn.Timezone()
+		if n/100 != split {
+			return fmt.Errorf("got %d from split %d", n, split)
+		}
+		seen[n]++
 		return nil
 	}); err != nil {
 		t.Fatal(err)
 	}
+	if len(seen) != 40 {
+		t.Errorf("got %d distinct rows, wanted 40", len(seen))
+	}
 
-	tx, err := testDb.BeginTx(ctx, nil)
-	if err != nil {
+	tbl := "test_parallel" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" AS SELECT LEVEL AS id FROM DUAL CONNECT BY LEVEL <= 1000"); err != nil {
 		t.Fatal(err)
 	}
-	defer tx.Rollback()
-	qry = "ALTER SESSION SET time_zone = 'UTC'"
-	if _, err = tx.ExecContext(ctx, qry); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
+	if splits, err := godror.RowidSplits(ctx, testDb, tbl, "id", 4); err != nil {
+		t.Error(err)
+	} else {
+		var n int
+		if err := godror.ParallelQuery(ctx, testDb, splits, 0, func(_ int, _ func(...interface{}) error) error {
+			n++
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if n != 1000 {
+			t.Errorf("got %d rows from %d splits, wanted 1000", n, len(splits))
+		}
 	}
 
-	epoch := time.Date(2017, 11, 20, 12, 14, 21, 0, time.UTC)
-	epochPlus := epoch.AddDate(0, -6, 0)
-	const timeFmt = "2006-01-02T15:04:05"
-	_ = epoch
-	for name, tC := range map[string]struct {
-		In   interface{}
-		Want string
-	}{
-		// "int_0":{In:[]int32{}, Want:""},
-		"num_0": {In: []godror.Number{}, Want: ""},
-		"vc_0":  {In: []string{}, Want: ""},
-		"dt_0":  {In: []time.Time{}, Want: ""},
-		"dt_00": {In: []godror.NullTime{}, Want: ""},
-
-		"num_3": {
-			In:   []godror.Number{"1", "2.72", "-3.14"},
-			Want: "1:1\n2:2.72\n3:-3.14\n",
-		},
-		"vc_3": {
-			In:   []string{"a", "", "cCc"},
-			Want: "1:a\n2:\n3:cCc\n",
-		},
-		"dt_2": {
-			In: []time.Time{epoch, epochPlus},
-			Want: ("1:" + epoch.In(serverTZ).Format(timeFmt) + "\n" +
-				"2:" + epochPlus.In(serverTZ).Format(timeFmt) + "\n"),
-		},
-		"dt_02": {
-			In: []godror.NullTime{{Valid: true, Time: epoch},
-				{Valid: true, Time: epochPlus}},
-			Want: ("1:" + epoch.In(serverTZ).Format(timeFmt) + "\n" +
-				"2:" + epochPlus.In(serverTZ).Format(timeFmt) + "\n"),
-		},
-
-		// "ids_1": { In:   []time.Duration{32 * time.Second}, Want: "1:32s\n", },
-	} {
-		typ := strings.SplitN(name, "_", 2)[0]
-		qry := "BEGIN :1 := " + pkg + ".in_" + typ + "(:2); END;"
-		var res string
-		if _, err := tx.ExecContext(ctx, qry, godror.PlSQLArrays,
-			sql.Out{Dest: &res}, tC.In,
-		); err != nil {
-			t.Error(fmt.Errorf("%q. %s %+v: %w", name, qry, tC.In, err))
-		}
-		t.Logf("%q. %q", name, res)
-		if typ == "num" {
-			res = strings.Replace(res, ",", ".", -1)
+	// the splits span several segments (partitions)
+	tbl = "test_parallel_part" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" PARTITION BY HASH (id) PARTITIONS 4 AS SELECT LEVEL AS id FROM DUAL CONNECT BY LEVEL <= 1000"); err != nil {
+		t.Skip(err)
+	}
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
+	if splits, err := godror.RowidSplits(ctx, testDb, tbl, "id", 3); err != nil {
+		t.Error(err)
+	} else {
+		var n int
+		if err := godror.ParallelQuery(ctx, testDb, splits, 0, func(_ int, _ func(...interface{}) error) error {
+			n++
+			return nil
+		}); err != nil {
+			t.Fatal(err)
 		}
-		if res != tC.Want {
-			t.Errorf("%q. got %q, wanted %q.", name, res, tC.Want)
+		if n != 1000 {
+			t.Errorf("partitioned: got %d rows from %d splits, wanted 1000", n, len(splits))
 		}
 	}
 }
 
-func TestDbmsOutput(t *testing.T) {
-	defer tl.enableLogging(t)()
-	ctx, cancel := context.WithTimeout(testContext("DbmsOutput"), 10*time.Second)
+func TestBindHook(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("BindHook"), 10*time.Second)
 	defer cancel()
 
-	conn, err := testDb.Conn(ctx)
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer conn.Close()
-	if err := godror.EnableDbmsOutput(ctx, conn); err != nil {
-		t.Fatal(err)
-	}
-
-	txt := `árvíztűrő tükörfúrógép`
-	qry := "BEGIN DBMS_OUTPUT.PUT_LINE('" + txt + "'); END;"
-	if _, err := conn.ExecContext(ctx, qry); err != nil {
-		t.Fatal(err)
+	var got []godror.BindValue
+	ctx = godror.ContextWithBindHook(ctx, func(_ context.Context, qry string, binds []godror.BindValue) {
+		got = append(got[:0], binds...)
+	}, godror.RedactPolicy{Names: []string{"secret"}})
+	const qry = "SELECT :plain||:secret FROM DUAL"
+	var s string
+	if err := testDb.QueryRowContext(ctx, qry, sql.Named("plain", "a"), sql.Named("secret", "b")).Scan(&s); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-
-	var buf bytes.Buffer
-	if err := godror.ReadDbmsOutput(ctx, &buf, conn); err != nil {
-		t.Error(err)
+	if s != "ab" {
+		t.Errorf("got %q, wanted ab", s)
 	}
-	t.Log(buf.String())
-	if buf.String() != txt+"\n" {
-		t.Errorf("got\n%q, wanted\n%q", buf.String(), txt+"\n")
+	t.Logf("%+v", got)
+	if len(got) != 2 || got[0].Value != "a" || got[1].Value != "***" {
+		t.Errorf("got %+v, wanted a and ***", got)
 	}
 }
 
-func TestInOutArray(t *testing.T) {
+func TestSQLBoolean(t *testing.T) {
 	t.Parallel()
-	defer tl.enableLogging(t)()
-
-	ctx, cancel := context.WithTimeout(testContext("InOutArray"), 20*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("SQLBoolean"), 30*time.Second)
 	defer cancel()
 
-	pkg := strings.ToUpper("test_pkg" + tblSuffix)
-	qry := `CREATE OR REPLACE PACKAGE ` + pkg + ` AS
-TYPE int_tab_typ IS TABLE OF BINARY_INTEGER INDEX BY PLS_INTEGER;
-TYPE num_tab_typ IS TABLE OF NUMBER INDEX BY PLS_INTEGER;
-TYPE vc_tab_typ IS TABLE OF VARCHAR2(100) INDEX BY PLS_INTEGER;
-TYPE dt_tab_typ IS TABLE OF DATE INDEX BY PLS_INTEGER;
-TYPE lob_tab_typ IS TABLE OF CLOB INDEX BY PLS_INTEGER;
+	clientVersion, _ := godror.ClientVersion(ctx, testDb)
+	serverVersion, _ := godror.ServerVersion(ctx, testDb)
+	if clientVersion.Version < 23 || serverVersion.Version < 23 {
+		t.Skipf("client=%d or server=%d < 23", clientVersion.Version, serverVersion.Version)
+	}
 
-PROCEDURE inout_int(p_int IN OUT int_tab_typ);
-PROCEDURE inout_num(p_num IN OUT num_tab_typ);
-PROCEDURE inout_vc(p_vc IN OUT vc_tab_typ);
-PROCEDURE inout_dt(p_dt IN OUT dt_tab_typ);
-PROCEDURE p2(
-	--p_int IN OUT int_tab_typ,
-	p_num IN OUT num_tab_typ, p_vc IN OUT vc_tab_typ, p_dt IN OUT dt_tab_typ);
-END;
-`
-	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		t.Fatal(err, qry)
+	tbl := "test_sqlbool" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER(3), b BOOLEAN)"); err != nil {
+		t.Fatal(err)
 	}
-	defer testDb.Exec("DROP PACKAGE " + pkg)
-
-	qry = `CREATE OR REPLACE PACKAGE BODY ` + pkg + ` AS
-PROCEDURE inout_int(p_int IN OUT int_tab_typ) IS
-  v_idx PLS_INTEGER;
-BEGIN
-  DBMS_OUTPUT.PUT_LINE('p_int.COUNT='||p_int.COUNT||' FIRST='||p_int.FIRST||' LAST='||p_int.LAST);
-  v_idx := p_int.FIRST;
-  WHILE v_idx IS NOT NULL LOOP
-    p_int(v_idx) := NVL(p_int(v_idx) * 2, 1);
-	v_idx := p_int.NEXT(v_idx);
-  END LOOP;
-  p_int(NVL(p_int.LAST, 0)+1) := p_int.COUNT;
-END;
-
-PROCEDURE inout_num(p_num IN OUT num_tab_typ) IS
-  v_idx PLS_INTEGER;
-BEGIN
-  DBMS_OUTPUT.PUT_LINE('p_num.COUNT='||p_num.COUNT||' FIRST='||p_num.FIRST||' LAST='||p_num.LAST);
-  v_idx := p_num.FIRST;
-  WHILE v_idx IS NOT NULL LOOP
-    p_num(v_idx) := NVL(p_num(v_idx) / 2, 0.5);
-	v_idx := p_num.NEXT(v_idx);
-  END LOOP;
-  p_num(NVL(p_num.LAST, 0)+1) := p_num.COUNT;
-END;
-
-PROCEDURE inout_vc(p_vc IN OUT vc_tab_typ) IS
-  v_idx PLS_INTEGER;
-BEGIN
-  DBMS_OUTPUT.PUT_LINE('p_vc.COUNT='||p_vc.COUNT||' FIRST='||p_vc.FIRST||' LAST='||p_vc.LAST);
-  v_idx := p_vc.FIRST;
-  WHILE v_idx IS NOT NULL LOOP
-    p_vc(v_idx) := NVL(p_vc(v_idx) ||' +', '-');
-	v_idx := p_vc.NEXT(v_idx);
-  END LOOP;
-  p_vc(NVL(p_vc.LAST, 0)+1) := p_vc.COUNT;
-END;
-
-PROCEDURE inout_dt(p_dt IN OUT dt_tab_typ) IS
-  v_idx PLS_INTEGER;
-BEGIN
-  DBMS_OUTPUT.PUT_LINE('p_dt.COUNT='||p_dt.COUNT||' FIRST='||p_dt.FIRST||' LAST='||p_dt.LAST);
-  v_idx := p_dt.FIRST;
-  WHILE v_idx IS NOT NULL LOOP
-    DBMS_OUTPUT.PUT_LINE(v_idx||'='||TO_CHAR(p_dt(v_idx), 'YYYY-MM-DD HH24:MI:SS'));
-    p_dt(v_idx) := NVL(p_dt(v_idx) + 1, TRUNC(SYSDATE)-v_idx);
-	v_idx := p_dt.NEXT(v_idx);
-  END LOOP;
-  p_dt(NVL(p_dt.LAST, 0)+1) := TRUNC(SYSDATE);
-  DBMS_OUTPUT.PUT_LINE('p_dt.COUNT='||p_dt.COUNT||' FIRST='||p_dt.FIRST||' LAST='||p_dt.LAST);
-END;
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
 
-PROCEDURE p2(
-	--p_int IN OUT int_tab_typ,
-	p_num IN OUT num_tab_typ,
-	p_vc IN OUT vc_tab_typ,
-	p_dt IN OUT dt_tab_typ
---, p_lob IN OUT lob_tab_typ
-) IS
-BEGIN
-  --inout_int(p_int);
-  inout_num(p_num);
-  inout_vc(p_vc);
-  inout_dt(p_dt);
-  --p_lob := NULL;
-END p2;
-END;
-`
-	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		t.Fatal(err, qry)
+	qry := "INSERT INTO " + tbl + " (id, b) VALUES (:1, :2)"
+	if _, err := testDb.ExecContext(ctx, qry, 1, true); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
 	}
-	compileErrors, err := godror.GetCompileErrors(ctx, testDb, false)
+	if _, err := testDb.ExecContext(ctx, qry,
+		[]int{2, 3, 4, 5},
+		[]sql.NullBool{{Valid: true, Bool: true}, {Valid: true}, {}, {Valid: true, Bool: true}},
+	); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	if _, err := testDb.ExecContext(ctx, qry, []int{6, 7}, []bool{true, false}); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+
+	qry = "SELECT id, b FROM " + tbl + " ORDER BY id"
+	rows, err := testDb.QueryContext(ctx, qry)
 	if err != nil {
-		t.Fatal(err)
+		t.Fatalf("%s: %+v", qry, err)
 	}
-	if len(compileErrors) != 0 {
-		t.Logf("compile errors: %v", compileErrors)
-		for _, ce := range compileErrors {
-			if strings.Contains(ce.Error(), pkg) {
-				t.Fatal(ce)
-			}
+	defer rows.Close()
+	want := []sql.NullBool{{Valid: true, Bool: true}, {Valid: true, Bool: true}, {Valid: true}, {}, {Valid: true, Bool: true}, {Valid: true, Bool: true}, {Valid: true}}
+	var got []sql.NullBool
+	for rows.Next() {
+		var id int
+		var b sql.NullBool
+		if err := rows.Scan(&id, &b); err != nil {
+			t.Fatal(err)
 		}
+		got = append(got, b)
 	}
-
-	intgr := []int32{3, 1, 4, 0, 0}[:3]
-	intgrWant := []int32{3 * 2, 1 * 2, 4 * 2, 3}
-	_ = intgrWant
-	num := []godror.Number{"3.14", "-2.48", ""}[:2]
-	numWant := []godror.Number{"1.57", "-1.24", "2"}
-	vc := []string{"string", "bring", ""}[:2]
-	vcWant := []string{"string +", "bring +", "2"}
-	var today time.Time
-	qry = "SELECT TRUNC(SYSDATE) FROM DUAL"
-	if testDb.QueryRowContext(ctx, qry).Scan(&today); err != nil {
+	if err := rows.Err(); err != nil {
 		t.Fatal(err)
 	}
-	dt := []time.Time{
-		time.Date(2017, 6, 18, 7, 5, 51, 0, time.Local),
-		{},
-		today.Add(-2 * 24 * time.Hour),
-		today,
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(d)
 	}
-	dt[1] = dt[0].Add(24 * time.Hour)
-	dtWant := make([]time.Time, len(dt))
-	for i, d := range dt {
-		if i < len(dt)-1 {
-			// p_dt(v_idx) := NVL(p_dt(v_idx) + 1, TRUNC(SYSDATE)-v_idx);
-			dtWant[i] = d.AddDate(0, 0, 1)
-		} else {
-			// p_dt(NVL(p_dt.LAST, 0)+1) := TRUNC(SYSDATE);
-			dtWant[i] = d
-		}
+
+	var b bool
+	qry = "SELECT b FROM " + tbl + " WHERE id = :1 AND b = :2"
+	if err := testDb.QueryRowContext(ctx, qry, 1, true).Scan(&b); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
 	}
-	dt = dt[:len(dt)-1]
+	if !b {
+		t.Error("got false, wanted true")
+	}
+}
+
+func TestOpenCursors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("OpenCursors"), 10*time.Second)
+	defer cancel()
 
 	conn, err := testDb.Conn(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer conn.Close()
-	if _, err = conn.ExecContext(ctx, "ALTER SESSION SET time_zone = local"); err != nil {
+	var before int
+	if err = godror.Raw(ctx, conn, func(c godror.Conn) error { before = c.(godror.OpenCursorsConn).OpenCursorCount(); return nil }); err != nil {
 		t.Fatal(err)
 	}
-	godror.EnableDbmsOutput(ctx, conn)
-
-	opts := []cmp.Option{
-		cmp.Comparer(func(x, y time.Time) bool { return x.Equal(y) }),
-	}
-
-	for _, tC := range []struct {
-		In, Want interface{}
-		Name     string
-	}{
-		{Name: "vc", In: vc, Want: vcWant},
-		{Name: "num", In: num, Want: numWant},
-		{Name: "dt", In: dt, Want: dtWant},
-		// {Name: "int", In: intgr, Want: intgrWant},
-		{Name: "vc-1", In: vc[:1], Want: []string{"string +", "1"}},
-		{Name: "vc-0", In: vc[:0], Want: []string{"0"}},
-	} {
-		tC := tC
-		t.Run("inout_"+tC.Name, func(t *testing.T) {
-			t.Logf("%s=%s", tC.Name, tC.In)
-			nm := strings.SplitN(tC.Name, "-", 2)[0]
-			qry = "BEGIN " + pkg + ".inout_" + nm + "(:1); END;"
-			dst := copySlice(tC.In)
-			if _, err := conn.ExecContext(ctx, qry,
-				godror.PlSQLArrays,
-				sql.Out{Dest: dst, In: true},
-			); err != nil {
-				t.Fatalf("%s\n%#v\n%+v", qry, dst, err)
-			}
-			got := reflect.ValueOf(dst).Elem().Interface()
-			if nm == "dt" {
-				t.Logf("\nin =%v\ngot=%v\nwt= %v", tC.In, got, tC.Want)
-			}
-
-			if cmp.Equal(got, tC.Want, opts...) {
-				return
-			}
-			t.Errorf("%s: %s", tC.Name, cmp.Diff(printSlice(tC.Want), printSlice(got)))
-			var buf bytes.Buffer
-			if err := godror.ReadDbmsOutput(ctx, &buf, conn); err != nil {
-				t.Error(err)
-			}
-			t.Log("OUTPUT:", buf.String())
-		})
+	const qry = "SELECT 1 FROM DUAL"
+	stmt, err := conn.PrepareContext(ctx, qry)
+	if err != nil {
+		t.Fatal(err)
 	}
-
-	// lob := []godror.Lob{godror.Lob{IsClob: true, Reader: strings.NewReader("abcdef")}}
-	t.Run("p2", func(t *testing.T) {
-		if _, err := conn.ExecContext(ctx,
-			"BEGIN "+pkg+".p2(:1, :2, :3); END;",
-			godror.PlSQLArrays,
-			// sql.Out{Dest: &intgr, In: true},
-			sql.Out{Dest: &num, In: true},
-			sql.Out{Dest: &vc, In: true},
-			sql.Out{Dest: &dt, In: true},
-			// sql.Out{Dest: &lob, In: true},
-		); err != nil {
-			t.Fatal(err)
-		}
-		t.Logf("int=%#v num=%#v vc=%#v dt=%#v", intgr, num, vc, dt)
-		// if d := cmp.Diff(intgr, intgrWant); d != "" {
-		//	t.Errorf("int: %s", d)
-		// }
-		if d := cmp.Diff(num, numWant); d != "" {
-			t.Errorf("num: %s", d)
-		}
-		if d := cmp.Diff(vc, vcWant); d != "" {
-			t.Errorf("vc: %s", d)
+	if err = godror.Raw(ctx, conn, func(c godror.Conn) error {
+		cursors := c.(godror.OpenCursorsConn).OpenCursors()
+		t.Logf("%+v", cursors)
+		if len(cursors) != before+1 || cursors[len(cursors)-1].Query != qry {
+			t.Errorf("got %+v, wanted %d cursors with %q", cursors, before+1, qry)
 		}
-		if !cmp.Equal(dt, dtWant, opts...) {
-			if d := cmp.Diff(dt, dtWant); d != "" {
-				t.Errorf("dt: %s", d)
-			}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	stmt.Close()
+	if err = godror.Raw(ctx, conn, func(c godror.Conn) error {
+		if n := c.(godror.OpenCursorsConn).OpenCursorCount(); n != before {
+			t.Errorf("closed, but got %d cursors, wanted %d", n, before)
 		}
-	})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
 }
 
-func TestOutParam(t *testing.T) {
+func TestClientResultCache(t *testing.T) {
 	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("OutParam"), 10*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("ClientResultCache"), 10*time.Second)
 	defer cancel()
-	conn, err := testDb.Conn(ctx)
+
+	settings, err := godror.GetClientResultCacheSettings(ctx, testDb)
 	if err != nil {
-		t.Fatal(err)
-	}
-	defer conn.Close()
-	if _, err = conn.ExecContext(ctx, "ALTER SESSION SET time_zone = local"); err != nil {
-		t.Fatal(err)
+		t.Skip(err)
 	}
-	pkg := strings.ToUpper("test_p1" + tblSuffix)
-	qry := `CREATE OR REPLACE PROCEDURE
-` + pkg + `(p_int IN OUT INTEGER, p_num IN OUT NUMBER, p_vc IN OUT VARCHAR2, p_dt IN OUT DATE, p_lob IN OUT CLOB)
-IS
-BEGIN
-  p_int := NVL(p_int * 2, 1);
-  p_num := NVL(p_num / 2, 0.5);
-  p_vc := NVL(p_vc ||' +', '-');
-  p_dt := NVL(p_dt + 1, SYSDATE);
-  p_lob := NULL;
-END;`
-	if _, err = conn.ExecContext(ctx, qry); err != nil {
-		t.Fatal(err, qry)
+	t.Logf("settings: %+v", settings)
+	qry := godror.ResultCacheHint("SELECT COUNT(0) FROM all_objects WHERE ROWNUM <= 10")
+	for i := 0; i < 3; i++ {
+		var n int
+		if err := testDb.QueryRowContext(ctx, qry).Scan(&n); err != nil {
+			t.Fatalf("%s: %+v", qry, err)
+		}
 	}
-	defer testDb.Exec("DROP PROCEDURE " + pkg)
-
-	qry = "BEGIN " + pkg + "(:1, :2, :3, :4, :5); END;"
-	stmt, err := conn.PrepareContext(ctx, qry)
+	stats, err := godror.GetClientResultCacheStats(ctx, testDb)
 	if err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+		t.Skip(err)
 	}
-	defer stmt.Close()
+	t.Logf("stats: %+v", stats)
+	if settings.Size == 0 && stats.FindCount != 0 {
+		t.Errorf("client result cache is disabled, but got %d finds", stats.FindCount)
+	}
+}
 
-	var intgr int = 3
-	num := godror.Number("3.14")
-	var vc string = "string"
-	var dt time.Time = time.Date(2017, 6, 18, 7, 5, 51, 0, time.Local)
-	var lob godror.Lob = godror.Lob{IsClob: true, Reader: strings.NewReader("abcdef")}
-	if _, err := stmt.ExecContext(ctx,
-		sql.Out{Dest: &intgr, In: true},
-		sql.Out{Dest: &num, In: true},
-		sql.Out{Dest: &vc, In: true},
-		sql.Out{Dest: &dt, In: true},
-		sql.Out{Dest: &lob, In: true},
-	); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
-	}
-	t.Logf("int=%#v num=%#v vc=%#v dt=%#v", intgr, num, vc, dt)
-	if intgr != 6 {
-		t.Errorf("int: got %d, wanted %d", intgr, 6)
-	}
-	if num != "1.57" {
-		t.Errorf("num: got %q, wanted %q", num, "1.57")
-	}
-	if vc != "string +" {
-		t.Errorf("vc: got %q, wanted %q", vc, "string +")
-	}
-}
-
-func TestSelectRefCursor(t *testing.T) {
+func TestFetchMemory(t *testing.T) {
 	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("SelectRefCursor"), 10*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("FetchMemory"), 30*time.Second)
 	defer cancel()
-	rows, err := testDb.QueryContext(ctx, "SELECT CURSOR(SELECT object_name, object_type, object_id, created FROM all_objects WHERE ROWNUM <= 10) FROM DUAL")
+
+	cols := make([]string, 20)
+	for i := range cols {
+		cols[i] = fmt.Sprintf("TO_CLOB('%d') AS c%d", i, i)
+	}
+	qry := "SELECT " + strings.Join(cols, ", ") + " FROM DUAL CONNECT BY LEVEL <= 300"
+	rows, err := testDb.QueryContext(ctx, qry,
+		godror.FetchArraySize(1000), godror.FetchMemory(4<<20))
 	if err != nil {
-		t.Fatal(err)
+		t.Fatalf("%s: %+v", qry, err)
 	}
 	defer rows.Close()
+	dest := make([]interface{}, len(cols))
+	vals := make([]string, len(cols))
+	for i := range dest {
+		dest[i] = &vals[i]
+	}
+	var n int
 	for rows.Next() {
-		var sub driver.Rows
-		if err := rows.Scan(&sub); err != nil {
-			sub.Close()
+		if err := rows.Scan(dest...); err != nil {
 			t.Fatal(err)
 		}
-		defer sub.Close()
-		t.Logf("%[1]T %[1]p", sub)
-		cols := sub.(driver.RowsColumnTypeScanType).Columns()
-		t.Log("Columns", cols)
-		dests := make([]driver.Value, len(cols))
-		for {
-			if err := sub.Next(dests); err != nil {
-				if err == io.EOF {
-					break
-				}
-				sub.Close()
-				t.Error(err)
-				break
-			}
-			// fmt.Println(dests)
-			t.Log(dests)
+		if vals[len(vals)-1] != strconv.Itoa(len(vals)-1) {
+			t.Errorf("%d. got %q", n, vals)
 		}
-		sub.Close()
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 300 {
+		t.Errorf("got %d rows, wanted 300", n)
 	}
-	// Test the Finalizers
-	runtime.GC()
 }
 
-func TestSelectRefCursorWrap(t *testing.T) {
-	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("SelectRefCursorWrap"), 10*time.Second)
+func TestPreparedQuery(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("PreparedQuery"), 30*time.Second)
 	defer cancel()
-	rows, err := testDb.QueryContext(ctx, "SELECT CURSOR(SELECT object_name, object_type, object_id, created FROM all_objects WHERE ROWNUM <= 10) FROM DUAL")
+
+	pkg := "test_pq" + tblSuffix
+	crea := func(n int) {
+		for _, qry := range []string{
+			"CREATE OR REPLACE PACKAGE " + pkg + " AS g_cnt PLS_INTEGER := 0; FUNCTION next RETURN PLS_INTEGER; END;",
+			"CREATE OR REPLACE PACKAGE BODY " + pkg + " AS FUNCTION next RETURN PLS_INTEGER IS BEGIN g_cnt := g_cnt + " + strconv.Itoa(n) + "; RETURN g_cnt; END; END;",
+		} {
+			if _, err := testDb.ExecContext(ctx, qry); err != nil {
+				t.Fatalf("%s: %+v", qry, err)
+			}
+		}
+	}
+	crea(1)
+	defer testDb.ExecContext(context.Background(), "DROP PACKAGE "+pkg)
+
+	pq, err := godror.NewPreparedQuery(ctx, testDb, "BEGIN :1 := "+pkg+".next; END;")
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var intf driver.Rows
-		if err := rows.Scan(&intf); err != nil {
-			t.Error(err)
-			continue
-		}
-		t.Logf("%[1]T %[1]p", intf)
-		dr := intf
-		sub, err := godror.WrapRows(ctx, testDb, dr)
-		if err != nil {
-			dr.Close()
+	defer pq.Close()
+	var n int
+	for i := 0; i < 3; i++ {
+		if _, err = pq.ExecContext(ctx, sql.Out{Dest: &n}); err != nil {
 			t.Fatal(err)
 		}
-		t.Log("Sub", sub)
-		for sub.Next() {
-			var oName, oType, oID string
-			var created time.Time
-			if err := sub.Scan(&oName, &oType, &oID, &created); err != nil {
-				dr.Close()
-				sub.Close()
-				t.Error(err)
-				break
-			}
-			t.Log(oName, oType, oID, created)
-		}
-		dr.Close()
-		sub.Close()
 	}
-	// Test the Finalizers
-	runtime.GC()
+	// discard the package state
+	crea(2)
+	if _, err = pq.ExecContext(ctx, sql.Out{Dest: &n}); err != nil {
+		t.Fatalf("after recompilation: %+v", err)
+	}
+	t.Log("n:", n)
 }
 
-func TestExecRefCursor(t *testing.T) {
+func TestInsertReturningID(t *testing.T) {
 	t.Parallel()
-	defer tl.enableLogging(t)()
-	ctx, cancel := context.WithTimeout(testContext("ExecRefCursor"), 30*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("InsertReturningID"), 30*time.Second)
 	defer cancel()
-	funName := "test_rc" + tblSuffix
-	funQry := "CREATE OR REPLACE FUNCTION " + funName + ` RETURN SYS_REFCURSOR IS
-  v_cur SYS_REFCURSOR;
-BEGIN
-  OPEN v_cur FOR SELECT object_name FROM all_objects WHERE ROWNUM < 10;
-  RETURN(v_cur);
-END;`
-	if _, err := testDb.ExecContext(ctx, funQry); err != nil {
-		t.Fatalf("%s: %v", funQry, err)
+	tbl := "test_insert_id" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER GENERATED ALWAYS AS IDENTITY, a VARCHAR2(10))"); err != nil {
+		if strings.Contains(err.Error(), "ORA-02000") {
+			t.Skip(err)
+		}
+		t.Fatal(err)
 	}
-	defer testDb.ExecContext(ctx, `DROP FUNCTION `+funName)
-	qry := "BEGIN :1 := " + funName + "; END;"
-	var dr driver.Rows
-	if _, err := testDb.ExecContext(ctx, qry, sql.Out{Dest: &dr}); err != nil {
-		t.Fatalf("%s: %v", qry, err)
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
+
+	qry := "INSERT INTO " + tbl + " (a) VALUES (:1)"
+	id, err := godror.InsertReturningID(ctx, testDb, qry, "id", "a")
+	if err != nil {
+		t.Fatal(err)
 	}
-	defer dr.Close()
-	sub, err := godror.WrapRows(ctx, testDb, dr)
+	ids, err := godror.InsertReturningIDs(ctx, testDb, qry, "id", []string{"b", "c", "d"})
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer sub.Close()
-	t.Log("Sub", sub)
-	for sub.Next() {
-		var s string
-		if err := sub.Scan(&s); err != nil {
-			t.Fatal(err)
+	t.Log("id:", id, "ids:", ids)
+	if len(ids) != 3 {
+		t.Fatalf("got %d ids, wanted 3", len(ids))
+	}
+	for i, x := range ids {
+		if x <= id || i != 0 && x <= ids[i-1] {
+			t.Errorf("%d. id %d is not increasing (%d, %v)", i, x, id, ids)
 		}
-		t.Log(s)
 	}
-	runtime.GC()
-	time.Sleep(time.Second)
-	runtime.GC()
+	var a string
+	if err = testDb.QueryRowContext(ctx, "SELECT a FROM "+tbl+" WHERE id = :1", ids[1]).Scan(&a); err != nil {
+		t.Fatal(err)
+	}
+	if a != "c" {
+		t.Errorf("got %q for %d, wanted %q", a, ids[1], "c")
+	}
 }
 
-func TestExecuteMany(t *testing.T) {
+func TestWatchLongOps(t *testing.T) {
 	t.Parallel()
-	defer tl.enableLogging(t)()
-
-	ctx, cancel := context.WithTimeout(testContext("ExecuteMany"), 30*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("WatchLongOps"), 30*time.Second)
 	defer cancel()
-	tbl := "test_em" + tblSuffix
-	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
-	testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (f_id INTEGER, f_int INTEGER, f_num NUMBER, f_num_6 NUMBER(6), F_num_5_2 NUMBER(5,2), f_vc VARCHAR2(30), F_dt DATE)")
-	defer testDb.Exec("DROP TABLE " + tbl)
-
-	const num = 1000
-	ints := make([]int, num)
-	nums := make([]godror.Number, num)
-	int32s := make([]int32, num)
-	floats := make([]float64, num)
-	strs := make([]string, num)
-	dates := make([]time.Time, num)
-
-	tx, err := testDb.BeginTx(ctx, nil)
+	conn, err := testDb.Conn(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer tx.Rollback()
-	if _, err = tx.ExecContext(ctx, "ALTER SESSION SET time_zone = local"); err != nil {
+	defer conn.Close()
+	session, err := godror.CurrentSessionID(ctx, conn)
+	if err != nil {
 		t.Fatal(err)
 	}
-	// This is instead of now: a nice moment in time right before the summer time shift
-	now := time.Date(2017, 10, 29, 1, 27, 53, 0, time.Local).Truncate(time.Second)
-	ids := make([]int, num)
-	for i := range nums {
-		ids[i] = i
-		ints[i] = i << 1
-		nums[i] = godror.Number(strconv.Itoa(i))
-		int32s[i] = int32(i)
-		floats[i] = float64(i) / float64(3.14)
-		strs[i] = fmt.Sprintf("%x", i)
-		dates[i] = now.Add(-time.Duration(i) * time.Hour)
-	}
-	for i, tc := range []struct {
-		Value interface{}
-		Name  string
-	}{
-		{Name: "f_int", Value: ints},
-		{Name: "f_num", Value: nums},
-		{Name: "f_num_6", Value: int32s},
-		{Name: "f_num_5_2", Value: floats},
-		{Name: "f_vc", Value: strs},
-		{Name: "f_dt", Value: dates},
-	} {
-		res, execErr := tx.ExecContext(ctx,
-			"INSERT INTO "+tbl+" ("+tc.Name+") VALUES (:1)", //nolint:gas
-			tc.Value)
-		if execErr != nil {
-			t.Fatalf("%d. INSERT INTO "+tbl+" (%q) VALUES (%+v): %#v", //nolint:gas
-				i, tc.Name, tc.Value, execErr)
+	t.Log("session:", session)
+
+	watchCtx, watchCancel := context.WithCancel(ctx)
+	defer watchCancel()
+	ch := godror.WatchLongOps(watchCtx, testDb, session, 200*time.Millisecond)
+	done := make(chan []godror.LongOpProgress, 1)
+	go func() {
+		var progs []godror.LongOpProgress
+		for p := range ch {
+			t.Logf("%s: %d/%d %s (%.0f%%)", p.OpName, p.SoFar, p.TotalWork, p.Units, p.Percent())
+			progs = append(progs, p)
 		}
-		ra, raErr := res.RowsAffected()
-		if raErr != nil {
-			t.Error(raErr)
-		} else if ra != num {
-			t.Errorf("%d. %q: wanted %d rows, got %d", i, tc.Name, num, ra)
+		done <- progs
+	}()
+
+	const qry = `DECLARE
+  v_rindex BINARY_INTEGER := DBMS_APPLICATION_INFO.set_session_longops_nohint;
+  v_slno BINARY_INTEGER;
+BEGIN
+  FOR i IN 1..5 LOOP
+    DBMS_APPLICATION_INFO.set_session_longops(rindex=>v_rindex, slno=>v_slno,
+      op_name=>'godror_test', target_desc=>'test', sofar=>i, totalwork=>5, units=>'steps');
+    DBMS_SESSION.SLEEP(0.5);
+  END LOOP;
+END;`
+	if _, err = conn.ExecContext(ctx, qry); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(500 * time.Millisecond)
+	watchCancel()
+	progs := <-done
+	if len(progs) == 0 {
+		t.Fatal("got no progress")
+	}
+	if last := progs[len(progs)-1]; last.Err != nil {
+		if strings.Contains(last.Err.Error(), "ORA-00942") {
+			t.Skip(last.Err)
 		}
+		t.Fatal(last.Err)
 	}
-	tx.Rollback()
-
-	testDb.ExecContext(ctx, "TRUNCATE TABLE "+tbl+"")
+	if last := progs[len(progs)-1]; last.OpName != "godror_test" || !last.Done() {
+		t.Errorf("got %+v, wanted godror_test done", last)
+	}
+}
 
-	if tx, err = testDb.BeginTx(ctx, nil); err != nil {
+func TestTPC(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("TPC"), 30*time.Second)
+	defer cancel()
+	tbl := "test_tpc" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER(3))"); err != nil {
 		t.Fatal(err)
 	}
-	defer tx.Rollback()
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
 
-	res, err := tx.ExecContext(ctx,
-		`INSERT INTO `+tbl+ //nolint:gas
-			` (f_id, f_int, f_num, f_num_6, F_num_5_2, F_vc, F_dt)
-			VALUES
-			(:1, :2, :3, :4, :5, :6, :7)`,
-		ids, ints, nums, int32s, floats, strs, dates)
+	conn, err := testDb.Conn(ctx)
 	if err != nil {
-		t.Fatalf("%#v", err)
+		t.Fatal(err)
 	}
-	ra, err := res.RowsAffected()
-	if err != nil {
-		t.Error(err)
-	} else if ra != num {
-		t.Errorf("wanted %d rows, got %d", num, ra)
+	defer conn.Close()
+	xid := godror.Xid{FormatID: 4242, GlobalTransactionID: []byte("godror-" + tbl), BranchQualifier: []byte("b1")}
+	if err = godror.Raw(ctx, conn, func(c godror.Conn) error {
+		return c.(godror.TPCConn).TPCBegin(xid, time.Minute, godror.TPCBeginNew)
+	}); err != nil {
+		t.Fatal(err)
 	}
-
-	rows, err := tx.QueryContext(ctx,
-		"SELECT * FROM "+tbl+" ORDER BY F_id", //nolint:gas
-	)
-	if err != nil {
+	if _, err = conn.ExecContext(ctx, "INSERT INTO "+tbl+" (id) VALUES (1)"); err != nil {
 		t.Fatal(err)
 	}
-	defer rows.Close()
-	i := 0
-	for rows.Next() {
-		var id, Int int
-		var num string
-		var vc string
-		var num6 int32
-		var num52 float64
-		var dt time.Time
-		if err := rows.Scan(&id, &Int, &num, &num6, &num52, &vc, &dt); err != nil {
-			t.Fatal(err)
+	var commitNeeded bool
+	if err = godror.Raw(ctx, conn, func(c godror.Conn) error {
+		if err := c.(godror.TPCConn).TPCEnd(xid, godror.TPCEndNormal); err != nil {
+			return err
 		}
-		if id != i {
-			t.Fatalf("ID got %d, wanted %d.", id, i)
+		var err error
+		commitNeeded, err = c.(godror.TPCConn).TPCPrepare(xid)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !commitNeeded {
+		t.Fatal("commit is not needed after an INSERT")
+	}
+	var n int
+	if err = testDb.QueryRowContext(ctx, "SELECT COUNT(0) FROM "+tbl).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("got %d rows before commit, wanted 0", n)
+	}
+	if err = godror.Raw(ctx, conn, func(c godror.Conn) error { return c.(godror.TPCConn).TPCCommit(xid, false) }); err != nil {
+		t.Fatal(err)
+	}
+	if err = testDb.QueryRowContext(ctx, "SELECT COUNT(0) FROM "+tbl).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("got %d rows after commit, wanted 1", n)
+	}
+}
+
+func TestTPCRecovery(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("TPCRecovery"), 30*time.Second)
+	defer cancel()
+	tbl := "test_tpc_recovery" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER(3))"); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
+
+	xid := godror.Xid{FormatID: 4242, GlobalTransactionID: []byte("godror-" + tbl), BranchQualifier: []byte("b1")}
+	func() {
+		conn, err := testDb.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
 		}
-		if Int != ints[i] {
-			t.Errorf("%d. INT got %d, wanted %d.", i, Int, ints[i])
+		defer conn.Close()
+		if err = godror.Raw(ctx, conn, func(c godror.Conn) error {
+			return c.(godror.TPCConn).TPCBegin(xid, time.Minute, godror.TPCBeginNew)
+		}); err != nil {
+			t.Fatal(err)
 		}
-		if num != string(nums[i]) {
-			t.Errorf("%d. NUM got %q, wanted %q.", i, num, nums[i])
+		if _, err = conn.ExecContext(ctx, "INSERT INTO "+tbl+" (id) VALUES (1)"); err != nil {
+			t.Fatal(err)
 		}
-		if num6 != int32s[i] {
-			t.Errorf("%d. NUM_6 got %v, wanted %v.", i, num6, int32s[i])
+		if err = godror.Raw(ctx, conn, func(c godror.Conn) error {
+			if err := c.(godror.TPCConn).TPCEnd(xid, godror.TPCEndNormal); err != nil {
+				return err
+			}
+			_, err := c.(godror.TPCConn).TPCPrepare(xid)
+			return err
+		}); err != nil {
+			t.Fatal(err)
 		}
-		rounded := float64(int64(floats[i]/0.005+0.5)) * 0.005
-		if math.Abs(num52-rounded) > 0.05 {
-			t.Errorf("%d. NUM_5_2 got %v, wanted %v.", i, num52, rounded)
+	}()
+
+	xids, err := godror.PendingXids(ctx, testDb)
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-00942") {
+			t.Skip(err)
 		}
-		if vc != strs[i] {
-			t.Errorf("%d. VC got %q, wanted %q.", i, vc, strs[i])
+		t.Fatal(err)
+	}
+	t.Log("pending:", xids)
+	var found bool
+	for _, x := range xids {
+		if found = x.String() == xid.String(); found {
+			break
 		}
-		t.Logf("%d. dt=%v", i, dt)
-		if !dt.Equal(dates[i]) {
-			if fmt.Sprintf("%v", dt) == "2017-10-29 02:27:53 +0100 CET" &&
-				fmt.Sprintf("%v", dates[i]) == "2017-10-29 00:27:53 +0000 UTC" {
-				t.Logf("%d. got DT %v, wanted %v (%v)", i, dt, dates[i], dt.Sub(dates[i]))
-			} else {
-				t.Errorf("%d. got DT %v, wanted %v (%v)", i, dt, dates[i], dt.Sub(dates[i]))
-			}
+	}
+	if !found {
+		t.Errorf("%s is not pending", xid)
+	}
+	if err = godror.CommitPrepared(ctx, testDb, xid); err != nil {
+		if strings.Contains(err.Error(), "ORA-01031") {
+			t.Skip(err)
 		}
-		i++
+		t.Fatal(err)
+	}
+	var n int
+	if err = testDb.QueryRowContext(ctx, "SELECT COUNT(0) FROM "+tbl).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("got %d rows after commit, wanted 1", n)
 	}
 }
-func TestReadWriteLOB(t *testing.T) {
+
+func TestAutonomous(t *testing.T) {
 	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("ReadWriteLob"), 30*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("Autonomous"), 30*time.Second)
 	defer cancel()
-	conn, err := testDb.Conn(ctx)
-	if err != nil {
+	tbl := "test_autonomous" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER(3))"); err != nil {
 		t.Fatal(err)
 	}
-	defer conn.Close()
-	tbl := "test_lob" + tblSuffix
-	conn.ExecContext(ctx, "DROP TABLE "+tbl)
-	conn.ExecContext(ctx,
-		"CREATE TABLE "+tbl+" (f_id NUMBER(6), f_blob BLOB, f_clob CLOB)", //nolint:gas
-	)
-	defer testDb.Exec(
-		"DROP TABLE " + tbl, //nolint:gas
-	)
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
 
-	stmt, err := conn.PrepareContext(ctx,
-		"INSERT INTO "+tbl+" (F_id, f_blob, F_clob) VALUES (:1, :2, :3)", //nolint:gas
-	)
+	tx, err := testDb.BeginTx(ctx, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer stmt.Close()
-
-	for tN, tC := range []struct {
-		String string
-		Bytes  []byte
-	}{
-		{Bytes: []byte{0, 1, 2, 3, 4, 5}, String: "12345"},
-	} {
-
-		if _, err = stmt.ExecContext(ctx, tN*2, tC.Bytes, tC.String); err != nil {
-			t.Errorf("%d/1. (%v, %q): %v", tN, tC.Bytes, tC.String, err)
-			continue
-		}
-		if _, err = stmt.ExecContext(ctx, tN*3+1,
-			godror.Lob{Reader: bytes.NewReader(tC.Bytes)},
-			godror.Lob{Reader: strings.NewReader(tC.String), IsClob: true},
-		); err != nil {
-			t.Errorf("%d/2. (%v, %q): %v", tN, tC.Bytes, tC.String, err)
-		}
-
-		var rows *sql.Rows
-		rows, err = conn.QueryContext(ctx,
-			"SELECT F_id, F_blob, F_clob FROM "+tbl+" WHERE F_id IN (:1, :2)", //nolint:gas
-			godror.LobAsReader(),
-			2*tN, 2*tN+1)
-		if err != nil {
-			t.Errorf("%d/3. %v", tN, err)
-			continue
-		}
-		for rows.Next() {
-			var id, blob, clob interface{}
-			if err = rows.Scan(&id, &blob, &clob); err != nil {
-				rows.Close()
-				t.Errorf("%d/3. scan: %v", tN, err)
-				continue
-			}
-			t.Logf("%d. blob=%+v clob=%+v", id, blob, clob)
-			if blob, ok := blob.(*godror.Lob); !ok {
-				t.Errorf("%d. %T is not LOB", id, blob)
-			} else {
-				got := make([]byte, len(tC.Bytes))
-				n, err := io.ReadFull(blob, got)
-				t.Logf("%d. BLOB read %d (%q =%t= %q): %+v", id, n, got, bytes.Equal(got, tC.Bytes), tC.Bytes, err)
-				if err != nil {
-					t.Errorf("%d. %v", id, err)
-				} else if !bytes.Equal(got, tC.Bytes) {
-					t.Errorf("%d. got %q for BLOB, wanted %q", id, got, tC.Bytes)
-				}
-			}
-			if clob, ok := clob.(*godror.Lob); !ok {
-				t.Errorf("%d. %T is not LOB", id, clob)
-			} else {
-				var got []byte
-				if got, err = io.ReadAll(clob); err != nil {
-					t.Errorf("%d. %v", id, err)
-				} else if got := string(got); got != tC.String {
-					t.Errorf("%d. got %q for CLOB, wanted %q", id, got, tC.String)
-				}
-			}
-		}
-		rows.Close()
+	defer tx.Rollback()
+	if _, err = tx.ExecContext(ctx, "INSERT INTO "+tbl+" (id) VALUES (1)"); err != nil {
+		t.Fatal(err)
+	}
+	if err = godror.Autonomous(ctx, testDb, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO "+tbl+" (id) VALUES (2)")
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = godror.AutonomousBlock(ctx, tx, "INSERT INTO "+tbl+" (id) VALUES (:1)", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err = godror.AutonomousBlock(ctx, tx, "INSERT INTO "+tbl+" (id) VALUES (:1); RAISE_APPLICATION_ERROR(-20001, 'x')", 4); err == nil {
+		t.Error("wanted error")
+	}
+	if err = tx.Rollback(); err != nil {
+		t.Fatal(err)
 	}
 
-	rows, err := conn.QueryContext(ctx,
-		"SELECT F_blob, F_clob FROM "+tbl+"", //nolint:gas
-		godror.ClobAsString())
+	var got []int
+	rows, err := testDb.QueryContext(ctx, "SELECT id FROM "+tbl+" ORDER BY id")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var b []byte
-		var s string
-		if err = rows.Scan(&b, &s); err != nil {
-			t.Error(err)
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			t.Fatal(err)
 		}
-		t.Logf("clobAsString: %q", s)
+		got = append(got, id)
+	}
+	if want := []int{2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
 	}
+}
 
-	qry := "SELECT CURSOR(SELECT f_id, F_blob, f_clob FROM " + tbl + " WHERE ROWNUM <= 10) FROM DUAL"
-	rows, err = testDb.QueryContext(ctx, qry, godror.ClobAsString())
+func TestStatefulConn(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("StatefulConn"), 30*time.Second)
+	defer cancel()
+	var replaced int
+	sc, err := godror.NewStatefulConn(ctx, testDb, func(ctx context.Context, err error) {
+		t.Log("replaced:", err)
+		replaced++
+	})
 	if err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+		t.Fatal(err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var intf interface{}
-		if err := rows.Scan(&intf); err != nil {
-			t.Error(err)
-			continue
+	defer sc.Close()
+
+	const dateFormat = "YYYY\"godror\"MM"
+	if _, err = sc.ExecContext(ctx, "ALTER SESSION SET NLS_DATE_FORMAT = '"+dateFormat+"'"); err != nil {
+		t.Fatal(err)
+	}
+	if err = sc.CaptureNLS(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err = sc.SetState(ctx, "client_info", func(ctx context.Context, ex godror.Execer) error {
+		_, err := ex.ExecContext(ctx, "BEGIN DBMS_APPLICATION_INFO.set_client_info('godror_stateful'); END;")
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	getState := func() (string, string) {
+		var format, info string
+		rows, err := sc.QueryContext(ctx, "SELECT value, SYS_CONTEXT('USERENV', 'CLIENT_INFO') FROM nls_session_parameters WHERE parameter = 'NLS_DATE_FORMAT'")
+		if err != nil {
+			t.Fatal(err)
 		}
-		t.Logf("%T", intf)
-		sub := intf.(driver.RowsColumnTypeScanType)
-		cols := sub.Columns()
-		t.Log("Columns", cols)
-		dests := make([]driver.Value, len(cols))
-		for {
-			if err := sub.Next(dests); err != nil {
-				if err == io.EOF {
-					break
-				}
-				t.Error(err)
-				break
+		defer rows.Close()
+		for rows.Next() {
+			if err = rows.Scan(&format, &info); err != nil {
+				t.Fatal(err)
 			}
-			// fmt.Println(dests)
-			t.Log(dests)
 		}
-		sub.Close()
+		return format, info
 	}
+	pre, _ := getState()
 
+	// simulate a failed session
+	if err = sc.Raw(func(interface{}) error { return driver.ErrBadConn }); !errors.Is(err, godror.ErrSessionReplaced) {
+		t.Fatalf("got %+v, wanted ErrSessionReplaced", err)
+	}
+	if replaced != 1 {
+		t.Errorf("onReplaced called %d times, wanted 1", replaced)
+	}
+	format, info := getState()
+	t.Logf("format: %q -> %q, info: %q", pre, format, info)
+	if format != pre {
+		t.Errorf("got NLS_DATE_FORMAT %q, wanted %q", format, pre)
+	}
+	if info != "godror_stateful" {
+		t.Errorf("got client info %q, wanted godror_stateful", info)
+	}
 }
 
-func TestReadWriteBfile(t *testing.T) {
+func TestCommitMode(t *testing.T) {
 	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("ReadWritBfile"), 30*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("CommitMode"), 30*time.Second)
 	defer cancel()
-	conn, err := testDb.Conn(ctx)
-	if err != nil {
+	tbl := "test_commit_mode" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER(3))"); err != nil {
 		t.Fatal(err)
 	}
-	defer conn.Close()
-	tbl := "test_Bfile" + tblSuffix
-	conn.ExecContext(ctx, "DROP TABLE "+tbl)
-	conn.ExecContext(ctx,
-		"CREATE TABLE "+tbl+" (f_id NUMBER(6), f_bf BFILE)", //nolint:gas
-	)
-	defer testDb.Exec(
-		"DROP TABLE " + tbl, //nolint:gas
-	)
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
 
-	stmt, err := conn.PrepareContext(ctx,
-		"INSERT INTO "+tbl+" (F_id, f_bf) VALUES (:1, BFILENAME(:2, :3))", //nolint:gas
-	)
+	for i, mode := range []godror.CommitMode{godror.CommitWriteBatch | godror.CommitWriteNoWait, godror.CommitWriteNoWait} {
+		tx, err := testDb.BeginTx(godror.ContextWithCommitMode(ctx, mode), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tx.ExecContext(ctx, "INSERT INTO "+tbl+" (id) VALUES (:1)", i); err != nil {
+			tx.Rollback()
+			t.Fatal(err)
+		}
+		if err = tx.Commit(); err != nil {
+			t.Fatalf("%d: %+v", mode, err)
+		}
+	}
+	// the next transaction on the connection commits normally
+	tx, err := testDb.BeginTx(ctx, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer stmt.Close()
-
-	for tN, tC := range []struct {
-		Dir  string
-		File string
-	}{
-		{"TEST", "1.txt"},
-	} {
+	if _, err = tx.ExecContext(ctx, "INSERT INTO "+tbl+" (id) VALUES (:1)", 9); err != nil {
+		tx.Rollback()
+		t.Fatal(err)
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	if err = testDb.QueryRowContext(ctx, "SELECT COUNT(0) FROM "+tbl).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("got %d rows, wanted 3", n)
+	}
+}
 
-		if _, err = stmt.ExecContext(ctx, tN*2, tC.Dir, tC.File); err != nil {
-			t.Errorf("%d/1. (%s, %s): %v", tN, tC.Dir, tC.File, err)
-			continue
-		}
-
-		var rows *sql.Rows
-		rows, err = conn.QueryContext(ctx,
-			"SELECT F_id, F_bf FROM "+tbl+" WHERE F_id = :1", //nolint:gas
-			2*tN)
-		if err != nil {
-			t.Errorf("%d/2. %v", tN, err)
-			continue
-		}
-		for rows.Next() {
-			var id, bfile interface{}
-			if err = rows.Scan(&id, &bfile); err != nil {
-				rows.Close()
-				t.Errorf("%d/2. scan: %v", tN, err)
-				continue
-			}
-			t.Logf("%d. bfile=%+v", id, bfile)
-			if b, ok := bfile.(*godror.Lob); !ok {
-				t.Errorf("%d. %T is not LOB", id, b)
-			} else {
-				lobD, err := b.Hijack()
-				if err != nil {
-					t.Error(err)
-				}
-				dir, file, err := lobD.GetFileName()
-				if err != nil {
-					t.Error(err)
-				}
-				if dir != tC.Dir {
-					t.Errorf("the got dir %v not equal want %v", dir, tC.Dir)
-				}
-				if file != tC.File {
-					t.Errorf("the got file %v not equal want %v", file, tC.File)
-				}
-			}
-		}
-		rows.Close()
-	}
-}
-
-func printSlice(orig interface{}) interface{} {
-	ro := reflect.ValueOf(orig)
-	if ro.Kind() == reflect.Ptr {
-		ro = ro.Elem()
-	}
-	ret := make([]string, 0, ro.Len())
-	for i := 0; i < ro.Len(); i++ {
-		ret = append(ret, fmt.Sprintf("%v", ro.Index(i).Interface()))
-	}
-	return ret
-}
-func copySlice(orig interface{}) interface{} {
-	ro := reflect.ValueOf(orig)
-	rc := reflect.New(reflect.TypeOf(orig)).Elem() // *[]s
-	rc.Set(reflect.MakeSlice(ro.Type(), ro.Len(), ro.Cap()+1))
-	for i := 0; i < ro.Len(); i++ {
-		rc.Index(i).Set(ro.Index(i))
+func TestXAResource(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("XAResource"), 30*time.Second)
+	defer cancel()
+	tbl := "test_xa" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER(3))"); err != nil {
+		t.Fatal(err)
 	}
-	return rc.Addr().Interface()
-}
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
 
-func TestOpenCloseDB(t *testing.T) {
-	cs, err := godror.ParseDSN(testConStr)
+	conn, err := testDb.Conn(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
-	cs.MinSessions, cs.MaxSessions = 0, 4
-	cs.StandaloneConnection = godror.Bool(true)
-	const countQry = "SELECT COUNT(0) FROM user_objects"
-	ctx, cancel := context.WithCancel(testContext("OpenCloseDB"))
-	defer cancel()
-	grp, ctx := errgroup.WithContext(ctx)
-	grp.SetLimit(cs.MaxSessions)
-	for i := 0; i < 32; i++ {
-		cs := cs
-		cs.WaitTimeout += time.Duration(i)
-		grp.Go(func() error {
-			// To make the connections differ
-			t.Log(cs.String())
-			db := sql.OpenDB(godror.NewConnector(cs))
-			defer db.Close()
-			conn, err := db.Conn(ctx)
-			if err != nil {
-				return fmt.Errorf("Open: %w", err)
-			}
-			defer conn.Close()
-			var n int32
-			err = conn.QueryRowContext(ctx, countQry).Scan(&n)
-			t.Logf("object count: %d (%+v)", n, err)
-			return nil
-		})
+	defer conn.Close()
+	xa := godror.NewXAResource(conn)
+	xid := godror.Xid{FormatID: 4242, GlobalTransactionID: []byte("godror-" + tbl), BranchQualifier: []byte("b1")}
+	count := func() int {
+		var n int
+		if err := testDb.QueryRowContext(ctx, "SELECT COUNT(0) FROM "+tbl).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
 	}
-	if err := grp.Wait(); err != nil {
-		t.Fatal(err)
+	insert := func(id int) {
+		if _, err := xa.ExecContext(ctx, "INSERT INTO "+tbl+" (id) VALUES (:1)", id); err != nil {
+			t.Fatalf("insert %d: %+v", id, err)
+		}
 	}
-}
 
-func TestOpenCloseConn(t *testing.T) {
-	cs, err := godror.ParseDSN(testConStr)
-	if err != nil {
+	if err = xa.Start(ctx, xid, time.Minute, godror.TPCBeginNew); err != nil {
 		t.Fatal(err)
 	}
-	cs.MinSessions, cs.MaxSessions = 0, 4
-	t.Log(cs.String())
-	db := sql.OpenDB(godror.NewConnector(cs))
-	defer db.Close()
-	const countQry = "SELECT COUNT(0) FROM user_objects"
-	ctx, cancel := context.WithCancel(testContext("OpenCloseConn"))
-	defer cancel()
-	limitCh := make(chan struct{}, cs.MaxSessions)
-	grp, ctx := errgroup.WithContext(ctx)
-	for i := 0; i < 100; i++ {
-		limitCh <- struct{}{}
-		grp.Go(func() error {
-			defer func() { <-limitCh }()
-			conn, err := db.Conn(ctx)
-			if err != nil {
-				return fmt.Errorf("Open: %w", err)
-			}
-			defer conn.Close()
-			var n int32
-			err = conn.QueryRowContext(ctx, countQry).Scan(&n)
-			t.Logf("object count: %d (%+v)", n, err)
-			return nil
-		})
-	}
-	if err := grp.Wait(); err != nil {
+	insert(1)
+	if err = xa.Suspend(ctx, xid); err != nil {
 		t.Fatal(err)
 	}
-}
-
-func TestOpenCloseTx(t *testing.T) {
-	cs, err := godror.ParseDSN(testConStr)
-	if err != nil {
+	if err = xa.Resume(ctx, xid); err != nil {
 		t.Fatal(err)
 	}
-	cs.MinSessions, cs.MaxSessions = 1, 6
-	t.Log(cs.String())
-	db, err := sql.Open("godror", cs.StringWithPassword())
-	if err != nil {
+	insert(2)
+	if err = xa.End(ctx, xid, godror.TPCEndNormal); err != nil {
 		t.Fatal(err)
 	}
-	defer func() {
-		if cErr := db.Close(); cErr != nil {
-			t.Error("CLOSE:", cErr)
-		}
-	}()
-	db.SetMaxIdleConns(cs.MinSessions)
-	db.SetMaxOpenConns(cs.MaxSessions)
-	ctx, cancel := context.WithCancel(testContext("OpenCloseTx"))
-	defer cancel()
-	const module = "godror.v2.test-OpenCloseTx "
-	const countQry = "SELECT COUNT(0) FROM v$session WHERE module LIKE '" + module + "%'"
-	stmt, err := db.PrepareContext(ctx, countQry)
+	if n := count(); n != 0 {
+		t.Errorf("got %d rows before commit, wanted 0", n)
+	}
+	readOnly, err := xa.Prepare(ctx, xid)
 	if err != nil {
-		if strings.Contains(err.Error(), "ORA-12516:") {
-			t.Skip(err)
-		}
 		t.Fatal(err)
 	}
-	defer stmt.Close()
-
-	sessCount := func() (n int, stats godror.PoolStats, err error) {
-		var sErr error
-		sErr = godror.Raw(ctx, db, func(cx godror.Conn) error {
-			var gErr error
-			stats, gErr = cx.GetPoolStats()
-			return gErr
-		})
-		if qErr := stmt.QueryRowContext(ctx).Scan(&n); qErr != nil && sErr == nil {
-			sErr = qErr
-		}
-		return n, stats, sErr
+	if readOnly {
+		t.Fatal("read-only after INSERTs")
 	}
-	n, ps, err := sessCount()
-	if err != nil {
-		t.Skip(err)
+	if err = xa.Commit(ctx, xid, false); err != nil {
+		t.Fatal(err)
 	}
-	if n > 0 {
-		t.Logf("sessCount=%d, stats=%s at start!", n, ps)
+	if n := count(); n != 2 {
+		t.Errorf("got %d rows after commit, wanted 2", n)
 	}
-	var tt godror.TraceTag
-	for i := 0; i < cs.MaxSessions*2; i++ {
-		t.Logf("%d. PREPARE", i+1)
-		stmt, err := db.PrepareContext(ctx, "SELECT 1 FROM DUAL")
+}
+
+func TestQueryMap(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("QueryMap"), 10*time.Second)
+	defer cancel()
+
+	const qry = "SELECT LEVEL AS n, 'x'||LEVEL AS s, CAST(NULL AS DATE) AS d FROM DUAL CONNECT BY LEVEL <= 3"
+	var n int
+	for m, err := range godror.QueryMap(ctx, testDb, qry) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		if n, ps, err = sessCount(); err != nil {
-			t.Error(err)
-		} else {
-			t.Logf("sessCount=%d stats=%s", n, ps)
+		n++
+		if got, want := fmt.Sprintf("%v", m["N"]), strconv.Itoa(n); got != want {
+			t.Errorf("N: got %q, wanted %q", got, want)
 		}
-		tt.Module = fmt.Sprintf("%s%d", module, 2*i)
-		ctx = godror.ContextWithTraceTag(ctx, tt)
-		tx1, err1 := db.BeginTx(ctx, nil)
-		if err1 != nil {
-			t.Fatal(err1)
+		if got, want := m["S"], "x"+strconv.Itoa(n); got != want {
+			t.Errorf("S: got %#v, wanted %q", got, want)
 		}
-		tt.Module = fmt.Sprintf("%s%d", module, 2*i+1)
-		ctx = godror.ContextWithTraceTag(ctx, tt)
-		tx2, err2 := db.BeginTx(ctx, nil)
-		if err2 != nil {
-			if strings.Contains(err2.Error(), "ORA-12516:") {
-				tx1.Rollback()
-				break
-			}
-			t.Fatal(err2)
+		if d, ok := m["D"]; !ok || d != nil {
+			t.Errorf("D: got %#v (%t), wanted nil", d, ok)
 		}
-		if n, ps, err = sessCount(); err != nil {
-			t.Log(err)
-		} else if n == 0 {
-			t.Errorf("sessCount=0, stats=%s want at least 2", ps)
-		} else {
-			t.Logf("sessCount=%d stats=%s", n, ps)
+		if n == 2 {
+			break
 		}
-		tx1.Rollback()
-		tx2.Rollback()
-		stmt.Close()
 	}
-	if n, ps, err = sessCount(); err != nil {
-		t.Log(err)
-	} else if n > 7 {
-		t.Errorf("sessCount=%d stats=%s", n, ps)
+	if n != 2 {
+		t.Errorf("got %d rows, wanted 2", n)
 	}
 }
 
-func TestOpenBadMemory(t *testing.T) {
-	var mem runtime.MemStats
-	runtime.GC()
-	runtime.ReadMemStats(&mem)
-	t.Log("Allocated 0:", mem.Alloc)
-	zero := mem.Alloc
-	for i := 0; i < 100; i++ {
-		badConStr := strings.Replace(testConStr, "@", fmt.Sprintf("BAD%dBAD@", i), 1)
-		db, err := sql.Open("godror", badConStr)
-		if err != nil {
-			t.Fatalf("bad connection string %q didn't produce error!", badConStr)
+func TestCallTimeout(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("CallTimeout"), time.Minute)
+	defer cancel()
+
+	const qry = "BEGIN DBMS_SESSION.sleep(3); END;"
+	start := time.Now()
+	_, err := testDb.ExecContext(ctx, qry, godror.CallTimeout(500*time.Millisecond))
+	t.Logf("%s: %v (%s)", qry, err, time.Since(start))
+	if err == nil {
+		t.Fatal("wanted timeout error")
+	}
+	if !godror.IsCallTimeout(err) {
+		if strings.Contains(err.Error(), "PLS-00302") { // no DBMS_SESSION.sleep before 18c
+			t.Skip(err)
 		}
-		db.Close()
-		runtime.GC()
-		runtime.ReadMemStats(&mem)
-		t.Logf("Allocated %d: %d", i+1, mem.Alloc)
+		t.Errorf("wanted call timeout error, got %+v", err)
 	}
-	d := mem.Alloc - zero
-	if mem.Alloc < zero {
-		d = 0
+	if ctx.Err() != nil {
+		t.Errorf("context is done: %v", ctx.Err())
 	}
-	t.Logf("atlast: %d", d)
-	if d > 64<<10 {
-		t.Errorf("Consumed more than 64KiB of memory: %d", d)
+	if dur := time.Since(start); dur > 2*time.Second {
+		t.Errorf("call timeout did not work, took %s", dur)
 	}
 }
 
-func TestSelectFloat(t *testing.T) {
+func TestDefineReuse(t *testing.T) {
 	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("SelectFloat"), 10*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("DefineReuse"), 10*time.Second)
 	defer cancel()
-	tbl := "test_numbers" + tblSuffix
-	qry := `CREATE TABLE ` + tbl + ` (
-  INT_COL     NUMBER,
-  FLOAT_COL  NUMBER,
-  EMPTY_INT_COL NUMBER
-)`
-	testDb.Exec("DROP TABLE " + tbl)
-	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+
+	const qry = "SELECT LEVEL, 'x'||LEVEL, SYSDATE FROM DUAL CONNECT BY LEVEL <= :1"
+	stmt, err := testDb.PrepareContext(ctx, qry)
+	if err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	defer stmt.Close()
+	query := func() {
+		rows, err := stmt.QueryContext(ctx, 3)
+		if err != nil {
+			t.Fatalf("%s: %+v", qry, err)
+		}
+		defer rows.Close()
+		var n int
+		for rows.Next() {
+			n++
+		}
+		if err = rows.Err(); err != nil || n != 3 {
+			t.Fatalf("got %d rows, error %+v", n, err)
+		}
+	}
+	query()
+	before := godror.GetDefineStats()
+	for i := 0; i < 5; i++ {
+		query()
+	}
+	after := godror.GetDefineStats()
+	t.Logf("before: %+v after: %+v", before, after)
+	if after.Reused-before.Reused < 5*3 {
+		t.Errorf("wanted at least %d reused define buffers, got %d", 5*3, after.Reused-before.Reused)
+	}
+}
+
+func TestForEachRow(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("ForEachRow"), 10*time.Second)
+	defer cancel()
+
+	const qry = "SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= :1"
+	var sum int
+	if err := godror.ForEachRow(ctx, testDb, qry, []interface{}{4}, func(scan func(...interface{}) error) error {
+		var n int
+		if err := scan(&n); err != nil {
+			return err
+		}
+		sum += n
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if sum != 1+2+3+4 {
+		t.Errorf("got %d, wanted %d", sum, 1+2+3+4)
+	}
+
+	errStop := errors.New("stop")
+	var n int
+	if err := godror.ForEachRow(ctx, testDb, qry, []interface{}{4}, func(func(...interface{}) error) error {
+		if n++; n == 2 {
+			return errStop
+		}
+		return nil
+	}); !errors.Is(err, errStop) || n != 2 {
+		t.Errorf("got %v after %d rows, wanted %v after 2", err, n, errStop)
+	}
+}
+
+func TestParseOnly(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("ParseOnly"), 10*time.Second)
+	defer cancel()
+
+	tbl := "test_not_exist" + tblSuffix
+	cnt := func() int {
+		var cnt int64
+		if err := testDb.QueryRowContext(ctx,
+			"SELECT COUNT(0) FROM user_tables WHERE table_name = UPPER('"+tbl+"')").Scan(&cnt); //nolint:gas
+		err != nil {
+			t.Fatal(err)
+		}
+		return int(cnt)
+	}
+
+	if cnt() != 0 {
+		if _, err := testDb.ExecContext(ctx, "DROP TABLE "+tbl); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+"(t VARCHAR2(1))", godror.ParseOnly()); err != nil {
+		t.Fatal(err)
+	}
+	if got := cnt(); got != 1 {
+		t.Errorf("got %d, wanted 0", got)
+	}
+}
+
+func TestInputArray(t *testing.T) {
+	t.Parallel()
+	defer tl.enableLogging(t)()
+	ctx, cancel := context.WithTimeout(testContext("InputArray"), 10*time.Second)
+	defer cancel()
+
+	pkg := strings.ToUpper("test_in_pkg" + tblSuffix)
+	qry := `CREATE OR REPLACE PACKAGE ` + pkg + ` AS
+TYPE int_tab_typ IS TABLE OF BINARY_INTEGER INDEX BY PLS_INTEGER;
+TYPE num_tab_typ IS TABLE OF NUMBER INDEX BY PLS_INTEGER;
+TYPE vc_tab_typ IS TABLE OF VARCHAR2(100) INDEX BY PLS_INTEGER;
+TYPE dt_tab_typ IS TABLE OF DATE INDEX BY PLS_INTEGER;
+TYPE ids_tab_typ IS TABLE OF INTERVAL DAY TO SECOND INDEX BY PLS_INTEGER;
+--TYPE lob_tab_typ IS TABLE OF CLOB INDEX BY PLS_INTEGER;
+
+FUNCTION in_int(p_int IN int_tab_typ) RETURN VARCHAR2;
+FUNCTION in_num(p_num IN num_tab_typ) RETURN VARCHAR2;
+FUNCTION in_vc(p_vc IN vc_tab_typ) RETURN VARCHAR2;
+FUNCTION in_dt(p_dt IN dt_tab_typ) RETURN VARCHAR2;
+FUNCTION in_ids(p_dur IN ids_tab_typ) RETURN VARCHAR2;
+END;
+`
+	testDb.Exec("DROP PACKAGE " + pkg)
+	t.Log("package", pkg)
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(err, qry)
+	}
+	defer testDb.Exec("DROP PACKAGE " + pkg)
+
+	qry = `CREATE OR REPLACE PACKAGE BODY ` + pkg + ` AS
+FUNCTION in_int(p_int IN int_tab_typ) RETURN VARCHAR2 IS
+  v_idx PLS_INTEGER;
+  v_res VARCHAR2(32767);
+BEGIN
+  v_idx := p_int.FIRST;
+  WHILE v_idx IS NOT NULL LOOP
+    v_res := v_res||v_idx||':'||p_int(v_idx)||CHR(10);
+    v_idx := p_int.NEXT(v_idx);
+  END LOOP;
+  RETURN(v_res);
+END;
+
+FUNCTION in_num(p_num IN num_tab_typ) RETURN VARCHAR2 IS
+  v_idx PLS_INTEGER;
+  v_res VARCHAR2(32767);
+BEGIN
+  v_idx := p_num.FIRST;
+  WHILE v_idx IS NOT NULL LOOP
+    v_res := v_res||v_idx||':'||p_num(v_idx)||CHR(10);
+    v_idx := p_num.NEXT(v_idx);
+  END LOOP;
+  RETURN(v_res);
+END;
+
+FUNCTION in_vc(p_vc IN vc_tab_typ) RETURN VARCHAR2 IS
+  v_idx PLS_INTEGER;
+  v_res VARCHAR2(32767);
+BEGIN
+  v_idx := p_vc.FIRST;
+  WHILE v_idx IS NOT NULL LOOP
+    v_res := v_res||v_idx||':'||p_vc(v_idx)||CHR(10);
+    v_idx := p_vc.NEXT(v_idx);
+  END LOOP;
+  RETURN(v_res);
+END;
+FUNCTION in_dt(p_dt IN dt_tab_typ) RETURN VARCHAR2 IS
+  v_idx PLS_INTEGER;
+  v_res VARCHAR2(32767);
+BEGIN
+  v_idx := p_dt.FIRST;
+  WHILE v_idx IS NOT NULL LOOP
+    v_res := v_res||v_idx||':'||TO_CHAR(p_dt(v_idx), 'YYYY-MM-DD"T"HH24:MI:SS')||CHR(10);
+    v_idx := p_dt.NEXT(v_idx);
+  END LOOP;
+  RETURN(v_res);
+END;
+FUNCTION in_ids(p_dur IN ids_tab_typ) RETURN VARCHAR2 IS
+  V_idx PLS_INTEGER;
+  v_res VARCHAR2(32767);
+BEGIN
+  v_idx := p_dur.FIRST;
+  WHILE v_idx IS NOT NULL LOOP
+    v_res := v_res||v_idx||':'||TO_CHAR(p_dur(v_idx), 'YYYY-MM-DD"T"HH24:MI:SS')||CHR(10);
+    v_idx := p_dur.NEXT(v_idx);
+  END LOOP;
+  RETURN(v_res);
+END;
+END;
+`
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(err, qry)
+	}
+	compileErrors, err := godror.GetCompileErrors(ctx, testDb, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(compileErrors) != 0 {
+		t.Logf("compile errors: %v", compileErrors)
+		for _, ce := range compileErrors {
+			if strings.Contains(ce.Error(), pkg) {
+				t.Fatal(ce)
+			}
+		}
+	}
+	serverTZ := time.Local
+	if err = godror.Raw(ctx, testDb, func(conn godror.Conn) error {
+		serverTZ = conn.Timezone()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := testDb.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qry = "ALTER SESSION SET time_zone = 'UTC'"
+	if _, err = tx.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+
+	epoch := time.Date(2017, 11, 20, 12, 14, 21, 0, time.UTC)
+	epochPlus := epoch.AddDate(0, -6, 0)
+	const timeFmt = "2006-01-02T15:04:05"
+	_ = epoch
+	for name, tC := range map[string]struct {
+		In   interface{}
+		Want string
+	}{
+		// "int_0":{In:[]int32{}, Want:""},
+		"num_0": {In: []godror.Number{}, Want: ""},
+		"vc_0":  {In: []string{}, Want: ""},
+		"dt_0":  {In: []time.Time{}, Want: ""},
+		"dt_00": {In: []godror.NullTime{}, Want: ""},
+
+		"num_3": {
+			In:   []godror.Number{"1", "2.72", "-3.14"},
+			Want: "1:1\n2:2.72\n3:-3.14\n",
+		},
+		"vc_3": {
+			In:   []string{"a", "", "cCc"},
+			Want: "1:a\n2:\n3:cCc\n",
+		},
+		"dt_2": {
+			In: []time.Time{epoch, epochPlus},
+			Want: ("1:" + epoch.In(serverTZ).Format(timeFmt) + "\n" +
+				"2:" + epochPlus.In(serverTZ).Format(timeFmt) + "\n"),
+		},
+		"dt_02": {
+			In: []godror.NullTime{{Valid: true, Time: epoch},
+				{Valid: true, Time: epochPlus}},
+			Want: ("1:" + epoch.In(serverTZ).Format(timeFmt) + "\n" +
+				"2:" + epochPlus.In(serverTZ).Format(timeFmt) + "\n"),
+		},
+
+		// "ids_1": { In:   []time.Duration{32 * time.Second}, Want: "1:32s\n", },
+	} {
+		typ := strings.SplitN(name, "_", 2)[0]
+		qry := "BEGIN :1 := " + pkg + ".in_" + typ + "(:2); END;"
+		var res string
+		if _, err := tx.ExecContext(ctx, qry, godror.PlSQLArrays,
+			sql.Out{Dest: &res}, tC.In,
+		); err != nil {
+			t.Error(fmt.Errorf("%q. %s %+v: %w", name, qry, tC.In, err))
+		}
+		t.Logf("%q. %q", name, res)
+		if typ == "num" {
+			res = strings.Replace(res, ",", ".", -1)
+		}
+		if res != tC.Want {
+			t.Errorf("%q. got %q, wanted %q.", name, res, tC.Want)
+		}
+	}
+}
+
+func TestDbmsOutput(t *testing.T) {
+	defer tl.enableLogging(t)()
+	ctx, cancel := context.WithTimeout(testContext("DbmsOutput"), 10*time.Second)
+	defer cancel()
+
+	conn, err := testDb.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if err := godror.EnableDbmsOutput(ctx, conn); err != nil {
+		t.Fatal(err)
+	}
+
+	txt := `árvíztűrő tükörfúrógép`
+	qry := "BEGIN DBMS_OUTPUT.PUT_LINE('" + txt + "'); END;"
+	if _, err := conn.ExecContext(ctx, qry); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := godror.ReadDbmsOutput(ctx, &buf, conn); err != nil {
+		t.Error(err)
+	}
+	t.Log(buf.String())
+	if buf.String() != txt+"\n" {
+		t.Errorf("got\n%q, wanted\n%q", buf.String(), txt+"\n")
+	}
+}
+
+func TestInOutArray(t *testing.T) {
+	t.Parallel()
+	defer tl.enableLogging(t)()
+
+	ctx, cancel := context.WithTimeout(testContext("InOutArray"), 20*time.Second)
+	defer cancel()
+
+	pkg := strings.ToUpper("test_pkg" + tblSuffix)
+	qry := `CREATE OR REPLACE PACKAGE ` + pkg + ` AS
+TYPE int_tab_typ IS TABLE OF BINARY_INTEGER INDEX BY PLS_INTEGER;
+TYPE num_tab_typ IS TABLE OF NUMBER INDEX BY PLS_INTEGER;
+TYPE vc_tab_typ IS TABLE OF VARCHAR2(100) INDEX BY PLS_INTEGER;
+TYPE dt_tab_typ IS TABLE OF DATE INDEX BY PLS_INTEGER;
+TYPE lob_tab_typ IS TABLE OF CLOB INDEX BY PLS_INTEGER;
+
+PROCEDURE inout_int(p_int IN OUT int_tab_typ);
+PROCEDURE inout_num(p_num IN OUT num_tab_typ);
+PROCEDURE inout_vc(p_vc IN OUT vc_tab_typ);
+PROCEDURE inout_dt(p_dt IN OUT dt_tab_typ);
+PROCEDURE p2(
+	--p_int IN OUT int_tab_typ,
+	p_num IN OUT num_tab_typ, p_vc IN OUT vc_tab_typ, p_dt IN OUT dt_tab_typ);
+END;
+`
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(err, qry)
+	}
+	defer testDb.Exec("DROP PACKAGE " + pkg)
+
+	qry = `CREATE OR REPLACE PACKAGE BODY ` + pkg + ` AS
+PROCEDURE inout_int(p_int IN OUT int_tab_typ) IS
+  v_idx PLS_INTEGER;
+BEGIN
+  DBMS_OUTPUT.PUT_LINE('p_int.COUNT='||p_int.COUNT||' FIRST='||p_int.FIRST||' LAST='||p_int.LAST);
+  v_idx := p_int.FIRST;
+  WHILE v_idx IS NOT NULL LOOP
+    p_int(v_idx) := NVL(p_int(v_idx) * 2, 1);
+	v_idx := p_int.NEXT(v_idx);
+  END LOOP;
+  p_int(NVL(p_int.LAST, 0)+1) := p_int.COUNT;
+END;
+
+PROCEDURE inout_num(p_num IN OUT num_tab_typ) IS
+  v_idx PLS_INTEGER;
+BEGIN
+  DBMS_OUTPUT.PUT_LINE('p_num.COUNT='||p_num.COUNT||' FIRST='||p_num.FIRST||' LAST='||p_num.LAST);
+  v_idx := p_num.FIRST;
+  WHILE v_idx IS NOT NULL LOOP
+    p_num(v_idx) := NVL(p_num(v_idx) / 2, 0.5);
+	v_idx := p_num.NEXT(v_idx);
+  END LOOP;
+  p_num(NVL(p_num.LAST, 0)+1) := p_num.COUNT;
+END;
+
+PROCEDURE inout_vc(p_vc IN OUT vc_tab_typ) IS
+  v_idx PLS_INTEGER;
+BEGIN
+  DBMS_OUTPUT.PUT_LINE('p_vc.COUNT='||p_vc.COUNT||' FIRST='||p_vc.FIRST||' LAST='||p_vc.LAST);
+  v_idx := p_vc.FIRST;
+  WHILE v_idx IS NOT NULL LOOP
+    p_vc(v_idx) := NVL(p_vc(v_idx) ||' +', '-');
+	v_idx := p_vc.NEXT(v_idx);
+  END LOOP;
+  p_vc(NVL(p_vc.LAST, 0)+1) := p_vc.COUNT;
+END;
+
+PROCEDURE inout_dt(p_dt IN OUT dt_tab_typ) IS
+  v_idx PLS_INTEGER;
+BEGIN
+  DBMS_OUTPUT.PUT_LINE('p_dt.COUNT='||p_dt.COUNT||' FIRST='||p_dt.FIRST||' LAST='||p_dt.LAST);
+  v_idx := p_dt.FIRST;
+  WHILE v_idx IS NOT NULL LOOP
+    DBMS_OUTPUT.PUT_LINE(v_idx||'='||TO_CHAR(p_dt(v_idx), 'YYYY-MM-DD HH24:MI:SS'));
+    p_dt(v_idx) := NVL(p_dt(v_idx) + 1, TRUNC(SYSDATE)-v_idx);
+	v_idx := p_dt.NEXT(v_idx);
+  END LOOP;
+  p_dt(NVL(p_dt.LAST, 0)+1) := TRUNC(SYSDATE);
+  DBMS_OUTPUT.PUT_LINE('p_dt.COUNT='||p_dt.COUNT||' FIRST='||p_dt.FIRST||' LAST='||p_dt.LAST);
+END;
+
+PROCEDURE p2(
+	--p_int IN OUT int_tab_typ,
+	p_num IN OUT num_tab_typ,
+	p_vc IN OUT vc_tab_typ,
+	p_dt IN OUT dt_tab_typ
+--, p_lob IN OUT lob_tab_typ
+) IS
+BEGIN
+  --inout_int(p_int);
+  inout_num(p_num);
+  inout_vc(p_vc);
+  inout_dt(p_dt);
+  --p_lob := NULL;
+END p2;
+END;
+`
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(err, qry)
+	}
+	compileErrors, err := godror.GetCompileErrors(ctx, testDb, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(compileErrors) != 0 {
+		t.Logf("compile errors: %v", compileErrors)
+		for _, ce := range compileErrors {
+			if strings.Contains(ce.Error(), pkg) {
+				t.Fatal(ce)
+			}
+		}
+	}
+
+	intgr := []int32{3, 1, 4, 0, 0}[:3]
+	intgrWant := []int32{3 * 2, 1 * 2, 4 * 2, 3}
+	_ = intgrWant
+	num := []godror.Number{"3.14", "-2.48", ""}[:2]
+	numWant := []godror.Number{"1.57", "-1.24", "2"}
+	vc := []string{"string", "bring", ""}[:2]
+	vcWant := []string{"string +", "bring +", "2"}
+	var today time.Time
+	qry = "SELECT TRUNC(SYSDATE) FROM DUAL"
+	if testDb.QueryRowContext(ctx, qry).Scan(&today); err != nil {
+		t.Fatal(err)
+	}
+	dt := []time.Time{
+		time.Date(2017, 6, 18, 7, 5, 51, 0, time.Local),
+		{},
+		today.Add(-2 * 24 * time.Hour),
+		today,
+	}
+	dt[1] = dt[0].Add(24 * time.Hour)
+	dtWant := make([]time.Time, len(dt))
+	for i, d := range dt {
+		if i < len(dt)-1 {
+			// p_dt(v_idx) := NVL(p_dt(v_idx) + 1, TRUNC(SYSDATE)-v_idx);
+			dtWant[i] = d.AddDate(0, 0, 1)
+		} else {
+			// p_dt(NVL(p_dt.LAST, 0)+1) := TRUNC(SYSDATE);
+			dtWant[i] = d
+		}
+	}
+	dt = dt[:len(dt)-1]
+
+	conn, err := testDb.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err = conn.ExecContext(ctx, "ALTER SESSION SET time_zone = local"); err != nil {
+		t.Fatal(err)
+	}
+	godror.EnableDbmsOutput(ctx, conn)
+
+	opts := []cmp.Option{
+		cmp.Comparer(func(x, y time.Time) bool { return x.Equal(y) }),
+	}
+
+	for _, tC := range []struct {
+		In, Want interface{}
+		Name     string
+	}{
+		{Name: "vc", In: vc, Want: vcWant},
+		{Name: "num", In: num, Want: numWant},
+		{Name: "dt", In: dt, Want: dtWant},
+		// {Name: "int", In: intgr, Want: intgrWant},
+		{Name: "vc-1", In: vc[:1], Want: []string{"string +", "1"}},
+		{Name: "vc-0", In: vc[:0], Want: []string{"0"}},
+	} {
+		tC := tC
+		t.Run("inout_"+tC.Name, func(t *testing.T) {
+			t.Logf("%s=%s", tC.Name, tC.In)
+			nm := strings.SplitN(tC.Name, "-", 2)[0]
+			qry = "BEGIN " + pkg + ".inout_" + nm + "(:1); END;"
+			dst := copySlice(tC.In)
+			if _, err := conn.ExecContext(ctx, qry,
+				godror.PlSQLArrays,
+				sql.Out{Dest: dst, In: true},
+			); err != nil {
+				t.Fatalf("%s\n%#v\n%+v", qry, dst, err)
+			}
+			got := reflect.ValueOf(dst).Elem().Interface()
+			if nm == "dt" {
+				t.Logf("\nin =%v\ngot=%v\nwt= %v", tC.In, got, tC.Want)
+			}
+
+			if cmp.Equal(got, tC.Want, opts...) {
+				return
+			}
+			t.Errorf("%s: %s", tC.Name, cmp.Diff(printSlice(tC.Want), printSlice(got)))
+			var buf bytes.Buffer
+			if err := godror.ReadDbmsOutput(ctx, &buf, conn); err != nil {
+				t.Error(err)
+			}
+			t.Log("OUTPUT:", buf.String())
+		})
+	}
+
+	// lob := []godror.Lob{godror.Lob{IsClob: true, Reader: strings.NewReader("abcdef")}}
+	t.Run("p2", func(t *testing.T) {
+		if _, err := conn.ExecContext(ctx,
+			"BEGIN "+pkg+".p2(:1, :2, :3); END;",
+			godror.PlSQLArrays,
+			// sql.Out{Dest: &intgr, In: true},
+			sql.Out{Dest: &num, In: true},
+			sql.Out{Dest: &vc, In: true},
+			sql.Out{Dest: &dt, In: true},
+			// sql.Out{Dest: &lob, In: true},
+		); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("int=%#v num=%#v vc=%#v dt=%#v", intgr, num, vc, dt)
+		// if d := cmp.Diff(intgr, intgrWant); d != "" {
+		//	t.Errorf("int: %s", d)
+		// }
+		if d := cmp.Diff(num, numWant); d != "" {
+			t.Errorf("num: %s", d)
+		}
+		if d := cmp.Diff(vc, vcWant); d != "" {
+			t.Errorf("vc: %s", d)
+		}
+		if !cmp.Equal(dt, dtWant, opts...) {
+			if d := cmp.Diff(dt, dtWant); d != "" {
+				t.Errorf("dt: %s", d)
+			}
+		}
+	})
+}
+
+func TestOutParam(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("OutParam"), 10*time.Second)
+	defer cancel()
+	conn, err := testDb.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err = conn.ExecContext(ctx, "ALTER SESSION SET time_zone = local"); err != nil {
+		t.Fatal(err)
+	}
+	pkg := strings.ToUpper("test_p1" + tblSuffix)
+	qry := `CREATE OR REPLACE PROCEDURE
+` + pkg + `(p_int IN OUT INTEGER, p_num IN OUT NUMBER, p_vc IN OUT VARCHAR2, p_dt IN OUT DATE, p_lob IN OUT CLOB)
+IS
+BEGIN
+  p_int := NVL(p_int * 2, 1);
+  p_num := NVL(p_num / 2, 0.5);
+  p_vc := NVL(p_vc ||' +', '-');
+  p_dt := NVL(p_dt + 1, SYSDATE);
+  p_lob := NULL;
+END;`
+	if _, err = conn.ExecContext(ctx, qry); err != nil {
+		t.Fatal(err, qry)
+	}
+	defer testDb.Exec("DROP PROCEDURE " + pkg)
+
+	qry = "BEGIN " + pkg + "(:1, :2, :3, :4, :5); END;"
+	stmt, err := conn.PrepareContext(ctx, qry)
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer stmt.Close()
+
+	var intgr int = 3
+	num := godror.Number("3.14")
+	var vc string = "string"
+	var dt time.Time = time.Date(2017, 6, 18, 7, 5, 51, 0, time.Local)
+	var lob godror.Lob = godror.Lob{IsClob: true, Reader: strings.NewReader("abcdef")}
+	if _, err := stmt.ExecContext(ctx,
+		sql.Out{Dest: &intgr, In: true},
+		sql.Out{Dest: &num, In: true},
+		sql.Out{Dest: &vc, In: true},
+		sql.Out{Dest: &dt, In: true},
+		sql.Out{Dest: &lob, In: true},
+	); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	t.Logf("int=%#v num=%#v vc=%#v dt=%#v", intgr, num, vc, dt)
+	if intgr != 6 {
+		t.Errorf("int: got %d, wanted %d", intgr, 6)
+	}
+	if num != "1.57" {
+		t.Errorf("num: got %q, wanted %q", num, "1.57")
+	}
+	if vc != "string +" {
+		t.Errorf("vc: got %q, wanted %q", vc, "string +")
+	}
+}
+
+func TestSelectRefCursor(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("SelectRefCursor"), 10*time.Second)
+	defer cancel()
+	rows, err := testDb.QueryContext(ctx, "SELECT CURSOR(SELECT object_name, object_type, object_id, created FROM all_objects WHERE ROWNUM <= 10) FROM DUAL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var sub driver.Rows
+		if err := rows.Scan(&sub); err != nil {
+			sub.Close()
+			t.Fatal(err)
+		}
+		defer sub.Close()
+		t.Logf("%[1]T %[1]p", sub)
+		cols := sub.(driver.RowsColumnTypeScanType).Columns()
+		t.Log("Columns", cols)
+		dests := make([]driver.Value, len(cols))
+		for {
+			if err := sub.Next(dests); err != nil {
+				if err == io.EOF {
+					break
+				}
+				sub.Close()
+				t.Error(err)
+				break
+			}
+			// fmt.Println(dests)
+			t.Log(dests)
+		}
+		sub.Close()
+	}
+	// Test the Finalizers
+	runtime.GC()
+}
+
+func TestSelectRefCursorWrap(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("SelectRefCursorWrap"), 10*time.Second)
+	defer cancel()
+	rows, err := testDb.QueryContext(ctx, "SELECT CURSOR(SELECT object_name, object_type, object_id, created FROM all_objects WHERE ROWNUM <= 10) FROM DUAL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var intf driver.Rows
+		if err := rows.Scan(&intf); err != nil {
+			t.Error(err)
+			continue
+		}
+		t.Logf("%[1]T %[1]p", intf)
+		dr := intf
+		sub, err := godror.WrapRows(ctx, testDb, dr)
+		if err != nil {
+			dr.Close()
+			t.Fatal(err)
+		}
+		t.Log("Sub", sub)
+		for sub.Next() {
+			var oName, oType, oID string
+			var created time.Time
+			if err := sub.Scan(&oName, &oType, &oID, &created); err != nil {
+				dr.Close()
+				sub.Close()
+				t.Error(err)
+				break
+			}
+			t.Log(oName, oType, oID, created)
+		}
+		dr.Close()
+		sub.Close()
+	}
+	// Test the Finalizers
+	runtime.GC()
+}
+
+func TestSelectRefCursorSQLRows(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("SelectRefCursorSQLRows"), 10*time.Second)
+	defer cancel()
+	rows, err := testDb.QueryContext(ctx, "SELECT level, CURSOR(SELECT object_name FROM all_objects WHERE ROWNUM <= 3) FROM DUAL CONNECT BY level <= 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var n int
+	for rows.Next() {
+		var level int
+		var sub sql.Rows
+		if err := rows.Scan(&level, &sub); err != nil {
+			t.Fatal(err)
+		}
+		var m int
+		for sub.Next() {
+			var oName string
+			if err := sub.Scan(&oName); err != nil {
+				t.Fatal(err)
+			}
+			m++
+		}
+		if err := sub.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if m != 3 {
+			t.Errorf("%d. got %d sub rows, wanted 3", level, m)
+		}
+		n++
+		// sub is closed by the parent's next Next.
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("got %d rows, wanted 3", n)
+	}
+}
+
+func TestExecRefCursor(t *testing.T) {
+	t.Parallel()
+	defer tl.enableLogging(t)()
+	ctx, cancel := context.WithTimeout(testContext("ExecRefCursor"), 30*time.Second)
+	defer cancel()
+	funName := "test_rc" + tblSuffix
+	funQry := "CREATE OR REPLACE FUNCTION " + funName + ` RETURN SYS_REFCURSOR IS
+  v_cur SYS_REFCURSOR;
+BEGIN
+  OPEN v_cur FOR SELECT object_name FROM all_objects WHERE ROWNUM < 10;
+  RETURN(v_cur);
+END;`
+	if _, err := testDb.ExecContext(ctx, funQry); err != nil {
+		t.Fatalf("%s: %v", funQry, err)
+	}
+	defer testDb.ExecContext(ctx, `DROP FUNCTION `+funName)
+	qry := "BEGIN :1 := " + funName + "; END;"
+	var dr driver.Rows
+	if _, err := testDb.ExecContext(ctx, qry, sql.Out{Dest: &dr}); err != nil {
+		t.Fatalf("%s: %v", qry, err)
+	}
+	defer dr.Close()
+	sub, err := godror.WrapRows(ctx, testDb, dr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+	t.Log("Sub", sub)
+	for sub.Next() {
+		var s string
+		if err := sub.Scan(&s); err != nil {
+			t.Fatal(err)
+		}
+		t.Log(s)
+	}
+	runtime.GC()
+	time.Sleep(time.Second)
+	runtime.GC()
+}
+
+func TestExecuteMany(t *testing.T) {
+	t.Parallel()
+	defer tl.enableLogging(t)()
+
+	ctx, cancel := context.WithTimeout(testContext("ExecuteMany"), 30*time.Second)
+	defer cancel()
+	tbl := "test_em" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (f_id INTEGER, f_int INTEGER, f_num NUMBER, f_num_6 NUMBER(6), F_num_5_2 NUMBER(5,2), f_vc VARCHAR2(30), F_dt DATE)")
+	defer testDb.Exec("DROP TABLE " + tbl)
+
+	const num = 1000
+	ints := make([]int, num)
+	nums := make([]godror.Number, num)
+	int32s := make([]int32, num)
+	floats := make([]float64, num)
+	strs := make([]string, num)
+	dates := make([]time.Time, num)
+
+	tx, err := testDb.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if _, err = tx.ExecContext(ctx, "ALTER SESSION SET time_zone = local"); err != nil {
+		t.Fatal(err)
+	}
+	// This is instead of now: a nice moment in time right before the summer time shift
+	now := time.Date(2017, 10, 29, 1, 27, 53, 0, time.Local).Truncate(time.Second)
+	ids := make([]int, num)
+	for i := range nums {
+		ids[i] = i
+		ints[i] = i << 1
+		nums[i] = godror.Number(strconv.Itoa(i))
+		int32s[i] = int32(i)
+		floats[i] = float64(i) / float64(3.14)
+		strs[i] = fmt.Sprintf("%x", i)
+		dates[i] = now.Add(-time.Duration(i) * time.Hour)
+	}
+	for i, tc := range []struct {
+		Value interface{}
+		Name  string
+	}{
+		{Name: "f_int", Value: ints},
+		{Name: "f_num", Value: nums},
+		{Name: "f_num_6", Value: int32s},
+		{Name: "f_num_5_2", Value: floats},
+		{Name: "f_vc", Value: strs},
+		{Name: "f_dt", Value: dates},
+	} {
+		res, execErr := tx.ExecContext(ctx,
+			"INSERT INTO "+tbl+" ("+tc.Name+") VALUES (:1)", //nolint:gas
+			tc.Value)
+		if execErr != nil {
+			t.Fatalf("%d. INSERT INTO "+tbl+" (%q) VALUES (%+v): %#v", //nolint:gas
+				i, tc.Name, tc.Value, execErr)
+		}
+		ra, raErr := res.RowsAffected()
+		if raErr != nil {
+			t.Error(raErr)
+		} else if ra != num {
+			t.Errorf("%d. %q: wanted %d rows, got %d", i, tc.Name, num, ra)
+		}
+	}
+	tx.Rollback()
+
+	testDb.ExecContext(ctx, "TRUNCATE TABLE "+tbl+"")
+
+	if tx, err = testDb.BeginTx(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO `+tbl+ //nolint:gas
+			` (f_id, f_int, f_num, f_num_6, F_num_5_2, F_vc, F_dt)
+			VALUES
+			(:1, :2, :3, :4, :5, :6, :7)`,
+		ids, ints, nums, int32s, floats, strs, dates)
+	if err != nil {
+		t.Fatalf("%#v", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		t.Error(err)
+	} else if ra != num {
+		t.Errorf("wanted %d rows, got %d", num, ra)
+	}
+
+	rows, err := tx.QueryContext(ctx,
+		"SELECT * FROM "+tbl+" ORDER BY F_id", //nolint:gas
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	i := 0
+	for rows.Next() {
+		var id, Int int
+		var num string
+		var vc string
+		var num6 int32
+		var num52 float64
+		var dt time.Time
+		if err := rows.Scan(&id, &Int, &num, &num6, &num52, &vc, &dt); err != nil {
+			t.Fatal(err)
+		}
+		if id != i {
+			t.Fatalf("ID got %d, wanted %d.", id, i)
+		}
+		if Int != ints[i] {
+			t.Errorf("%d. INT got %d, wanted %d.", i, Int, ints[i])
+		}
+		if num != string(nums[i]) {
+			t.Errorf("%d. NUM got %q, wanted %q.", i, num, nums[i])
+		}
+		if num6 != int32s[i] {
+			t.Errorf("%d. NUM_6 got %v, wanted %v.", i, num6, int32s[i])
+		}
+		rounded := float64(int64(floats[i]/0.005+0.5)) * 0.005
+		if math.Abs(num52-rounded) > 0.05 {
+			t.Errorf("%d. NUM_5_2 got %v, wanted %v.", i, num52, rounded)
+		}
+		if vc != strs[i] {
+			t.Errorf("%d. VC got %q, wanted %q.", i, vc, strs[i])
+		}
+		t.Logf("%d. dt=%v", i, dt)
+		if !dt.Equal(dates[i]) {
+			if fmt.Sprintf("%v", dt) == "2017-10-29 02:27:53 +0100 CET" &&
+				fmt.Sprintf("%v", dates[i]) == "2017-10-29 00:27:53 +0000 UTC" {
+				t.Logf("%d. got DT %v, wanted %v (%v)", i, dt, dates[i], dt.Sub(dates[i]))
+			} else {
+				t.Errorf("%d. got DT %v, wanted %v (%v)", i, dt, dates[i], dt.Sub(dates[i]))
+			}
+		}
+		i++
+	}
+}
+func TestReadWriteLOB(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("ReadWriteLob"), 30*time.Second)
+	defer cancel()
+	conn, err := testDb.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	tbl := "test_lob" + tblSuffix
+	conn.ExecContext(ctx, "DROP TABLE "+tbl)
+	conn.ExecContext(ctx,
+		"CREATE TABLE "+tbl+" (f_id NUMBER(6), f_blob BLOB, f_clob CLOB)", //nolint:gas
+	)
+	defer testDb.Exec(
+		"DROP TABLE " + tbl, //nolint:gas
+	)
+
+	stmt, err := conn.PrepareContext(ctx,
+		"INSERT INTO "+tbl+" (F_id, f_blob, F_clob) VALUES (:1, :2, :3)", //nolint:gas
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	for tN, tC := range []struct {
+		String string
+		Bytes  []byte
+	}{
+		{Bytes: []byte{0, 1, 2, 3, 4, 5}, String: "12345"},
+	} {
+
+		if _, err = stmt.ExecContext(ctx, tN*2, tC.Bytes, tC.String); err != nil {
+			t.Errorf("%d/1. (%v, %q): %v", tN, tC.Bytes, tC.String, err)
+			continue
+		}
+		if _, err = stmt.ExecContext(ctx, tN*3+1,
+			godror.Lob{Reader: bytes.NewReader(tC.Bytes)},
+			godror.Lob{Reader: strings.NewReader(tC.String), IsClob: true},
+		); err != nil {
+			t.Errorf("%d/2. (%v, %q): %v", tN, tC.Bytes, tC.String, err)
+		}
+
+		var rows *sql.Rows
+		rows, err = conn.QueryContext(ctx,
+			"SELECT F_id, F_blob, F_clob FROM "+tbl+" WHERE F_id IN (:1, :2)", //nolint:gas
+			godror.LobAsReader(),
+			2*tN, 2*tN+1)
+		if err != nil {
+			t.Errorf("%d/3. %v", tN, err)
+			continue
+		}
+		for rows.Next() {
+			var id, blob, clob interface{}
+			if err = rows.Scan(&id, &blob, &clob); err != nil {
+				rows.Close()
+				t.Errorf("%d/3. scan: %v", tN, err)
+				continue
+			}
+			t.Logf("%d. blob=%+v clob=%+v", id, blob, clob)
+			if blob, ok := blob.(*godror.Lob); !ok {
+				t.Errorf("%d. %T is not LOB", id, blob)
+			} else {
+				got := make([]byte, len(tC.Bytes))
+				n, err := io.ReadFull(blob, got)
+				t.Logf("%d. BLOB read %d (%q =%t= %q): %+v", id, n, got, bytes.Equal(got, tC.Bytes), tC.Bytes, err)
+				if err != nil {
+					t.Errorf("%d. %v", id, err)
+				} else if !bytes.Equal(got, tC.Bytes) {
+					t.Errorf("%d. got %q for BLOB, wanted %q", id, got, tC.Bytes)
+				}
+			}
+			if clob, ok := clob.(*godror.Lob); !ok {
+				t.Errorf("%d. %T is not LOB", id, clob)
+			} else {
+				var got []byte
+				if got, err = io.ReadAll(clob); err != nil {
+					t.Errorf("%d. %v", id, err)
+				} else if got := string(got); got != tC.String {
+					t.Errorf("%d. got %q for CLOB, wanted %q", id, got, tC.String)
+				}
+			}
+		}
+		rows.Close()
+	}
+
+	rows, err := conn.QueryContext(ctx,
+		"SELECT F_blob, F_clob FROM "+tbl+"", //nolint:gas
+		godror.ClobAsString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var b []byte
+		var s string
+		if err = rows.Scan(&b, &s); err != nil {
+			t.Error(err)
+		}
+		t.Logf("clobAsString: %q", s)
+	}
+
+	qry := "SELECT CURSOR(SELECT f_id, F_blob, f_clob FROM " + tbl + " WHERE ROWNUM <= 10) FROM DUAL"
+	rows, err = testDb.QueryContext(ctx, qry, godror.ClobAsString())
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var intf interface{}
+		if err := rows.Scan(&intf); err != nil {
+			t.Error(err)
+			continue
+		}
+		t.Logf("%T", intf)
+		sub := intf.(driver.RowsColumnTypeScanType)
+		cols := sub.Columns()
+		t.Log("Columns", cols)
+		dests := make([]driver.Value, len(cols))
+		for {
+			if err := sub.Next(dests); err != nil {
+				if err == io.EOF {
+					break
+				}
+				t.Error(err)
+				break
+			}
+			// fmt.Println(dests)
+			t.Log(dests)
+		}
+		sub.Close()
+	}
+
+}
+
+func TestReadWriteBfile(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("ReadWritBfile"), 30*time.Second)
+	defer cancel()
+	conn, err := testDb.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	tbl := "test_Bfile" + tblSuffix
+	conn.ExecContext(ctx, "DROP TABLE "+tbl)
+	conn.ExecContext(ctx,
+		"CREATE TABLE "+tbl+" (f_id NUMBER(6), f_bf BFILE)", //nolint:gas
+	)
+	defer testDb.Exec(
+		"DROP TABLE " + tbl, //nolint:gas
+	)
+
+	stmt, err := conn.PrepareContext(ctx,
+		"INSERT INTO "+tbl+" (F_id, f_bf) VALUES (:1, BFILENAME(:2, :3))", //nolint:gas
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	for tN, tC := range []struct {
+		Dir  string
+		File string
+	}{
+		{"TEST", "1.txt"},
+	} {
+
+		if _, err = stmt.ExecContext(ctx, tN*2, tC.Dir, tC.File); err != nil {
+			t.Errorf("%d/1. (%s, %s): %v", tN, tC.Dir, tC.File, err)
+			continue
+		}
+
+		var rows *sql.Rows
+		rows, err = conn.QueryContext(ctx,
+			"SELECT F_id, F_bf FROM "+tbl+" WHERE F_id = :1", //nolint:gas
+			2*tN)
+		if err != nil {
+			t.Errorf("%d/2. %v", tN, err)
+			continue
+		}
+		for rows.Next() {
+			var id, bfile interface{}
+			if err = rows.Scan(&id, &bfile); err != nil {
+				rows.Close()
+				t.Errorf("%d/2. scan: %v", tN, err)
+				continue
+			}
+			t.Logf("%d. bfile=%+v", id, bfile)
+			if b, ok := bfile.(*godror.Lob); !ok {
+				t.Errorf("%d. %T is not LOB", id, b)
+			} else {
+				lobD, err := b.Hijack()
+				if err != nil {
+					t.Error(err)
+				}
+				dir, file, err := lobD.GetFileName()
+				if err != nil {
+					t.Error(err)
+				}
+				if dir != tC.Dir {
+					t.Errorf("the got dir %v not equal want %v", dir, tC.Dir)
+				}
+				if file != tC.File {
+					t.Errorf("the got file %v not equal want %v", file, tC.File)
+				}
+			}
+		}
+		rows.Close()
+	}
+}
+
+func printSlice(orig interface{}) interface{} {
+	ro := reflect.ValueOf(orig)
+	if ro.Kind() == reflect.Ptr {
+		ro = ro.Elem()
+	}
+	ret := make([]string, 0, ro.Len())
+	for i := 0; i < ro.Len(); i++ {
+		ret = append(ret, fmt.Sprintf("%v", ro.Index(i).Interface()))
+	}
+	return ret
+}
+func copySlice(orig interface{}) interface{} {
+	ro := reflect.ValueOf(orig)
+	rc := reflect.New(reflect.TypeOf(orig)).Elem() // *[]s
+	rc.Set(reflect.MakeSlice(ro.Type(), ro.Len(), ro.Cap()+1))
+	for i := 0; i < ro.Len(); i++ {
+		rc.Index(i).Set(ro.Index(i))
+	}
+	return rc.Addr().Interface()
+}
+
+func TestOpenCloseDB(t *testing.T) {
+	cs, err := godror.ParseDSN(testConStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs.MinSessions, cs.MaxSessions = 0, 4
+	cs.StandaloneConnection = godror.Bool(true)
+	const countQry = "SELECT COUNT(0) FROM user_objects"
+	ctx, cancel := context.WithCancel(testContext("OpenCloseDB"))
+	defer cancel()
+	grp, ctx := errgroup.WithContext(ctx)
+	grp.SetLimit(cs.MaxSessions)
+	for i := 0; i < 32; i++ {
+		cs := cs
+		cs.WaitTimeout += time.Duration(i)
+		grp.Go(func() error {
+			// To make the connections differ
+			t.Log(cs.String())
+			db := sql.OpenDB(godror.NewConnector(cs))
+			defer db.Close()
+			conn, err := db.Conn(ctx)
+			if err != nil {
+				return fmt.Errorf("Open: %w", err)
+			}
+			defer conn.Close()
+			var n int32
+			err = conn.QueryRowContext(ctx, countQry).Scan(&n)
+			t.Logf("object count: %d (%+v)", n, err)
+			return nil
+		})
+	}
+	if err := grp.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpenCloseConn(t *testing.T) {
+	cs, err := godror.ParseDSN(testConStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs.MinSessions, cs.MaxSessions = 0, 4
+	t.Log(cs.String())
+	db := sql.OpenDB(godror.NewConnector(cs))
+	defer db.Close()
+	const countQry = "SELECT COUNT(0) FROM user_objects"
+	ctx, cancel := context.WithCancel(testContext("OpenCloseConn"))
+	defer cancel()
+	limitCh := make(chan struct{}, cs.MaxSessions)
+	grp, ctx := errgroup.WithContext(ctx)
+	for i := 0; i < 100; i++ {
+		limitCh <- struct{}{}
+		grp.Go(func() error {
+			defer func() { <-limitCh }()
+			conn, err := db.Conn(ctx)
+			if err != nil {
+				return fmt.Errorf("Open: %w", err)
+			}
+			defer conn.Close()
+			var n int32
+			err = conn.QueryRowContext(ctx, countQry).Scan(&n)
+			t.Logf("object count: %d (%+v)", n, err)
+			return nil
+		})
+	}
+	if err := grp.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpenCloseTx(t *testing.T) {
+	cs, err := godror.ParseDSN(testConStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs.MinSessions, cs.MaxSessions = 1, 6
+	t.Log(cs.String())
+	db, err := sql.Open("godror", cs.StringWithPassword())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			t.Error("CLOSE:", cErr)
+		}
+	}()
+	db.SetMaxIdleConns(cs.MinSessions)
+	db.SetMaxOpenConns(cs.MaxSessions)
+	ctx, cancel := context.WithCancel(testContext("OpenCloseTx"))
+	defer cancel()
+	const module = "godror.v2.test-OpenCloseTx "
+	const countQry = "SELECT COUNT(0) FROM v$session WHERE module LIKE '" + module + "%'"
+	stmt, err := db.PrepareContext(ctx, countQry)
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-12516:") {
+			t.Skip(err)
+		}
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	sessCount := func() (n int, stats godror.PoolStats, err error) {
+		var sErr error
+		sErr = godror.Raw(ctx, db, func(cx godror.Conn) error {
+			var gErr error
+			stats, gErr = cx.GetPoolStats()
+			return gErr
+		})
+		if qErr := stmt.QueryRowContext(ctx).Scan(&n); qErr != nil && sErr == nil {
+			sErr = qErr
+		}
+		return n, stats, sErr
+	}
+	n, ps, err := sessCount()
+	if err != nil {
+		t.Skip(err)
+	}
+	if n > 0 {
+		t.Logf("sessCount=%d, stats=%s at start!", n, ps)
+	}
+	var tt godror.TraceTag
+	for i := 0; i < cs.MaxSessions*2; i++ {
+		t.Logf("%d. PREPARE", i+1)
+		stmt, err := db.PrepareContext(ctx, "SELECT 1 FROM DUAL")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n, ps, err = sessCount(); err != nil {
+			t.Error(err)
+		} else {
+			t.Logf("sessCount=%d stats=%s", n, ps)
+		}
+		tt.Module = fmt.Sprintf("%s%d", module, 2*i)
+		ctx = godror.ContextWithTraceTag(ctx, tt)
+		tx1, err1 := db.BeginTx(ctx, nil)
+		if err1 != nil {
+			t.Fatal(err1)
+		}
+		tt.Module = fmt.Sprintf("%s%d", module, 2*i+1)
+		ctx = godror.ContextWithTraceTag(ctx, tt)
+		tx2, err2 := db.BeginTx(ctx, nil)
+		if err2 != nil {
+			if strings.Contains(err2.Error(), "ORA-12516:") {
+				tx1.Rollback()
+				break
+			}
+			t.Fatal(err2)
+		}
+		if n, ps, err = sessCount(); err != nil {
+			t.Log(err)
+		} else if n == 0 {
+			t.Errorf("sessCount=0, stats=%s want at least 2", ps)
+		} else {
+			t.Logf("sessCount=%d stats=%s", n, ps)
+		}
+		tx1.Rollback()
+		tx2.Rollback()
+		stmt.Close()
+	}
+	if n, ps, err = sessCount(); err != nil {
+		t.Log(err)
+	} else if n > 7 {
+		t.Errorf("sessCount=%d stats=%s", n, ps)
+	}
+}
+
+func TestOpenBadMemory(t *testing.T) {
+	var mem runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&mem)
+	t.Log("Allocated 0:", mem.Alloc)
+	zero := mem.Alloc
+	for i := 0; i < 100; i++ {
+		badConStr := strings.Replace(testConStr, "@", fmt.Sprintf("BAD%dBAD@", i), 1)
+		db, err := sql.Open("godror", badConStr)
+		if err != nil {
+			t.Fatalf("bad connection string %q didn't produce error!", badConStr)
+		}
+		db.Close()
+		runtime.GC()
+		runtime.ReadMemStats(&mem)
+		t.Logf("Allocated %d: %d", i+1, mem.Alloc)
+	}
+	d := mem.Alloc - zero
+	if mem.Alloc < zero {
+		d = 0
+	}
+	t.Logf("atlast: %d", d)
+	if d > 64<<10 {
+		t.Errorf("Consumed more than 64KiB of memory: %d", d)
+	}
+}
+
+func TestSelectFloat(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("SelectFloat"), 10*time.Second)
+	defer cancel()
+	tbl := "test_numbers" + tblSuffix
+	qry := `CREATE TABLE ` + tbl + ` (
+  INT_COL     NUMBER,
+  FLOAT_COL  NUMBER,
+  EMPTY_INT_COL NUMBER
+)`
+	testDb.Exec("DROP TABLE " + tbl)
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer testDb.Exec("DROP TABLE " + tbl)
+
+	const INT, FLOAT = 1234567, 4.5
+	qry = `INSERT INTO ` + tbl + //nolint:gas
+		` (INT_COL, FLOAT_COL, EMPTY_INT_COL)
+     VALUES (1234567, 45/10, NULL)`
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+
+	qry = "SELECT int_col, float_col, empty_int_col FROM " + tbl //nolint:gas
+	type numbers struct {
+		Number  godror.Number
+		String  string
+		NString sql.NullString
+		NInt    sql.NullInt64
+		Int64   int64
+		Float   float64
+		Int     int
+	}
+	var n numbers
+	var i1, i2, i3 interface{}
+	for tName, tC := range map[string]struct {
+		Dest [3]interface{}
+		Want numbers
+	}{
+		"int,float,nstring": {
+			Dest: [3]interface{}{&n.Int, &n.Float, &n.NString},
+			Want: numbers{Int: INT, Float: FLOAT},
+		},
+		"inf,float,Number": {
+			Dest: [3]interface{}{&n.Int, &n.Float, &n.Number},
+			Want: numbers{Int: INT, Float: FLOAT},
+		},
+		"int64,float,nullInt": {
+			Dest: [3]interface{}{&n.Int64, &n.Float, &n.NInt},
+			Want: numbers{Int64: INT, Float: FLOAT},
+		},
+		"intf,intf,intf": {
+			Dest: [3]interface{}{&i1, &i2, &i3},
+			Want: numbers{Int64: INT, Float: FLOAT},
+		},
+	} {
+		i1, i2, i3 = nil, nil, nil
+		n = numbers{}
+		F := func() error {
+			err := testDb.QueryRowContext(ctx, qry).Scan(tC.Dest[0], tC.Dest[1], tC.Dest[2])
+			if err != nil {
+				err = fmt.Errorf("%s: %w", qry, err)
+			}
+			return err
+		}
+		if err := F(); err != nil {
+			if strings.HasSuffix(err.Error(), "unsupported Scan, storing driver.Value type <nil> into type *string") {
+				t.Log("WARNING:", err)
+				continue
+			}
+			noLogging := tl.enableLogging(t)
+			err = F()
+			t.Errorf("%q: %v", tName, fmt.Errorf("%s: %w", qry, err))
+			noLogging()
+			continue
+		}
+		if tName == "intf,intf,intf" {
+			t.Logf("%q: %#v, %#v, %#v", tName, i1, i2, i3)
+			continue
+		}
+		t.Logf("%q: %+v", tName, n)
+		if n != tC.Want {
+			t.Errorf("%q:\ngot\t%+v,\nwanted\t%+v.", tName, n, tC.Want)
+		}
+	}
+}
+
+func TestNumInputs(t *testing.T) {
+	t.Parallel()
+	var a, b string
+	if err := testDb.QueryRow("SELECT :1, :2 FROM DUAL", 'a', 'b').Scan(&a, &b); err != nil {
+		t.Errorf("two inputs: %+v", err)
+	}
+	if err := testDb.QueryRow("SELECT :a, :b FROM DUAL", 'a', 'b').Scan(&a, &b); err != nil {
+		t.Errorf("two named inputs: %+v", err)
+	}
+	if err := testDb.QueryRow("SELECT :a, :a FROM DUAL", sql.Named("a", a)).Scan(&a, &b); err != nil {
+		t.Errorf("named inputs: %+v", err)
+	}
+}
+
+func TestPtrArg(t *testing.T) {
+	t.Parallel()
+	s := "dog"
+	rows, err := testDb.Query("SELECT * FROM user_objects WHERE object_name=:1", &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+}
+
+func TestRanaOraIssue244(t *testing.T) {
+	tableName := "test_ora_issue_244" + tblSuffix
+	qry := "CREATE TABLE " + tableName + " (FUND_ACCOUNT VARCHAR2(18) NOT NULL, FUND_CODE VARCHAR2(6) NOT NULL, BUSINESS_FLAG NUMBER(10) NOT NULL, MONEY_TYPE VARCHAR2(3) NOT NULL)"
+	testDb.Exec("DROP TABLE " + tableName)
+	if _, err := testDb.Exec(qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	var max int
+	ctx, cancel := context.WithCancel(testContext("RanaOraIssue244-1"))
+	txs := make([]*sql.Tx, 0, maxSessions/2)
+	for max = 0; max < maxSessions/2; max++ {
+		tx, err := testDb.BeginTx(ctx, nil)
+		if err != nil {
+			max--
+			break
+		}
+		txs = append(txs, tx)
+	}
+	for _, tx := range txs {
+		tx.Rollback()
+	}
+	cancel()
+	t.Logf("maxSessions=%d max=%d", maxSessions, max)
+
+	dur := time.Minute / 2
+	if testing.Short() {
+		dur = 10 * time.Second
+	}
+	ctx, cancel = context.WithTimeout(testContext("RanaOraIssue244-2"), dur)
+	defer cancel()
+	defer testDb.Exec("DROP TABLE " + tableName)
+	const bf = "143"
+	const sc = "270004"
+	qry = "INSERT INTO " + tableName + " (fund_account, fund_code, business_flag, money_type) VALUES (:1, :2, :3, :4)" //nolint:gas
+	tx, err := testDb.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	stmt, err := tx.PrepareContext(ctx, qry)
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	fas := []string{"14900666", "1868091", "1898964", "14900397"}
+	for _, v := range fas {
+		if _, err := stmt.ExecContext(ctx, v, sc, bf, "0"); err != nil {
+			stmt.Close()
+			t.Fatal(err)
+		}
+	}
+	stmt.Close()
+	tx.Commit()
+
+	qry = `SELECT fund_account, money_type FROM ` + tableName + ` WHERE business_flag = :1 AND fund_code = :2 AND fund_account = :3` //nolint:gas
+	grp, grpCtx := errgroup.WithContext(ctx)
+	for i := 0; i < max; i++ {
+		index := rand.Intn(len(fas))
+		i, qry := i, qry
+		grp.Go(func() error {
+			tx, err := testDb.BeginTx(grpCtx, &sql.TxOptions{ReadOnly: true})
+			if err != nil {
+				return err
+			}
+			defer tx.Rollback()
+
+			stmt, err := tx.PrepareContext(grpCtx, qry)
+			if err != nil {
+				return fmt.Errorf("%d.Prepare %q: %w", i, qry, err)
+			}
+			defer stmt.Close()
+
+			for j := 0; j < 3; j++ {
+				select {
+				case <-grpCtx.Done():
+					return grpCtx.Err()
+				default:
+				}
+				index = (index + 1) % len(fas)
+				rows, err := stmt.QueryContext(grpCtx, bf, sc, fas[index])
+				if err != nil {
+					return fmt.Errorf("%d.tx=%p stmt=%p %d. %q: %w", i, tx, stmt, j, qry, err)
+				}
+
+				for rows.Next() {
+					var acc, mt string
+					if err = rows.Scan(&acc, &mt); err != nil {
+						err = fmt.Errorf("Scan: %w", err)
+						break
+					}
+
+					if acc != fas[index] {
+						err = fmt.Errorf("got acc %q, wanted %q", acc, fas[index])
+						break
+					}
+					if mt != "0" {
+						err = fmt.Errorf("got mt %q, wanted 0", mt)
+						break
+					}
+				}
+				rows.Close()
+				if err == nil {
+					err = rows.Err()
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if err := grp.Wait(); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		if errors.Is(err, driver.ErrBadConn) {
+			return
+		}
+		errS := errors.Unwrap(err).Error()
+		switch errS {
+		case "sql: statement is closed",
+			"sql: transaction has already been committed or rolled back":
+			return
+		}
+		if strings.Contains(errS, "ORA-12516:") || strings.Contains(errS, "ORA-24496:") {
+			t.Log(err)
+		} else {
+			t.Error(err)
+		}
+	}
+}
+
+func TestNumberMarshal(t *testing.T) {
+	t.Parallel()
+	var n godror.Number
+	if err := testDb.QueryRow("SELECT 6000370006565900000073 FROM DUAL").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	t.Log(n.String())
+	b, err := n.MarshalJSON()
+	t.Logf("%s", b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte{'e'}) {
+		t.Errorf("got %q, wanted without scientific notation", b)
+	}
+	if b, err = json.Marshal(struct {
+		N godror.Number
+	}{N: n},
+	); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("%s", b)
+}
+
+func TestExecHang(t *testing.T) {
+	defer tl.enableLogging(t)()
+	ctx, cancel := context.WithTimeout(testContext("ExecHang"), 1*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	errMismatch := errors.New("mismatch")
+	var wg sync.WaitGroup
+	for i := 0; i < cap(done); i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := ctx.Err(); err != nil {
+				done <- err
+				return
+			}
+			_, err := testDb.ExecContext(ctx, "BEGIN DBMS_SESSION.sleep(3); END;")
+			t.Logf("%d. %v", i, err)
+			if err == nil {
+				done <- fmt.Errorf("%w: %d. wanted timeout got %+v", errMismatch, i, err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+}
+
+func TestNumberNull(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("NumberNull"), time.Minute)
+	defer cancel()
+	testDb.Exec("DROP TABLE number_test")
+	qry := `CREATE TABLE number_test (
+		caseNum NUMBER(3),
+		precisionNum NUMBER(5),
+      precScaleNum NUMBER(5, 0),
+		normalNum NUMBER
+		)`
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer testDb.Exec("DROP TABLE number_test")
+
+	qry = `
+		INSERT ALL
+		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (1, 4, 65, 123)
+		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (2, NULL, NULL, NULL)
+		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (3, NULL, NULL, NULL)
+		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (4, NULL, 42, NULL)
+		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (5, NULL, NULL, 31)
+		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (6, 3, 3, 4)
+		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (7, NULL, NULL, NULL)
+		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (8, 6, 9, 7)
+		SELECT 1 FROM DUAL`
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	qry = "SELECT precisionNum, precScaleNum, normalNum FROM number_test ORDER BY caseNum"
+	rows, err := testDb.Query(qry)
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var precisionNum, recScaleNum, normalNum sql.NullInt64
+		if err = rows.Scan(&precisionNum, &recScaleNum, &normalNum); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Log(precisionNum, recScaleNum, normalNum)
+
+		if precisionNum.Int64 == 0 && precisionNum.Valid {
+			t.Errorf("precisionNum=%v, wanted {0 false}", precisionNum)
+		}
+		if recScaleNum.Int64 == 0 && recScaleNum.Valid {
+			t.Errorf("recScaleNum=%v, wanted {0 false}", recScaleNum)
+		}
+	}
+
+	rows, err = testDb.Query(qry)
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var precisionNumStr, recScaleNumStr, normalNumStr sql.NullString
+		if err = rows.Scan(&precisionNumStr, &recScaleNumStr, &normalNumStr); err != nil {
+			t.Fatal(err)
+		}
+		t.Log(precisionNumStr, recScaleNumStr, normalNumStr)
+	}
+}
+
+func TestNullFloat(t *testing.T) {
+	t.Parallel()
+	testDb.Exec("DROP TABLE test_char")
+	if _, err := testDb.Exec(`CREATE TABLE test_char (
+			CHARS VARCHAR2(10 BYTE),
+			FLOATS NUMBER(10, 2)
+		)`); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.Exec("DROP TABLE test_char")
+
+	tx, err := testDb.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		"INSERT INTO test_char VALUES(:CHARS, :FLOATS)",
+		[]string{"dog", "", "cat"},
+		/*[]sql.NullString{sql.NullString{"dog", true},
+		sql.NullString{"", false},
+		sql.NullString{"cat", true}},*/
+		[]sql.NullFloat64{
+			{Float64: 3.14, Valid: true},
+			{Float64: 12.36, Valid: true},
+			{Float64: 0.0, Valid: false},
+		},
+	)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestColumnSize(t *testing.T) {
+	t.Parallel()
+	testDb.Exec("DROP TABLE test_column_size")
+	if _, err := testDb.Exec(`CREATE TABLE test_column_size (
+		vc20b VARCHAR2(20 BYTE),
+		vc1b VARCHAR2(1 BYTE),
+		nvc20 NVARCHAR2(20),
+		nvc1 NVARCHAR2(1),
+		vc20c VARCHAR2(20 CHAR),
+		vc1c VARCHAR2(1 CHAR)
+	)`); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.Exec("DROP TABLE test_column_size")
+
+	r, err := testDb.Query("SELECT * FROM test_column_size")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	rts, err := r.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, col := range rts {
+		l, _ := col.Length()
+
+		t.Logf("Column %q has length %v", col.Name(), l)
+	}
+}
+
+func TestReturning(t *testing.T) {
+	t.Parallel()
+	defer tl.enableLogging(t)()
+	testDb.Exec("DROP TABLE test_returning")
+	if _, err := testDb.Exec("CREATE TABLE test_returning (a VARCHAR2(20))"); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.Exec("DROP TABLE test_returning")
+
+	want := "abraca dabra"
+	var got string
+	if _, err := testDb.Exec(
+		`INSERT INTO test_returning (a) VALUES (UPPER(:1)) RETURNING a INTO :2`,
+		want, sql.Out{Dest: &got},
+	); err != nil {
+		t.Fatal(err)
+	}
+	want = strings.ToUpper(want)
+	if want != got {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+
+	if _, err := testDb.Exec(
+		`UPDATE test_returning SET a = '1' WHERE 1=0 RETURNING a /*LASTINSERTID*/ INTO :1`,
+		sql.Out{Dest: &got},
+	); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("RETURNING (zero set): %v", got)
+}
+
+func TestBindStruct(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("BindStruct"), 10*time.Second)
+	defer cancel()
+	type params struct {
+		Name string `db:"name"`
+		Skip string `db:"-"`
+		ID   int    `db:"id"`
+	}
+	var got string
+	if err := testDb.QueryRowContext(ctx,
+		"SELECT :name||'#'||:id||'#'||:name FROM DUAL",
+		params{ID: 3, Name: "A", Skip: "x"},
+	).Scan(&got); err != nil {
+		t.Fatal(err)
+	}
+	if want := "A#3#A"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+
+	if err := testDb.QueryRowContext(ctx,
+		"SELECT :name||'#'||:id FROM DUAL", sql.Named("name", "A"),
+	).Scan(&got); err == nil {
+		t.Errorf("missing argument: got %q, wanted error", got)
+	}
+}
+
+func TestOutStruct(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("OutStruct"), 10*time.Second)
+	defer cancel()
+	var res struct {
+		Rows driver.Rows `db:"rows"`
+		Msg  string      `db:"msg,inout"`
+		Num  int         `db:"num"`
+	}
+	res.Msg = "hello"
+	if _, err := testDb.ExecContext(ctx,
+		`BEGIN :num := :id * 2; :msg := UPPER(:msg); OPEN :rows FOR SELECT :id FROM DUAL; END;`,
+		sql.Named("id", 21), godror.OutStruct(&res),
+	); err != nil {
+		t.Fatal(err)
+	}
+	if res.Rows == nil {
+		t.Fatal("nil rows")
+	}
+	defer res.Rows.Close()
+	if res.Num != 42 || res.Msg != "HELLO" {
+		t.Errorf("got %+v", res)
+	}
+	dest := make([]driver.Value, 1)
+	if err := res.Rows.Next(dest); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("rows: %v", dest)
+}
+
+func TestReturningSlice(t *testing.T) {
+	t.Parallel()
+	defer tl.enableLogging(t)()
+	testDb.Exec("DROP TABLE test_returning_slice")
+	if _, err := testDb.Exec("CREATE TABLE test_returning_slice (id NUMBER(3), a VARCHAR2(20))"); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.Exec("DROP TABLE test_returning_slice")
+
+	ids := []int64{1, 2, 3, 4}
+	names := []string{"a", "b", "c", "d"}
+	var got []string
+	if _, err := testDb.Exec(
+		`INSERT INTO test_returning_slice (id, a) VALUES (:1, UPPER(:2)) RETURNING a INTO :3`,
+		ids, names, sql.Out{Dest: &got},
+	); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"A", "B", "C", "D"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("array insert: got %q, wanted %q", got, want)
+	}
+
+	var gotIDs []int64
+	if _, err := testDb.Exec(
+		`UPDATE test_returning_slice SET a = LOWER(a) WHERE id > :1 RETURNING id INTO :2`,
+		1, sql.Out{Dest: &gotIDs},
+	); err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(gotIDs, func(i, j int) bool { return gotIDs[i] < gotIDs[j] })
+	if want := ids[1:]; !reflect.DeepEqual(gotIDs, want) {
+		t.Errorf("multi-row update: got %v, wanted %v", gotIDs, want)
+	}
+}
+
+func TestMaxOpenCursorsORA1000(t *testing.T) {
+	ctx, cancel := context.WithCancel(testContext("ORA1000"))
+	defer cancel()
+	rows, err := testDb.QueryContext(ctx, "SELECT * FROM user_objects WHERE ROWNUM < 100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var openCursors sql.NullInt64
+	const qry1 = "SELECT p.value FROM v$parameter p WHERE p.name = 'open_cursors'"
+	if err := testDb.QueryRowContext(ctx, qry1).Scan(&openCursors); err == nil {
+		t.Logf("open_cursors=%v", openCursors)
+	} else {
+		if err := testDb.QueryRow(qry1).Scan(&openCursors); err != nil {
+			var cErr interface{ Code() int }
+			if errors.As(err, &cErr) && cErr.Code() == 942 {
+				t.Logf("%s: %+v", qry1, err)
+			} else {
+				t.Error(fmt.Errorf("%s: %w", qry1, err))
+			}
+		} else {
+			t.Log(fmt.Errorf("%s: %w", qry1, err))
+		}
+	}
+	n := int(openCursors.Int64)
+	if 0 <= n || n >= 100 {
+		n = 100
+	}
+	n *= 2
+	for i := 0; i < n; i++ {
+		var cnt int64
+		qry2 := "SELECT /* " + strconv.Itoa(i) + " */ 1 FROM DUAL"
+		if err = testDb.QueryRowContext(ctx, qry2).Scan(&cnt); err != nil {
+			t.Fatal(fmt.Errorf("%d. %s: %w", i, qry2, err))
+		}
+	}
+}
+
+func TestRO(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(testContext("RO"))
+	defer cancel()
+	tx, err := testDb.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if _, err = tx.QueryContext(ctx, "SELECT 1 FROM DUAL"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = tx.ExecContext(ctx, "CREATE TABLE test_table (i INTEGER)"); err == nil {
+		t.Log("RO allows CREATE TABLE ?")
+	}
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNullIntoNum(t *testing.T) {
+	t.Parallel()
+	testDb.Exec("DROP TABLE test_null_num")
+	qry := "CREATE TABLE test_null_num (i NUMBER(3))"
+	if _, err := testDb.Exec(qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer testDb.Exec("DROP TABLE test_null_num")
+
+	qry = "INSERT INTO test_null_num (i) VALUES (:1)"
+	var i *int
+	if _, err := testDb.Exec(qry, i); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+}
+
+func TestPing(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(testContext("Ping"), 1*time.Second)
+	defer cancel()
+	err := testDb.PingContext(ctx)
+	cancel()
+	if err != nil {
+		t.Error(err)
+	}
+
+	P, err := godror.ParseDSN(testConStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	P.ConnectString = "--BAD---" + P.ConnectString
+	badDB, err := sql.Open("godror", P.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel = context.WithTimeout(testContext("Ping"), 1*time.Second)
+	defer cancel()
+
+	dl, _ := ctx.Deadline()
+	err = badDB.PingContext(ctx)
+	ok := dl.After(time.Now())
+	if err != nil {
+		t.Log(err)
+	} else {
+		t.Log("ping succeeded")
+		if !ok {
+			t.Error("ping succeeded after deadline!")
+		}
+	}
+}
+
+func TestNoConnectionPooling(t *testing.T) {
+	t.Parallel()
+	db, err := sql.Open("godror",
+		strings.Replace(
+			strings.Replace(testConStr, "TestClassName", godror.NoConnectionPoolingConnectionClass, 1),
+			"standaloneConnection=0", "standaloneConnection=1", 1,
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+}
+
+func TestExecTimeout(t *testing.T) {
+	t.Parallel()
+	defer tl.enableLogging(t)()
+	ctx, cancel := context.WithTimeout(testContext("ExecTimeout"), 100*time.Millisecond)
+	defer cancel()
+	if _, err := testDb.ExecContext(ctx, "DECLARE cnt PLS_INTEGER; BEGIN SELECT COUNT(0) INTO cnt FROM (SELECT 1 FROM all_objects WHERE ROWNUM < 1000), (SELECT 1 FROM all_objects WHERE rownum < 1000); END;"); err != nil {
+		t.Log(err)
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	t.Parallel()
+	defer tl.enableLogging(t)()
+	ctx, cancel := context.WithTimeout(testContext("QueryTimeout"), 100*time.Millisecond)
+	defer cancel()
+	if rows, err := testDb.QueryContext(ctx, "SELECT COUNT(0) FROM (SELECT 1 FROM all_objects WHERE rownum < 1000), (SELECT 1 FROM all_objects WHERE rownum < 1000)"); err != nil {
+		t.Log(err)
+	} else {
+		rows.Close()
+	}
+}
+func TestQueryLOBTimeout(t *testing.T) {
+	t.Parallel()
+	defer tl.enableLogging(t)()
+	ctx, cancel := context.WithTimeout(testContext("QueryLOBTimeout"), 10*time.Second)
+	defer cancel()
+	tbl := "test_query_lob_" + tblSuffix
+	drop := func() { testDb.ExecContext(context.Background(), "DROP TABLE "+tbl) }
+	drop()
+	qry := "CREATE TABLE " + tbl + " (i NUMBER(3), lob BLOB)"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	defer drop()
+
+	// Generate some lob, 1MiB size
+	length := int(1 << 20)
+	lobGen := godror.Lob{
+		Reader: ReaderFunc(func(p []byte) (int, error) {
+			if length <= 0 {
+				return 0, io.EOF
+			}
+			var n int
+			for i := 0; i < len(p) && length > 0; i++ {
+				p[i] = byte(length & 0xff)
+				n++
+				length--
+			}
+			if length <= 0 {
+				return n, io.EOF
+			}
+			return n, nil
+		}),
+	}
+	qry = "INSERT INTO " + tbl + " (i, lob) VALUES (0, :1)"
+	if _, err := testDb.ExecContext(ctx, qry, lobGen); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+
+	ctx, cancel = context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+	if rows, err := testDb.QueryContext(ctx, "SELECT A.i, A.lob FROM all_objects B, "+tbl+" A FETCH FIRST 1 ROW ONLY"); err != nil {
+		t.Log(err)
+	} else {
+		defer rows.Close()
+		var i int
+		var s []byte
+		for rows.Next() {
+			if err := rows.Scan(&i, &s); err != nil {
+				t.Errorf("scan: %+v", err)
+				break
+			}
+			fmt.Println(i, len(s))
+			t.Log(i, len(s))
+		}
+	}
+}
+
+func TestSDO(t *testing.T) {
+	// t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("SDO"), 30*time.Second)
+	defer cancel()
+	innerQry := `SELECT MDSYS.SDO_GEOMETRY(
+	3001,
+	NULL,
+	NULL,
+	MDSYS.SDO_ELEM_INFO_ARRAY(
+		1,1,1,4,1,0,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL
+	),
+	MDSYS.SDO_ORDINATE_ARRAY(
+		480736.567,10853969.692,0,0.998807402795312,-0.0488238888381834,0,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL)
+		) SHAPE FROM DUAL`
+	selectQry := `SELECT shape, DUMP(shape), CASE WHEN shape IS NULL THEN 'I' ELSE 'N' END FROM (` + innerQry + ")"
+	rows, err := testDb.QueryContext(ctx, selectQry)
+	if err != nil {
+		if !strings.Contains(err.Error(), `ORA-00904: "MDSYS"."SDO_GEOMETRY"`) {
+			t.Fatal(fmt.Errorf("%s: %w", selectQry, err))
+		}
+		for _, qry := range []string{
+			`CREATE TYPE test_sdo_point_type AS OBJECT (
+			   X NUMBER,
+			   Y NUMBER,
+			   Z NUMBER)`,
+			"CREATE TYPE test_sdo_elem_info_array AS VARRAY (1048576) of NUMBER",
+			"CREATE TYPE test_sdo_ordinate_array AS VARRAY (1048576) of NUMBER",
+			`CREATE TYPE test_sdo_geometry AS OBJECT (
+			 SDO_GTYPE NUMBER,
+			 SDO_SRID NUMBER,
+			 SDO_POINT test_SDO_POINT_TYPE,
+			 SDO_ELEM_INFO test_SDO_ELEM_INFO_ARRAY,
+			 SDO_ORDINATES test_SDO_ORDINATE_ARRAY)`,
+
+			`CREATE TABLE test_sdo(
+					id INTEGER not null,
+					shape test_SDO_GEOMETRY not null
+				)`,
+		} {
+			var drop string
+			if strings.HasPrefix(qry, "CREATE TYPE") {
+				drop = "DROP TYPE " + qry[12:strings.Index(qry, " AS")] + " FORCE"
+			} else {
+				drop = "DROP TABLE " + qry[13:strings.Index(qry, "(")]
+			}
+			testDb.ExecContext(ctx, drop)
+			t.Log(drop)
+			if _, err = testDb.ExecContext(ctx, qry); err != nil {
+				err = fmt.Errorf("%s: %w", qry, err)
+				t.Log(err)
+				if !strings.Contains(err.Error(), "ORA-01031:") {
+					t.Fatal(err)
+				}
+				t.Skip(err)
+			}
+			defer testDb.ExecContext(ctx, drop)
+		}
+
+		selectQry = strings.Replace(selectQry, "MDSYS.SDO_", "test_SDO_", -1)
+		if rows, err = testDb.QueryContext(ctx, selectQry); err != nil {
+			t.Fatal(fmt.Errorf("%s: %w", selectQry, err))
+		}
+
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var dmp, isNull string
+		var intf interface{}
+		if err = rows.Scan(&intf, &dmp, &isNull); err != nil {
+			t.Error(fmt.Errorf("%s: %w", "scan", err))
+		}
+		t.Log(dmp, isNull)
+		obj := intf.(*godror.Object)
+		// t.Log("obj:", obj)
+		printObj(t, "", obj)
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func printObj(t *testing.T, name string, obj *godror.Object) {
+	if obj == nil {
+		return
+	}
+	for key := range obj.Attributes {
+		sub, err := obj.Get(key)
+		t.Logf("%s.%s. %+v (err=%+v)\n", name, key, sub, err)
+		if err != nil {
+			t.Errorf("ERROR: %+v", err)
+		}
+		if ss, ok := sub.(*godror.Object); ok {
+			printObj(t, name+"."+key, ss)
+		} else if coll, ok := sub.(*godror.ObjectCollection); ok {
+			slice, err := coll.AsSlice(nil)
+			t.Logf("%s.%s. %+v", name, key, slice)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+var _ = driver.Valuer((*Custom)(nil))
+var _ = sql.Scanner((*Custom)(nil))
+
+type Custom struct {
+	Num int64
+}
+
+func (t *Custom) Value() (driver.Value, error) {
+	return t.Num, nil
+}
+
+func (t *Custom) Scan(v interface{}) error {
+	var err error
+	switch v := v.(type) {
+	case int64:
+		t.Num = v
+	case string:
+		t.Num, err = strconv.ParseInt(v, 10, 64)
+	case float64:
+		t.Num = int64(v)
+	default:
+		err = fmt.Errorf("unknown type %T", v)
+	}
+	return err
+}
+
+func TestSelectCustomType(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("SelectCustomType"), 10*time.Second)
+	defer cancel()
+	conn, err := testDb.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	tbl := "test_custom_type" + tblSuffix
+	conn.ExecContext(ctx, "DROP TABLE "+tbl)
+	qry := "CREATE TABLE " + tbl + " (nm VARCHAR2(30), typ VARCHAR2(30), id NUMBER(6), created DATE)"
+	if _, err = conn.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer testDb.Exec("DROP TABLE " + tbl)
+
+	n := 1000
+	nms, typs, ids, createds := make([]string, n), make([]string, n), make([]int, n), make([]time.Time, n)
+	now := time.Now()
+	for i := range nms {
+		nms[i], typs[i], ids[i], createds[i] = fmt.Sprintf("obj-%d", i), "OBJECT", i, now.Add(-time.Duration(i)*time.Second)
+	}
+	qry = "INSERT INTO " + tbl + " (nm, typ, id, created) VALUES (:1, :2, :3, :4)"
+	if _, err = conn.ExecContext(ctx, qry, nms, typs, ids, createds); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+
+	const num = 10
+	nums := &Custom{Num: num}
+	type underlying int64
+	numbers := underlying(num)
+	rows, err := conn.QueryContext(ctx,
+		"SELECT nm, typ, id, created FROM "+tbl+" WHERE ROWNUM < COALESCE(:alpha, :beta, 2) ORDER BY id",
+		sql.Named("alpha", nums),
+		sql.Named("beta", int64(numbers)),
+	)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	n = 0
+	oldOid := int64(0)
+	for rows.Next() {
+		var tbl, typ string
+		var oid int64
+		var created time.Time
+		if err := rows.Scan(&tbl, &typ, &oid, &created); err != nil {
+			t.Fatal(err)
+		}
+		t.Log(tbl, typ, oid, created)
+		if tbl == "" {
+			t.Fatal("empty tbl")
+		}
+		n++
+		if oldOid > oid {
+			t.Errorf("got oid=%d, wanted sth < %d.", oid, oldOid)
+		}
+		oldOid = oid
+	}
+	if n == 0 || n > num {
+		t.Errorf("got %d rows, wanted %d", n, num)
+	}
+}
+
+func TestExecInt64(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("ExecInt64"), 10*time.Second)
+	defer cancel()
+	qry := `CREATE OR REPLACE PROCEDURE test_i64_out(p_int NUMBER, p_out1 OUT NUMBER, p_out2 OUT NUMBER) IS
+	BEGIN p_out1 := p_int; p_out2 := p_int; END;`
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.ExecContext(ctx, "DROP PROCEDURE test_i64_out")
+
+	qry = "BEGIN test_i64_out(:1, :2, :3); END;"
+	var num sql.NullInt64
+	var str string
+	defer tl.enableLogging(t)()
+	if _, err := testDb.ExecContext(ctx, qry, 3.14, sql.Out{Dest: &num}, sql.Out{Dest: &str}); err != nil {
+		t.Fatal(err)
+	}
+	t.Log("num:", num, "str:", str)
+}
+
+func TestImplicitResults(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("ImplicitResults"), 10*time.Second)
+	defer cancel()
+	const qry = `declare
+			c0 sys_refcursor;
+            c1 sys_refcursor;
+            c2 sys_refcursor;
+        begin
+            OPEN c0 FOR SELECT 0 FROM DUAL;
+			:1 := c0;
+            open c1 for select 1 from DUAL;
+            dbms_sql.return_result(c1);
+            open c2 for select 'A' from DUAL;
+            dbms_sql.return_result(c2);
+        end;`
+	var rows driver.Rows
+	if _, err := testDb.ExecContext(ctx, qry, sql.Out{Dest: &rows}); err != nil {
+		if strings.Contains(err.Error(), "PLS-00302:") {
+			t.Skip()
+		}
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	if rows == nil {
+		t.Fatal("rows is nil")
+	}
+	defer rows.Close()
+	r := rows.(driver.RowsNextResultSet)
+	for r.HasNextResultSet() {
+		if err := r.NextResultSet(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestStartupShutdown(t *testing.T) {
+	ensureSystemDB(t)
+	if os.Getenv("GODROR_DB_SHUTDOWN") != "1" {
+		t.Skip("GODROR_DB_SHUTDOWN != 1, skipping shutdown/startup test")
+	}
+	p, err := godror.ParseDSN(testSystemConStr)
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", testSystemConStr, err))
+	}
+	if !(p.AdminRole == godror.SysDBA || p.AdminRole == godror.SysOPER) {
+		p.AdminRole = godror.SysDBA
+	}
+	if !p.IsPrelim {
+		p.IsPrelim = true
+	}
+	db := sql.OpenDB(godror.NewConnector(p))
+	defer db.Close()
+	ctx, cancel := context.WithTimeout(testContext("StartupShutdown"), time.Minute)
+	defer cancel()
+
+	if err = db.PingContext(ctx); err != nil {
+		t.Fatal(err, p.StringWithPassword())
+	}
+
+	if err = godror.Raw(ctx, db, func(conn godror.Conn) error {
+		if err = conn.Shutdown(godror.ShutdownTransactionalLocal); err != nil {
+			return err
+		}
+		return conn.Shutdown(godror.ShutdownFinal)
+	}); err != nil {
+		t.Errorf("SHUTDOWN: %+v", err)
+	}
+	db.Close()
+
+	db = sql.OpenDB(godror.NewConnector(p))
+	defer db.Close()
+	if err = godror.Raw(ctx, db, func(conn godror.Conn) error {
+		return conn.Startup(godror.StartupDefault)
+	}); err != nil {
+		t.Log("Couldn't start up database. run 'echo startup | sqlplus / as sysdba'")
+		t.Errorf("STARTUP: %+v", err)
+	}
+}
+
+func TestIssue134(t *testing.T) {
+	cleanup := func() {
+		for _, qry := range []string{
+			`DROP TYPE test_prj_task_tt`,
+			`DROP TYPE test_prj_task_ot`,
+			`DROP PROCEDURE test_create_task_activity`,
+		} {
+			testDb.Exec(qry)
+		}
+	}
+	cleanup()
+	ctx, cancel := context.WithTimeout(testContext("Issue134"), 10*time.Second)
+	defer cancel()
+	cx, err := testDb.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cx.Close()
+	for _, qry := range []string{
+		`CREATE OR REPLACE TYPE test_PRJ_TASK_ot AS OBJECT (
+	PROJECT_NUMBER VARCHAR2(100)
+	,SOURCE_ID VARCHAR2(100)
+	,TASK_NAME VARCHAR2(300)
+	,TASK_DESCRIPTION VARCHAR2(2000)
+	,TASK_START_DATE DATE
+	,TASK_END_DATE DATE
+	,TASK_COST NUMBER
+	,SOURCE_PARENT_ID NUMBER
+	,TASK_TYPE VARCHAR2(100)
+	,QUANTITY NUMBER 
+	,data BLOB
+)`,
+		`CREATE OR REPLACE TYPE test_PRJ_TASK_tt IS TABLE OF test_PRJ_TASK_ot`,
+		`CREATE OR REPLACE FUNCTION test_CREATE_TASK_ACTIVITY (
+    p_create_task_i IN test_PRJ_TASK_tt,
+	p_project_id_i IN NUMBER) RETURN test_prj_task_ot 
+IS 
+  v_obj test_prj_task_ot;
+BEGIN 
+  IF p_create_task_i.COUNT = 0 THEN
+    v_obj := test_prj_task_ot(
+	PROJECT_NUMBER=>NULL
+	,SOURCE_ID=>NULL
+	,TASK_NAME=>NULL
+	,TASK_DESCRIPTION=>NULL
+	,TASK_START_DATE=>NULL
+	,TASK_END_DATE=>NULL
+	,TASK_COST=>NULL
+	,SOURCE_PARENT_ID=>NULL
+	,TASK_TYPE=>NULL
+	,QUANTITY=>NULL
+	,data=>NULL);
+  ELSE
+    v_obj := p_create_task_i(p_create_task_i.FIRST);
+  END IF;
+  DBMS_LOB.createtemporary(v_obj.data, TRUE, 31);
+  DBMS_LOB.writeAppend(v_obj.data, 10, 
+    UTL_RAW.cast_to_raw(TO_CHAR(SYSDATE, 'YYYY-MM-DD')));
+  RETURN(v_obj);
+END;`,
+	} {
+		if _, err := cx.ExecContext(ctx, qry); err != nil {
+			t.Fatal(fmt.Errorf("%s: %w", qry, err))
+		}
+	}
+	defer cleanup()
+	compileErrors, err := godror.GetCompileErrors(ctx, cx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ce := range compileErrors {
+		t.Error(ce)
+	}
+	if len(compileErrors) != 0 {
+		t.Fatal("compile failed")
+	}
+
+	conn, err := godror.DriverConn(ctx, cx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ot, err := conn.GetObjectType("TEST_PRJ_TASK_tt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj, err := ot.NewObject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Close()
+	t.Logf("obj=%#v", obj)
+	rt, err := conn.GetObjectType("TEST_PRJ_TASK_ot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret, err := rt.NewObject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ret.Close()
+
+	qry := "BEGIN :1 := test_create_task_activity(:2, :3); END;"
+	if err := prepExec(ctx, conn, qry,
+		driver.NamedValue{Value: &ret, Ordinal: 1},
+		driver.NamedValue{Value: &obj, Ordinal: 2},
+		driver.NamedValue{Value: 1, Ordinal: 3},
+	); err != nil {
+		t.Fatalf("%s [%#v, 1]: %+v", qry, obj, err)
+	}
+	t.Logf("ret: %#v", ret)
+	var data godror.Data
+	if err := ret.GetAttribute(&data, "DATA"); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := io.ReadAll(data.GetLob()); err != nil {
+		t.Fatal(err)
+	} else {
+		t.Logf("DATA: %q", b)
+	}
+}
+
+func TestDateRset(t *testing.T) {
+	defer tl.enableLogging(t)()
+	ctx := testContext("DateRset")
+
+	const qry = `DECLARE
+  v_cur SYS_REFCURSOR;
+BEGIN
+  OPEN v_cur FOR
+    SELECT TO_DATE('2015/05/15 8:30:25', 'YYYY/MM/DD HH:MI:SS') as DD FROM DUAL
+    UNION ALL SELECT TO_DATE('2015/05/15 8:30:25', 'YYYY/MM/DD HH:MI:SS') as DD FROM DUAL;
+  :1 := v_cur;
+END;`
+
+	for i := 0; i < maxSessions/2; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+
+			stmt, err := testDb.PrepareContext(ctx, qry)
+			if err != nil {
+				t.Fatalf("%s: %+v", qry, err)
+			}
+			defer stmt.Close()
+
+			var rows1 driver.Rows
+			if _, err := stmt.ExecContext(ctx, sql.Out{Dest: &rows1}); err != nil {
+				t.Fatalf("%s: %+v", qry, err)
+
+			}
+			defer rows1.Close()
+			cols1 := rows1.Columns()
+			values := make([]driver.Value, len(cols1))
+
+			var rowNum int
+			for {
+				rowNum++
+				if err := rows1.Next(values); err != nil {
+					if err == io.EOF {
+						break
+					}
+				}
+				t.Logf("%[1]d. %[2]T %[2]v", rowNum, values[0])
+			}
+		})
+	}
+}
+
+func TestTsRset(t *testing.T) {
+	defer tl.enableLogging(t)()
+	ctx := testContext("TsRset")
+
+	const qry = `DECLARE
+  v_cur SYS_REFCURSOR;
+BEGIN
+  OPEN v_cur FOR
+	SELECT TO_TIMESTAMP_TZ('2019-05-01 09:39:12 01:00', 'YYYY-MM-DD HH24:MI:SS TZH:TZM') FROM DUAL
+	UNION ALL SELECT FROM_TZ(TO_TIMESTAMP('2019-05-01 09:39:12', 'YYYY-MM-DD HH24:MI:SS'), '01:00') FROM DUAL;
+  :1 := v_cur;
+END;`
+
+	for i := 0; i < maxSessions/2; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+
+			var rows1 driver.Rows
+			if _, err := testDb.ExecContext(ctx, qry, sql.Out{Dest: &rows1}); err != nil {
+				t.Fatalf("%s: %+v", qry, err)
+
+			}
+			defer rows1.Close()
+			cols1 := rows1.Columns()
+			values := make([]driver.Value, len(cols1))
+
+			var rowNum int
+			for {
+				rowNum++
+				if err := rows1.Next(values); err != nil {
+					if err == io.EOF {
+						break
+					}
+				}
+				t.Logf("%[1]d. %[2]T %[2]v", rowNum, values[0])
+			}
+		})
+	}
+}
+
+func TestTsTZ(t *testing.T) {
+	t.Parallel()
+	fields := []string{
+		"FROM_TZ(TO_TIMESTAMP('2019-05-01 09:39:12', 'YYYY-MM-DD HH24:MI:SS'), '{{.TZ}}')",
+		"TO_TIMESTAMP_TZ('2019-05-01 09:39:12 {{.TZ}}', 'YYYY-MM-DD HH24:MI:SS {{.TZDec}}')",
+		"CAST(TO_TIMESTAMP_TZ('2019-05-01 09:39:12 {{.TZ}}', 'YYYY-MM-DD HH24:MI:SS {{.TZDec}}') AS DATE)",
+	}
+	ctx, cancel := context.WithTimeout(testContext("TsTZ"), 10*time.Second)
+	defer cancel()
+
+	defer tl.enableLogging(t)()
+
+	for _, Case := range []struct {
+		TZ, TZDec string
+	}{
+		{"00:00", "TZH:TZM"},
+		{"01:00", "TZH:TZM"},
+		{"-01:00", "TZH:TZM"},
+		{"Europe/Berlin", "TZR"},
+	} {
+		repl := strings.NewReplacer("{{.TZ}}", Case.TZ, "{{.TZDec}}", Case.TZDec)
+		for i, f := range fields {
+			f = repl.Replace(f)
+			qry := "SELECT DUMP(" + f + ") FROM DUAL"
+			var s string
+			if err := testDb.QueryRowContext(ctx, qry).Scan(&s); err != nil {
+				if Case.TZDec != "TZR" {
+					t.Fatalf("%s: %s: %+v", Case.TZ, qry, err)
+				}
+				t.Logf("%s: %s: %+v", Case.TZ, qry, err)
+			}
+			t.Logf("%s: DUMP[%d]: %q", Case.TZ, i, s)
+
+			qry = "SELECT " + f + " FROM DUAL"
+			var ts time.Time
+			if err := testDb.QueryRowContext(ctx, qry).Scan(&ts); err != nil {
+				var oerr *godror.OraErr
+				if errors.As(err, &oerr) && oerr.Code() == 1805 {
+					t.Skipf("%s: %s: %+v", Case.TZ, qry, err)
+					continue
+				}
+				t.Fatalf("%s: %s: %+v", Case.TZ, qry, err)
+			}
+			t.Logf("%s: %d: %s", Case.TZ, i, ts)
+		}
+	}
+
+	qry := "SELECT filename, version FROM v$timezone_file"
+	rows, err := testDb.QueryContext(ctx, qry)
+	if err != nil {
+		t.Log(qry, err)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var fn, ver string
+		if err := rows.Scan(&fn, &ver); err != nil {
+			t.Log(qry, err)
+			continue
+		}
+		t.Log(fn, ver)
+	}
+	t.Skip("wanted non-zero time")
+}
+
+func TestGetDBTimezone(t *testing.T) {
+	t.Parallel()
+	defer tl.enableLogging(t)()
+
+	ctx, cancel := context.WithTimeout(testContext("GetDBTimeZone"), 10*time.Second)
+	defer cancel()
+	tx, err := testDb.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qry := `SELECT TO_CHAR(SYSDATE, 'YYYY-MM-DD"T"HH24:MI:SS'), TO_CHAR(CURRENT_DATE, 'YYYY-MM-DD"T"hH24:MI:SS'), DBTIMEZONE, SESSIONTIMEZONE, TO_CHAR(SYSTIMESTAMP, 'TZR') AS dbOSTZ, SYSTIMESTAMP FROM DUAL`
+	var sysdate, currentDate, dbTz, tzr, sts, dbOSTZ, ts string
+	if err := tx.QueryRowContext(ctx, qry).Scan(&sysdate, &currentDate, &dbTz, &tzr, &dbOSTZ, &ts); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	t.Logf("sysdate=%q currentDate=%q dbTZ=%q TZR=%q dbOSTZ=%q ts=%q", sysdate, currentDate, dbTz, tzr, dbOSTZ, ts)
+
+	qry = "ALTER SESSION SET time_zone = 'UTC'"
+	if _, err := tx.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	qry = `SELECT DBTIMEZONE, SESSIONTIMEZONE, TO_CHAR(SYSTIMESTAMP, '"TZR="TZR "TZD="TZD "TZH:TZM="TZH:TZM'), LOCALTIMESTAMP||'' FROM DUAL`
+	var tz, lts string
+	if err := tx.QueryRowContext(ctx, qry).Scan(&dbTz, &tz, &sts, &lts); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	t.Log("db timezone:", dbTz, "session timezone:", tz, "systimestamp:", sts, "localtimestamp:", lts)
+
+	today := time.Now().Truncate(time.Second)
+	for i, tim := range []time.Time{today, today.AddDate(0, 6, 0)} {
+		t.Log("local:", tim.Format(time.RFC3339))
+
+		qry = "SELECT :1 FROM DUAL"
+		var dbTime time.Time
+		if err := tx.QueryRowContext(ctx, qry, tim).Scan(&dbTime); err != nil {
+			t.Fatal(fmt.Errorf("%s: %w", qry, err))
+		}
+		t.Log("db:", dbTime.Format(time.RFC3339))
+		if !dbTime.Equal(tim) {
+			msg := fmt.Sprintf("db says %s, local is %s", dbTime.Format(time.RFC3339), tim.Format(time.RFC3339))
+			if i == 0 {
+				t.Error("ERROR:", msg)
+			} else {
+				t.Log(msg)
+			}
+		}
+	}
+}
+
+func TestConnParamsTimezone(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("ConnParamsTZ"), 30*time.Second)
+	defer cancel()
+
+	getServerTZ := func(db *sql.DB) *time.Location {
+		var serverTZ *time.Location
+		if err := godror.Raw(ctx, db, func(cx godror.Conn) error {
+			serverTZ = cx.Timezone()
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return serverTZ
+	}
+
+	orig := getServerTZ(testDb)
+	_, off := time.Now().In(orig).Zone()
+	diff := 7200
+	if off > 12*3600-diff {
+		diff = -diff
+	}
+	off += diff
+
+	P, err := godror.ParseDSN(testConStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.FixedZone(fmt.Sprintf("%d=%s%+d", off, orig, diff), off)
+	P.Timezone = want
+	t.Logf("Set Timezone from %s to %s", orig, want)
+	db := sql.OpenDB(godror.NewConnector(P))
+	got := getServerTZ(db)
+	if got != want {
+		t.Errorf("got %s, wanted %s", got, want)
+	}
+}
+
+func TestNumberAsStringBool(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("NumberBool"), 3*time.Second)
+	defer cancel()
+	const qry = "SELECT 181 id, 1 status FROM DUAL"
+	rows, err := testDb.QueryContext(ctx, qry, godror.NumberAsString())
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+
+	for rows.Next() {
+		var id int
+		var status bool
+		if err := rows.Scan(&id, &status); err != nil {
+			t.Errorf("failed to scan data: %s\n", err)
+		}
+		t.Logf("Source id=%d, status=%t\n", id, status)
+		if !status {
+			t.Errorf("wanted true, got %t", status)
+		}
+	}
+}
+
+func TestFetchAsString(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("FetchAsString"), 3*time.Second)
+	defer cancel()
+	const qry = "SELECT 1.50 num, 3 cnt, TO_DATE('2025-01-02', 'YYYY-MM-DD') dt, HEXTORAW('CAFE') raw FROM DUAL"
+	for _, tC := range []struct {
+		Option godror.Option
+		Name   string
+		Types  [4]string
+	}{
+		{Name: "all", Option: godror.FetchAsString(), Types: [4]string{"string", "string", "string", "string"}},
+		{Name: "num+raw", Option: godror.FetchAsString("NUM", "raw"), Types: [4]string{"string", "godror.Number", "time.Time", "string"}},
+	} {
+		var dest [4]interface{}
+		if err := testDb.QueryRowContext(ctx, qry, tC.Option).Scan(&dest[0], &dest[1], &dest[2], &dest[3]); err != nil {
+			t.Fatalf("%s: %s: %+v", tC.Name, qry, err)
+		}
+		for i, v := range dest {
+			if got := fmt.Sprintf("%T", v); got != tC.Types[i] {
+				t.Errorf("%s: %d. got %s (%v), wanted %s", tC.Name, i, got, v, tC.Types[i])
+			}
+		}
+		if dest[3] != "CAFE" {
+			t.Errorf("%s: raw: got %v, wanted CAFE", tC.Name, dest[3])
+		}
+		t.Logf("%s: %q", tC.Name, dest)
+	}
+}
+
+func TestDST(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("DST"), 10*time.Minute)
+	defer cancel()
+
+	defer tl.enableLogging(t)()
+	t.Parallel()
+
+	start := time.Date(1950, 1, 1, 0, 0, 0, 0, time.Local)
+	now := time.Now()
+	maxNum := (365*4 + 1) * (now.Year() - start.Year() + 1) / 4
+	qry := `SELECT dt, TO_CHAR(dt, 'YYYY-MM-DD') as tx FROM (
+		SELECT TO_DATE('` + start.Format("2006-01-02") + `', 'YYYY-MM-DD')+rn AS dt 
+			FROM (SELECT ROWNUM AS rn FROM DUAL CONNECT BY LEVEL <= ` + strconv.Itoa(maxNum) + "))"
+	rows, err := testDb.QueryContext(ctx, qry)
+	if err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	var stmt *sql.Stmt
+	{
+		const qry = `SELECT TO_CHAR(:1, 'YYYY-MM-DD HH24:MI:SS') FROM DUAL`
+		if stmt, err = testDb.PrepareContext(ctx, qry); err != nil {
+			t.Fatal(err)
+		}
+		defer stmt.Close()
+	}
+
+	var want string
+	first := true
+	for rows.Next() {
+		var tim time.Time
+		if err := rows.Scan(&tim, &want); err != nil {
+			t.Fatalf("scan %s: %+v", qry, err)
+		}
+		if tim.Location() == time.UTC {
+			t.Skip("db is UTC")
+		}
+		// t.Log("tim:", tim, "want:", want)
+		if got := tim.Format("2006-01-02 15:04:05"); !strings.HasSuffix(got, " 00:00:00") {
+			t.Logf("got %s, wanted %s", tim.Format(time.RFC3339), want)
+			var s string
+			if err := stmt.QueryRowContext(ctx, tim).Scan(&s); err != nil {
+				t.Fatalf("scan [%v]: %+v", tim, err)
+			}
+			if s != want+" 00:00:00" {
+				t.Errorf("wanted %q, got %q", want, s)
+			}
+		}
+		if first {
+			t.Log("first:", want)
+			first = false
+		}
 	}
-	defer testDb.Exec("DROP TABLE " + tbl)
+	t.Log("last:", want)
+}
 
-	const INT, FLOAT = 1234567, 4.5
-	qry = `INSERT INTO ` + tbl + //nolint:gas
-		` (INT_COL, FLOAT_COL, EMPTY_INT_COL)
-     VALUES (1234567, 45/10, NULL)`
-	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+func TestNumberBool(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("NumberBool"), 3*time.Second)
+	defer cancel()
+	const qry = "SELECT 181 id, '1' status FROM DUAL"
+	rows, err := testDb.QueryContext(ctx, qry)
+	if err != nil {
 		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
 
-	qry = "SELECT int_col, float_col, empty_int_col FROM " + tbl //nolint:gas
-	type numbers struct {
-		Number  godror.Number
-		String  string
-		NString sql.NullString
-		NInt    sql.NullInt64
-		Int64   int64
-		Float   float64
-		Int     int
-	}
-	var n numbers
-	var i1, i2, i3 interface{}
-	for tName, tC := range map[string]struct {
-		Dest [3]interface{}
-		Want numbers
-	}{
-		"int,float,nstring": {
-			Dest: [3]interface{}{&n.Int, &n.Float, &n.NString},
-			Want: numbers{Int: INT, Float: FLOAT},
-		},
-		"inf,float,Number": {
-			Dest: [3]interface{}{&n.Int, &n.Float, &n.Number},
-			Want: numbers{Int: INT, Float: FLOAT},
-		},
-		"int64,float,nullInt": {
-			Dest: [3]interface{}{&n.Int64, &n.Float, &n.NInt},
-			Want: numbers{Int64: INT, Float: FLOAT},
-		},
-		"intf,intf,intf": {
-			Dest: [3]interface{}{&i1, &i2, &i3},
-			Want: numbers{Int64: INT, Float: FLOAT},
-		},
-	} {
-		i1, i2, i3 = nil, nil, nil
-		n = numbers{}
-		F := func() error {
-			err := testDb.QueryRowContext(ctx, qry).Scan(tC.Dest[0], tC.Dest[1], tC.Dest[2])
-			if err != nil {
-				err = fmt.Errorf("%s: %w", qry, err)
-			}
-			return err
+	for rows.Next() {
+		var id int
+		var status bool
+		if err := rows.Scan(&id, &status); err != nil {
+			t.Errorf("failed to scan data: %s\n", err)
 		}
-		if err := F(); err != nil {
-			if strings.HasSuffix(err.Error(), "unsupported Scan, storing driver.Value type <nil> into type *string") {
-				t.Log("WARNING:", err)
-				continue
-			}
-			noLogging := tl.enableLogging(t)
-			err = F()
-			t.Errorf("%q: %v", tName, fmt.Errorf("%s: %w", qry, err))
-			noLogging()
-			continue
+		t.Logf("Source id=%d, status=%t\n", id, status)
+		if !status {
+			t.Errorf("wanted true, got %t", status)
 		}
-		if tName == "intf,intf,intf" {
-			t.Logf("%q: %#v, %#v, %#v", tName, i1, i2, i3)
-			continue
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("Cancel"), 15*time.Second)
+	defer cancel()
+	subCtx, subCancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		const qry = `BEGIN DBMS_SESSION.sleep(10); END;`
+		if _, err := testDb.ExecContext(subCtx, qry); err != nil {
+			done <- err
 		}
-		t.Logf("%q: %+v", tName, n)
-		if n != tC.Want {
-			t.Errorf("%q:\ngot\t%+v,\nwanted\t%+v.", tName, n, tC.Want)
+	}()
+	time.Sleep(time.Second)
+	subCancel()
+	time.Sleep(time.Second)
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Error(err)
 		}
+	default:
+		t.Error("hasn't finished yet")
 	}
 }
 
-func TestNumInputs(t *testing.T) {
-	t.Parallel()
-	var a, b string
-	if err := testDb.QueryRow("SELECT :1, :2 FROM DUAL", 'a', 'b').Scan(&a, &b); err != nil {
-		t.Errorf("two inputs: %+v", err)
+func TestCancelFetch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("CancelFetch"), 15*time.Second)
+	defer cancel()
+	testDb.ExecContext(ctx, "DROP FUNCTION test_slow_rows")
+	if _, err := testDb.ExecContext(ctx, `CREATE OR REPLACE FUNCTION test_slow_rows(p_n IN PLS_INTEGER) RETURN PLS_INTEGER IS
+BEGIN
+  IF p_n > 1 THEN DBMS_SESSION.sleep(10); END IF;
+  RETURN p_n;
+END;`); err != nil {
+		t.Fatal(err)
 	}
-	if err := testDb.QueryRow("SELECT :a, :b FROM DUAL", 'a', 'b').Scan(&a, &b); err != nil {
-		t.Errorf("two named inputs: %+v", err)
+	defer testDb.Exec("DROP FUNCTION test_slow_rows")
+
+	before := godror.GetCancelStats()
+	subCtx, subCancel := context.WithCancel(ctx)
+	defer subCancel()
+	rows, err := testDb.QueryContext(subCtx,
+		"SELECT test_slow_rows(LEVEL) FROM DUAL CONNECT BY LEVEL <= 3",
+		godror.PrefetchCount(1), godror.FetchArraySize(1))
+	if err != nil {
+		t.Fatal(err)
 	}
-	if err := testDb.QueryRow("SELECT :a, :a FROM DUAL", sql.Named("a", a)).Scan(&a, &b); err != nil {
-		t.Errorf("named inputs: %+v", err)
+	defer rows.Close()
+	time.AfterFunc(time.Second, subCancel)
+	start := time.Now()
+	for rows.Next() {
+	}
+	err = rows.Err()
+	dur := time.Since(start)
+	t.Logf("canceled after %s: %+v", dur, err)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("wanted context.Canceled, got %+v", err)
+	}
+	if dur > 5*time.Second {
+		t.Errorf("cancelation took %s", dur)
+	}
+	after := godror.GetCancelStats()
+	t.Logf("cancel stats: %+v", after)
+	if after.Breaks <= before.Breaks {
+		t.Errorf("wanted more than %d breaks, got %d", before.Breaks, after.Breaks)
 	}
 }
 
-func TestPtrArg(t *testing.T) {
-	t.Parallel()
-	s := "dog"
-	rows, err := testDb.Query("SELECT * FROM user_objects WHERE object_name=:1", &s)
+func TestStreamRowsPipelined(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("StreamRowsPipelined"), 30*time.Second)
+	defer cancel()
+	testDb.ExecContext(ctx, "DROP FUNCTION test_pipe_slow")
+	testDb.ExecContext(ctx, "DROP TYPE test_pipe_tt")
+	if _, err := testDb.ExecContext(ctx, "CREATE OR REPLACE TYPE test_pipe_tt IS TABLE OF NUMBER"); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.Exec("DROP TYPE test_pipe_tt")
+	if _, err := testDb.ExecContext(ctx, `CREATE OR REPLACE FUNCTION test_pipe_slow(p_n IN PLS_INTEGER) RETURN test_pipe_tt PIPELINED IS
+BEGIN
+  FOR i IN 1..p_n LOOP
+    IF i > 1 THEN DBMS_SESSION.sleep(1); END IF;
+    PIPE ROW(i);
+  END LOOP;
+  RETURN;
+END;`); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.Exec("DROP FUNCTION test_pipe_slow")
+
+	start := time.Now()
+	rows, err := testDb.QueryContext(ctx, "SELECT COLUMN_VALUE FROM TABLE(test_pipe_slow(4))", godror.StreamRows(1))
 	if err != nil {
 		t.Fatal(err)
 	}
-	rows.Close()
+	defer rows.Close()
+	if !rows.Next() {
+		t.Fatal(rows.Err())
+	}
+	if dur := time.Since(start); dur > 2*time.Second {
+		t.Errorf("first row arrived after %s", dur)
+	}
+	n := 1
+	for rows.Next() {
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 4 {
+		t.Errorf("got %d rows, wanted 4", n)
+	}
 }
 
-func TestRanaOraIssue244(t *testing.T) {
-	tableName := "test_ora_issue_244" + tblSuffix
-	qry := "CREATE TABLE " + tableName + " (FUND_ACCOUNT VARCHAR2(18) NOT NULL, FUND_CODE VARCHAR2(6) NOT NULL, BUSINESS_FLAG NUMBER(10) NOT NULL, MONEY_TYPE VARCHAR2(3) NOT NULL)"
-	testDb.Exec("DROP TABLE " + tableName)
-	if _, err := testDb.Exec(qry); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
-	}
-	var max int
-	ctx, cancel := context.WithCancel(testContext("RanaOraIssue244-1"))
-	txs := make([]*sql.Tx, 0, maxSessions/2)
-	for max = 0; max < maxSessions/2; max++ {
-		tx, err := testDb.BeginTx(ctx, nil)
+func TestBackgroundFetch(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("BackgroundFetch"), 30*time.Second)
+	defer cancel()
+	qry := "SELECT LEVEL, TO_CHAR(LEVEL), SYSDATE + LEVEL FROM DUAL CONNECT BY LEVEL <= 1000"
+	fetch := func(options ...interface{}) ([]string, error) {
+		rows, err := testDb.QueryContext(ctx, qry, options...)
 		if err != nil {
-			max--
-			break
+			return nil, err
 		}
-		txs = append(txs, tx)
+		defer rows.Close()
+		var res []string
+		for rows.Next() {
+			var n int
+			var s string
+			var d time.Time
+			if err := rows.Scan(&n, &s, &d); err != nil {
+				return res, err
+			}
+			res = append(res, fmt.Sprintf("%d;%s;%s", n, s, d.Format(time.RFC3339)))
+		}
+		return res, rows.Err()
 	}
-	for _, tx := range txs {
-		tx.Rollback()
+	want, err := fetch(godror.FetchArraySize(64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := fetch(godror.FetchArraySize(64), godror.BackgroundFetch())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(d)
 	}
-	cancel()
-	t.Logf("maxSessions=%d max=%d", maxSessions, max)
 
-	dur := time.Minute / 2
+	// the LOBs read into strings are fetched in the background, too
+	qry = "SELECT LEVEL, TO_CLOB(LEVEL)||TO_NCLOB('x'), SYSDATE + LEVEL FROM DUAL CONNECT BY LEVEL <= 1000"
+	if want, err = fetch(godror.FetchArraySize(64)); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = fetch(godror.FetchArraySize(64), godror.BackgroundFetch()); err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(d)
+	}
+
+	// Close before consuming all the rows
+	rows, err := testDb.QueryContext(ctx, qry, godror.FetchArraySize(10), godror.BackgroundFetch())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 15 && rows.Next(); i++ {
+	}
+	if err = rows.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
 	if testing.Short() {
-		dur = 10 * time.Second
+		t.Skip("skip cancel test")
 	}
-	ctx, cancel = context.WithTimeout(testContext("RanaOraIssue244-2"), dur)
-	defer cancel()
-	defer testDb.Exec("DROP TABLE " + tableName)
-	const bf = "143"
-	const sc = "270004"
-	qry = "INSERT INTO " + tableName + " (fund_account, fund_code, business_flag, money_type) VALUES (:1, :2, :3, :4)" //nolint:gas
-	tx, err := testDb.BeginTx(ctx, nil)
+	db, err := sql.Open("godror", testConStr)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer tx.Rollback()
-	stmt, err := tx.PrepareContext(ctx, qry)
+	defer db.Close()
+	pid := os.Getpid()
+	maxConc := maxSessions / 2
+	db.SetMaxOpenConns(maxConc - 1)
+	db.SetMaxIdleConns(1)
+	ctx, cancel := context.WithCancel(testContext("Timeout"))
+	defer cancel()
+	const qryCount = "SELECT COUNT(0) FROM v$session WHERE username = USER AND process = TO_CHAR(:1)"
+	cntStmt, err := testDb.PrepareContext(ctx, qryCount)
 	if err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+		t.Fatalf("%q: %v", qryCount, err)
 	}
-	fas := []string{"14900666", "1868091", "1898964", "14900397"}
-	for _, v := range fas {
-		if _, err := stmt.ExecContext(ctx, v, sc, bf, "0"); err != nil {
-			stmt.Close()
-			t.Fatal(err)
+	// Just a test, as Prepared statements does not work
+	cntStmt.Close()
+	Cnt := func() int {
+		var cnt int
+		if err := db.QueryRowContext(ctx, qryCount, pid).Scan(&cnt); err != nil {
+			if !strings.Contains(err.Error(), "ORA-00942:") {
+				t.Fatal(fmt.Errorf("%s: %w", qryCount, err))
+			}
 		}
+		return cnt
 	}
-	stmt.Close()
-	tx.Commit()
 
-	qry = `SELECT fund_account, money_type FROM ` + tableName + ` WHERE business_flag = :1 AND fund_code = :2 AND fund_account = :3` //nolint:gas
-	grp, grpCtx := errgroup.WithContext(ctx)
-	for i := 0; i < max; i++ {
-		index := rand.Intn(len(fas))
-		i, qry := i, qry
+	t.Log("Pid:", pid)
+	goal := Cnt() + 1
+	t.Logf("Before: %d", goal)
+	const qry = "BEGIN FOR rows IN (SELECT 1 FROM DUAL) LOOP DBMS_SESSION.SLEEP(10); END LOOP; END;"
+	subCtx, subCancel := context.WithTimeout(ctx, time.Duration(2*maxConc+1)*time.Second)
+	grp, grpCtx := errgroup.WithContext(subCtx)
+	for i := 0; i < maxConc; i++ {
 		grp.Go(func() error {
-			tx, err := testDb.BeginTx(grpCtx, &sql.TxOptions{ReadOnly: true})
-			if err != nil {
-				return err
-			}
-			defer tx.Rollback()
-
-			stmt, err := tx.PrepareContext(grpCtx, qry)
-			if err != nil {
-				return fmt.Errorf("%d.Prepare %q: %w", i, qry, err)
-			}
-			defer stmt.Close()
-
-			for j := 0; j < 3; j++ {
-				select {
-				case <-grpCtx.Done():
-					return grpCtx.Err()
-				default:
-				}
-				index = (index + 1) % len(fas)
-				rows, err := stmt.QueryContext(grpCtx, bf, sc, fas[index])
-				if err != nil {
-					return fmt.Errorf("%d.tx=%p stmt=%p %d. %q: %w", i, tx, stmt, j, qry, err)
-				}
-
-				for rows.Next() {
-					var acc, mt string
-					if err = rows.Scan(&acc, &mt); err != nil {
-						err = fmt.Errorf("Scan: %w", err)
-						break
-					}
-
-					if acc != fas[index] {
-						err = fmt.Errorf("got acc %q, wanted %q", acc, fas[index])
-						break
-					}
-					if mt != "0" {
-						err = fmt.Errorf("got mt %q, wanted 0", mt)
-						break
-					}
-				}
-				rows.Close()
-				if err == nil {
-					err = rows.Err()
-				}
-				if err != nil {
-					return err
-				}
+			if _, err := db.ExecContext(grpCtx, qry); err != nil && !errors.Is(err, context.Canceled) {
+				return fmt.Errorf("%s: %w", qry, err)
 			}
 			return nil
 		})
 	}
-	if err := grp.Wait(); err != nil && !errors.Is(err, context.DeadlineExceeded) {
-		if errors.Is(err, driver.ErrBadConn) {
-			return
-		}
-		errS := errors.Unwrap(err).Error()
-		switch errS {
-		case "sql: statement is closed",
-			"sql: transaction has already been committed or rolled back":
+	time.Sleep(time.Second)
+	t.Logf("After exec, before cancel: %d", Cnt())
+	subCancel()
+	t.Logf("After cancel: %d", Cnt())
+	if err = grp.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+		t.Fatal(err)
+	}
+	t.Logf("After finish: %d; goal: %d", Cnt(), goal)
+
+	for i := 0; i < 2*maxConc; i++ {
+		cnt := Cnt()
+		t.Logf("After %ds: %d", i+1, cnt)
+		if cnt <= goal {
 			return
 		}
-		if strings.Contains(errS, "ORA-12516:") || strings.Contains(errS, "ORA-24496:") {
-			t.Log(err)
-		} else {
-			t.Error(err)
-		}
+		time.Sleep(time.Second)
 	}
+	t.Skip("cancelation timed out")
 }
 
-func TestNumberMarshal(t *testing.T) {
-	t.Parallel()
-	var n godror.Number
-	if err := testDb.QueryRow("SELECT 6000370006565900000073 FROM DUAL").Scan(&n); err != nil {
+func TestObject(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("Object"), 10*time.Second)
+	defer cancel()
+	conn, err := testDb.Conn(ctx)
+	if err != nil {
 		t.Fatal(err)
 	}
-	t.Log(n.String())
-	b, err := n.MarshalJSON()
-	t.Logf("%s", b)
+	defer conn.Close()
+	testCon, err := godror.DriverConn(ctx, conn)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if bytes.Contains(b, []byte{'e'}) {
-		t.Errorf("got %q, wanted without scientific notation", b)
-	}
-	if b, err = json.Marshal(struct {
-		N godror.Number
-	}{N: n},
-	); err != nil {
+	defer testCon.Close()
+	if err = testCon.Ping(ctx); err != nil {
 		t.Fatal(err)
 	}
-	t.Logf("%s", b)
-}
+	t.Logf("dbstats: %#v", testDb.Stats())
 
-func TestExecHang(t *testing.T) {
-	defer tl.enableLogging(t)()
-	ctx, cancel := context.WithTimeout(testContext("ExecHang"), 1*time.Second)
-	defer cancel()
-	done := make(chan error, 1)
-	errMismatch := errors.New("mismatch")
-	var wg sync.WaitGroup
-	for i := 0; i < cap(done); i++ {
-		i := i
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if err := ctx.Err(); err != nil {
-				done <- err
-				return
-			}
-			_, err := testDb.ExecContext(ctx, "BEGIN DBMS_SESSION.sleep(3); END;")
-			t.Logf("%d. %v", i, err)
-			if err == nil {
-				done <- fmt.Errorf("%w: %d. wanted timeout got %+v", errMismatch, i, err)
-			}
-		}()
+	cleanup := func() {
+		testDb.Exec("DROP PROCEDURE test_obj_modify")
+		testDb.Exec("DROP TYPE test_obj_tab_t")
+		testDb.Exec("DROP TYPE test_obj_rec_t")
 	}
-	wg.Wait()
-	close(done)
-	if err := <-done; err != nil {
+	cleanup()
+	const crea = `
+CREATE OR REPLACE TYPE test_obj_rec_t AS OBJECT (num NUMBER, vc VARCHAR2(1000), dt DATE);
+CREATE OR REPLACE TYPE test_obj_tab_t AS TABLE OF test_obj_rec_t;
+CREATE OR REPLACE PROCEDURE test_obj_modify(p_obj IN OUT NOCOPY test_obj_tab_t) IS
+BEGIN
+  p_obj.EXTEND;
+  p_obj(p_obj.LAST) := test_obj_rec_t(
+    num => 314/100 + p_obj.COUNT,
+    vc  => 'abraka dabra',
+    dt  => SYSDATE);
+END;`
+	for _, qry := range strings.Split(crea, "\nCREATE OR") {
+		if qry == "" {
+			continue
+		}
+		qry = "CREATE OR" + qry
+		if _, err = testDb.ExecContext(ctx, qry); err != nil {
+			t.Fatal(fmt.Errorf("%s: %w", qry, err))
+		}
+	}
+
+	defer cleanup()
+
+	cOt, err := testCon.GetObjectType(strings.ToUpper("test_obj_tab_t"))
+	if err != nil {
 		t.Fatal(err)
 	}
+	t.Log(cOt)
+
+	// create object from the type
+	coll, err := cOt.NewCollection()
+	if err != nil {
+		t.Fatal("NewCollection:", err)
+	}
+	defer coll.Close()
+
+	// create an element object
+	elt, err := cOt.CollectionOf.NewObject()
+	if err != nil {
+		t.Fatal("collection.NewObject:", err)
+	}
+	defer elt.Close()
+
+	// append to the collection
+	t.Logf("append an empty %s", elt)
+	coll.AppendObject(elt)
+
+	const mod = "BEGIN test_obj_modify(:1); END;"
+	if err = prepExec(ctx, testCon, mod, driver.NamedValue{Ordinal: 1, Value: coll}); err != nil {
+		t.Error(err)
+	}
+	t.Logf("coll: %s", coll)
+	var data godror.Data
+	for i, err := coll.First(); err == nil; i, err = coll.Next(i) {
+		if err = coll.GetItem(&data, i); err != nil {
+			t.Fatal(err)
+		}
+		elt = data.GetObject()
 
+		t.Logf("elt[%d] : %s", i, elt)
+		for attr := range elt.Attributes {
+			val, err := elt.Get(attr)
+			if err != nil {
+				t.Error(err, attr)
+			}
+			t.Logf("elt[%d].%s=%s", i, attr, val)
+		}
+	}
 }
 
-func TestNumberNull(t *testing.T) {
-	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("NumberNull"), time.Minute)
-	defer cancel()
-	testDb.Exec("DROP TABLE number_test")
-	qry := `CREATE TABLE number_test (
-		caseNum NUMBER(3),
-		precisionNum NUMBER(5),
-      precScaleNum NUMBER(5, 0),
-		normalNum NUMBER
-		)`
-	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+func TestNewPassword(t *testing.T) {
+	P, err := godror.ParseDSN(testConStr)
+	if err != nil {
+		t.Fatal(err)
 	}
-	defer testDb.Exec("DROP TABLE number_test")
+	ctx, cancel := context.WithTimeout(testContext("NewPassword"), 10*time.Second)
+	defer cancel()
+	const user, oldPassword, newPassword = "test_expired", "oldPassw0rd_long", "newPassw0rd_longer"
 
-	qry = `
-		INSERT ALL
-		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (1, 4, 65, 123)
-		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (2, NULL, NULL, NULL)
-		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (3, NULL, NULL, NULL)
-		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (4, NULL, 42, NULL)
-		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (5, NULL, NULL, 31)
-		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (6, 3, 3, 4)
-		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (7, NULL, NULL, NULL)
-		INTO number_test (caseNum, precisionNum, precScaleNum, normalNum) VALUES (8, 6, 9, 7)
-		SELECT 1 FROM DUAL`
+	testDb.Exec("DROP USER " + user)
+	// GRANT CREATE USER, DROP USER TO test
+	// GRANT CREATE SESSION TO test WITH ADMIN OPTION
+	qry := "CREATE USER " + user + " IDENTIFIED BY " + oldPassword + " PASSWORD EXPIRE"
 	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+		if strings.Contains(err.Error(), "ORA-01031:") {
+			t.Log("Please issue this:\nGRANT CREATE USER, DROP USER TO " + P.Username + ";\n" +
+				"GRANT CREATE SESSION TO " + P.Username + " WITH ADMIN OPTION;\n")
+			t.Skip(err)
+		}
+		t.Fatal(err)
 	}
-	qry = "SELECT precisionNum, precScaleNum, normalNum FROM number_test ORDER BY caseNum"
-	rows, err := testDb.Query(qry)
-	if err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	defer testDb.Exec("DROP USER " + user)
+
+	qry = "GRANT CREATE SESSION TO " + user
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		if strings.Contains(err.Error(), "ORA-01031:") {
+			t.Log("Please issue this:\nGRANT CREATE SESSION TO " + P.Username + " WITH ADMIN OPTION;\n")
+		}
+		t.Fatal(err)
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var precisionNum, recScaleNum, normalNum sql.NullInt64
-		if err = rows.Scan(&precisionNum, &recScaleNum, &normalNum); err != nil {
+	P.Username, P.Password = user, godror.NewPassword(oldPassword)
+	P.StandaloneConnection = godror.Bool(true)
+	P.NewPassword = godror.NewPassword(newPassword)
+	{
+		db, err := sql.Open("godror", P.StringWithPassword())
+		if err != nil {
 			t.Fatal(err)
 		}
+		err = db.Ping()
+		db.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
 
-		t.Log(precisionNum, recScaleNum, normalNum)
-
-		if precisionNum.Int64 == 0 && precisionNum.Valid {
-			t.Errorf("precisionNum=%v, wanted {0 false}", precisionNum)
+	P.Password = P.NewPassword
+	P.NewPassword.Reset()
+	{
+		db, err := sql.Open("godror", P.StringWithPassword())
+		if err != nil {
+			t.Fatal(err)
 		}
-		if recScaleNum.Int64 == 0 && recScaleNum.Valid {
-			t.Errorf("recScaleNum=%v, wanted {0 false}", recScaleNum)
+		err = db.Ping()
+		db.Close()
+		if err != nil {
+			t.Fatal(err)
 		}
 	}
+}
 
-	rows, err = testDb.Query(qry)
+func TestConnClass(t *testing.T) {
+	P, err := godror.ParseDSN(testConStr)
 	if err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(testContext("ConnClass"), 10*time.Second)
+	defer cancel()
+
+	const connClass = "fc8153b840"
+	P.ConnClass = connClass
+
+	db, err := sql.Open("godror", P.StringWithPassword())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.PingContext(ctx); err != nil {
+		t.Skip(err)
+	}
+
+	const qry = "SELECT username,program,cclass_name FROM v$cpool_conn_info"
+	rows, err := db.QueryContext(ctx, qry)
+	if err != nil {
+		var oerr *godror.OraErr
+		if errors.As(err, &oerr) && oerr.Code() == 942 {
+			t.Skip(err)
+		}
+		t.Fatalf("%s: %+v", qry, err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var precisionNumStr, recScaleNumStr, normalNumStr sql.NullString
-		if err = rows.Scan(&precisionNumStr, &recScaleNumStr, &normalNumStr); err != nil {
+		var usr, prg, class string
+		if err = rows.Scan(&usr, &prg, &class); err != nil {
 			t.Fatal(err)
 		}
-		t.Log(precisionNumStr, recScaleNumStr, normalNumStr)
+		t.Log(usr, prg, class)
 	}
 }
 
-func TestNullFloat(t *testing.T) {
+func TestOnInit(t *testing.T) {
 	t.Parallel()
-	testDb.Exec("DROP TABLE test_char")
-	if _, err := testDb.Exec(`CREATE TABLE test_char (
-			CHARS VARCHAR2(10 BYTE),
-			FLOATS NUMBER(10, 2)
-		)`); err != nil {
+	P, err := godror.ParseDSN(testConStr)
+	if err != nil {
 		t.Fatal(err)
 	}
-	defer testDb.Exec("DROP TABLE test_char")
+	const numChars = "#@"
+	P.SetSessionParamOnInit("nls_numeric_characters", numChars)
+	t.Log(P.String())
+	ctx, cancel := context.WithTimeout(testContext("OnInit"), 10*time.Second)
+	defer cancel()
 
-	tx, err := testDb.Begin()
+	db, err := sql.Open("godror", P.StringWithPassword())
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer tx.Rollback()
+	defer db.Close()
 
-	_, err = tx.Exec(
-		"INSERT INTO test_char VALUES(:CHARS, :FLOATS)",
-		[]string{"dog", "", "cat"},
-		/*[]sql.NullString{sql.NullString{"dog", true},
-		sql.NullString{"", false},
-		sql.NullString{"cat", true}},*/
-		[]sql.NullFloat64{
-			{Float64: 3.14, Valid: true},
-			{Float64: 12.36, Valid: true},
-			{Float64: 0.0, Valid: false},
-		},
-	)
-	if err != nil {
-		t.Error(err)
+	conns := make([]*sql.Conn, 3)
+	defer func() {
+		for _, c := range conns {
+			if c != nil {
+				c.Close()
+			}
+		}
+	}()
+	for i := range conns {
+		if conns[i], err = db.Conn(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		const qry = "SELECT value, TO_CHAR(123/10) AS num FROM v$nls_parameters WHERE parameter = 'NLS_NUMERIC_CHARACTERS'"
+		var v, n string
+		if err = conns[i].QueryRowContext(ctx, qry).Scan(&v, &n); err != nil {
+			t.Errorf("%d. %+v", i, err)
+		}
+		t.Logf("%d. v=%q n=%q", i, v, n)
+		if v != numChars {
+			t.Errorf("%d. got %q wanted %q", i, v, numChars)
+		}
+		if n != "12#3" {
+			t.Errorf("%d. got %q wanted 12#3", i, n)
+		}
 	}
 }
 
-func TestColumnSize(t *testing.T) {
-	t.Parallel()
-	testDb.Exec("DROP TABLE test_column_size")
-	if _, err := testDb.Exec(`CREATE TABLE test_column_size (
-		vc20b VARCHAR2(20 BYTE),
-		vc1b VARCHAR2(1 BYTE),
-		nvc20 NVARCHAR2(20),
-		nvc1 NVARCHAR2(1),
-		vc20c VARCHAR2(20 CHAR),
-		vc1c VARCHAR2(1 CHAR)
-	)`); err != nil {
+func TestOnInitParallel(t *testing.T) {
+	P, err := godror.ParseDSN(testConStr)
+	if err != nil {
 		t.Fatal(err)
 	}
-	defer testDb.Exec("DROP TABLE test_column_size")
+	const numChars = "#@"
+	P.SetSessionParamOnInit("nls_numeric_characters", numChars)
+	t.Log(P.String())
+	ctx, cancel := context.WithTimeout(testContext("OnInit"), 1*time.Minute)
+	defer cancel()
 
-	r, err := testDb.Query("SELECT * FROM test_column_size")
+	db, err := sql.Open("godror", P.StringWithPassword())
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer r.Close()
-	rts, err := r.ColumnTypes()
-	if err != nil {
-		t.Fatal(err)
+	defer db.Close()
+
+	const want = "12#3"
+	const qry = "SELECT value, TO_CHAR(123/10) AS num FROM v$nls_parameters WHERE parameter = 'NLS_NUMERIC_CHARACTERS'"
+	for i := 0; i < 4; i++ {
+		var wg sync.WaitGroup
+		for j := 0; j < maxSessions*2; j++ {
+			wg.Add(1)
+			go func(i, j int) {
+				defer wg.Done()
+				var v, n string
+				if err := db.QueryRowContext(ctx, qry).Scan(&v, &n); err != nil {
+					if errors.Is(err, context.Canceled) {
+						return
+					}
+					var ec interface{ Code() int }
+					if errors.As(err, &ec) && (ec.Code() == 28547 || ec.Code() == 18) {
+						return
+					}
+					t.Errorf("%d*%d. %+v", i, j, err)
+					return
+				}
+				//t.Logf("%d. v=%q n=%q", i, v, n)
+				if v != numChars || n != want {
+					t.Errorf("%d*%d. got %q(%q) wanted %q(%q)", i, j, v, n, numChars, want)
+					cancel()
+				}
+			}(i, j)
+		}
+		wg.Wait()
 	}
-	for _, col := range rts {
-		l, _ := col.Length()
+}
+
+func TestSelectTypes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("SelectTypes"), time.Minute)
+	defer cancel()
+	const createQry = `CREATE TABLE TEST_TYPES (
+			A_LOB BFILE,
+			B BINARY_DOUBLE,
+			C BINARY_FLOAT,
+			D_LOB BLOB,
+			E CHAR(10),
+			F_LOB CLOB,
+			G DATE,
+			GN DATE,
+			H NUMBER(18 , 2),
+			I FLOAT(126),
+			J FLOAT(10),
+			K NUMBER(38 , 0),
+			L INTERVAL DAY TO SECOND(6),
+			M INTERVAL YEAR TO MONTH,
+			--N LONG,
+			P NCHAR(100),
+			Q_LOB NCLOB,
+			R NUMBER(18 , 2),
+			S NUMBER(18 , 2),
+			T NVARCHAR2(100),
+			U RAW(100),
+			V FLOAT(63),
+			W NUMBER(38 , 0),
+			X TIMESTAMP,
+			Y TIMESTAMP WITH LOCAL TIME ZONE,
+			Z TIMESTAMP WITH TIME ZONE,
+			AA VARCHAR2(100),
+			AB XMLTYPE
+		)`
+	testDb.ExecContext(ctx, "DROP TABLE test_types")
+	if _, err := testDb.ExecContext(ctx, createQry); err != nil {
+		t.Fatalf("%s: %+v", createQry, err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(testContext("SelectTypes-drop"), 10*time.Second)
+		defer cancel()
+		testDb.ExecContext(ctx, "DROP TABLE test_types")
+	}()
+
+	const insertQry = `INSERT INTO test_types
+  (b, c, e, g, gn,
+   h, i, j,
+   k, l,
+   r, s, u,
+   v, w, x, y,
+   z,
+   aa)
+  VALUES (3.14, 4.15, 'char(10)', TO_DATE('2020-01-21 09:16:36', 'YYYY-MM-DD HH24:MI:SS'), NULL,
+          1/3, 5.16, 6.17,
+          123456789012345678901234567890, INTERVAL '8' HOUR,
+		  7.18, 8.19, HEXTORAW('deadbeef'),
+		  0.01, -3, SYSTIMESTAMP, SYSTIMESTAMP,
+		  TIMESTAMP '2018-02-15 14:00:00.00 CET',
+          'varchar2(100)')`
 
-		t.Logf("Column %q has length %v", col.Name(), l)
+	if _, err := testDb.ExecContext(ctx, insertQry); err != nil {
+		t.Fatalf("%s: %+v", insertQry, err)
 	}
-}
-
-func TestReturning(t *testing.T) {
-	t.Parallel()
-	defer tl.enableLogging(t)()
-	testDb.Exec("DROP TABLE test_returning")
-	if _, err := testDb.Exec("CREATE TABLE test_returning (a VARCHAR2(20))"); err != nil {
-		t.Fatal(err)
+	const insertQry2 = `INSERT INTO test_types (z) VALUES (cast(TO_TIMESTAMP_TZ('2018-02-15T14:00:00 01:00','yyyy-mm-dd"T"hh24:mi:ss TZH:TZM') as date))`
+	if _, err := testDb.ExecContext(ctx, insertQry2); err != nil {
+		t.Fatalf("%s: %+v", insertQry2, err)
 	}
-	defer testDb.Exec("DROP TABLE test_returning")
-
-	want := "abraca dabra"
-	var got string
-	if _, err := testDb.Exec(
-		`INSERT INTO test_returning (a) VALUES (UPPER(:1)) RETURNING a INTO :2`,
-		want, sql.Out{Dest: &got},
-	); err != nil {
+	var n int32
+	if err := testDb.QueryRowContext(ctx, "SELECT COUNT(0) FROM test_types").Scan(&n); err != nil {
 		t.Fatal(err)
 	}
-	want = strings.ToUpper(want)
-	if want != got {
-		t.Errorf("got %q, wanted %q", got, want)
+	if n != 2 {
+		t.Errorf("%d rows in the table, wanted 2", n)
 	}
 
-	if _, err := testDb.Exec(
-		`UPDATE test_returning SET a = '1' WHERE 1=0 RETURNING a /*LASTINSERTID*/ INTO :1`,
-		sql.Out{Dest: &got},
-	); err != nil {
+	var dbTZ string
+	if err := testDb.QueryRowContext(ctx, "SELECT dbtimezone FROM DUAL").Scan(&dbTZ); err != nil {
 		t.Fatal(err)
 	}
-	t.Logf("RETURNING (zero set): %v", got)
-}
-
-func TestMaxOpenCursorsORA1000(t *testing.T) {
-	ctx, cancel := context.WithCancel(testContext("ORA1000"))
-	defer cancel()
-	rows, err := testDb.QueryContext(ctx, "SELECT * FROM user_objects WHERE ROWNUM < 100")
+	rows, err := testDb.QueryContext(ctx, "SELECT filename, version FROM v$timezone_file")
 	if err != nil {
-		t.Fatal(err)
-	}
-	defer rows.Close()
-
-	var openCursors sql.NullInt64
-	const qry1 = "SELECT p.value FROM v$parameter p WHERE p.name = 'open_cursors'"
-	if err := testDb.QueryRowContext(ctx, qry1).Scan(&openCursors); err == nil {
-		t.Logf("open_cursors=%v", openCursors)
+		t.Log(err)
 	} else {
-		if err := testDb.QueryRow(qry1).Scan(&openCursors); err != nil {
-			var cErr interface{ Code() int }
-			if errors.As(err, &cErr) && cErr.Code() == 942 {
-				t.Logf("%s: %+v", qry1, err)
-			} else {
-				t.Error(fmt.Errorf("%s: %w", qry1, err))
+		for rows.Next() {
+			var fn, ver string
+			if err = rows.Scan(&fn, &ver); err != nil {
+				t.Fatal(err)
 			}
-		} else {
-			t.Log(fmt.Errorf("%s: %w", qry1, err))
-		}
-	}
-	n := int(openCursors.Int64)
-	if 0 <= n || n >= 100 {
-		n = 100
-	}
-	n *= 2
-	for i := 0; i < n; i++ {
-		var cnt int64
-		qry2 := "SELECT /* " + strconv.Itoa(i) + " */ 1 FROM DUAL"
-		if err = testDb.QueryRowContext(ctx, qry2).Scan(&cnt); err != nil {
-			t.Fatal(fmt.Errorf("%d. %s: %w", i, qry2, err))
+			t.Logf("v$timezone file=%q version=%q", fn, ver)
 		}
+		rows.Close()
 	}
-}
 
-func TestRO(t *testing.T) {
-	t.Parallel()
-	ctx, cancel := context.WithCancel(testContext("RO"))
-	defer cancel()
-	tx, err := testDb.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true})
+	const qry = "SELECT * FROM test_types"
+
+	// get rows
+	rows, err = testDb.QueryContext(ctx, qry)
 	if err != nil {
-		t.Fatal(err)
-	}
-	defer tx.Rollback()
-	if _, err = tx.QueryContext(ctx, "SELECT 1 FROM DUAL"); err != nil {
-		t.Fatal(err)
-	}
-	if _, err = tx.ExecContext(ctx, "CREATE TABLE test_table (i INTEGER)"); err == nil {
-		t.Log("RO allows CREATE TABLE ?")
+		t.Fatalf("%s: %+v", qry, err)
 	}
-	if err = tx.Commit(); err != nil {
+	defer rows.Close()
+
+	// get columns name
+	colsName, err := rows.Columns()
+	if err != nil {
 		t.Fatal(err)
 	}
-}
+	t.Log("columns:", colsName)
 
-func TestNullIntoNum(t *testing.T) {
-	t.Parallel()
-	testDb.Exec("DROP TABLE test_null_num")
-	qry := "CREATE TABLE test_null_num (i NUMBER(3))"
-	if _, err := testDb.Exec(qry); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	// get types of query columns
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
 	}
-	defer testDb.Exec("DROP TABLE test_null_num")
 
-	qry = "INSERT INTO test_null_num (i) VALUES (:1)"
-	var i *int
-	if _, err := testDb.Exec(qry, i); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
-	}
-}
+	// total columns
+	totalColumns := len(colsName)
 
-func TestPing(t *testing.T) {
-	t.Parallel()
+	oracleFieldParse := func(datatype string, field interface{}) interface{} {
+		// DEBUG: print the type of field and datatype returned for the drivers
+		t.Logf("%T\t%s\n", field, datatype)
 
-	ctx, cancel := context.WithTimeout(testContext("Ping"), 1*time.Second)
-	defer cancel()
-	err := testDb.PingContext(ctx)
-	cancel()
-	if err != nil {
-		t.Error(err)
+		if field == nil {
+			return nil
+		}
+		switch x := field.(type) {
+		case string:
+			return x
+		case godror.Number:
+			return string(x)
+		case []uint8:
+			switch datatype {
+			case "RAW", "LONG RAW":
+				return fmt.Sprintf("%x", x)
+			default:
+				return fmt.Sprintf("unsupported datatype %s", datatype)
+			}
+		case godror.NullTime:
+			if !x.Valid {
+				return "NULL"
+			}
+			if x.Time.IsZero() {
+				t.Errorf("zero NullTime.Time, and Valid!?")
+			}
+			return x.Time.Format(time.RFC3339)
+		case time.Time:
+			return x.Format(time.RFC3339)
+		default:
+			return fmt.Sprintf("%v", field)
+		}
 	}
 
-	P, err := godror.ParseDSN(testConStr)
-	if err != nil {
-		t.Fatal(err)
-	}
-	P.ConnectString = "--BAD---" + P.ConnectString
-	badDB, err := sql.Open("godror", P.String())
-	if err != nil {
-		t.Fatal(err)
+	// create a slice of interface{}'s to represent each column,
+	// and a second slice to contain pointers to each item in the columns slice
+	columns := make([]interface{}, totalColumns)
+	recordPointers := make([]interface{}, totalColumns)
+	for i := range columns {
+		if types[i].DatabaseTypeName() == "DATE" {
+			var t godror.NullTime
+			recordPointers[i] = &t
+			columns[i] = t
+		} else {
+			recordPointers[i] = &columns[i]
+		}
 	}
 
-	ctx, cancel = context.WithTimeout(testContext("Ping"), 1*time.Second)
-	defer cancel()
-
-	dl, _ := ctx.Deadline()
-	err = badDB.PingContext(ctx)
-	ok := dl.After(time.Now())
-	if err != nil {
-		t.Log(err)
-	} else {
-		t.Log("ping succeeded")
-		if !ok {
-			t.Error("ping succeeded after deadline!")
+	dumpRows := func() {
+		dests := make([]string, 0, len(colsName))
+		params := make([]interface{}, 0, cap(dests))
+		var dumpQry strings.Builder
+		dumpQry.WriteString("SELECT ")
+		for _, col := range colsName {
+			if strings.HasSuffix(col, "_LOB") {
+				continue
+			}
+			if len(dests) != 0 {
+				dumpQry.WriteString(", ")
+			}
+			fmt.Fprintf(&dumpQry, "'%[1]s='||DUMP(%[1]s, 1017,1,10) AS %[1]s", col)
+			dests = append(dests, "")
+			params = append(params, &dests[len(dests)-1])
+		}
+		dumpQry.WriteString(" FROM test_types")
+		qry := dumpQry.String()
+		rows, err := testDb.QueryContext(ctx, qry)
+		if err != nil {
+			t.Errorf("%s: %+v", qry, err)
+			return
+		}
+		defer rows.Close()
+		var i int
+		for rows.Next() {
+			if err = rows.Scan(params...); err != nil {
+				t.Fatal(err)
+			}
+			i++
+			t.Logf("%d. %q", i, dests)
+		}
+		if err = rows.Err(); err != nil {
+			t.Fatal(err)
 		}
 	}
-}
 
-func TestNoConnectionPooling(t *testing.T) {
-	t.Parallel()
-	db, err := sql.Open("godror",
-		strings.Replace(
-			strings.Replace(testConStr, "TestClassName", godror.NoConnectionPoolingConnectionClass, 1),
-			"standaloneConnection=0", "standaloneConnection=1", 1,
-		),
-	)
-	if err != nil {
+	// record destination
+	record := make([]interface{}, totalColumns)
+	var rowCount int
+	for rows.Next() {
+		// Scan the result into the record pointers...
+		if err = rows.Scan(recordPointers...); err != nil {
+			dumpRows()
+			t.Fatal(err)
+		}
+		rowCount++
+
+		// Parse each field of recordPointers for get a custom field depending the type
+		for idxCol := range recordPointers {
+			record[idxCol] = oracleFieldParse(types[idxCol].DatabaseTypeName(), reflect.ValueOf(recordPointers[idxCol]).Elem().Interface())
+		}
+
+		t.Log(record)
+	}
+	if err = rows.Err(); err != nil {
+		var cErr interface{ Code() int }
+		if errors.As(err, &cErr) && cErr.Code() == 1805 {
+			t.Skip(err)
+		}
 		t.Fatal(err)
 	}
-	db.Close()
-}
-
-func TestExecTimeout(t *testing.T) {
-	t.Parallel()
-	defer tl.enableLogging(t)()
-	ctx, cancel := context.WithTimeout(testContext("ExecTimeout"), 100*time.Millisecond)
-	defer cancel()
-	if _, err := testDb.ExecContext(ctx, "DECLARE cnt PLS_INTEGER; BEGIN SELECT COUNT(0) INTO cnt FROM (SELECT 1 FROM all_objects WHERE ROWNUM < 1000), (SELECT 1 FROM all_objects WHERE rownum < 1000); END;"); err != nil {
-		t.Log(err)
+	dumpRows()
+	if rowCount != 2 {
+		t.Errorf("read %d rows, wanted 2", rowCount)
 	}
 }
 
-func TestQueryTimeout(t *testing.T) {
-	t.Parallel()
-	defer tl.enableLogging(t)()
-	ctx, cancel := context.WithTimeout(testContext("QueryTimeout"), 100*time.Millisecond)
-	defer cancel()
-	if rows, err := testDb.QueryContext(ctx, "SELECT COUNT(0) FROM (SELECT 1 FROM all_objects WHERE rownum < 1000), (SELECT 1 FROM all_objects WHERE rownum < 1000)"); err != nil {
-		t.Log(err)
-	} else {
-		rows.Close()
-	}
-}
-func TestQueryLOBTimeout(t *testing.T) {
+func TestInsertIntervalDS(t *testing.T) {
 	t.Parallel()
-	defer tl.enableLogging(t)()
-	ctx, cancel := context.WithTimeout(testContext("QueryLOBTimeout"), 10*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("InsertIntervalDS"), 10*time.Second)
 	defer cancel()
-	tbl := "test_query_lob_" + tblSuffix
-	drop := func() { testDb.ExecContext(context.Background(), "DROP TABLE "+tbl) }
-	drop()
-	qry := "CREATE TABLE " + tbl + " (i NUMBER(3), lob BLOB)"
+	const tbl = "test_interval_ds"
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	qry := "CREATE TABLE " + tbl + " (F_interval_ds INTERVAL DAY TO SECOND(3))"
 	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		t.Fatalf("%s: %+v", qry, err)
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-	defer drop()
+	defer func() { testDb.ExecContext(testContext("InsertIntervalDS-drop"), "DROP TABLE "+tbl) }()
 
-	// Generate some lob, 1MiB size
-	length := int(1 << 20)
-	lobGen := godror.Lob{
-		Reader: ReaderFunc(func(p []byte) (int, error) {
-			if length <= 0 {
-				return 0, io.EOF
-			}
-			var n int
-			for i := 0; i < len(p) && length > 0; i++ {
-				p[i] = byte(length & 0xff)
-				n++
-				length--
-			}
-			if length <= 0 {
-				return n, io.EOF
-			}
-			return n, nil
-		}),
+	qry = "INSERT INTO " + tbl + " (F_interval_ds) VALUES (INTERVAL '32' SECOND)"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-	qry = "INSERT INTO " + tbl + " (i, lob) VALUES (0, :1)"
-	if _, err := testDb.ExecContext(ctx, qry, lobGen); err != nil {
-		t.Fatalf("%s: %+v", qry, err)
+	qry = "INSERT INTO " + tbl + " (F_interval_ds) VALUES (:1)"
+	if _, err := testDb.ExecContext(ctx, qry, 33*time.Second); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
 
-	ctx, cancel = context.WithTimeout(ctx, 500*time.Millisecond)
-	defer cancel()
-	if rows, err := testDb.QueryContext(ctx, "SELECT A.i, A.lob FROM all_objects B, "+tbl+" A FETCH FIRST 1 ROW ONLY"); err != nil {
-		t.Log(err)
-	} else {
-		defer rows.Close()
-		var i int
-		var s []byte
-		for rows.Next() {
-			if err := rows.Scan(&i, &s); err != nil {
-				t.Errorf("scan: %+v", err)
-				break
-			}
-			fmt.Println(i, len(s))
-			t.Log(i, len(s))
+	qry = "SELECT F_interval_ds FROM " + tbl + " ORDER BY 1"
+	rows, err := testDb.QueryContext(ctx, qry)
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer rows.Close()
+	var got []time.Duration
+	for rows.Next() {
+		var dur time.Duration
+		if err = rows.Scan(&dur); err != nil {
+			t.Fatal(err)
 		}
+		got = append(got, dur)
+	}
+	t.Log("got:", got)
+	if !(len(got) == 2 && got[0] == 32*time.Second && got[1] == 33*time.Second) {
+		t.Errorf("wanted [32s, 33s], got %v", got)
 	}
 }
-
-func TestSDO(t *testing.T) {
-	// t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("SDO"), 30*time.Second)
+func TestIntervalYM(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("IntervalYM"), 10*time.Second)
 	defer cancel()
-	innerQry := `SELECT MDSYS.SDO_GEOMETRY(
-	3001,
-	NULL,
-	NULL,
-	MDSYS.SDO_ELEM_INFO_ARRAY(
-		1,1,1,4,1,0,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL
-	),
-	MDSYS.SDO_ORDINATE_ARRAY(
-		480736.567,10853969.692,0,0.998807402795312,-0.0488238888381834,0,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL,NULL)
-		) SHAPE FROM DUAL`
-	selectQry := `SELECT shape, DUMP(shape), CASE WHEN shape IS NULL THEN 'I' ELSE 'N' END FROM (` + innerQry + ")"
-	rows, err := testDb.QueryContext(ctx, selectQry)
-	if err != nil {
-		if !strings.Contains(err.Error(), `ORA-00904: "MDSYS"."SDO_GEOMETRY"`) {
-			t.Fatal(fmt.Errorf("%s: %w", selectQry, err))
-		}
-		for _, qry := range []string{
-			`CREATE TYPE test_sdo_point_type AS OBJECT (
-			   X NUMBER,
-			   Y NUMBER,
-			   Z NUMBER)`,
-			"CREATE TYPE test_sdo_elem_info_array AS VARRAY (1048576) of NUMBER",
-			"CREATE TYPE test_sdo_ordinate_array AS VARRAY (1048576) of NUMBER",
-			`CREATE TYPE test_sdo_geometry AS OBJECT (
-			 SDO_GTYPE NUMBER,
-			 SDO_SRID NUMBER,
-			 SDO_POINT test_SDO_POINT_TYPE,
-			 SDO_ELEM_INFO test_SDO_ELEM_INFO_ARRAY,
-			 SDO_ORDINATES test_SDO_ORDINATE_ARRAY)`,
-
-			`CREATE TABLE test_sdo(
-					id INTEGER not null,
-					shape test_SDO_GEOMETRY not null
-				)`,
-		} {
-			var drop string
-			if strings.HasPrefix(qry, "CREATE TYPE") {
-				drop = "DROP TYPE " + qry[12:strings.Index(qry, " AS")] + " FORCE"
-			} else {
-				drop = "DROP TABLE " + qry[13:strings.Index(qry, "(")]
-			}
-			testDb.ExecContext(ctx, drop)
-			t.Log(drop)
-			if _, err = testDb.ExecContext(ctx, qry); err != nil {
-				err = fmt.Errorf("%s: %w", qry, err)
-				t.Log(err)
-				if !strings.Contains(err.Error(), "ORA-01031:") {
-					t.Fatal(err)
-				}
-				t.Skip(err)
-			}
-			defer testDb.ExecContext(ctx, drop)
-		}
+	tbl := "test_interval_ym" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	qry := "CREATE TABLE " + tbl + " (id NUMBER(3), F_interval_ym INTERVAL YEAR TO MONTH)"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer func() { testDb.ExecContext(testContext("IntervalYM-drop"), "DROP TABLE "+tbl) }()
 
-		selectQry = strings.Replace(selectQry, "MDSYS.SDO_", "test_SDO_", -1)
-		if rows, err = testDb.QueryContext(ctx, selectQry); err != nil {
-			t.Fatal(fmt.Errorf("%s: %w", selectQry, err))
-		}
+	qry = "INSERT INTO " + tbl + " (id, F_interval_ym) VALUES (1, INTERVAL '1-2' YEAR TO MONTH)"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	qry = "INSERT INTO " + tbl + " (id, F_interval_ym) VALUES (:1, :2)"
+	if _, err := testDb.ExecContext(ctx, qry,
+		[]int{2, 3}, []godror.IntervalYM{{Years: -3, Months: -4}, {Months: 5}},
+	); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
 
+	qry = "SELECT F_interval_ym, F_interval_ym FROM " + tbl + " ORDER BY id"
+	rows, err := testDb.QueryContext(ctx, qry)
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
 	defer rows.Close()
+	var got []godror.IntervalYM
+	var gotS []string
 	for rows.Next() {
-		var dmp, isNull string
-		var intf interface{}
-		if err = rows.Scan(&intf, &dmp, &isNull); err != nil {
-			t.Error(fmt.Errorf("%s: %w", "scan", err))
+		var ym godror.IntervalYM
+		var s string
+		if err = rows.Scan(&ym, &s); err != nil {
+			t.Fatal(err)
 		}
-		t.Log(dmp, isNull)
-		obj := intf.(*godror.Object)
-		// t.Log("obj:", obj)
-		printObj(t, "", obj)
+		got, gotS = append(got, ym), append(gotS, s)
 	}
 	if err = rows.Err(); err != nil {
 		t.Fatal(err)
 	}
-}
+	t.Log("got:", got, gotS)
+	if want := []godror.IntervalYM{{Years: 1, Months: 2}, {Years: -3, Months: -4}, {Months: 5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+	if want := []string{"1-2", "-3-4", "0-5"}; !reflect.DeepEqual(gotS, want) {
+		t.Errorf("got %q, wanted %q", gotS, want)
+	}
 
-func printObj(t *testing.T, name string, obj *godror.Object) {
-	if obj == nil {
-		return
+	var out godror.IntervalYM
+	qry = "BEGIN :1 := :2 + INTERVAL '1-1' YEAR TO MONTH; END;"
+	if _, err = testDb.ExecContext(ctx, qry, sql.Out{Dest: &out}, godror.IntervalYM{Years: 1, Months: 2}); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-	for key := range obj.Attributes {
-		sub, err := obj.Get(key)
-		t.Logf("%s.%s. %+v (err=%+v)\n", name, key, sub, err)
-		if err != nil {
-			t.Errorf("ERROR: %+v", err)
-		}
-		if ss, ok := sub.(*godror.Object); ok {
-			printObj(t, name+"."+key, ss)
-		} else if coll, ok := sub.(*godror.ObjectCollection); ok {
-			slice, err := coll.AsSlice(nil)
-			t.Logf("%s.%s. %+v", name, key, slice)
-			if err != nil {
-				t.Fatal(err)
-			}
-		}
+	if want := (godror.IntervalYM{Years: 2, Months: 3}); out != want {
+		t.Errorf("got %v, wanted %v", out, want)
 	}
 }
 
-var _ = driver.Valuer((*Custom)(nil))
-var _ = sql.Scanner((*Custom)(nil))
-
-type Custom struct {
-	Num int64
-}
+func TestIntervalDS(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("IntervalDS"), 10*time.Second)
+	defer cancel()
+	tbl := "test_interval_ds" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	qry := "CREATE TABLE " + tbl + " (id NUMBER(3), F_interval_ds INTERVAL DAY(9) TO SECOND(9))"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer func() { testDb.ExecContext(testContext("IntervalDS-drop"), "DROP TABLE "+tbl) }()
 
-func (t *Custom) Value() (driver.Value, error) {
-	return t.Num, nil
-}
+	qry = "INSERT INTO " + tbl + " (id, F_interval_ds) VALUES (1, INTERVAL '999999999 23:59:59.999999999' DAY(9) TO SECOND(9))"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	qry = "INSERT INTO " + tbl + " (id, F_interval_ds) VALUES (:1, :2)"
+	if _, err := testDb.ExecContext(ctx, qry,
+		[]int{2, 3}, []time.Duration{-(26*time.Hour + 1), 3*time.Second + 123456789},
+	); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
 
-func (t *Custom) Scan(v interface{}) error {
-	var err error
-	switch v := v.(type) {
-	case int64:
-		t.Num = v
-	case string:
-		t.Num, err = strconv.ParseInt(v, 10, 64)
-	case float64:
-		t.Num = int64(v)
-	default:
-		err = fmt.Errorf("unknown type %T", v)
+	qry = "SELECT F_interval_ds FROM " + tbl + " ORDER BY id"
+	rows, err := testDb.QueryContext(ctx, qry)
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer rows.Close()
+	var got []godror.IntervalDS
+	for rows.Next() {
+		var ds godror.IntervalDS
+		if err = rows.Scan(&ds); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, ds)
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	t.Log("got:", got)
+	if want := []godror.IntervalDS{
+		{Days: 999999999, Hours: 23, Minutes: 59, Seconds: 59, Nanoseconds: 999999999},
+		{Days: -1, Hours: -2, Nanoseconds: -1},
+		{Seconds: 3, Nanoseconds: 123456789},
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+
+	var d time.Duration
+	qry = "SELECT F_interval_ds FROM " + tbl + " WHERE id = 3"
+	if err = testDb.QueryRowContext(ctx, qry).Scan(&d); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	if want := 3*time.Second + 123456789; d != want {
+		t.Errorf("got %s, wanted %s", d, want)
+	}
+
+	var out godror.IntervalDS
+	qry = "BEGIN :1 := :2 + INTERVAL '1' SECOND; END;"
+	if _, err = testDb.ExecContext(ctx, qry, sql.Out{Dest: &out},
+		godror.IntervalDS{Days: 500000, Nanoseconds: 5},
+	); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	if want := (godror.IntervalDS{Days: 500000, Seconds: 1, Nanoseconds: 5}); out != want {
+		t.Errorf("got %v, wanted %v", out, want)
 	}
-	return err
 }
 
-func TestSelectCustomType(t *testing.T) {
+func TestTimestampLTZ(t *testing.T) {
 	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("SelectCustomType"), 10*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("TimestampLTZ"), 10*time.Second)
 	defer cancel()
 	conn, err := testDb.Conn(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer conn.Close()
-	tbl := "test_custom_type" + tblSuffix
-	conn.ExecContext(ctx, "DROP TABLE "+tbl)
-	qry := "CREATE TABLE " + tbl + " (nm VARCHAR2(30), typ VARCHAR2(30), id NUMBER(6), created DATE)"
-	if _, err = conn.ExecContext(ctx, qry); err != nil {
+
+	const qryTZ = "SELECT SESSIONTIMEZONE FROM DUAL"
+	var origTZ string
+	if err = conn.QueryRowContext(ctx, qryTZ).Scan(&origTZ); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qryTZ, err))
+	}
+	// the time zone set by the session itself is restored, too
+	if _, err = conn.ExecContext(ctx, "ALTER SESSION SET TIME_ZONE = '-03:00'"); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.ExecContext(context.Background(), "ALTER SESSION SET TIME_ZONE = '"+origTZ+"'")
+	origTZ = "-03:00"
+
+	want := time.Date(2024, 3, 31, 12, 34, 56, 0, time.UTC)
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	qry := `SELECT SESSIONTIMEZONE, TO_CHAR(CAST(ltz AS TIMESTAMP), 'YYYY-MM-DD HH24:MI:SS'), ltz
+  FROM (SELECT CAST(:1 AS TIMESTAMP WITH LOCAL TIME ZONE) AS ltz FROM DUAL)`
+	var sessTZ, local string
+	var got time.Time
+	if err = conn.QueryRowContext(ctx, qry, want,
+		godror.SessionTimezone("+05:00"), godror.LTZLocation(loc),
+	).Scan(&sessTZ, &local, &got); err != nil {
 		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-	defer testDb.Exec("DROP TABLE " + tbl)
+	t.Logf("sessionTZ=%q local=%q got=%v", sessTZ, local, got)
+	if sessTZ != "+05:00" {
+		t.Errorf("got session time zone %q, wanted +05:00", sessTZ)
+	}
+	if local != "2024-03-31 17:34:56" {
+		t.Errorf("got local time %q, wanted 2024-03-31 17:34:56", local)
+	}
+	if !got.Equal(want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+	if got.Location() != loc {
+		t.Errorf("got location %v, wanted %v", got.Location(), loc)
+	}
 
-	n := 1000
-	nms, typs, ids, createds := make([]string, n), make([]string, n), make([]int, n), make([]time.Time, n)
-	now := time.Now()
-	for i := range nms {
-		nms[i], typs[i], ids[i], createds[i] = fmt.Sprintf("obj-%d", i), "OBJECT", i, now.Add(-time.Duration(i)*time.Second)
+	var afterTZ string
+	if err = conn.QueryRowContext(ctx, qryTZ).Scan(&afterTZ); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qryTZ, err))
 	}
-	qry = "INSERT INTO " + tbl + " (nm, typ, id, created) VALUES (:1, :2, :3, :4)"
-	if _, err = conn.ExecContext(ctx, qry, nms, typs, ids, createds); err != nil {
+	if afterTZ != origTZ {
+		t.Errorf("session time zone is %q after the query, wanted %q", afterTZ, origTZ)
+	}
+}
+
+func TestNString(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("NString"), 10*time.Second)
+	defer cancel()
+	tbl := "test_nstring" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	qry := "CREATE TABLE " + tbl + " (id NUMBER(3), F_nvc NVARCHAR2(100))"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
 		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
+	defer func() { testDb.ExecContext(testContext("NString-drop"), "DROP TABLE "+tbl) }()
 
-	const num = 10
-	nums := &Custom{Num: num}
-	type underlying int64
-	numbers := underlying(num)
-	rows, err := conn.QueryContext(ctx,
-		"SELECT nm, typ, id, created FROM "+tbl+" WHERE ROWNUM < COALESCE(:alpha, :beta, 2) ORDER BY id",
-		sql.Named("alpha", nums),
-		sql.Named("beta", int64(numbers)),
-	)
+	want := []godror.NString{"árvíztűrő tükörfúrógép", "日本語 ☃"}
+	qry = "INSERT INTO " + tbl + " (id, F_nvc) VALUES (:1, :2)"
+	if _, err := testDb.ExecContext(ctx, qry, []int{1, 2}, want); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+
+	qry = "SELECT F_nvc FROM " + tbl + " ORDER BY id"
+	rows, err := testDb.QueryContext(ctx, qry)
 	if err != nil {
-		t.Fatalf("%+v", err)
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-	n = 0
-	oldOid := int64(0)
+	defer rows.Close()
+	if cts, err := rows.ColumnTypes(); err != nil {
+		t.Fatal(err)
+	} else if st := cts[0].ScanType(); st != reflect.TypeOf("") {
+		t.Errorf("got scan type %v, wanted string", st)
+	}
+	var got []godror.NString
 	for rows.Next() {
-		var tbl, typ string
-		var oid int64
-		var created time.Time
-		if err := rows.Scan(&tbl, &typ, &oid, &created); err != nil {
+		var s godror.NString
+		if err = rows.Scan(&s); err != nil {
 			t.Fatal(err)
 		}
-		t.Log(tbl, typ, oid, created)
-		if tbl == "" {
-			t.Fatal("empty tbl")
-		}
-		n++
-		if oldOid > oid {
-			t.Errorf("got oid=%d, wanted sth < %d.", oid, oldOid)
-		}
-		oldOid = oid
+		got = append(got, s)
 	}
-	if n == 0 || n > num {
-		t.Errorf("got %d rows, wanted %d", n, num)
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+
+	var out godror.NString
+	qry = "BEGIN :1 := UNISTR('\\2603') || :2; END;"
+	if _, err = testDb.ExecContext(ctx, qry, sql.Out{Dest: &out}, godror.NString("ő")); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	if want := godror.NString("☃ő"); out != want {
+		t.Errorf("got %q, wanted %q", out, want)
 	}
 }
 
-func TestExecInt64(t *testing.T) {
+func TestNullGeneric(t *testing.T) {
 	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("ExecInt64"), 10*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("NullGeneric"), 10*time.Second)
 	defer cancel()
-	qry := `CREATE OR REPLACE PROCEDURE test_i64_out(p_int NUMBER, p_out1 OUT NUMBER, p_out2 OUT NUMBER) IS
-	BEGIN p_out1 := p_int; p_out2 := p_int; END;`
+	tbl := "test_null_generic" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	qry := "CREATE TABLE " + tbl + " (id NUMBER(3), F_num NUMBER(9), F_txt VARCHAR2(10))"
 	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		t.Fatal(err)
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-	defer testDb.ExecContext(ctx, "DROP PROCEDURE test_i64_out")
+	defer func() { testDb.ExecContext(testContext("NullGeneric-drop"), "DROP TABLE "+tbl) }()
 
-	qry = "BEGIN test_i64_out(:1, :2, :3); END;"
-	var num sql.NullInt64
-	var str string
-	defer tl.enableLogging(t)()
-	if _, err := testDb.ExecContext(ctx, qry, 3.14, sql.Out{Dest: &num}, sql.Out{Dest: &str}); err != nil {
-		t.Fatal(err)
+	qry = "INSERT INTO " + tbl + " (id, F_num, F_txt) VALUES (:1, :2, :3)"
+	if _, err := testDb.ExecContext(ctx, qry,
+		[]int{1, 2},
+		[]sql.Null[int32]{{V: 3, Valid: true}, {}},
+		[]sql.Null[string]{{}, {V: "b", Valid: true}},
+	); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-	t.Log("num:", num, "str:", str)
-}
-
-func TestImplicitResults(t *testing.T) {
-	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("ImplicitResults"), 10*time.Second)
-	defer cancel()
-	const qry = `declare
-			c0 sys_refcursor;
-            c1 sys_refcursor;
-            c2 sys_refcursor;
-        begin
-            OPEN c0 FOR SELECT 0 FROM DUAL;
-			:1 := c0;
-            open c1 for select 1 from DUAL;
-            dbms_sql.return_result(c1);
-            open c2 for select 'A' from DUAL;
-            dbms_sql.return_result(c2);
-        end;`
-	var rows driver.Rows
-	if _, err := testDb.ExecContext(ctx, qry, sql.Out{Dest: &rows}); err != nil {
-		if strings.Contains(err.Error(), "PLS-00302:") {
-			t.Skip()
-		}
+	if _, err := testDb.ExecContext(ctx, qry, 3, sql.Null[int64]{}, sql.Null[string]{V: "c", Valid: true}); err != nil {
 		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-	if rows == nil {
-		t.Fatal("rows is nil")
+
+	qry = "SELECT F_num, F_txt FROM " + tbl + " ORDER BY id"
+	rows, err := testDb.QueryContext(ctx, qry)
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
 	defer rows.Close()
-	r := rows.(driver.RowsNextResultSet)
-	for r.HasNextResultSet() {
-		if err := r.NextResultSet(); err != nil {
-			t.Error(err)
+	var nums []sql.Null[int64]
+	var txts []sql.Null[string]
+	for rows.Next() {
+		var n sql.Null[int64]
+		var s sql.Null[string]
+		if err = rows.Scan(&n, &s); err != nil {
+			t.Fatal(err)
 		}
+		nums, txts = append(nums, n), append(txts, s)
 	}
-}
-
-func TestStartupShutdown(t *testing.T) {
-	ensureSystemDB(t)
-	if os.Getenv("GODROR_DB_SHUTDOWN") != "1" {
-		t.Skip("GODROR_DB_SHUTDOWN != 1, skipping shutdown/startup test")
-	}
-	p, err := godror.ParseDSN(testSystemConStr)
-	if err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", testSystemConStr, err))
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
 	}
-	if !(p.AdminRole == godror.SysDBA || p.AdminRole == godror.SysOPER) {
-		p.AdminRole = godror.SysDBA
+	if want := []sql.Null[int64]{{V: 3, Valid: true}, {}, {}}; !reflect.DeepEqual(nums, want) {
+		t.Errorf("got %v, wanted %v", nums, want)
 	}
-	if !p.IsPrelim {
-		p.IsPrelim = true
+	if want := []sql.Null[string]{{}, {V: "b", Valid: true}, {V: "c", Valid: true}}; !reflect.DeepEqual(txts, want) {
+		t.Errorf("got %v, wanted %v", txts, want)
 	}
-	db := sql.OpenDB(godror.NewConnector(p))
-	defer db.Close()
-	ctx, cancel := context.WithTimeout(testContext("StartupShutdown"), time.Minute)
-	defer cancel()
 
-	if err = db.PingContext(ctx); err != nil {
-		t.Fatal(err, p.StringWithPassword())
+	var out, outNull sql.Null[int64]
+	qry = "BEGIN :1 := :2 * 2; :3 := NULL; END;"
+	if _, err = testDb.ExecContext(ctx, qry,
+		sql.Out{Dest: &out}, sql.Null[int64]{V: 21, Valid: true}, sql.Out{Dest: &outNull},
+	); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-
-	if err = godror.Raw(ctx, db, func(conn godror.Conn) error {
-		if err = conn.Shutdown(godror.ShutdownTransactionalLocal); err != nil {
-			return err
-		}
-		return conn.Shutdown(godror.ShutdownFinal)
-	}); err != nil {
-		t.Errorf("SHUTDOWN: %+v", err)
+	if want := (sql.Null[int64]{V: 42, Valid: true}); out != want || outNull.Valid {
+		t.Errorf("got %v and %v, wanted %v and NULL", out, outNull, want)
 	}
-	db.Close()
+}
 
-	db = sql.OpenDB(godror.NewConnector(p))
-	defer db.Close()
-	if err = godror.Raw(ctx, db, func(conn godror.Conn) error {
-		return conn.Startup(godror.StartupDefault)
-	}); err != nil {
-		t.Log("Couldn't start up database. run 'echo startup | sqlplus / as sysdba'")
-		t.Errorf("STARTUP: %+v", err)
+func TestRowID(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("RowID"), 10*time.Second)
+	defer cancel()
+	tbl := "test_rowid" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	qry := "CREATE TABLE " + tbl + " (id NUMBER(3), txt VARCHAR2(10))"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer func() { testDb.ExecContext(testContext("RowID-drop"), "DROP TABLE "+tbl) }()
+	qry = "INSERT INTO " + tbl + " (id, txt) VALUES (1, 'a')"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-}
 
-func TestIssue134(t *testing.T) {
-	cleanup := func() {
-		for _, qry := range []string{
-			`DROP TYPE test_prj_task_tt`,
-			`DROP TYPE test_prj_task_ot`,
-			`DROP PROCEDURE test_create_task_activity`,
-		} {
-			testDb.Exec(qry)
-		}
+	qry = `SELECT ROWID, DBMS_ROWID.rowid_object(ROWID), DBMS_ROWID.rowid_relative_fno(ROWID),
+       DBMS_ROWID.rowid_block_number(ROWID), DBMS_ROWID.rowid_row_number(ROWID)
+  FROM ` + tbl
+	var rid godror.RowID
+	var want godror.RowIDParts
+	if err := testDb.QueryRowContext(ctx, qry).Scan(&rid, &want.Object, &want.File, &want.Block, &want.Row); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-	cleanup()
-	ctx, cancel := context.WithTimeout(testContext("Issue134"), 10*time.Second)
-	defer cancel()
-	cx, err := testDb.Conn(ctx)
+	got, err := rid.Parts()
+	t.Logf("rowid=%q parts=%+v", rid, got)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer cx.Close()
-	for _, qry := range []string{
-		`CREATE OR REPLACE TYPE test_PRJ_TASK_ot AS OBJECT (
-	PROJECT_NUMBER VARCHAR2(100)
-	,SOURCE_ID VARCHAR2(100)
-	,TASK_NAME VARCHAR2(300)
-	,TASK_DESCRIPTION VARCHAR2(2000)
-	,TASK_START_DATE DATE
-	,TASK_END_DATE DATE
-	,TASK_COST NUMBER
-	,SOURCE_PARENT_ID NUMBER
-	,TASK_TYPE VARCHAR2(100)
-	,QUANTITY NUMBER 
-	,data BLOB
-)`,
-		`CREATE OR REPLACE TYPE test_PRJ_TASK_tt IS TABLE OF test_PRJ_TASK_ot`,
-		`CREATE OR REPLACE FUNCTION test_CREATE_TASK_ACTIVITY (
-    p_create_task_i IN test_PRJ_TASK_tt,
-	p_project_id_i IN NUMBER) RETURN test_prj_task_ot 
-IS 
-  v_obj test_prj_task_ot;
-BEGIN 
-  IF p_create_task_i.COUNT = 0 THEN
-    v_obj := test_prj_task_ot(
-	PROJECT_NUMBER=>NULL
-	,SOURCE_ID=>NULL
-	,TASK_NAME=>NULL
-	,TASK_DESCRIPTION=>NULL
-	,TASK_START_DATE=>NULL
-	,TASK_END_DATE=>NULL
-	,TASK_COST=>NULL
-	,SOURCE_PARENT_ID=>NULL
-	,TASK_TYPE=>NULL
-	,QUANTITY=>NULL
-	,data=>NULL);
-  ELSE
-    v_obj := p_create_task_i(p_create_task_i.FIRST);
-  END IF;
-  DBMS_LOB.createtemporary(v_obj.data, TRUE, 31);
-  DBMS_LOB.writeAppend(v_obj.data, 10, 
-    UTL_RAW.cast_to_raw(TO_CHAR(SYSDATE, 'YYYY-MM-DD')));
-  RETURN(v_obj);
-END;`,
+	if got != want {
+		t.Errorf("got %+v, wanted %+v", got, want)
+	}
+
+	qry = "UPDATE " + tbl + " SET txt = 'b' WHERE ROWID = :1"
+	res, err := testDb.ExecContext(ctx, qry, rid)
+	if err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	if n, err := res.RowsAffected(); err != nil || n != 1 {
+		t.Errorf("%s: updated %d rows (%+v), wanted 1", qry, n, err)
+	}
+}
+
+func TestConverter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("Converter"), 30*time.Second)
+	defer cancel()
+	moneyType, holderType := "test_money_ot"+tblSuffix, "test_money_holder_ot"+tblSuffix
+	testDb.ExecContext(ctx, "DROP TYPE "+holderType)
+	testDb.ExecContext(ctx, "DROP TYPE "+moneyType)
+	for _, qry := range []string{
+		"CREATE OR REPLACE TYPE " + moneyType + " AS OBJECT (amount NUMBER, currency VARCHAR2(3))",
+		"CREATE OR REPLACE TYPE " + holderType + " AS OBJECT (price " + moneyType + ")",
 	} {
-		if _, err := cx.ExecContext(ctx, qry); err != nil {
-			t.Fatal(fmt.Errorf("%s: %w", qry, err))
+		if _, err := testDb.ExecContext(ctx, qry); err != nil {
+			t.Fatalf("%s: %+v", qry, err)
 		}
 	}
-	defer cleanup()
-	compileErrors, err := godror.GetCompileErrors(ctx, cx, false)
-	if err != nil {
-		t.Fatal(err)
+	defer testDb.Exec("DROP TYPE " + moneyType)
+	defer testDb.Exec("DROP TYPE " + holderType)
+
+	godror.RegisterConverter(moneyType,
+		func(d *godror.Data) (interface{}, error) {
+			o := d.GetObject()
+			if o == nil {
+				return nil, nil
+			}
+			defer o.Close()
+			amount, err := o.Get("AMOUNT")
+			if err != nil {
+				return nil, err
+			}
+			currency, err := o.Get("CURRENCY")
+			if err != nil {
+				return nil, err
+			}
+			return fmt.Sprintf("%v %v", amount, currency), nil
+		},
+		func(v interface{}, d *godror.Data) error {
+			var amount, currency string
+			if _, err := fmt.Sscan(v.(string), &amount, &currency); err != nil {
+				return err
+			}
+			o, err := d.ObjectType.NewObject()
+			if err != nil {
+				return err
+			}
+			if err = o.Set("AMOUNT", godror.Number(amount)); err != nil {
+				return err
+			}
+			if err = o.Set("CURRENCY", currency); err != nil {
+				return err
+			}
+			d.SetObject(o)
+			return nil
+		},
+	)
+	defer godror.RegisterConverter(moneyType, nil, nil)
+
+	var s string
+	qry := "SELECT " + moneyType + "(12.5, 'HUF') FROM DUAL"
+	before := godror.GetResourceStats()
+	if err := testDb.QueryRowContext(ctx, qry).Scan(&s); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
 	}
-	for _, ce := range compileErrors {
-		t.Error(ce)
+	if want := "12.5 HUF"; s != want {
+		t.Errorf("%s: got %q, wanted %q", qry, s, want)
 	}
-	if len(compileErrors) != 0 {
-		t.Fatal("compile failed")
+	if after := godror.GetResourceStats(); after.Objects != before.Objects {
+		t.Errorf("converting the object leaked %d Objects", after.Objects-before.Objects)
 	}
 
-	conn, err := godror.DriverConn(ctx, cx)
+	conn, err := testDb.Conn(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer conn.Close()
-	ot, err := conn.GetObjectType("TEST_PRJ_TASK_tt")
+	ot, err := godror.GetObjectType(ctx, conn, holderType)
 	if err != nil {
 		t.Fatal(err)
 	}
-	obj, err := ot.NewObject()
+	defer ot.Close()
+	holder, err := ot.NewObject()
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer obj.Close()
-	t.Logf("obj=%#v", obj)
-	rt, err := conn.GetObjectType("TEST_PRJ_TASK_ot")
-	if err != nil {
+	defer holder.Close()
+	if err = holder.Set("PRICE", "3 EUR"); err != nil {
 		t.Fatal(err)
 	}
-	ret, err := rt.NewObject()
-	if err != nil {
+	if v, err := holder.Get("PRICE"); err != nil {
 		t.Fatal(err)
+	} else if want := "3 EUR"; v != want {
+		t.Errorf("Get: got %#v, wanted %q", v, want)
 	}
-	defer ret.Close()
+}
 
-	qry := "BEGIN :1 := test_create_task_activity(:2, :3); END;"
-	if err := prepExec(ctx, conn, qry,
-		driver.NamedValue{Value: &ret, Ordinal: 1},
-		driver.NamedValue{Value: &obj, Ordinal: 2},
-		driver.NamedValue{Value: 1, Ordinal: 3},
-	); err != nil {
-		t.Fatalf("%s [%#v, 1]: %+v", qry, obj, err)
-	}
-	t.Logf("ret: %#v", ret)
-	var data godror.Data
-	if err := ret.GetAttribute(&data, "DATA"); err != nil {
+func TestUUID(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("UUID"), 10*time.Second)
+	defer cancel()
+	u, err := godror.ParseUUID("00112233-4455-6677-8899-aabbccddeeff")
+	if err != nil {
 		t.Fatal(err)
 	}
-	if b, err := io.ReadAll(data.GetLob()); err != nil {
-		t.Fatal(err)
-	} else {
-		t.Logf("DATA: %q", b)
+	const qry = "SELECT RAWTOHEX(:1), RAWTOHEX(:2), :3 FROM DUAL"
+	var big, mixed string
+	var back godror.UUID
+	if err = testDb.QueryRowContext(ctx, qry, u, godror.UUIDIn(&u, godror.UUIDMixedEndian), u).Scan(&big, &mixed, &back); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	if want := "00112233445566778899AABBCCDDEEFF"; big != want {
+		t.Errorf("big endian: got %q, wanted %q", big, want)
+	}
+	if want := "33221100554477668899AABBCCDDEEFF"; mixed != want {
+		t.Errorf("mixed endian: got %q, wanted %q", mixed, want)
+	}
+	if back != u {
+		t.Errorf("got %s, wanted %s", back, u)
 	}
-}
-
-func TestDateRset(t *testing.T) {
-	defer tl.enableLogging(t)()
-	ctx := testContext("DateRset")
-
-	const qry = `DECLARE
-  v_cur SYS_REFCURSOR;
-BEGIN
-  OPEN v_cur FOR
-    SELECT TO_DATE('2015/05/15 8:30:25', 'YYYY/MM/DD HH:MI:SS') as DD FROM DUAL
-    UNION ALL SELECT TO_DATE('2015/05/15 8:30:25', 'YYYY/MM/DD HH:MI:SS') as DD FROM DUAL;
-  :1 := v_cur;
-END;`
-
-	for i := 0; i < maxSessions/2; i++ {
-		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			t.Parallel()
-			ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-			defer cancel()
-
-			stmt, err := testDb.PrepareContext(ctx, qry)
-			if err != nil {
-				t.Fatalf("%s: %+v", qry, err)
-			}
-			defer stmt.Close()
-
-			var rows1 driver.Rows
-			if _, err := stmt.ExecContext(ctx, sql.Out{Dest: &rows1}); err != nil {
-				t.Fatalf("%s: %+v", qry, err)
-
-			}
-			defer rows1.Close()
-			cols1 := rows1.Columns()
-			values := make([]driver.Value, len(cols1))
 
-			var rowNum int
-			for {
-				rowNum++
-				if err := rows1.Next(values); err != nil {
-					if err == io.EOF {
-						break
-					}
-				}
-				t.Logf("%[1]d. %[2]T %[2]v", rowNum, values[0])
-			}
-		})
+	const qry2 = "SELECT HEXTORAW('33221100554477668899AABBCCDDEEFF') FROM DUAL"
+	back = godror.UUID{}
+	if err = testDb.QueryRowContext(ctx, qry2).Scan(godror.UUIDIn(&back, godror.UUIDMixedEndian)); err != nil {
+		t.Fatalf("%s: %+v", qry2, err)
+	}
+	if back != u {
+		t.Errorf("%s: got %s, wanted %s", qry2, back, u)
 	}
 }
 
-func TestTsRset(t *testing.T) {
-	defer tl.enableLogging(t)()
-	ctx := testContext("TsRset")
-
-	const qry = `DECLARE
-  v_cur SYS_REFCURSOR;
-BEGIN
-  OPEN v_cur FOR
-	SELECT TO_TIMESTAMP_TZ('2019-05-01 09:39:12 01:00', 'YYYY-MM-DD HH24:MI:SS TZH:TZM') FROM DUAL
-	UNION ALL SELECT FROM_TZ(TO_TIMESTAMP('2019-05-01 09:39:12', 'YYYY-MM-DD HH24:MI:SS'), '01:00') FROM DUAL;
-  :1 := v_cur;
-END;`
-
-	for i := 0; i < maxSessions/2; i++ {
-		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			t.Parallel()
-			ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-			defer cancel()
+func TestBindDurationAs(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("BindDurationAs"), 10*time.Second)
+	defer cancel()
+	const qry = "SELECT TO_CHAR(:1), TO_CHAR(DATE '2020-01-01' + :2, 'YYYY-MM-DD HH24:MI:SS') FROM DUAL"
+	var secs, date string
+	d := 36 * time.Hour
+	if err := testDb.QueryRowContext(ctx, qry, d, d, godror.BindDurationAs(godror.DurationAsDays)).Scan(&secs, &date); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	if secs != "1.5" || date != "2020-01-02 12:00:00" {
+		t.Errorf("days: got %q, %q", secs, date)
+	}
+	if err := testDb.QueryRowContext(ctx, qry, d, d, godror.BindDurationAs(godror.DurationAsSeconds)).Scan(&secs, &date); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	if secs != "129600" {
+		t.Errorf("seconds: got %q", secs)
+	}
 
-			var rows1 driver.Rows
-			if _, err := testDb.ExecContext(ctx, qry, sql.Out{Dest: &rows1}); err != nil {
-				t.Fatalf("%s: %+v", qry, err)
+	var out time.Duration
+	const plsql = "BEGIN :1 := :2 * 2; END;"
+	if _, err := testDb.ExecContext(ctx, plsql, sql.Out{Dest: &out}, 90*time.Minute, godror.BindDurationAs(godror.DurationAsDays)); err != nil {
+		t.Fatalf("%s: %+v", plsql, err)
+	}
+	if want := 3 * time.Hour; out != want {
+		t.Errorf("out: got %s, wanted %s", out, want)
+	}
 
-			}
-			defer rows1.Close()
-			cols1 := rows1.Columns()
-			values := make([]driver.Value, len(cols1))
+	if _, err := testDb.ExecContext(ctx, plsql, sql.Out{Dest: &out}, d, godror.BindDurationAs(godror.DurationAsError)); !errors.Is(err, godror.ErrAmbiguousDuration) {
+		t.Errorf("got %+v, wanted %v", err, godror.ErrAmbiguousDuration)
+	}
+}
 
-			var rowNum int
-			for {
-				rowNum++
-				if err := rows1.Next(values); err != nil {
-					if err == io.EOF {
-						break
-					}
-				}
-				t.Logf("%[1]d. %[2]T %[2]v", rowNum, values[0])
-			}
-		})
+func TestNumberAsDecimal128(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("NumberAsDecimal128"), 10*time.Second)
+	defer cancel()
+	const qry = "SELECT 123.45, -0.5, 0, 1e125, CAST(1/3 AS NUMBER), NULL FROM DUAL"
+	want := []string{"123.45", "-0.5", "0", "1" + strings.Repeat("0", 125), "0." + strings.Repeat("3", 34), "0"}
+	got := make([]godror.Decimal128, len(want))
+	dest := make([]interface{}, len(got))
+	for i := range got {
+		dest[i] = &got[i]
+	}
+	if err := testDb.QueryRowContext(ctx, qry, godror.NumberAsDecimal128()).Scan(dest...); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	for i, d := range got {
+		if s := d.String(); s != want[i] {
+			t.Errorf("%d. got %q, wanted %q", i, s, want[i])
+		}
 	}
 }
 
-func TestTsTZ(t *testing.T) {
+func TestNumberAsInt64(t *testing.T) {
 	t.Parallel()
-	fields := []string{
-		"FROM_TZ(TO_TIMESTAMP('2019-05-01 09:39:12', 'YYYY-MM-DD HH24:MI:SS'), '{{.TZ}}')",
-		"TO_TIMESTAMP_TZ('2019-05-01 09:39:12 {{.TZ}}', 'YYYY-MM-DD HH24:MI:SS {{.TZDec}}')",
-		"CAST(TO_TIMESTAMP_TZ('2019-05-01 09:39:12 {{.TZ}}', 'YYYY-MM-DD HH24:MI:SS {{.TZDec}}') AS DATE)",
+	ctx, cancel := context.WithTimeout(testContext("NumberAsInt64"), 10*time.Second)
+	defer cancel()
+	const qry = "SELECT 12345678901, -5, 1.5, 99999999999999999999, CAST(7 AS NUMBER(19)) FROM DUAL"
+	rows, err := testDb.QueryContext(ctx, qry, godror.NumberAsInt64())
+	if err != nil {
+		t.Fatalf("%s: %+v", qry, err)
 	}
-	ctx, cancel := context.WithTimeout(testContext("TsTZ"), 10*time.Second)
+	defer rows.Close()
+	if !rows.Next() {
+		t.Fatal(rows.Err())
+	}
+	got := make([]interface{}, 5)
+	dest := make([]interface{}, len(got))
+	for i := range got {
+		dest[i] = &got[i]
+	}
+	if err = rows.Scan(dest...); err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{int64(12345678901), int64(-5), godror.Number("1.5"), godror.Number("99999999999999999999"), int64(7)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, wanted %#v", got, want)
+	}
+}
+
+func TestNumberByPrecision(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("NumberByPrecision"), 10*time.Second)
 	defer cancel()
+	const qry = "SELECT CAST(12 AS NUMBER(10)), CAST(1.5 AS NUMBER(5,1)), 42, CAST(7 AS NUMBER(19)) FROM DUAL"
+	rows, err := testDb.QueryContext(ctx, qry, godror.NumberByPrecision(), godror.NumberAsString())
+	if err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	defer rows.Close()
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantTypes := []reflect.Type{reflect.TypeOf(int64(0)), reflect.TypeOf(godror.Number("")), reflect.TypeOf(godror.Number("")), reflect.TypeOf(godror.Number(""))}
+	for i, ct := range types {
+		if got := ct.ScanType(); got != wantTypes[i] {
+			t.Errorf("%d. got scan type %v, wanted %v", i, got, wantTypes[i])
+		}
+	}
+	if !rows.Next() {
+		t.Fatal(rows.Err())
+	}
+	got := make([]interface{}, 4)
+	dest := make([]interface{}, len(got))
+	for i := range got {
+		dest[i] = &got[i]
+	}
+	if err = rows.Scan(dest...); err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{int64(12), godror.Number("1.5"), godror.Number("42"), godror.Number("7")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, wanted %#v", got, want)
+	}
+}
 
-	defer tl.enableLogging(t)()
+func TestNonFinite(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("NonFinite"), 10*time.Second)
+	defer cancel()
+	const qry = "SELECT BINARY_DOUBLE_NAN, -BINARY_DOUBLE_INFINITY, BINARY_FLOAT_INFINITY, TO_BINARY_DOUBLE(1.5) FROM DUAL"
+	var f1, f2, f3, f4 sql.NullFloat64
+	if err := testDb.QueryRowContext(ctx, qry, godror.NonFiniteFetch(godror.NonFiniteNull)).Scan(&f1, &f2, &f3, &f4); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	if f1.Valid || f2.Valid || f3.Valid || !f4.Valid || f4.Float64 != 1.5 {
+		t.Errorf("got %v, %v, %v, %v", f1, f2, f3, f4)
+	}
+	if err := testDb.QueryRowContext(ctx, qry, godror.NonFiniteFetch(godror.NonFiniteError)).Scan(&f1, &f2, &f3, &f4); !errors.Is(err, godror.ErrNonFinite) {
+		t.Errorf("got %+v, wanted %v", err, godror.ErrNonFinite)
+	}
 
-	for _, Case := range []struct {
-		TZ, TZDec string
-	}{
-		{"00:00", "TZH:TZM"},
-		{"01:00", "TZH:TZM"},
-		{"-01:00", "TZH:TZM"},
-		{"Europe/Berlin", "TZR"},
-	} {
-		repl := strings.NewReplacer("{{.TZ}}", Case.TZ, "{{.TZDec}}", Case.TZDec)
-		for i, f := range fields {
-			f = repl.Replace(f)
-			qry := "SELECT DUMP(" + f + ") FROM DUAL"
-			var s string
-			if err := testDb.QueryRowContext(ctx, qry).Scan(&s); err != nil {
-				if Case.TZDec != "TZR" {
-					t.Fatalf("%s: %s: %+v", Case.TZ, qry, err)
-				}
-				t.Logf("%s: %s: %+v", Case.TZ, qry, err)
-			}
-			t.Logf("%s: DUMP[%d]: %q", Case.TZ, i, s)
+	const qry2 = "SELECT NVL2(:1, 'x', 'null') FROM DUAL"
+	var s string
+	if err := testDb.QueryRowContext(ctx, qry2, math.Inf(1), godror.NonFiniteBind(godror.NonFiniteNull)).Scan(&s); err != nil {
+		t.Fatalf("%s: %+v", qry2, err)
+	} else if s != "null" {
+		t.Errorf("bind: got %q, wanted null", s)
+	}
+	if err := testDb.QueryRowContext(ctx, qry2, math.NaN(), godror.NonFiniteBind(godror.NonFiniteError)).Scan(&s); !errors.Is(err, godror.ErrNonFinite) {
+		t.Errorf("bind: got %+v, wanted %v", err, godror.ErrNonFinite)
+	}
+}
 
-			qry = "SELECT " + f + " FROM DUAL"
-			var ts time.Time
-			if err := testDb.QueryRowContext(ctx, qry).Scan(&ts); err != nil {
-				var oerr *godror.OraErr
-				if errors.As(err, &oerr) && oerr.Code() == 1805 {
-					t.Skipf("%s: %s: %+v", Case.TZ, qry, err)
-					continue
-				}
-				t.Fatalf("%s: %s: %+v", Case.TZ, qry, err)
-			}
-			t.Logf("%s: %d: %s", Case.TZ, i, ts)
-		}
+func TestTimestampTZRegion(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("TimestampTZRegion"), 10*time.Second)
+	defer cancel()
+	const qry = "SELECT TO_TIMESTAMP_TZ(:1, 'YYYY-MM-DD HH24:MI:SS.FF9 TZR') FROM DUAL"
+	in := godror.TimestampTZ{
+		Time:   time.Date(2025, 3, 30, 12, 0, 0, 0, time.FixedZone("+02:00", 2*3600)),
+		Region: "Europe/Vienna",
+	}
+	var out godror.TimestampTZ
+	if err := testDb.QueryRowContext(ctx, qry, in, godror.FetchTimestampTZ()).Scan(&out); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	if out.Region != in.Region || !out.Time.Equal(in.Time) {
+		t.Errorf("got %v, wanted %v", out, in)
+	}
+	// without the option, only the offset remains
+	var tm time.Time
+	if err := testDb.QueryRowContext(ctx, qry, in).Scan(&tm); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	} else if _, off := tm.Zone(); off != 2*3600 || !tm.Equal(in.Time) {
+		t.Errorf("got %v, wanted %v", tm, in.Time)
 	}
+}
 
-	qry := "SELECT filename, version FROM v$timezone_file"
-	rows, err := testDb.QueryContext(ctx, qry)
+func TestTimestampPrecision(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("TimestampPrecision"), 30*time.Second)
+	defer cancel()
+	tbl := "test_ts_prec" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	qry := "CREATE TABLE " + tbl + " (id NUMBER(3), ts9 TIMESTAMP(9), ts3 TIMESTAMP(3), tstz9 TIMESTAMP(9) WITH TIME ZONE, ts0 TIMESTAMP(0))"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+	defer func() { testDb.ExecContext(testContext("TimestampPrecision-drop"), "DROP TABLE "+tbl) }()
+
+	base := time.Date(2025, 6, 7, 8, 9, 10, 0, time.UTC)
+	nss := []int{1, 123456789, 999999999, 999500000, 500000000}
+	ids := make([]int, len(nss))
+	times := make([]time.Time, len(nss))
+	for i, ns := range nss {
+		ids[i], times[i] = i, base.Add(time.Duration(ns))
+	}
+	qry = "INSERT INTO " + tbl + " (id, ts9, ts3, tstz9, ts0) VALUES (:1, :2, :3, :4, :5)"
+	if _, err := testDb.ExecContext(ctx, qry, ids, times, times, times, times); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	}
+
+	qry = "SELECT id, ts9, ts3, tstz9, ts0 FROM " + tbl + " ORDER BY id"
+	var infos []godror.ColumnInfo
+	rows, err := testDb.QueryContext(ctx, qry, godror.FetchColumnInfo(&infos))
 	if err != nil {
-		t.Log(qry, err)
-		return
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
 	defer rows.Close()
+	for i, want := range []int{0, 9, 3, 9, 0} {
+		if got := infos[i].FsPrecision; got != want {
+			t.Errorf("%s: got fsPrecision %d, wanted %d", infos[i].Name, got, want)
+		}
+	}
 	for rows.Next() {
-		var fn, ver string
-		if err := rows.Scan(&fn, &ver); err != nil {
-			t.Log(qry, err)
-			continue
+		var id int
+		var ts9, ts3, tstz9, ts0 time.Time
+		if err = rows.Scan(&id, &ts9, &ts3, &tstz9, &ts0); err != nil {
+			t.Fatal(err)
+		}
+		want := times[id]
+		if !ts9.Equal(want) || !tstz9.Equal(want) {
+			t.Errorf("%d. got %v and %v, wanted %v", id, ts9, tstz9, want)
+		}
+		// Oracle rounds to the declared precision
+		if w := want.Round(time.Millisecond); !ts3.Equal(w) {
+			t.Errorf("%d. TIMESTAMP(3): got %v, wanted %v", id, ts3, w)
+		}
+		if w := want.Round(time.Second); !ts0.Equal(w) {
+			t.Errorf("%d. TIMESTAMP(0): got %v, wanted %v", id, ts0, w)
 		}
-		t.Log(fn, ver)
 	}
-	t.Skip("wanted non-zero time")
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	const plsql = `DECLARE
+  TYPE ts_tab_typ IS TABLE OF TIMESTAMP(9) INDEX BY PLS_INTEGER;
+  v_tab ts_tab_typ := :1;
+BEGIN
+  :2 := TO_CHAR(v_tab(v_tab.LAST), 'FF9');
+END;`
+	var ff string
+	if _, err := testDb.ExecContext(ctx, plsql, godror.PlSQLTimestampArrays, times, sql.Out{Dest: &ff}); err != nil {
+		t.Fatal(fmt.Errorf("%s: %w", plsql, err))
+	}
+	if want := fmt.Sprintf("%09d", nss[len(nss)-1]); ff != want {
+		t.Errorf("PL/SQL array: got %q, wanted %q", ff, want)
+	}
 }
 
-func TestGetDBTimezone(t *testing.T) {
+func TestCharsetConversion(t *testing.T) {
 	t.Parallel()
-	defer tl.enableLogging(t)()
-
-	ctx, cancel := context.WithTimeout(testContext("GetDBTimeZone"), 10*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("CharsetConversion"), 10*time.Second)
 	defer cancel()
-	tx, err := testDb.BeginTx(ctx, nil)
+	ei, err := godror.ClientEncoding(ctx, testDb)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer tx.Rollback()
-	qry := `SELECT TO_CHAR(SYSDATE, 'YYYY-MM-DD"T"HH24:MI:SS'), TO_CHAR(CURRENT_DATE, 'YYYY-MM-DD"T"hH24:MI:SS'), DBTIMEZONE, SESSIONTIMEZONE, TO_CHAR(SYSTIMESTAMP, 'TZR') AS dbOSTZ, SYSTIMESTAMP FROM DUAL`
-	var sysdate, currentDate, dbTz, tzr, sts, dbOSTZ, ts string
-	if err := tx.QueryRowContext(ctx, qry).Scan(&sysdate, &currentDate, &dbTz, &tzr, &dbOSTZ, &ts); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	t.Logf("encoding: %+v", ei)
+	if ei.Encoding != "UTF-8" {
+		t.Skipf("client encoding is %q", ei.Encoding)
 	}
-	t.Logf("sysdate=%q currentDate=%q dbTZ=%q TZR=%q dbOSTZ=%q ts=%q", sysdate, currentDate, dbTz, tzr, dbOSTZ, ts)
 
-	qry = "ALTER SESSION SET time_zone = 'UTC'"
-	if _, err := tx.ExecContext(ctx, qry); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	const qry = "SELECT 'árvíz', N'tűrő', UNISTR('a\\FFFDb'), 1 FROM DUAL"
+	var infos []godror.ColumnInfo
+	var s1, s2, s3 string
+	var n int
+	if err = testDb.QueryRowContext(ctx, qry, godror.FetchColumnInfo(&infos)).Scan(&s1, &s2, &s3, &n); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
 	}
-	qry = `SELECT DBTIMEZONE, SESSIONTIMEZONE, TO_CHAR(SYSTIMESTAMP, '"TZR="TZR "TZD="TZD "TZH:TZM="TZH:TZM'), LOCALTIMESTAMP||'' FROM DUAL`
-	var tz, lts string
-	if err := tx.QueryRowContext(ctx, qry).Scan(&dbTz, &tz, &sts, &lts); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	for i, want := range []godror.CharsetForm{godror.CharsetFormImplicit, godror.CharsetFormNChar, godror.CharsetFormNChar, 0} {
+		if got := infos[i].CharsetForm; got != want {
+			t.Errorf("%d. got charset form %d, wanted %d", i, got, want)
+		}
 	}
-	t.Log("db timezone:", dbTz, "session timezone:", tz, "systimestamp:", sts, "localtimestamp:", lts)
+	if err = testDb.QueryRowContext(ctx, qry, godror.StrictCharsetConversion()).Scan(&s1, &s2, &s3, &n); !errors.Is(err, godror.ErrCharsetConversion) {
+		t.Errorf("strict: got %+v, wanted %v", err, godror.ErrCharsetConversion)
+	}
+	const qry2 = "SELECT 'árvíz', N'tűrő' FROM DUAL"
+	if err = testDb.QueryRowContext(ctx, qry2, godror.StrictCharsetConversion()).Scan(&s1, &s2); err != nil {
+		t.Errorf("%s: %+v", qry2, err)
+	}
+}
 
-	today := time.Now().Truncate(time.Second)
-	for i, tim := range []time.Time{today, today.AddDate(0, 6, 0)} {
-		t.Log("local:", tim.Format(time.RFC3339))
+func TestCharPadding(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("CharPadding"), 10*time.Second)
+	defer cancel()
+	const qry = "SELECT CAST('abc' AS CHAR(5)), CAST(N'xy' AS NCHAR(4)), CAST('abc ' AS VARCHAR2(5)) FROM DUAL"
+	var c, nc, vc string
+	if err := testDb.QueryRowContext(ctx, qry).Scan(&c, &nc, &vc); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	} else if c != "abc  " || nc != "xy  " || vc != "abc " {
+		t.Errorf("padded: got %q, %q, %q", c, nc, vc)
+	}
+	if err := testDb.QueryRowContext(ctx, qry, godror.TrimChar()).Scan(&c, &nc, &vc); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	} else if c != "abc" || nc != "xy" || vc != "abc " {
+		t.Errorf("trimmed: got %q, %q, %q", c, nc, vc)
+	}
 
-		qry = "SELECT :1 FROM DUAL"
-		var dbTime time.Time
-		if err := tx.QueryRowContext(ctx, qry, tim).Scan(&dbTime); err != nil {
-			t.Fatal(fmt.Errorf("%s: %w", qry, err))
-		}
-		t.Log("db:", dbTime.Format(time.RFC3339))
-		if !dbTime.Equal(tim) {
-			msg := fmt.Sprintf("db says %s, local is %s", dbTime.Format(time.RFC3339), tim.Format(time.RFC3339))
-			if i == 0 {
-				t.Error("ERROR:", msg)
-			} else {
-				t.Log(msg)
-			}
+	const qry2 = "SELECT COUNT(0) FROM (SELECT CAST('abc' AS CHAR(5)) c FROM DUAL) WHERE c = :1"
+	for _, tc := range []struct {
+		Opt  godror.Option
+		Want int
+	}{{Opt: nil, Want: 0}, {Opt: godror.BindStringAsChar(), Want: 1}} {
+		var n int
+		if err := testDb.QueryRowContext(ctx, qry2, "abc", tc.Opt).Scan(&n); err != nil {
+			t.Fatalf("%s: %+v", qry2, err)
+		} else if n != tc.Want {
+			t.Errorf("%v: got %d, wanted %d", tc.Opt != nil, n, tc.Want)
 		}
 	}
 }
 
-func TestConnParamsTimezone(t *testing.T) {
+func TestDateSentinel(t *testing.T) {
 	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("ConnParamsTZ"), 30*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("DateSentinel"), 10*time.Second)
 	defer cancel()
+	sentinel := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	const qry = "SELECT TO_DATE('0001-01-01','YYYY-MM-DD'), TO_DATE('2025-03-04','YYYY-MM-DD'), CAST(NULL AS DATE) FROM DUAL"
+	var nt0, nt1, nt2 sql.NullTime
+	if err := testDb.QueryRowContext(ctx, qry, godror.DateSentinelFetch(sentinel)).Scan(&nt0, &nt1, &nt2); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	} else if nt0.Valid || !nt1.Valid || nt2.Valid {
+		t.Errorf("got %v, %v, %v", nt0, nt1, nt2)
+	}
+	var t0, t1, t2 time.Time
+	if err := testDb.QueryRowContext(ctx, qry, godror.DateSentinelFetch(sentinel), godror.NullDateAsZeroTime()).Scan(&t0, &t1, &t2); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	} else if !t0.IsZero() || t1.IsZero() || !t2.IsZero() {
+		t.Errorf("NullDateAsZeroTime: got %v, %v, %v", t0, t1, t2)
+	}
 
-	getServerTZ := func(db *sql.DB) *time.Location {
-		var serverTZ *time.Location
-		if err := godror.Raw(ctx, db, func(cx godror.Conn) error {
-			serverTZ = cx.Timezone()
-			return nil
-		}); err != nil {
-			t.Fatal(err)
-		}
-		return serverTZ
+	const qry2 = "SELECT TO_CHAR(:1, 'YYYY-MM-DD'), TO_CHAR(:2, 'YYYY-MM-DD') FROM DUAL"
+	var s1, s2 sql.NullString
+	if err := testDb.QueryRowContext(ctx, qry2, time.Time{}, sql.NullTime{}, godror.DateSentinelBind(sentinel)).Scan(&s1, &s2); err != nil {
+		t.Fatalf("%s: %+v", qry2, err)
+	} else if s1.String != "0001-01-01" || s2.String != "0001-01-01" {
+		t.Errorf("bind: got %v, %v", s1, s2)
 	}
+}
 
-	orig := getServerTZ(testDb)
-	_, off := time.Now().In(orig).Zone()
-	diff := 7200
-	if off > 12*3600-diff {
-		diff = -diff
+func TestAllowedValues(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("AllowedValues"), 30*time.Second)
+	defer cancel()
+	tbl := "test_allowed_values" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	qry := "CREATE TABLE " + tbl + " (status VARCHAR2(1) NOT NULL CHECK (status IN ('A','I','D')) CHECK (status IN ('A','I')), txt VARCHAR2(10))"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
 	}
-	off += diff
+	defer func() { testDb.ExecContext(testContext("AllowedValues-drop"), "DROP TABLE "+tbl) }()
 
-	P, err := godror.ParseDSN(testConStr)
+	values, err := godror.AllowedValues(ctx, testDb, tbl, "status")
 	if err != nil {
 		t.Fatal(err)
 	}
-	want := time.FixedZone(fmt.Sprintf("%d=%s%+d", off, orig, diff), off)
-	P.Timezone = want
-	t.Logf("Set Timezone from %s to %s", orig, want)
-	db := sql.OpenDB(godror.NewConnector(P))
-	got := getServerTZ(db)
-	if got != want {
-		t.Errorf("got %s, wanted %s", got, want)
+	if len(values) != 2 || values[0] != "A" || values[1] != "I" {
+		t.Errorf("got %q, wanted [A I]", values)
+	}
+	type status int
+	const (
+		active status = iota + 1
+		inactive
+	)
+	if _, err = godror.NewEnumMap(values, map[string]status{"A": active, "I": inactive}); err != nil {
+		t.Error(err)
+	}
+	if values, err = godror.AllowedValues(ctx, testDb, tbl, "txt"); err != nil {
+		t.Fatal(err)
+	} else if values != nil {
+		t.Errorf("txt: got %q, wanted nil", values)
 	}
 }
 
-func TestNumberAsStringBool(t *testing.T) {
+func TestCharSemantics(t *testing.T) {
 	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("NumberBool"), 3*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("CharSemantics"), 30*time.Second)
 	defer cancel()
-	const qry = "SELECT 181 id, 1 status FROM DUAL"
-	rows, err := testDb.QueryContext(ctx, qry, godror.NumberAsString())
-	if err != nil {
+	tbl := "test_char_semantics" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	qry := "CREATE TABLE " + tbl + " (b VARCHAR2(10 BYTE), c VARCHAR2(10 CHAR), fc CHAR(3 CHAR), n NVARCHAR2(10), num NUMBER(3))"
+	if _, err := testDb.ExecContext(ctx, qry); err != nil {
 		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
+	defer func() { testDb.ExecContext(testContext("CharSemantics-drop"), "DROP TABLE "+tbl) }()
 
-	for rows.Next() {
-		var id int
-		var status bool
-		if err := rows.Scan(&id, &status); err != nil {
-			t.Errorf("failed to scan data: %s\n", err)
-		}
-		t.Logf("Source id=%d, status=%t\n", id, status)
-		if !status {
-			t.Errorf("wanted true, got %t", status)
-		}
-	}
-}
-
-func TestDST(t *testing.T) {
-	ctx, cancel := context.WithTimeout(testContext("DST"), 10*time.Minute)
-	defer cancel()
-
-	defer tl.enableLogging(t)()
-	t.Parallel()
-
-	start := time.Date(1950, 1, 1, 0, 0, 0, 0, time.Local)
-	now := time.Now()
-	maxNum := (365*4 + 1) * (now.Year() - start.Year() + 1) / 4
-	qry := `SELECT dt, TO_CHAR(dt, 'YYYY-MM-DD') as tx FROM (
-		SELECT TO_DATE('` + start.Format("2006-01-02") + `', 'YYYY-MM-DD')+rn AS dt 
-			FROM (SELECT ROWNUM AS rn FROM DUAL CONNECT BY LEVEL <= ` + strconv.Itoa(maxNum) + "))"
-	rows, err := testDb.QueryContext(ctx, qry)
+	qry = "SELECT b, c, fc, n, num FROM " + tbl
+	var infos []godror.ColumnInfo
+	rows, err := testDb.QueryContext(ctx, qry, godror.FetchColumnInfo(&infos))
 	if err != nil {
-		t.Fatalf("%s: %+v", qry, err)
+		t.Fatal(fmt.Errorf("%s: %w", qry, err))
 	}
-	var stmt *sql.Stmt
-	{
-		const qry = `SELECT TO_CHAR(:1, 'YYYY-MM-DD HH24:MI:SS') FROM DUAL`
-		if stmt, err = testDb.PrepareContext(ctx, qry); err != nil {
-			t.Fatal(err)
-		}
-		defer stmt.Close()
+	rows.Close()
+	desc, err := godror.Describe(ctx, testDb, qry)
+	if err != nil {
+		t.Fatal(err)
 	}
-
-	var want string
-	first := true
-	for rows.Next() {
-		var tim time.Time
-		if err := rows.Scan(&tim, &want); err != nil {
-			t.Fatalf("scan %s: %+v", qry, err)
-		}
-		if tim.Location() == time.UTC {
-			t.Skip("db is UTC")
+	for i, want := range []struct {
+		Chars bool
+		Size  int
+	}{{false, 10}, {true, 10}, {true, 3}, {true, 10}, {false, 0}} {
+		ci, dc := infos[i], desc.Columns[i]
+		t.Logf("%s: %+v", ci.Name, ci)
+		if ci.CharSemantics != want.Chars || dc.CharSemantics != want.Chars {
+			t.Errorf("%s: got CharSemantics %t/%t, wanted %t", ci.Name, ci.CharSemantics, dc.CharSemantics, want.Chars)
 		}
-		// t.Log("tim:", tim, "want:", want)
-		if got := tim.Format("2006-01-02 15:04:05"); !strings.HasSuffix(got, " 00:00:00") {
-			t.Logf("got %s, wanted %s", tim.Format(time.RFC3339), want)
-			var s string
-			if err := stmt.QueryRowContext(ctx, tim).Scan(&s); err != nil {
-				t.Fatalf("scan [%v]: %+v", tim, err)
-			}
-			if s != want+" 00:00:00" {
-				t.Errorf("wanted %q, got %q", want, s)
-			}
+		if ci.SizeInChars != want.Size || dc.SizeInChars != want.Size {
+			t.Errorf("%s: got SizeInChars %d/%d, wanted %d", ci.Name, ci.SizeInChars, dc.SizeInChars, want.Size)
 		}
-		if first {
-			t.Log("first:", want)
-			first = false
+		if want.Size != 0 && (ci.DBSize < want.Size || dc.DBSize != ci.DBSize) {
+			t.Errorf("%s: got DBSize %d/%d", ci.Name, ci.DBSize, dc.DBSize)
 		}
 	}
-	t.Log("last:", want)
 }
 
-func TestNumberBool(t *testing.T) {
-	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("NumberBool"), 3*time.Second)
-	defer cancel()
-	const qry = "SELECT 181 id, '1' status FROM DUAL"
-	rows, err := testDb.QueryContext(ctx, qry)
-	if err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
-	}
+type testSpan struct {
+	err   error
+	name  string
+	attrs []godror.TraceAttr
+}
 
-	for rows.Next() {
-		var id int
-		var status bool
-		if err := rows.Scan(&id, &status); err != nil {
-			t.Errorf("failed to scan data: %s\n", err)
-		}
-		t.Logf("Source id=%d, status=%t\n", id, status)
-		if !status {
-			t.Errorf("wanted true, got %t", status)
+func (s *testSpan) SetAttributes(attrs ...godror.TraceAttr) { s.attrs = append(s.attrs, attrs...) }
+func (s *testSpan) End(err error)                           { s.err = err }
+func (s *testSpan) TraceID() string                         { return "4bf92f3577b34da6a3ce929d0e0e4736" }
+func (s *testSpan) attr(key string) interface{} {
+	for _, a := range s.attrs {
+		if a.Key == key {
+			return a.Value
 		}
 	}
+	return nil
 }
 
-func TestCancel(t *testing.T) {
-	ctx, cancel := context.WithTimeout(testContext("Cancel"), 15*time.Second)
+type testTracer struct {
+	spans []*testSpan
+	mu    sync.Mutex
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attrs ...godror.TraceAttr) (context.Context, godror.Span) {
+	s := &testSpan{name: name, attrs: attrs}
+	t.mu.Lock()
+	t.spans = append(t.spans, s)
+	t.mu.Unlock()
+	return ctx, s
+}
+
+func TestTracing(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("Tracing"), 30*time.Second)
 	defer cancel()
-	subCtx, subCancel := context.WithCancel(ctx)
-	done := make(chan error, 1)
-	go func() {
-		defer close(done)
-		const qry = `BEGIN DBMS_SESSION.sleep(10); END;`
-		if _, err := testDb.ExecContext(subCtx, qry); err != nil {
-			done <- err
-		}
-	}()
-	time.Sleep(time.Second)
-	subCancel()
-	time.Sleep(time.Second)
-	select {
-	case err := <-done:
-		if !errors.Is(err, context.Canceled) {
-			t.Error(err)
-		}
-	default:
-		t.Error("hasn't finished yet")
+	P, err := godror.ParseDSN(testConStr)
+	if err != nil {
+		t.Fatal(err)
 	}
-}
+	var tr testTracer
+	db := sql.OpenDB(godror.WithTracer(godror.NewConnector(P), &tr, godror.TraceOptions{}))
+	defer db.Close()
 
-func TestTimeout(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skip cancel test")
+	const qry = "SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= 10"
+	rows, err := db.QueryContext(ctx, qry, godror.FetchArraySize(4), godror.PrefetchCount(0))
+	if err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	var n int
+	for rows.Next() {
+		n++
+	}
+	if err = rows.Close(); err != nil {
+		t.Fatal(err)
 	}
-	db, err := sql.Open("godror", testConStr)
-	if err != nil {
+	var ecid sql.NullString
+	if err = db.QueryRowContext(ctx, "SELECT SYS_CONTEXT('USERENV', 'ECID') FROM DUAL").Scan(&ecid); err != nil {
 		t.Fatal(err)
 	}
-	defer db.Close()
-	pid := os.Getpid()
-	maxConc := maxSessions / 2
-	db.SetMaxOpenConns(maxConc - 1)
-	db.SetMaxIdleConns(1)
-	ctx, cancel := context.WithCancel(testContext("Timeout"))
+	t.Logf("ECID: %q", ecid.String)
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	counts := make(map[string]int)
+	var fetched int
+	for _, s := range tr.spans {
+		t.Logf("%s: %v %+v", s.name, s.err, s.attrs)
+		counts[s.name]++
+		switch s.name {
+		case "query":
+			if s.attr("db.query.text") == qry && s.attr("oracle.sql_id") == nil {
+				t.Errorf("query: no sql_id in %+v", s.attrs)
+			}
+		case "fetch":
+			if v, ok := s.attr("db.response.returned_rows").(int); ok {
+				fetched += v
+			}
+		}
+	}
+	if counts["connect"] == 0 || counts["query"] < 2 || counts["fetch"] < 3 {
+		t.Errorf("got %v spans", counts)
+	}
+	if fetched < n {
+		t.Errorf("fetch spans returned %d rows, wanted %d", fetched, n)
+	}
+}
+
+func TestMetricsCollectorDB(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("MetricsCollector"), 30*time.Second)
 	defer cancel()
-	const qryCount = "SELECT COUNT(0) FROM v$session WHERE username = USER AND process = TO_CHAR(:1)"
-	cntStmt, err := testDb.PrepareContext(ctx, qryCount)
+	mc := godror.NewMetricsCollector(testDb)
+	const qry = "SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= 10"
+	rows, err := testDb.QueryContext(ctx, qry)
 	if err != nil {
-		t.Fatalf("%q: %v", qryCount, err)
+		t.Fatalf("%s: %+v", qry, err)
 	}
-	// Just a test, as Prepared statements does not work
-	cntStmt.Close()
-	Cnt := func() int {
-		var cnt int
-		if err := db.QueryRowContext(ctx, qryCount, pid).Scan(&cnt); err != nil {
-			if !strings.Contains(err.Error(), "ORA-00942:") {
-				t.Fatal(fmt.Errorf("%s: %w", qryCount, err))
-			}
+	for rows.Next() {
+	}
+	rows.Close()
+	if _, err = testDb.ExecContext(ctx, "SELECT * FROM no_such_table_in_metrics"); err == nil {
+		t.Error("wanted error")
+	}
+
+	w := httptest.NewRecorder()
+	mc.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	out := w.Body.String()
+	t.Log(out)
+	for _, want := range []string{
+		`stmt_hash="` + godror.StatementHash(qry) + `"`,
+		`godror_errors_total{code="ORA-00942"}`,
+		`godror_db_connections{state="open"}`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q", want)
 		}
-		return cnt
 	}
+	if strings.Contains(out, "godror_rows_fetched_total 0\n") {
+		t.Error("no rows fetched")
+	}
+}
 
-	t.Log("Pid:", pid)
-	goal := Cnt() + 1
-	t.Logf("Before: %d", goal)
-	const qry = "BEGIN FOR rows IN (SELECT 1 FROM DUAL) LOOP DBMS_SESSION.SLEEP(10); END LOOP; END;"
-	subCtx, subCancel := context.WithTimeout(ctx, time.Duration(2*maxConc+1)*time.Second)
-	grp, grpCtx := errgroup.WithContext(subCtx)
-	for i := 0; i < maxConc; i++ {
-		grp.Go(func() error {
-			if _, err := db.ExecContext(grpCtx, qry); err != nil && !errors.Is(err, context.Canceled) {
-				return fmt.Errorf("%s: %w", qry, err)
-			}
-			return nil
-		})
+func TestSlowQueryHook(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("SlowQueryHook"), 30*time.Second)
+	defer cancel()
+	const qry = "SELECT LEVEL, :1 FROM DUAL CONNECT BY LEVEL <= 100 /* slow query hook */"
+	var mu sync.Mutex
+	var reports []godror.SlowQuery
+	godror.SetSlowQueryHook(0, func(_ context.Context, sq godror.SlowQuery) {
+		if sq.Query == qry {
+			mu.Lock()
+			reports = append(reports, sq)
+			mu.Unlock()
+		}
+	}, godror.RedactPolicy{All: true})
+	defer godror.SetSlowQueryHook(0, nil, godror.RedactPolicy{})
+
+	rows, err := testDb.QueryContext(ctx, qry, "secret", godror.FetchArraySize(10))
+	if err != nil {
+		t.Fatalf("%s: %+v", qry, err)
 	}
-	time.Sleep(time.Second)
-	t.Logf("After exec, before cancel: %d", Cnt())
-	subCancel()
-	t.Logf("After cancel: %d", Cnt())
-	if err = grp.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+	for rows.Next() {
+	}
+	if err = rows.Close(); err != nil {
 		t.Fatal(err)
 	}
-	t.Logf("After finish: %d; goal: %d", Cnt(), goal)
-
-	for i := 0; i < 2*maxConc; i++ {
-		cnt := Cnt()
-		t.Logf("After %ds: %d", i+1, cnt)
-		if cnt <= goal {
-			return
-		}
-		time.Sleep(time.Second)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reports) != 1 {
+		t.Fatalf("got %d reports, wanted 1", len(reports))
+	}
+	sq := reports[0]
+	t.Logf("%+v", sq)
+	if sq.Rows != 100 || sq.Session.SID == 0 || len(sq.Binds) != 1 || strings.Contains(sq.Binds[0], "secret") {
+		t.Errorf("got %+v", sq)
 	}
-	t.Skip("cancelation timed out")
 }
 
-func TestObject(t *testing.T) {
-	ctx, cancel := context.WithTimeout(testContext("Object"), 10*time.Second)
+func TestInterceptor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("Interceptor"), 30*time.Second)
 	defer cancel()
-	conn, err := testDb.Conn(ctx)
+	P, err := godror.ParseDSN(testConStr)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer conn.Close()
-	testCon, err := godror.DriverConn(ctx, conn)
+	d := godror.NewDriver()
+	defer d.Close()
+	var mu sync.Mutex
+	counts := make(map[godror.OpKind]int)
+	d.RegisterInterceptor(func(ctx context.Context, op *godror.Op, next func(context.Context, *godror.Op) error) error {
+		mu.Lock()
+		counts[op.Kind]++
+		mu.Unlock()
+		if op.Kind == godror.OpPrepare {
+			op.Query = strings.Replace(op.Query, "{{answer}}", "42", 1)
+		}
+		return next(ctx, op)
+	})
+	db := sql.OpenDB(d.NewConnector(P))
+	defer db.Close()
+
+	var n int
+	const qry = "SELECT {{answer}} FROM DUAL"
+	if err = db.QueryRowContext(ctx, qry).Scan(&n); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	} else if n != 42 {
+		t.Errorf("got %d, wanted 42", n)
+	}
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer testCon.Close()
-	if err = testCon.Ping(ctx); err != nil {
+	if _, err = tx.ExecContext(ctx, "SELECT 1 FROM DUAL"); err != nil {
 		t.Fatal(err)
 	}
-	t.Logf("dbstats: %#v", testDb.Stats())
-
-	cleanup := func() {
-		testDb.Exec("DROP PROCEDURE test_obj_modify")
-		testDb.Exec("DROP TYPE test_obj_tab_t")
-		testDb.Exec("DROP TYPE test_obj_rec_t")
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
 	}
-	cleanup()
-	const crea = `
-CREATE OR REPLACE TYPE test_obj_rec_t AS OBJECT (num NUMBER, vc VARCHAR2(1000), dt DATE);
-CREATE OR REPLACE TYPE test_obj_tab_t AS TABLE OF test_obj_rec_t;
-CREATE OR REPLACE PROCEDURE test_obj_modify(p_obj IN OUT NOCOPY test_obj_tab_t) IS
-BEGIN
-  p_obj.EXTEND;
-  p_obj(p_obj.LAST) := test_obj_rec_t(
-    num => 314/100 + p_obj.COUNT,
-    vc  => 'abraka dabra',
-    dt  => SYSDATE);
-END;`
-	for _, qry := range strings.Split(crea, "\nCREATE OR") {
-		if qry == "" {
-			continue
-		}
-		qry = "CREATE OR" + qry
-		if _, err = testDb.ExecContext(ctx, qry); err != nil {
-			t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	mu.Lock()
+	defer mu.Unlock()
+	t.Log(counts)
+	for _, k := range []godror.OpKind{godror.OpPrepare, godror.OpQuery, godror.OpFetch, godror.OpExec, godror.OpCommit} {
+		if counts[k] == 0 {
+			t.Errorf("no %s intercepted", k)
 		}
 	}
+}
 
-	defer cleanup()
-
-	cOt, err := testCon.GetObjectType(strings.ToUpper("test_obj_tab_t"))
+func TestRoundTrips(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("RoundTrips"), 30*time.Second)
+	defer cancel()
+	before := godror.GetRoundTripStats()
+	var rt godror.RoundTrips
+	const qry = "SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= 10"
+	rows, err := testDb.QueryContext(ctx, qry,
+		godror.FetchArraySize(4), godror.PrefetchCount(0), godror.CountRoundTrips(&rt))
 	if err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	var n int
+	for rows.Next() {
+		n++
+	}
+	if err = rows.Close(); err != nil {
 		t.Fatal(err)
 	}
-	t.Log(cOt)
+	t.Logf("%d rows: %+v", n, rt)
+	if n != 10 {
+		t.Errorf("got %d rows, wanted 10", n)
+	}
+	if rt.Execute != 1 || rt.Fetch < 3 {
+		t.Errorf("got %+v, wanted 1 execute and at least 3 fetches", rt)
+	}
+	if after := godror.GetRoundTripStats(); after.Total()-before.Total() < rt.Total() {
+		t.Errorf("driver stats grew from %+v to %+v only", before, after)
+	}
+}
 
-	// create object from the type
-	coll, err := cOt.NewCollection()
+func TestSQLTrace(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("SQLTrace"), 30*time.Second)
+	defer cancel()
+	conn, err := testDb.Conn(ctx)
 	if err != nil {
-		t.Fatal("NewCollection:", err)
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if err = godror.SetTracefileIdentifier(ctx, conn, "godror_test"); err != nil {
+		t.Fatal(err)
+	}
+	if err = godror.EnableSQLTrace(ctx, conn, godror.SQLTraceOptions{Binds: true, Waits: true}); err != nil {
+		t.Skip(err)
+	}
+	const qry = "SELECT /* godror_sqltrace */ :1 FROM DUAL"
+	var s string
+	err = conn.QueryRowContext(ctx, qry, "traced").Scan(&s)
+	if dErr := godror.DisableSQLTrace(ctx, conn); dErr != nil && err == nil {
+		err = dErr
 	}
-	defer coll.Close()
-
-	// create an element object
-	elt, err := cOt.CollectionOf.NewObject()
 	if err != nil {
-		t.Fatal("collection.NewObject:", err)
+		t.Fatal(err)
 	}
-	defer elt.Close()
+	name, err := godror.TraceFileName(ctx, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log("trace file:", name)
+	if !strings.Contains(name, "godror_test") {
+		t.Errorf("trace file %q does not contain the identifier", name)
+	}
+	var buf strings.Builder
+	if err = godror.ReadTraceFile(ctx, &buf, conn, name); err != nil {
+		t.Skip(err)
+	}
+	if buf.Len() != 0 && !strings.Contains(buf.String(), "godror_sqltrace") {
+		t.Errorf("trace does not contain the query: %q", buf.String())
+	}
+}
 
-	// append to the collection
-	t.Logf("append an empty %s", elt)
-	coll.AppendObject(elt)
+func TestCapturePlan(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("CapturePlan"), 30*time.Second)
+	defer cancel()
+	var plans []godror.ExecutionPlan
+	const qry = "SELECT /*+ GATHER_PLAN_STATISTICS */ COUNT(0) FROM all_objects"
+	var n int64
+	if err := testDb.QueryRowContext(ctx, qry,
+		godror.CapturePlan(0, func(_ context.Context, ep godror.ExecutionPlan) { plans = append(plans, ep) }),
+	).Scan(&n); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	if len(plans) != 1 {
+		t.Fatalf("got %d plans, wanted 1", len(plans))
+	}
+	ep := plans[0]
+	t.Logf("%s (%s): %s\n%s", ep.SQLID, ep.Elapsed, ep.Err, ep.Plan)
+	if ep.SQLID == "" {
+		t.Error("no SQL_ID")
+	}
+	if ep.Err != nil {
+		t.Skip(ep.Err)
+	}
+	if !strings.Contains(ep.Plan, ep.SQLID) {
+		t.Errorf("plan does not contain the SQL_ID %q", ep.SQLID)
+	}
+}
 
-	const mod = "BEGIN test_obj_modify(:1); END;"
-	if err = prepExec(ctx, testCon, mod, driver.NamedValue{Ordinal: 1, Value: coll}); err != nil {
-		t.Error(err)
+func TestOraErrDiagnostics(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("OraErrDiagnostics"), 10*time.Second)
+	defer cancel()
+	const qry = "SELECT dummy FROM godror_not_existing_table"
+	_, err := testDb.QueryContext(ctx, qry)
+	oe, ok := godror.AsOraErr(err)
+	if !ok {
+		t.Fatalf("wanted OraErr, got %+v", err)
 	}
-	t.Logf("coll: %s", coll)
-	var data godror.Data
-	for i, err := coll.First(); err == nil; i, err = coll.Next(i) {
-		if err = coll.GetItem(&data, i); err != nil {
-			t.Fatal(err)
-		}
-		elt = data.GetObject()
+	t.Logf("code=%d offset=%d sql=%q snippet=%q", oe.Code(), oe.Offset(), oe.SQL(), oe.Snippet())
+	if oe.Code() != 942 {
+		t.Errorf("got %d, wanted 942", oe.Code())
+	}
+	if oe.SQL() != qry {
+		t.Errorf("got SQL %q, wanted %q", oe.SQL(), qry)
+	}
+	if want := "<*>godror_not_existing_table"; !strings.Contains(oe.Snippet(), want) {
+		t.Errorf("snippet %q does not contain %q", oe.Snippet(), want)
+	}
+	if godror.IsUniqueViolation(err) || godror.IsDeadlock(err) || godror.IsTimeout(err) {
+		t.Error("false positive predicate")
+	}
+}
 
-		t.Logf("elt[%d] : %s", i, elt)
-		for attr := range elt.Attributes {
-			val, err := elt.Get(attr)
-			if err != nil {
-				t.Error(err, attr)
-			}
-			t.Logf("elt[%d].%s=%s", i, attr, val)
-		}
+func TestBindsOnError(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("BindsOnError"), 10*time.Second)
+	defer cancel()
+	const qry = "SELECT TO_NUMBER(:1) FROM DUAL WHERE :2 = 1"
+	var n int64
+	err := testDb.QueryRowContext(ctx, qry, "not a number", 1,
+		godror.BindsOnError(godror.RedactPolicy{Positions: []int{1}}),
+	).Scan(&n)
+	var be *godror.BindsError
+	if !errors.As(err, &be) {
+		t.Fatalf("wanted BindsError, got %+v", err)
+	}
+	t.Log(be)
+	if len(be.Binds) != 2 || strings.Contains(be.Error(), "not a number") {
+		t.Errorf("got %q", be.Binds)
+	}
+	if oe, ok := godror.AsOraErr(err); !ok || oe.Code() != 1722 {
+		t.Errorf("got %+v, wanted ORA-01722", err)
 	}
 }
 
-func TestNewPassword(t *testing.T) {
+func TestPoolEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("PoolEvents"), 30*time.Second)
+	defer cancel()
 	P, err := godror.ParseDSN(testConStr)
 	if err != nil {
 		t.Fatal(err)
 	}
-	ctx, cancel := context.WithTimeout(testContext("NewPassword"), 10*time.Second)
-	defer cancel()
-	const user, oldPassword, newPassword = "test_expired", "oldPassw0rd_long", "newPassw0rd_longer"
-
-	testDb.Exec("DROP USER " + user)
-	// GRANT CREATE USER, DROP USER TO test
-	// GRANT CREATE SESSION TO test WITH ADMIN OPTION
-	qry := "CREATE USER " + user + " IDENTIFIED BY " + oldPassword + " PASSWORD EXPIRE"
-	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		if strings.Contains(err.Error(), "ORA-01031:") {
-			t.Log("Please issue this:\nGRANT CREATE USER, DROP USER TO " + P.Username + ";\n" +
-				"GRANT CREATE SESSION TO " + P.Username + " WITH ADMIN OPTION;\n")
-			t.Skip(err)
-		}
+	if P.IsStandalone() {
+		t.Skip("needs a session pool")
+	}
+	d := godror.NewDriver()
+	defer d.Close()
+	var mu sync.Mutex
+	counts := make(map[godror.PoolEventKind]int)
+	d.SetPoolEventListener(func(ev godror.PoolEvent) {
+		mu.Lock()
+		counts[ev.Kind]++
+		mu.Unlock()
+	})
+	db := sql.OpenDB(d.NewConnector(P))
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
 		t.Fatal(err)
 	}
-	defer testDb.Exec("DROP USER " + user)
-
-	qry = "GRANT CREATE SESSION TO " + user
-	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		if strings.Contains(err.Error(), "ORA-01031:") {
-			t.Log("Please issue this:\nGRANT CREATE SESSION TO " + P.Username + " WITH ADMIN OPTION;\n")
-		}
+	if err = conn.PingContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err = conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Close(); err != nil {
 		t.Fatal(err)
 	}
+	mu.Lock()
+	defer mu.Unlock()
+	t.Log(counts)
+	if counts[godror.PoolCheckout] == 0 || counts[godror.PoolReturn] == 0 {
+		t.Errorf("got %v, wanted checkouts and returns", counts)
+	}
+}
 
-	P.Username, P.Password = user, godror.NewPassword(oldPassword)
-	P.StandaloneConnection = godror.Bool(true)
-	P.NewPassword = godror.NewPassword(newPassword)
-	{
-		db, err := sql.Open("godror", P.StringWithPassword())
-		if err != nil {
-			t.Fatal(err)
-		}
-		err = db.Ping()
-		db.Close()
-		if err != nil {
-			t.Fatal(err)
-		}
+func TestTagExtractor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("TagExtractor"), 30*time.Second)
+	defer cancel()
+	P, err := godror.ParseDSN(testConStr)
+	if err != nil {
+		t.Fatal(err)
 	}
+	d := godror.NewDriver()
+	defer d.Close()
+	type tenantKey struct{}
+	d.SetTagExtractor(func(ctx context.Context) (godror.ContextTags, bool) {
+		tenant, ok := ctx.Value(tenantKey{}).(string)
+		return godror.ContextTags{TraceTag: godror.TraceTag{Module: "godror-test", Action: tenant}, ECID: "godror-" + tenant}, ok
+	})
+	db := sql.OpenDB(d.NewConnector(P))
+	defer db.Close()
 
-	P.Password = P.NewPassword
-	P.NewPassword.Reset()
-	{
-		db, err := sql.Open("godror", P.StringWithPassword())
-		if err != nil {
-			t.Fatal(err)
+	for _, tenant := range []string{"acme", "initech"} {
+		ctx := context.WithValue(ctx, tenantKey{}, tenant)
+		var module, action string
+		const qry = "SELECT SYS_CONTEXT('USERENV', 'MODULE'), SYS_CONTEXT('USERENV', 'ACTION') FROM DUAL"
+		if err = db.QueryRowContext(ctx, qry).Scan(&module, &action); err != nil {
+			t.Fatalf("%s: %+v", qry, err)
 		}
-		err = db.Ping()
-		db.Close()
-		if err != nil {
-			t.Fatal(err)
+		t.Logf("module=%q action=%q", module, action)
+		if module != "godror-test" || action != tenant {
+			t.Errorf("got %q/%q, wanted godror-test/%s", module, action, tenant)
 		}
 	}
 }
 
-func TestConnClass(t *testing.T) {
-	P, err := godror.ParseDSN(testConStr)
+func TestResourceStats(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("ResourceStats"), 10*time.Second)
+	defer cancel()
+	before := godror.GetResourceStats()
+	stmt, err := testDb.PrepareContext(ctx, "SELECT 1 FROM DUAL")
 	if err != nil {
 		t.Fatal(err)
 	}
-	ctx, cancel := context.WithTimeout(testContext("ConnClass"), 10*time.Second)
-	defer cancel()
+	during := godror.GetResourceStats()
+	if err = stmt.Close(); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("before=%+v during=%+v", before, during)
+	if during.Conns < 1 || during.Stmts < 1 || during.CgoCalls <= before.CgoCalls {
+		t.Errorf("got %+v", during)
+	}
+}
 
-	const connClass = "fc8153b840"
-	P.ConnClass = connClass
+func TestHealthCheck(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("HealthCheck"), 10*time.Second)
+	defer cancel()
+	h, err := godror.HealthCheck(ctx, testDb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("%+v TAC=%t", h, h.TAC())
+	if h.Ping <= 0 || h.Server.Version == 0 {
+		t.Errorf("got %+v", h)
+	}
+	if len(h.Errs) == 0 && (h.OpenCursors <= 0 || h.FailoverType == "") {
+		t.Errorf("no diagnostics without errors: %+v", h)
+	}
+}
 
-	db, err := sql.Open("godror", P.StringWithPassword())
+func TestAuditHook(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("AuditHook"), 30*time.Second)
+	defer cancel()
+	P, err := godror.ParseDSN(testConStr)
 	if err != nil {
 		t.Fatal(err)
 	}
+	d := godror.NewDriver()
+	defer d.Close()
+	var mu sync.Mutex
+	var events []godror.AuditEvent
+	d.SetAuditHook(func(_ context.Context, ev godror.AuditEvent) {
+		mu.Lock()
+		events = append(events, ev)
+		mu.Unlock()
+	})
+	db := sql.OpenDB(d.NewConnector(P))
 	defer db.Close()
-	if err := db.PingContext(ctx); err != nil {
-		t.Skip(err)
+
+	tbl := "test_audit" + tblSuffix
+	db.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err = db.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER)"); err != nil {
+		t.Fatal(err)
 	}
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
 
-	const qry = "SELECT username,program,cclass_name FROM v$cpool_conn_info"
-	rows, err := db.QueryContext(ctx, qry)
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		var oerr *godror.OraErr
-		if errors.As(err, &oerr) && oerr.Code() == 942 {
-			t.Skip(err)
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	for _, qry := range []string{
+		"INSERT INTO " + tbl + " (id) SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= 3",
+		"UPDATE " + tbl + " SET id = id + 1 WHERE id > 1",
+	} {
+		if _, err = tx.ExecContext(ctx, qry); err != nil {
+			t.Fatalf("%s: %+v", qry, err)
 		}
-		t.Fatalf("%s: %+v", qry, err)
 	}
-	defer rows.Close()
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
 
-	for rows.Next() {
-		var usr, prg, class string
-		if err = rows.Scan(&usr, &prg, &class); err != nil {
-			t.Fatal(err)
-		}
-		t.Log(usr, prg, class)
+	mu.Lock()
+	defer mu.Unlock()
+	for _, ev := range events {
+		t.Logf("%s: %+v", ev.Kind, ev)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, wanted 3", len(events))
+	}
+	ev := events[2]
+	if ev.Kind != godror.OpCommit || ev.Rows != 5 || ev.TxID == "" || ev.TxID != events[0].TxID ||
+		len(ev.Tables) != 1 || !strings.EqualFold(ev.Tables[0], tbl) {
+		t.Errorf("got %+v", ev)
 	}
 }
 
-func TestOnInit(t *testing.T) {
+func TestGetOpenModes(t *testing.T) {
 	t.Parallel()
-	P, err := godror.ParseDSN(testConStr)
+	ctx, cancel := context.WithTimeout(testContext("GetOpenModes"), 10*time.Second)
+	defer cancel()
+	modes, err := godror.GetOpenModes(ctx, testDb)
 	if err != nil {
+		if strings.Contains(err.Error(), "ORA-00942") {
+			t.Skip(err)
+		}
 		t.Fatal(err)
 	}
-	const numChars = "#@"
-	P.SetSessionParamOnInit("nls_numeric_characters", numChars)
-	t.Log(P.String())
-	ctx, cancel := context.WithTimeout(testContext("OnInit"), 10*time.Second)
-	defer cancel()
+	t.Logf("%+v", modes)
+	if modes.Database == "" {
+		t.Errorf("got %+v", modes)
+	}
+}
 
-	db, err := sql.Open("godror", P.StringWithPassword())
+func TestSetContainer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("SetContainer"), 10*time.Second)
+	defer cancel()
+	conn, err := testDb.Conn(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer db.Close()
-
-	conns := make([]*sql.Conn, 3)
-	defer func() {
-		for _, c := range conns {
-			if c != nil {
-				c.Close()
-			}
-		}
-	}()
-	for i := range conns {
-		if conns[i], err = db.Conn(ctx); err != nil {
-			t.Fatal(err)
-		}
-
-		const qry = "SELECT value, TO_CHAR(123/10) AS num FROM v$nls_parameters WHERE parameter = 'NLS_NUMERIC_CHARACTERS'"
-		var v, n string
-		if err = conns[i].QueryRowContext(ctx, qry).Scan(&v, &n); err != nil {
-			t.Errorf("%d. %+v", i, err)
-		}
-		t.Logf("%d. v=%q n=%q", i, v, n)
-		if v != numChars {
-			t.Errorf("%d. got %q wanted %q", i, v, numChars)
-		}
-		if n != "12#3" {
-			t.Errorf("%d. got %q wanted 12#3", i, n)
+	defer conn.Close()
+	const qry = "SELECT SYS_CONTEXT('USERENV', 'CON_NAME') FROM DUAL"
+	var name string
+	if err = conn.QueryRowContext(ctx, qry).Scan(&name); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	t.Log("container:", name)
+	if err = godror.SetContainer(ctx, conn, `"`+name+`"`); err != nil {
+		if strings.Contains(err.Error(), "ORA-01031") || strings.Contains(err.Error(), "ORA-65090") {
+			t.Skip(err)
 		}
+		t.Fatal(err)
+	}
+	var got string
+	if err = conn.QueryRowContext(ctx, qry).Scan(&got); err != nil {
+		t.Fatalf("%s: %+v", qry, err)
+	}
+	if got != name {
+		t.Errorf("got %q, wanted %q", got, name)
 	}
 }
 
-func TestOnInitParallel(t *testing.T) {
+func TestResultCacheInterceptor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("ResultCacheInterceptor"), 30*time.Second)
+	defer cancel()
+	tbl := "test_result_cache" + tblSuffix
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER)"); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
+	if err := godror.SetTableResultCache(ctx, testDb, tbl, false); err != nil {
+		t.Fatal(err)
+	}
+
 	P, err := godror.ParseDSN(testConStr)
 	if err != nil {
 		t.Fatal(err)
 	}
-	const numChars = "#@"
-	P.SetSessionParamOnInit("nls_numeric_characters", numChars)
-	t.Log(P.String())
-	ctx, cancel := context.WithTimeout(testContext("OnInit"), 1*time.Minute)
-	defer cancel()
-
-	db, err := sql.Open("godror", P.StringWithPassword())
+	d := godror.NewDriver()
+	defer d.Close()
+	var mu sync.Mutex
+	var invalidated []string
+	ic, err := godror.NewResultCacheInterceptor(godror.ResultCacheOptions{
+		Tables: []string{tbl},
+		OnInvalidate: func(_ context.Context, tables []string) {
+			mu.Lock()
+			invalidated = append(invalidated, tables...)
+			mu.Unlock()
+		},
+	})
 	if err != nil {
 		t.Fatal(err)
 	}
+	d.RegisterInterceptor(ic)
+	db := sql.OpenDB(d.NewConnector(P))
 	defer db.Close()
 
-	const want = "12#3"
-	const qry = "SELECT value, TO_CHAR(123/10) AS num FROM v$nls_parameters WHERE parameter = 'NLS_NUMERIC_CHARACTERS'"
-	for i := 0; i < 4; i++ {
-		var wg sync.WaitGroup
-		for j := 0; j < maxSessions*2; j++ {
-			wg.Add(1)
-			go func(i, j int) {
-				defer wg.Done()
-				var v, n string
-				if err := db.QueryRowContext(ctx, qry).Scan(&v, &n); err != nil {
-					if errors.Is(err, context.Canceled) {
-						return
-					}
-					var ec interface{ Code() int }
-					if errors.As(err, &ec) && (ec.Code() == 28547 || ec.Code() == 18) {
-						return
-					}
-					t.Errorf("%d*%d. %+v", i, j, err)
-					return
-				}
-				//t.Logf("%d. v=%q n=%q", i, v, n)
-				if v != numChars || n != want {
-					t.Errorf("%d*%d. got %q(%q) wanted %q(%q)", i, j, v, n, numChars, want)
-					cancel()
-				}
-			}(i, j)
+	if _, err = db.ExecContext(ctx, "INSERT INTO "+tbl+" (id) VALUES (1)"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		var n int
+		if err = db.QueryRowContext(ctx, "SELECT COUNT(0) FROM "+tbl).Scan(&n); err != nil {
+			t.Fatal(err)
 		}
-		wg.Wait()
+		if n != 1 {
+			t.Errorf("got %d rows, wanted 1", n)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(invalidated) != 1 || !strings.EqualFold(invalidated[0], tbl) {
+		t.Errorf("invalidated %q, wanted %q", invalidated, tbl)
 	}
 }
 
-func TestSelectTypes(t *testing.T) {
-	ctx, cancel := context.WithTimeout(testContext("SelectTypes"), time.Minute)
+func TestSchedulerJob(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("SchedulerJob"), 30*time.Second)
 	defer cancel()
-	const createQry = `CREATE TABLE TEST_TYPES (
-			A_LOB BFILE,
-			B BINARY_DOUBLE,
-			C BINARY_FLOAT,
-			D_LOB BLOB,
-			E CHAR(10),
-			F_LOB CLOB,
-			G DATE,
-			GN DATE,
-			H NUMBER(18 , 2),
-			I FLOAT(126),
-			J FLOAT(10),
-			K NUMBER(38 , 0),
-			L INTERVAL DAY TO SECOND(6),
-			M INTERVAL YEAR TO MONTH,
-			--N LONG,
-			P NCHAR(100),
-			Q_LOB NCLOB,
-			R NUMBER(18 , 2),
-			S NUMBER(18 , 2),
-			T NVARCHAR2(100),
-			U RAW(100),
-			V FLOAT(63),
-			W NUMBER(38 , 0),
-			X TIMESTAMP,
-			Y TIMESTAMP WITH LOCAL TIME ZONE,
-			Z TIMESTAMP WITH TIME ZONE,
-			AA VARCHAR2(100),
-			AB XMLTYPE
-		)`
-	testDb.ExecContext(ctx, "DROP TABLE test_types")
-	if _, err := testDb.ExecContext(ctx, createQry); err != nil {
-		t.Fatalf("%s: %+v", createQry, err)
+	name := "test_job" + tblSuffix
+	_ = godror.DropJob(ctx, testDb, name, true)
+	start := time.Now().Add(-time.Minute)
+	if err := godror.CreateJob(ctx, testDb, godror.SchedulerJob{
+		Name: name, Type: godror.JobPLSQLBlock, Action: "BEGIN DBMS_SESSION.sleep(0.1); END;",
+		Comments: "godror test",
+	}); err != nil {
+		if strings.Contains(err.Error(), "ORA-27486") || strings.Contains(err.Error(), "ORA-01031") {
+			t.Skip(err)
+		}
+		t.Fatal(err)
 	}
-	defer func() {
-		ctx, cancel := context.WithTimeout(testContext("SelectTypes-drop"), 10*time.Second)
-		defer cancel()
-		testDb.ExecContext(ctx, "DROP TABLE test_types")
-	}()
-
-	const insertQry = `INSERT INTO test_types
-  (b, c, e, g, gn,
-   h, i, j,
-   k, l,
-   r, s, u,
-   v, w, x, y,
-   z,
-   aa)
-  VALUES (3.14, 4.15, 'char(10)', TO_DATE('2020-01-21 09:16:36', 'YYYY-MM-DD HH24:MI:SS'), NULL,
-          1/3, 5.16, 6.17,
-          123456789012345678901234567890, INTERVAL '8' HOUR,
-		  7.18, 8.19, HEXTORAW('deadbeef'),
-		  0.01, -3, SYSTIMESTAMP, SYSTIMESTAMP,
-		  TIMESTAMP '2018-02-15 14:00:00.00 CET',
-          'varchar2(100)')`
-
-	if _, err := testDb.ExecContext(ctx, insertQry); err != nil {
-		t.Fatalf("%s: %+v", insertQry, err)
+	defer godror.DropJob(context.Background(), testDb, name, true)
+	if err := godror.EnableJob(ctx, testDb, name); err != nil {
+		t.Fatal(err)
 	}
-	const insertQry2 = `INSERT INTO test_types (z) VALUES (cast(TO_TIMESTAMP_TZ('2018-02-15T14:00:00 01:00','yyyy-mm-dd"T"hh24:mi:ss TZH:TZM') as date))`
-	if _, err := testDb.ExecContext(ctx, insertQry2); err != nil {
-		t.Fatalf("%s: %+v", insertQry2, err)
+	if err := godror.RunJob(ctx, testDb, name, true); err != nil {
+		t.Fatal(err)
 	}
-	var n int32
-	if err := testDb.QueryRowContext(ctx, "SELECT COUNT(0) FROM test_types").Scan(&n); err != nil {
+	js, err := godror.GetJob(ctx, testDb, name)
+	if err != nil {
 		t.Fatal(err)
 	}
-	if n != 2 {
-		t.Errorf("%d rows in the table, wanted 2", n)
+	t.Logf("job: %+v", js)
+	if !strings.EqualFold(js.Name, name) {
+		t.Errorf("got %+v", js)
 	}
-
-	var dbTZ string
-	if err := testDb.QueryRowContext(ctx, "SELECT dbtimezone FROM DUAL").Scan(&dbTZ); err != nil {
+	details, err := godror.GetJobRunDetails(ctx, testDb, name, start)
+	if err != nil {
 		t.Fatal(err)
 	}
-	rows, err := testDb.QueryContext(ctx, "SELECT filename, version FROM v$timezone_file")
-	if err != nil {
-		t.Log(err)
-	} else {
-		for rows.Next() {
-			var fn, ver string
-			if err = rows.Scan(&fn, &ver); err != nil {
-				t.Fatal(err)
-			}
-			t.Logf("v$timezone file=%q version=%q", fn, ver)
+	t.Logf("details: %+v", details)
+	if len(details) != 0 && details[0].Status != "SUCCEEDED" {
+		t.Errorf("got %+v", details[0])
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("Metadata"), 30*time.Second)
+	defer cancel()
+	parent, child := strings.ToUpper("test_md_parent"+tblSuffix), strings.ToUpper("test_md_child"+tblSuffix)
+	testDb.ExecContext(ctx, "DROP TABLE "+child)
+	testDb.ExecContext(ctx, "DROP TABLE "+parent)
+	for _, qry := range []string{
+		"CREATE TABLE " + parent + " (id NUMBER(9) CONSTRAINT " + parent + "_pk PRIMARY KEY, name VARCHAR2(30) NOT NULL)",
+		"CREATE TABLE " + child + " (id NUMBER(9), parent_id NUMBER(9) CONSTRAINT " + child + "_fk REFERENCES " + parent + " ON DELETE CASCADE, qty NUMBER(5,2) DEFAULT 1)",
+		"CREATE INDEX " + child + "_i ON " + child + " (parent_id, qty DESC)",
+		"COMMENT ON COLUMN " + child + ".qty IS 'quantity'",
+	} {
+		if _, err := testDb.ExecContext(ctx, qry); err != nil {
+			t.Fatalf("%s: %+v", qry, err)
+		}
+	}
+	defer func() {
+		testDb.ExecContext(context.Background(), "DROP TABLE "+child)
+		testDb.ExecContext(context.Background(), "DROP TABLE "+parent)
+	}()
+
+	tables, err := metadata.Tables(ctx, testDb, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found int
+	for _, tbl := range tables {
+		if tbl.Name == parent || tbl.Name == child {
+			found++
 		}
-		rows.Close()
+	}
+	if found != 2 {
+		t.Errorf("found %d of the 2 tables in %d", found, len(tables))
 	}
 
-	const qry = "SELECT * FROM test_types"
-
-	// get rows
-	rows, err = testDb.QueryContext(ctx, qry)
+	cols, err := metadata.Columns(ctx, testDb, "", child)
 	if err != nil {
-		t.Fatalf("%s: %+v", qry, err)
+		t.Fatal(err)
+	}
+	t.Logf("columns: %+v", cols)
+	if len(cols) != 3 || cols[2].Name != "QTY" || cols[2].Precision != 5 || cols[2].Scale != 2 ||
+		cols[2].Comment != "quantity" || strings.TrimSpace(cols[2].Default) != "1" || !cols[2].Nullable {
+		t.Errorf("got %+v", cols)
 	}
-	defer rows.Close()
 
-	// get columns name
-	colsName, err := rows.Columns()
+	cons, err := metadata.Constraints(ctx, testDb, "", child)
 	if err != nil {
 		t.Fatal(err)
 	}
-	t.Log("columns:", colsName)
+	t.Logf("constraints: %+v", cons)
+	if len(cons) != 1 || cons[0].Type != metadata.ForeignKey || cons[0].RefTable != parent ||
+		cons[0].DeleteRule != "CASCADE" || len(cons[0].Columns) != 1 || len(cons[0].RefColumns) != 1 || cons[0].RefColumns[0] != "ID" {
+		t.Errorf("got %+v", cons)
+	}
 
-	// get types of query columns
-	types, err := rows.ColumnTypes()
+	idxs, err := metadata.Indexes(ctx, testDb, "", child)
 	if err != nil {
 		t.Fatal(err)
 	}
+	t.Logf("indexes: %+v", idxs)
+	if len(idxs) != 1 || len(idxs[0].Columns) != 2 || !idxs[0].Columns[1].Descending || idxs[0].Unique {
+		t.Errorf("got %+v", idxs)
+	}
 
-	// total columns
-	totalColumns := len(colsName)
+	if _, err = metadata.Sequences(ctx, testDb, ""); err != nil {
+		t.Error(err)
+	}
+	if _, err = metadata.ObjectTypes(ctx, testDb, ""); err != nil {
+		t.Error(err)
+	}
+}
 
-	oracleFieldParse := func(datatype string, field interface{}) interface{} {
-		// DEBUG: print the type of field and datatype returned for the drivers
-		t.Logf("%T\t%s\n", field, datatype)
+func TestGetDDL(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("GetDDL"), 30*time.Second)
+	defer cancel()
+	tbl := strings.ToUpper("test_ddl" + tblSuffix)
+	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
+	if _, err := testDb.ExecContext(ctx, "CREATE TABLE "+tbl+" (id NUMBER(9) PRIMARY KEY, name VARCHAR2(30))"); err != nil {
+		t.Fatal(err)
+	}
+	defer testDb.ExecContext(context.Background(), "DROP TABLE "+tbl)
 
-		if field == nil {
-			return nil
-		}
-		switch x := field.(type) {
-		case string:
-			return x
-		case godror.Number:
-			return string(x)
-		case []uint8:
-			switch datatype {
-			case "RAW", "LONG RAW":
-				return fmt.Sprintf("%x", x)
-			default:
-				return fmt.Sprintf("unsupported datatype %s", datatype)
-			}
-		case godror.NullTime:
-			if !x.Valid {
-				return "NULL"
-			}
-			if x.Time.IsZero() {
-				t.Errorf("zero NullTime.Time, and Valid!?")
-			}
-			return x.Time.Format(time.RFC3339)
-		case time.Time:
-			return x.Format(time.RFC3339)
-		default:
-			return fmt.Sprintf("%v", field)
-		}
+	rc, err := godror.GetDDL(ctx, testDb, "table", tbl, godror.DDLOptions{Terminator: true, NoStorage: true, NoSegmentAttributes: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(rc)
+	if closeErr := rc.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	ddl := strings.TrimSpace(string(b))
+	t.Log(ddl)
+	if !strings.Contains(ddl, "CREATE TABLE") || !strings.Contains(ddl, tbl) ||
+		strings.Contains(ddl, "STORAGE(") || strings.Contains(ddl, "TABLESPACE") || !strings.HasSuffix(ddl, ";") {
+		t.Errorf("got %q", ddl)
 	}
 
-	// create a slice of interface{}'s to represent each column,
-	// and a second slice to contain pointers to each item in the columns slice
-	columns := make([]interface{}, totalColumns)
-	recordPointers := make([]interface{}, totalColumns)
-	for i := range columns {
-		if types[i].DatabaseTypeName() == "DATE" {
-			var t godror.NullTime
-			recordPointers[i] = &t
-			columns[i] = t
-		} else {
-			recordPointers[i] = &columns[i]
-		}
+	if _, err = godror.GetDDL(ctx, testDb, "TABLE", tbl+"_NOT_EXIST", godror.DDLOptions{}); err == nil {
+		t.Error("wanted error for a not existing table")
 	}
+}
 
-	dumpRows := func() {
-		dests := make([]string, 0, len(colsName))
-		params := make([]interface{}, 0, cap(dests))
-		var dumpQry strings.Builder
-		dumpQry.WriteString("SELECT ")
-		for _, col := range colsName {
-			if strings.HasSuffix(col, "_LOB") {
-				continue
-			}
-			if len(dests) != 0 {
-				dumpQry.WriteString(", ")
-			}
-			fmt.Fprintf(&dumpQry, "'%[1]s='||DUMP(%[1]s, 1017,1,10) AS %[1]s", col)
-			dests = append(dests, "")
-			params = append(params, &dests[len(dests)-1])
-		}
-		dumpQry.WriteString(" FROM test_types")
-		qry := dumpQry.String()
-		rows, err := testDb.QueryContext(ctx, qry)
-		if err != nil {
-			t.Errorf("%s: %+v", qry, err)
-			return
-		}
-		defer rows.Close()
-		var i int
-		for rows.Next() {
-			if err = rows.Scan(params...); err != nil {
-				t.Fatal(err)
-			}
-			i++
-			t.Logf("%d. %q", i, dests)
-		}
-		if err = rows.Err(); err != nil {
-			t.Fatal(err)
-		}
+func TestDBLinks(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("DBLinks"), 30*time.Second)
+	defer cancel()
+	links, err := godror.GetDBLinks(ctx, testDb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("links: %+v", links)
+	if len(links) == 0 {
+		t.Skip("no database links")
+	}
+	tx, err := testDb.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	link := links[0].Name
+	if err = godror.PingDBLink(ctx, tx, link); err != nil {
+		t.Logf("ping %q: %+v (distributed: %t)", link, err, godror.IsDistributedTxError(err))
+		return
+	}
+	if err = tx.Rollback(); err != nil {
+		t.Fatal(err)
 	}
+}
 
-	// record destination
-	record := make([]interface{}, totalColumns)
-	var rowCount int
-	for rows.Next() {
-		// Scan the result into the record pointers...
-		if err = rows.Scan(recordPointers...); err != nil {
-			dumpRows()
-			t.Fatal(err)
-		}
-		rowCount++
+func TestSoda(t *testing.T) {
+	ctx, cancel := context.WithTimeout(testContext("Soda"), 30*time.Second)
+	defer cancel()
+	sdb, err := godror.NewSodaDB(ctx, testDb)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer sdb.Close()
+	name := "godror_test_soda" + tblSuffix
+	coll, err := sdb.CreateCollection(ctx, name, "")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer func() {
+		coll.Drop(context.Background())
+		coll.Close()
+	}()
 
-		// Parse each field of recordPointers for get a custom field depending the type
-		for idxCol := range recordPointers {
-			record[idxCol] = oracleFieldParse(types[idxCol].DatabaseTypeName(), reflect.ValueOf(recordPointers[idxCol]).Elem().Interface())
-		}
+	docs, err := coll.InsertMany(ctx,
+		[]byte(`{"n":1,"name":"a"}`), []byte(`{"n":2,"name":"b"}`), []byte(`{"n":`),
+		[]byte(`{"n":3,"name":"c"}`), []byte(`{"n":4,"name":"d"}`))
+	t.Logf("inserted: %+v", docs)
+	var insErrs *godror.SodaInsertErrors
+	if !errors.As(err, &insErrs) {
+		t.Fatalf("wanted SodaInsertErrors, got %+v", err)
+	} else if len(insErrs.Errs) != 1 || insErrs.Errs[0].Index != 2 {
+		t.Errorf("wanted error for the 2. document, got %+v", insErrs)
+	}
+	if len(docs) != 5 || docs[0].Key == "" || docs[2].Key != "" {
+		t.Errorf("got %+v", docs)
+	}
 
-		t.Log(record)
+	q := godror.SodaQuery{
+		Filter: map[string]interface{}{"n": map[string]interface{}{"$gt": 1}, "$orderby": map[string]interface{}{"n": 1}},
+		Skip:   1, Limit: 2,
 	}
-	if err = rows.Err(); err != nil {
-		var cErr interface{ Code() int }
-		if errors.As(err, &cErr) && cErr.Code() == 1805 {
-			t.Skip(err)
+	found, err := coll.Find(ctx, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, d := range found {
+		var v struct{ Name string }
+		if err = json.Unmarshal(d.Content, &v); err != nil {
+			t.Fatalf("%s: %+v", d.Content, err)
 		}
+		names = append(names, v.Name)
+	}
+	if got := strings.Join(names, ","); got != "c,d" {
+		t.Errorf("got %q, wanted c,d", got)
+	}
+	q.Skip, q.Limit = 0, 0
+	if n, err := coll.Count(ctx, q); err != nil {
 		t.Fatal(err)
+	} else if n != 3 {
+		t.Errorf("count: got %d, wanted 3", n)
 	}
-	dumpRows()
-	if rowCount != 2 {
-		t.Errorf("read %d rows, wanted 2", rowCount)
+	if found, err = coll.Find(ctx, godror.SodaQuery{Key: docs[0].Key}); err != nil {
+		t.Fatal(err)
+	} else if len(found) != 1 || found[0].Key != docs[0].Key {
+		t.Errorf("by key %q: got %+v", docs[0].Key, found)
+	}
+
+	const idxName = "godror_test_soda_n_idx"
+	if err = coll.CreateIndex(ctx, map[string]interface{}{
+		"name": idxName, "fields": []map[string]string{{"path": "n", "datatype": "number"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if specs, err := coll.Indexes(ctx); err != nil {
+		t.Log(err)
+	} else {
+		t.Logf("indexes: %s", specs)
+	}
+	if dropped, err := coll.DropIndex(ctx, idxName, false); err != nil {
+		t.Fatal(err)
+	} else if !dropped {
+		t.Errorf("index %q has not been dropped", idxName)
+	}
+
+	if n, err := coll.Remove(ctx, godror.SodaQuery{Filter: `{"n":{"$lt":3}}`}); err != nil {
+		t.Fatal(err)
+	} else if n != 2 {
+		t.Errorf("removed %d, wanted 2", n)
+	}
+	names, err = sdb.CollectionNames(ctx, name, 1)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(names) != 1 || names[0] != name {
+		t.Errorf("got %q, wanted %q", names, name)
 	}
 }
 
-func TestInsertIntervalDS(t *testing.T) {
+func TestCompilationError(t *testing.T) {
 	t.Parallel()
-	ctx, cancel := context.WithTimeout(testContext("InsertIntervalDS"), 10*time.Second)
+	ctx, cancel := context.WithTimeout(testContext("CompilationError"), 30*time.Second)
 	defer cancel()
-	const tbl = "test_interval_ds"
-	testDb.ExecContext(ctx, "DROP TABLE "+tbl)
-	qry := "CREATE TABLE " + tbl + " (F_interval_ds INTERVAL DAY TO SECOND(3))"
-	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	const name = "test_compilation_error"
+	qry := "CREATE OR REPLACE PROCEDURE " + name + " IS BEGIN no_such_proc; END;"
+	_, err := testDb.ExecContext(ctx, qry, godror.WarningAsError())
+	defer testDb.ExecContext(context.Background(), "DROP PROCEDURE "+name)
+	var ce *godror.CompilationError
+	if !errors.As(err, &ce) {
+		t.Fatalf("%s: got %+v, wanted CompilationError", qry, err)
 	}
-	defer func() { testDb.ExecContext(testContext("InsertIntervalDS-drop"), "DROP TABLE "+tbl) }()
-
-	qry = "INSERT INTO " + tbl + " (F_interval_ds) VALUES (INTERVAL '32' SECOND)"
-	if _, err := testDb.ExecContext(ctx, qry); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	t.Log(ce)
+	if len(ce.Errors) == 0 || ce.Errors[0].Type != "PROCEDURE" {
+		t.Errorf("got %+v", ce.Errors)
 	}
-	qry = "INSERT INTO " + tbl + " (F_interval_ds) VALUES (:1)"
-	if _, err := testDb.ExecContext(ctx, qry, 33*time.Second); err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+	errs, err := godror.GetObjectCompileErrors(ctx, testDb, name, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) == 0 || !strings.EqualFold(errs[0].Name, name) {
+		t.Errorf("got %+v", errs)
 	}
+}
 
-	qry = "SELECT F_interval_ds FROM " + tbl + " ORDER BY 1"
-	rows, err := testDb.QueryContext(ctx, qry)
+func TestZeroCopyBytes(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("ZeroCopyBytes"), 10*time.Second)
+	defer cancel()
+	const qry = `SELECT 'value-'||LEVEL, UTL_RAW.cast_to_raw('raw-'||LEVEL) FROM DUAL CONNECT BY LEVEL <= 3`
+	rows, err := testDb.QueryContext(ctx, qry, godror.ZeroCopyBytes(), godror.FetchArraySize(2))
 	if err != nil {
-		t.Fatal(fmt.Errorf("%s: %w", qry, err))
+		t.Fatalf("%s: %+v", qry, err)
 	}
 	defer rows.Close()
-	var got []time.Duration
+	var n int
 	for rows.Next() {
-		var dur time.Duration
-		if err = rows.Scan(&dur); err != nil {
+		n++
+		var s, r sql.RawBytes
+		if err = rows.Scan(&s, &r); err != nil {
 			t.Fatal(err)
 		}
-		got = append(got, dur)
+		if want := fmt.Sprintf("value-%d", n); string(s) != want {
+			t.Errorf("%d. got %q, wanted %q", n, s, want)
+		}
+		if want := fmt.Sprintf("raw-%d", n); string(r) != want {
+			t.Errorf("%d. got %q, wanted %q", n, r, want)
+		}
 	}
-	t.Log("got:", got)
-	if !(len(got) == 2 && got[0] == 32*time.Second && got[1] == 33*time.Second) {
-		t.Errorf("wanted [32s, 33s], got %v", got)
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("got %d rows, wanted 3", n)
 	}
 }
+
 func TestBool(t *testing.T) {
 	t.Parallel()
 	ctx, cancel := context.WithTimeout(testContext("Bool"), 10*time.Second)
@@ -4979,6 +8066,34 @@ func TestLevelSerializable(t *testing.T) {
 	}
 }
 
+func TestBeginTxIsolation(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("BeginTxIsolation"), 10*time.Second)
+	defer cancel()
+	for _, level := range []sql.IsolationLevel{sql.LevelReadUncommitted, sql.LevelWriteCommitted, sql.LevelLinearizable} {
+		if tx, err := testDb.BeginTx(ctx, &sql.TxOptions{Isolation: level}); err == nil {
+			tx.Rollback()
+			t.Errorf("%s: wanted error", level)
+		}
+	}
+	if tx, err := testDb.BeginTx(godror.ContextWithRollbackSegment(ctx, "rbs1"), &sql.TxOptions{ReadOnly: true}); err == nil {
+		tx.Rollback()
+		t.Error("rollback segment with read-only: wanted error")
+	}
+	for _, level := range []sql.IsolationLevel{sql.LevelDefault, sql.LevelReadCommitted, sql.LevelRepeatableRead, sql.LevelSnapshot, sql.LevelSerializable} {
+		tx, err := testDb.BeginTx(ctx, &sql.TxOptions{Isolation: level})
+		if err != nil {
+			t.Fatalf("%s: %+v", level, err)
+		}
+		var n int
+		err = tx.QueryRowContext(ctx, "SELECT 1 FROM DUAL").Scan(&n)
+		tx.Rollback()
+		if err != nil {
+			t.Errorf("%s: %+v", level, err)
+		}
+	}
+}
+
 func TestSelectAlterSessionIssue297(t *testing.T) {
 	ctx, cancel := context.WithTimeout(testContext(t.Name()), 10*time.Second)
 	defer cancel()
@@ -5237,3 +8352,37 @@ func TestWarningAsError(t *testing.T) {
 		}
 	}
 }
+
+func TestFetchArena(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(testContext("FetchArena"), 30*time.Second)
+	defer cancel()
+	const n = 1000
+	var stats godror.FetchArenaStats
+	rows, err := testDb.QueryContext(ctx,
+		"SELECT LEVEL, LEVEL/3, SYSDATE + LEVEL FROM DUAL CONNECT BY LEVEL <= :1",
+		n, godror.FetchArena(&stats), godror.FetchArraySize(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var i int
+	for rows.Next() {
+		var lvl int64
+		var third godror.Number
+		var tm time.Time
+		if err = rows.Scan(&lvl, &third, &tm); err != nil {
+			t.Fatal(err)
+		}
+		if i++; lvl != int64(i) || third == "" || tm.IsZero() {
+			t.Errorf("%d. got %d, %q, %v", i, lvl, third, tm)
+		}
+	}
+	if err = rows.Close(); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("stats: %+v", stats)
+	if i != n || stats.Arenas != 1 || stats.Values < n {
+		t.Errorf("got %d rows, stats %+v", i, stats)
+	}
+}
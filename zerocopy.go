@@ -0,0 +1,41 @@
+// Copyright 2025 The Godror Authors
+//
+//
+// SPDX-License-Identifier: UPL-1.0 OR Apache-2.0
+
+package godror
+
+import "sync/atomic"
+
+// zeroCopyDebug makes the ZeroCopyBytes values copies, poisoned on the next Next.
+var zeroCopyDebug atomic.Bool
+
+// zeroCopyPoison is the byte the values lent in debug mode are overwritten with.
+const zeroCopyPoison = 0xDB
+
+// SetZeroCopyDebug switches the debug mode of ZeroCopyBytes:
+// the values are copies, which are overwritten with 0xDB bytes on the next Next (or Close),
+// so the values used after that are easy to spot, not only when the fetch buffers are refilled.
+//
+// This is for tests, as it allocates for each value.
+func SetZeroCopyDebug(on bool) { zeroCopyDebug.Store(on) }
+
+// zeroCopyBytes returns b, aliasing the fetch buffer - or its copy in debug mode.
+func (r *rows) zeroCopyBytes(b []byte) []byte {
+	if !zeroCopyDebug.Load() {
+		return b
+	}
+	c := append([]byte(nil), b...)
+	r.lent = append(r.lent, c)
+	return c
+}
+
+// revokeZeroCopy poisons the values lent in debug mode.
+func (r *rows) revokeZeroCopy() {
+	for _, b := range r.lent {
+		for i := range b {
+			b[i] = zeroCopyPoison
+		}
+	}
+	r.lent = r.lent[:0]
+}